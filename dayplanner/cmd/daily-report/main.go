@@ -2,13 +2,20 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"daily-report-tool/internal/api"
+	"daily-report-tool/internal/cli"
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
 	"daily-report-tool/internal/repository"
 	"daily-report-tool/internal/service"
 	"daily-report-tool/internal/ui"
 	"daily-report-tool/internal/util"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 )
 
@@ -16,9 +23,17 @@ const (
 	configPath = "./config/config.json"
 	dataPath   = "./data/tasks"
 	logPath    = "./logs/app.log"
+
+	// passphraseEnv 提供加密口令的环境变量，设置后启动时不再显示口令输入窗口
+	passphraseEnv = "DAILY_REPORT_PASSPHRASE"
 )
 
 func main() {
+	// 带子命令启动时进入命令行模式，不初始化图形界面
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(runCLI(os.Args[1:]))
+	}
+
 	// 初始化日志系统
 	if err := util.InitLogger(logPath, util.INFO); err != nil {
 		fmt.Printf("初始化日志系统失败: %v\n", err)
@@ -48,14 +63,16 @@ func main() {
 	}
 	util.Info("配置目录已创建: %s", configDir)
 
-	// 初始化仓库层
-	taskRepo := repository.NewFileTaskRepository(dataPath)
-	configRepo := repository.NewFileConfigRepository(configPath)
+	// 检查数据完整性：隔离无法解析的日报，删除写入中断留下的临时文件；配置文件损坏时下面加载配置失败并退出
+	integrity, err := repository.CheckIntegrity(dataPath, configPath, repository.DefaultQuarantinePath(dataPath))
+	if err != nil {
+		util.Error("数据完整性检查失败: %v", err)
+		fmt.Printf("数据完整性检查失败: %v\n", err)
+	}
 
-	// 初始化服务层
-	taskService := service.NewTaskService(taskRepo, dataPath)
+	// 初始化配置
+	configRepo := repository.NewFileConfigRepository(configPath)
 	configService := service.NewConfigService(configRepo)
-	reminderService := service.NewReminderService(configService, taskService)
 
 	// 加载配置文件（如果不存在会自动创建默认配置）
 	config, err := configService.GetConfig()
@@ -66,6 +83,114 @@ func main() {
 	}
 	util.Info("配置文件已加载")
 
+	// 根据配置的存储后端初始化任务仓库
+	taskRepo, err := repository.NewTaskRepositoryFromConfig(config, dataPath)
+	if err != nil {
+		util.Error("初始化任务仓库失败: %v", err)
+		fmt.Printf("初始化任务仓库失败: %v\n", err)
+		os.Exit(1)
+	}
+	if closer, ok := taskRepo.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// 未启用加密时直接创建并显示主窗口（阻塞直到窗口关闭）
+	if config.Encryption == "" {
+		startMainWindow(fyneApp, config, configService, taskRepo, integrity).Show()
+		return
+	}
+
+	// 启用加密时先解锁数据密钥：使用系统钥匙串或设置了口令环境变量时直接解锁，否则显示口令输入窗口
+	openEncrypted := func(passphrase string) (*repository.EncryptedTaskRepository, error) {
+		source, err := repository.EncryptionKeySource(config, dataPath, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return repository.OpenEncryptedTaskRepository(taskRepo, dataPath, source)
+	}
+	passphrase := os.Getenv(passphraseEnv)
+	if config.Encryption == model.EncryptionKeyring || passphrase != "" {
+		encrypted, err := openEncrypted(passphrase)
+		if err != nil {
+			util.Error("解锁加密数据失败: %v", err)
+			fmt.Printf("解锁加密数据失败: %v\n", err)
+			os.Exit(1)
+		}
+		startMainWindow(fyneApp, config, configService, encrypted, integrity).Show()
+		return
+	}
+
+	var mainWindow *ui.MainWindow
+	creating := !keystore.Exists(keystore.KeyFilePath(dataPath))
+	unlockWindow := ui.NewUnlockWindow(fyneApp, creating, func(passphrase string) error {
+		encrypted, err := openEncrypted(passphrase)
+		if err != nil {
+			return err
+		}
+		mainWindow = startMainWindow(fyneApp, config, configService, encrypted, integrity)
+		return nil
+	}, func() {
+		mainWindow.GetWindow().Show()
+	})
+	unlockWindow.Show()
+	fyneApp.Run()
+}
+
+// startMainWindow 初始化服务层并创建主窗口，taskRepo 为解锁后的任务仓库，integrity 为启动时的数据检查结果
+func startMainWindow(fyneApp fyne.App, config *model.Config, configService *service.ConfigServiceImpl, taskRepo repository.TaskRepository, integrity *repository.IntegrityReport) *ui.MainWindow {
+	// 初始化服务层
+	taskService := service.NewTaskService(taskRepo, dataPath)
+	revisionRepo := repository.NewRevisionRepository(taskRepo, dataPath)
+	taskService.SetRevisionRepository(revisionRepo)
+	historyService := service.NewHistoryService(taskService, revisionRepo)
+	reminderService := service.NewReminderService(configService, taskService)
+	reminderService.SetStateRepository(repository.NewFileReminderStateRepository(repository.DefaultReminderStatePath(dataPath)))
+	reportService := service.NewReportService(taskService)
+	templateService := service.NewTemplateService(service.DefaultTemplateDir(configPath), taskService)
+	carryOverService := service.NewCarryOverService(taskService, taskRepo, configService)
+	exportService := service.NewExportService(taskService)
+	importService := service.NewImportService(taskService, taskRepo)
+	submitService := service.NewSubmitService(taskService, configService)
+	statsService := service.NewStatsService(taskService, configService)
+
+	// 按配置初始化数据同步，失败时只记录日志，不影响本机使用
+	syncService, err := service.NewSyncServiceFromConfig(config, dataPath, configPath)
+	if err != nil {
+		util.Error("初始化同步服务失败: %v", err)
+		fmt.Printf("初始化同步服务失败: %v\n", err)
+		syncService = nil
+	}
+	if syncService != nil {
+		taskService.SetSyncService(syncService)
+		// git 同步合并冲突时需要解密两边的内容
+		if encrypted, ok := taskRepo.(*repository.EncryptedTaskRepository); ok {
+			if codecSetter, ok := syncService.(interface{ SetTaskCodec(service.TaskCodec) }); ok {
+				codecSetter.SetTaskCodec(encrypted)
+			}
+		}
+	}
+
+	// 使用团队服务器存储时，组长可以在团队标签页查看成员的填报情况并提醒未填写的成员
+	teamService, err := service.NewTeamServiceFromConfig(config)
+	if err != nil {
+		util.Error("初始化团队服务失败: %v", err)
+		fmt.Printf("初始化团队服务失败: %v\n", err)
+		teamService = nil
+	}
+	if teamService != nil {
+		reminderService.SetTeamService(teamService)
+	}
+
+	// 附件保存在数据目录中，启用加密或使用团队服务器存储时不支持
+	var attachmentService service.AttachmentService
+	if attachments := service.NewAttachmentServiceFromConfig(config, dataPath, taskService); attachments != nil {
+		if syncService != nil {
+			attachments.SetSyncService(syncService)
+		}
+		exportService.SetAttachmentRepository(repository.NewFileAttachmentRepository(dataPath))
+		attachmentService = attachments
+	}
+
 	// 启动提醒服务（如果配置启用）
 	if config.ReminderEnabled {
 		if err := reminderService.Start(); err != nil {
@@ -78,17 +203,91 @@ func main() {
 		}
 	}
 
-	// 创建并显示主窗口
-	mainWindow := ui.NewMainWindow(fyneApp, taskService, configService, reminderService)
+	// 创建主窗口
+	mainWindow := ui.NewMainWindow(fyneApp, ui.Services{
+		Task:       taskService,
+		Config:     configService,
+		Reminder:   reminderService,
+		Report:     reportService,
+		History:    historyService,
+		Template:   templateService,
+		CarryOver:  carryOverService,
+		Export:     exportService,
+		Import:     importService,
+		Submit:     submitService,
+		Stats:      statsService,
+		Sync:       syncService,
+		Team:       teamService,
+		Attachment: attachmentService,
+	})
+
+	// 提示启动检查时隔离的损坏文件
+	if integrity != nil && len(integrity.Quarantined) > 0 {
+		util.ShowWarningDialog("数据检查",
+			fmt.Sprintf("以下文件已损坏，已移到 %s：\n%s\n\n可以通过\"查看 → 历史版本\"恢复对应日期的日报。",
+				integrity.QuarantineDir, strings.Join(integrity.Quarantined, "\n")),
+			mainWindow.GetWindow())
+	}
+
+	// 修改在自动保存之前先记录到保存日志，上次退出前有未保存的修改时提示恢复；日志为明文，启用加密时不记录
+	journalService := service.NewJournalServiceFromConfig(config, dataPath, taskService)
+	if journalService != nil {
+		mainWindow.SetJournalService(journalService)
+	}
+
+	// 启动同步服务，放在创建主窗口之后，确保冲突回调已经设置
+	if syncService != nil {
+		if err := syncService.Start(); err != nil {
+			util.Error("启动同步服务失败: %v", err)
+			fmt.Printf("启动同步服务失败: %v\n", err)
+		}
+	}
+
+	// 启动本机 HTTP API（如果配置启用），失败只记录日志
+	var apiServer *api.Server
+	if config.APIEnabled {
+		token, err := api.TokenFromConfig(configService)
+		if err == nil {
+			apiServer = api.NewServer(taskService, configService, token)
+			_, err = apiServer.Start(config.APIPort)
+		}
+		if err != nil {
+			util.Error("启动 HTTP API 失败: %v", err)
+			fmt.Printf("启动 HTTP API 失败: %v\n", err)
+			apiServer = nil
+		}
+	}
 
 	// 设置应用程序退出时的清理逻辑
 	mainWindow.GetWindow().SetOnClosed(func() {
 		// 停止提醒服务
 		reminderService.Stop()
+		if syncService != nil {
+			syncService.Stop()
+		}
+		if apiServer != nil {
+			apiServer.Stop()
+		}
+		if journalService != nil {
+			if err := journalService.Flush(); err != nil {
+				util.Error("写入保存日志失败: %v", err)
+			}
+		}
 		util.Info("应用程序已退出")
 		fmt.Println("应用程序已退出")
 	})
 
-	// 显示主窗口（阻塞直到窗口关闭）
-	mainWindow.Show()
+	return mainWindow
+}
+
+// runCLI 以命令行模式运行子命令，日志只写入文件
+func runCLI(args []string) int {
+	if err := util.InitFileLogger(logPath, util.INFO); err != nil {
+		fmt.Fprintf(os.Stderr, "初始化日志系统失败: %v\n", err)
+		return 1
+	}
+	defer util.GetLogger().Close()
+
+	util.Info("命令行模式启动: %v", args)
+	return cli.NewApp(configPath, dataPath).Run(args)
 }
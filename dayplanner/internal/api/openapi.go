@@ -0,0 +1,171 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OpenAPI 根据接口定义生成 OpenAPI 3.0 文档，结构体字段的 desc 标签作为字段说明
+func OpenAPI() map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]any{}
+
+	for _, rt := range apiRoutes() {
+		operation := map[string]any{
+			"operationId": rt.operation,
+			"summary":     rt.summary,
+			"responses": map[string]any{
+				"200":     successResponse(rt.response, schemas),
+				"default": jsonResponse("错误", schemaRef(reflect.TypeOf(errorView{}), schemas)),
+			},
+		}
+		if len(rt.params) > 0 {
+			var parameters []any
+			for _, p := range rt.params {
+				parameters = append(parameters, map[string]any{
+					"name":        p.name,
+					"in":          p.in,
+					"description": p.description,
+					"required":    p.required,
+					"schema":      map[string]any{"type": "string"},
+				})
+			}
+			operation["parameters"] = parameters
+		}
+		if rt.request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaRef(reflect.TypeOf(rt.request), schemas)},
+				},
+			}
+		}
+		if rt.public {
+			operation["security"] = []any{}
+		}
+
+		item, ok := paths[rt.path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "日报工具 HTTP API",
+			"version":     "1.0.0",
+			"description": "本机 HTTP API，只监听 127.0.0.1；除本文档外的接口都需要 Authorization: Bearer <api_token>",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []any{}}},
+	}
+}
+
+// successResponse 生成成功响应的描述
+func successResponse(response any, schemas map[string]any) map[string]any {
+	if _, ok := response.(htmlPage); ok {
+		return map[string]any{
+			"description": "成功",
+			"content": map[string]any{
+				"text/html": map[string]any{"schema": map[string]any{"type": "string"}},
+			},
+		}
+	}
+	return jsonResponse("成功", schemaRef(reflect.TypeOf(response), schemas))
+}
+
+// jsonResponse 生成 JSON 响应的描述
+func jsonResponse(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": schema},
+		},
+	}
+}
+
+// schemaRef 返回类型的 schema，具名结构体登记到 components 中并返回引用
+func schemaRef(t reflect.Type, schemas map[string]any) map[string]any {
+	if t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) {
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // 先占位，避免递归类型死循环
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return typeSchema(t, schemas)
+}
+
+// schemaName 由类型名生成 schema 名称，去掉 View 后缀并首字母大写，如 taskView → Task
+func schemaName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "View")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// structSchema 根据 json 和 desc 标签生成结构体的 schema
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema := schemaRef(field.Type, schemas)
+		if desc := field.Tag.Get("desc"); desc != "" {
+			if _, isRef := schema["$ref"]; isRef {
+				// OpenAPI 3.0 中 $ref 的同级字段会被忽略，用 allOf 包装以保留说明
+				schema = map[string]any{"allOf": []any{schema}}
+			}
+			schema["description"] = desc
+		}
+		properties[name] = schema
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// typeSchema 生成基本类型、切片和映射的 schema
+func typeSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaRef(t.Elem(), schemas)}
+	case reflect.Pointer:
+		return schemaRef(t.Elem(), schemas)
+	default:
+		return map[string]any{"type": "object"}
+	}
+}
@@ -0,0 +1,493 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+// route 接口定义，OpenAPI 文档同样由此生成
+type route struct {
+	method    string
+	path      string // ServeMux 路径模式，{name} 为路径参数
+	operation string // OpenAPI operationId
+	summary   string
+	params    []param
+	request   any  // 请求体类型的零值，nil 表示没有请求体
+	response  any  // 响应类型的零值，htmlPage 表示返回 HTML
+	public    bool // 不需要访问令牌
+	handler   func(s *Server, w http.ResponseWriter, r *http.Request) error
+}
+
+// param 路径或查询参数
+type param struct {
+	name        string
+	in          string // path 或 query
+	description string
+	required    bool
+}
+
+// htmlPage 标记返回 text/html 的接口
+type htmlPage struct{}
+
+// apiRoutes 返回所有接口
+func apiRoutes() []route {
+	dateParam := param{name: "date", in: "path", description: "日期 (YYYY-MM-DD)", required: true}
+	return []route{
+		{
+			method: http.MethodGet, path: "/api/tasks/{date}", operation: "getTask",
+			summary:  "获取指定日期的日报",
+			params:   []param{dateParam},
+			response: taskView{},
+			handler:  (*Server).handleGetTask,
+		},
+		{
+			method: http.MethodPut, path: "/api/tasks/{date}", operation: "saveTask",
+			summary:  "保存指定日期的日报，已有日报时覆盖内容",
+			params:   []param{dateParam},
+			request:  taskInput{},
+			response: taskView{},
+			handler:  (*Server).handleSaveTask,
+		},
+		{
+			method: http.MethodGet, path: "/api/tasks/{date}/html", operation: "getTaskHTML",
+			summary:  "获取指定日期的日报渲染后的 HTML 片段",
+			params:   []param{dateParam},
+			response: htmlPage{},
+			handler:  (*Server).handleGetTaskHTML,
+		},
+		{
+			method: http.MethodGet, path: "/api/tasks/{date}/items", operation: "getItems",
+			summary:  "获取指定日期日报中的任务项（带复选框的列表项）的结构化表示",
+			params:   []param{dateParam},
+			response: itemsView{},
+			handler:  (*Server).handleGetItems,
+		},
+		{
+			method: http.MethodPut, path: "/api/tasks/{date}/items", operation: "saveItems",
+			summary:  "将任务项写回日报：未修改的任务项保持原文，修改过的重新生成，省略 line 的新增到列表末尾，未列出的删除",
+			params:   []param{dateParam},
+			request:  itemsInput{},
+			response: itemsView{},
+			handler:  (*Server).handleSaveItems,
+		},
+		{
+			method: http.MethodGet, path: "/api/items", operation: "listItems",
+			summary: "列出日期范围内的任务项，可按状态过滤，如本周已完成的任务项",
+			params: []param{
+				{name: "from", in: "query", description: "开始日期 (YYYY-MM-DD)", required: true},
+				{name: "to", in: "query", description: "结束日期 (YYYY-MM-DD)，包含当天", required: true},
+				{name: "status", in: "query", description: "状态: todo、doing、done、blocked，为空时不过滤"},
+			},
+			response: itemListView{},
+			handler:  (*Server).handleListItems,
+		},
+		{
+			method: http.MethodGet, path: "/api/months/{month}", operation: "getMonth",
+			summary:  "列出月份内有日报的日期",
+			params:   []param{{name: "month", in: "path", description: "月份 (YYYY-MM)", required: true}},
+			response: monthView{},
+			handler:  (*Server).handleGetMonth,
+		},
+		{
+			method: http.MethodGet, path: "/api/search", operation: "search",
+			summary:  "全文搜索日报，多个关键词以空格分隔，按日期倒序返回",
+			params:   []param{{name: "q", in: "query", description: "搜索关键词", required: true}},
+			response: searchView{},
+			handler:  (*Server).handleSearch,
+		},
+		{
+			method: http.MethodGet, path: "/api/config", operation: "getConfig",
+			summary:  "获取配置概要（不包含 Webhook 地址、密码等敏感信息）",
+			response: configView{},
+			handler:  (*Server).handleGetConfig,
+		},
+		{
+			method: http.MethodGet, path: "/api/openapi.json", operation: "getOpenAPI",
+			summary:  "获取本 API 的 OpenAPI 文档",
+			response: map[string]any{},
+			public:   true,
+			handler:  (*Server).handleOpenAPI,
+		},
+	}
+}
+
+// taskView 日报
+type taskView struct {
+	Date      string    `json:"date" desc:"日期 (YYYY-MM-DD)"`
+	Content   string    `json:"content" desc:"Markdown 内容"`
+	CreatedAt time.Time `json:"created_at" desc:"创建时间"`
+	UpdatedAt time.Time `json:"updated_at" desc:"更新时间"`
+}
+
+// taskInput 保存日报的请求体
+type taskInput struct {
+	Content string `json:"content" desc:"Markdown 内容"`
+}
+
+// itemView 任务项
+type itemView struct {
+	Title           string     `json:"title" desc:"去掉状态、耗时、标签和项目标记后的标题（Markdown）"`
+	Status          string     `json:"status" desc:"状态: todo、doing、done、blocked"`
+	EstimateMinutes int        `json:"estimate_minutes,omitempty" desc:"预估耗时（分钟），对应 ~2h"`
+	ActualMinutes   int        `json:"actual_minutes,omitempty" desc:"实际耗时（分钟），对应 (2h)"`
+	Tags            []string   `json:"tags,omitempty" desc:"#标签"`
+	Projects        []string   `json:"projects,omitempty" desc:"+项目"`
+	Links           []linkView `json:"links,omitempty" desc:"链接，不在标题中的链接写回时追加到标题之后"`
+	Line            *int       `json:"line,omitempty" desc:"在日报中的行号（从 0 开始），新增的任务项省略"`
+}
+
+// linkView 任务项中的链接
+type linkView struct {
+	Text string `json:"text" desc:"链接文字，自动链接为空"`
+	URL  string `json:"url" desc:"链接地址"`
+}
+
+// itemsView 一天的任务项
+type itemsView struct {
+	Date  string     `json:"date" desc:"日期 (YYYY-MM-DD)"`
+	Items []itemView `json:"items" desc:"任务项，按出现顺序"`
+}
+
+// itemsInput 写回任务项的请求体
+type itemsInput struct {
+	Items []itemView `json:"items" desc:"写回后日报中的全部任务项"`
+}
+
+// datedItemView 带日期的任务项
+type datedItemView struct {
+	Date string   `json:"date" desc:"日期 (YYYY-MM-DD)"`
+	Item itemView `json:"item" desc:"任务项"`
+}
+
+// itemListView 日期范围内的任务项
+type itemListView struct {
+	From   string          `json:"from" desc:"开始日期 (YYYY-MM-DD)"`
+	To     string          `json:"to" desc:"结束日期 (YYYY-MM-DD)"`
+	Status string          `json:"status,omitempty" desc:"过滤的状态"`
+	Items  []datedItemView `json:"items" desc:"任务项，按日期和行号排列"`
+}
+
+// monthView 月份内有日报的日期
+type monthView struct {
+	Month string   `json:"month" desc:"月份 (YYYY-MM)"`
+	Dates []string `json:"dates" desc:"有日报的日期 (YYYY-MM-DD)，升序"`
+}
+
+// searchView 搜索结果
+type searchView struct {
+	Query   string     `json:"query" desc:"搜索关键词"`
+	Results []taskView `json:"results" desc:"匹配的日报，按日期倒序"`
+}
+
+// configView 配置概要，只包含不敏感的字段
+type configView struct {
+	ReminderEnabled bool   `json:"reminder_enabled" desc:"是否启用提醒"`
+	ReminderTime    string `json:"reminder_time" desc:"提醒时间 (HH:MM)"`
+	StorageBackend  string `json:"storage_backend" desc:"存储后端: file 或 sqlite"`
+	SyncBackend     string `json:"sync_backend" desc:"同步方式: git、webdav，为空表示不同步"`
+	Encryption      string `json:"encryption" desc:"加密的密钥来源: passphrase、keyring，为空表示不加密"`
+	Channels        int    `json:"channels" desc:"已配置的通知渠道数"`
+}
+
+// errorView 错误响应
+type errorView struct {
+	Error string `json:"error" desc:"错误信息"`
+}
+
+// newTaskView 将任务转换为响应格式
+func newTaskView(task *model.Task) taskView {
+	return taskView{
+		Date:      task.Date.Format("2006-01-02"),
+		Content:   task.Content,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+}
+
+// handleGetTask 获取日报，不存在时返回 404
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return &apiError{status: http.StatusNotFound, message: date.Format("2006-01-02") + " 没有日报"}
+	}
+	writeJSON(w, http.StatusOK, newTaskView(task))
+	return nil
+}
+
+// handleSaveTask 保存日报并返回保存后的内容
+func (s *Server) handleSaveTask(w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	var input taskInput
+	if err := decodeBody(r, &input); err != nil {
+		return err
+	}
+
+	if err := s.taskService.SaveTask(date, input.Content); err != nil {
+		return err
+	}
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("保存后未能读取 %s 的日报", date.Format("2006-01-02"))
+	}
+	writeJSON(w, http.StatusOK, newTaskView(task))
+	return nil
+}
+
+// handleGetItems 获取日报中的任务项，没有日报时返回空列表
+func (s *Server) handleGetItems(w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	items, err := s.itemService.GetItems(date)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, newItemsView(date, items))
+	return nil
+}
+
+// handleSaveItems 将任务项写回日报并返回写回后的任务项
+func (s *Server) handleSaveItems(w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	var input itemsInput
+	if err := decodeBody(r, &input); err != nil {
+		return err
+	}
+
+	items := make([]model.Item, 0, len(input.Items))
+	for _, view := range input.Items {
+		item := model.Item{
+			Title:    view.Title,
+			Status:   model.ItemStatus(view.Status),
+			Estimate: time.Duration(view.EstimateMinutes) * time.Minute,
+			Actual:   time.Duration(view.ActualMinutes) * time.Minute,
+			Tags:     view.Tags,
+			Projects: view.Projects,
+			Line:     -1,
+		}
+		if !model.ValidItemStatus(item.Status) {
+			return badRequest("无效的任务项状态 %q，可选 todo、doing、done、blocked", view.Status)
+		}
+		for _, link := range view.Links {
+			item.Links = append(item.Links, model.Link{Text: link.Text, URL: link.URL})
+		}
+		if view.Line != nil {
+			item.Line = *view.Line
+		}
+		items = append(items, item)
+	}
+
+	if err := s.itemService.SaveItems(date, items); err != nil {
+		if errors.Is(err, service.ErrInvalidItems) {
+			return badRequest("%v", err)
+		}
+		return err
+	}
+	saved, err := s.itemService.GetItems(date)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, newItemsView(date, saved))
+	return nil
+}
+
+// handleListItems 列出日期范围内的任务项
+func (s *Server) handleListItems(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	from, err := time.ParseInLocation("2006-01-02", query.Get("from"), time.Local)
+	if err != nil {
+		return badRequest("无效的开始日期 %q，格式应为 YYYY-MM-DD", query.Get("from"))
+	}
+	to, err := time.ParseInLocation("2006-01-02", query.Get("to"), time.Local)
+	if err != nil {
+		return badRequest("无效的结束日期 %q，格式应为 YYYY-MM-DD", query.Get("to"))
+	}
+	if to.Before(from) {
+		return badRequest("结束日期不能早于开始日期")
+	}
+	status := model.ItemStatus(query.Get("status"))
+	if status != "" && !model.ValidItemStatus(status) {
+		return badRequest("无效的任务项状态 %q，可选 todo、doing、done、blocked", status)
+	}
+
+	items, err := s.itemService.ListItems(from, to, status)
+	if err != nil {
+		return err
+	}
+	view := itemListView{
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+		Status: string(status),
+		Items:  make([]datedItemView, 0, len(items)),
+	}
+	for _, dated := range items {
+		view.Items = append(view.Items, datedItemView{Date: dated.Date.Format("2006-01-02"), Item: newItemView(dated.Item)})
+	}
+	writeJSON(w, http.StatusOK, view)
+	return nil
+}
+
+// newItemsView 将一天的任务项转换为响应格式
+func newItemsView(date time.Time, items []model.Item) itemsView {
+	view := itemsView{Date: date.Format("2006-01-02"), Items: make([]itemView, 0, len(items))}
+	for _, item := range items {
+		view.Items = append(view.Items, newItemView(item))
+	}
+	return view
+}
+
+// newItemView 将任务项转换为响应格式
+func newItemView(item model.Item) itemView {
+	line := item.Line
+	view := itemView{
+		Title:           item.Title,
+		Status:          string(item.Status),
+		EstimateMinutes: int(item.Estimate / time.Minute),
+		ActualMinutes:   int(item.Actual / time.Minute),
+		Tags:            item.Tags,
+		Projects:        item.Projects,
+		Line:            &line,
+	}
+	for _, link := range item.Links {
+		view.Links = append(view.Links, linkView{Text: link.Text, URL: link.URL})
+	}
+	return view
+}
+
+// handleGetTaskHTML 返回渲染后的 HTML 片段
+func (s *Server) handleGetTaskHTML(w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return &apiError{status: http.StatusNotFound, message: date.Format("2006-01-02") + " 没有日报"}
+	}
+	html, err := util.MarkdownToHTML(task.Content)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err = io.WriteString(w, html)
+	return err
+}
+
+// handleGetMonth 列出月份内有日报的日期
+func (s *Server) handleGetMonth(w http.ResponseWriter, r *http.Request) error {
+	month, err := time.ParseInLocation("2006-01", r.PathValue("month"), time.Local)
+	if err != nil {
+		return badRequest("无效的月份 %q，格式应为 YYYY-MM", r.PathValue("month"))
+	}
+	dates, err := s.taskService.GetMonthTaskDates(month.Year(), month.Month())
+	if err != nil {
+		return err
+	}
+	view := monthView{Month: month.Format("2006-01"), Dates: make([]string, 0, len(dates))}
+	for _, date := range dates {
+		view.Dates = append(view.Dates, date.Format("2006-01-02"))
+	}
+	writeJSON(w, http.StatusOK, view)
+	return nil
+}
+
+// handleSearch 全文搜索日报
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) error {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		return badRequest("缺少搜索关键词 q")
+	}
+	tasks, err := s.taskService.Search(query)
+	if err != nil {
+		return err
+	}
+	view := searchView{Query: query, Results: make([]taskView, 0, len(tasks))}
+	for _, task := range tasks {
+		view.Results = append(view.Results, newTaskView(task))
+	}
+	writeJSON(w, http.StatusOK, view)
+	return nil
+}
+
+// handleGetConfig 返回配置概要
+func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) error {
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return err
+	}
+	storageBackend := config.StorageBackend
+	if storageBackend == "" {
+		storageBackend = model.StorageBackendFile
+	}
+	writeJSON(w, http.StatusOK, configView{
+		ReminderEnabled: config.ReminderEnabled,
+		ReminderTime:    config.ReminderTime,
+		StorageBackend:  storageBackend,
+		SyncBackend:     config.SyncBackend,
+		Encryption:      config.Encryption,
+		Channels:        len(config.Channels),
+	})
+	return nil
+}
+
+// handleOpenAPI 返回 OpenAPI 文档
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, OpenAPI())
+	return nil
+}
+
+// decodeBody 解析 JSON 请求体，不允许未知字段
+func decodeBody(r *http.Request, value any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return &apiError{status: http.StatusRequestEntityTooLarge, message: "请求体过大"}
+		}
+		if errors.Is(err, io.EOF) {
+			return badRequest("请求体不能为空")
+		}
+		return badRequest("无效的请求体: %v", err)
+	}
+	return nil
+}
+
+// pathDate 解析路径中的日期参数
+func pathDate(r *http.Request) (time.Time, error) {
+	value := r.PathValue("date")
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, badRequest("无效的日期 %q，格式应为 YYYY-MM-DD", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,231 @@
+// Package api 实现本机 HTTP API，供看板、编辑器插件等工具读写日报
+// 服务只监听 127.0.0.1，除 OpenAPI 文档外的请求都需要在 Authorization 头中携带令牌
+// 同一包中的 TeamServer 实现团队模式的日报服务器，见 team_server.go
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+// DefaultPort 未配置 api_port 时的监听端口
+const DefaultPort = 17800
+
+// maxBodySize 请求体大小上限
+const maxBodySize = 1 << 20
+
+// Server 本机 HTTP API 服务
+type Server struct {
+	taskService   service.TaskService
+	configService service.ConfigService
+	itemService   service.ItemService
+	token         string
+	handler       http.Handler
+
+	mu         sync.Mutex
+	httpServer *http.Server
+}
+
+// NewServer 创建 HTTP API 服务，token 为访问令牌，不能为空
+func NewServer(taskService service.TaskService, configService service.ConfigService, token string) *Server {
+	s := &Server{
+		taskService:   taskService,
+		configService: configService,
+		itemService:   service.NewItemService(taskService),
+		token:         token,
+	}
+
+	mux := http.NewServeMux()
+	for _, rt := range apiRoutes() {
+		mux.Handle(rt.method+" "+rt.path, s.wrap(rt))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &apiError{status: http.StatusNotFound, message: "接口不存在"})
+	})
+	s.handler = mux
+	return s
+}
+
+// Handler 返回处理所有接口的 http.Handler
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Start 在 127.0.0.1 的指定端口上启动服务，port 为 0 时使用 DefaultPort，返回实际监听地址
+func (s *Server) Start(port int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return "", fmt.Errorf("HTTP API 已在运行")
+	}
+	if s.token == "" {
+		return "", fmt.Errorf("HTTP API 访问令牌不能为空")
+	}
+	if port == 0 {
+		port = DefaultPort
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return "", fmt.Errorf("监听端口 %d 失败: %w", port, err)
+	}
+	s.httpServer = &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func(httpServer *http.Server) {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			util.Error("HTTP API 服务异常退出: %v", err)
+		}
+	}(s.httpServer)
+
+	addr := listener.Addr().String()
+	util.Info("HTTP API 已启动: http://%s", addr)
+	return addr, nil
+}
+
+// Stop 停止服务，等待正在处理的请求完成
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		util.Warn("停止 HTTP API 失败: %v", err)
+	}
+	s.httpServer = nil
+	util.Info("HTTP API 已停止")
+}
+
+// wrap 为接口加上来源检查、令牌校验和错误处理
+func (s *Server) wrap(rt route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 只接受本机连接；同时检查 Host 头，防止 DNS 重绑定让网页脚本访问本机服务
+		if !isLoopback(r.RemoteAddr) || !isLoopbackHost(r.Host) {
+			writeError(w, &apiError{status: http.StatusForbidden, message: "只允许本机访问"})
+			return
+		}
+		if !rt.public && !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, &apiError{status: http.StatusUnauthorized, message: "缺少或错误的访问令牌"})
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+		if err := rt.handler(s, w, r); err != nil {
+			util.Warn("HTTP API %s %s 失败: %v", r.Method, r.URL.Path, err)
+			writeError(w, err)
+		}
+	})
+}
+
+// authorized 校验 Authorization: Bearer <令牌>
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(s.token)) == 1
+}
+
+// isLoopback 判断连接来源是否为本机
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLoopbackHost 判断请求的 Host 头是否指向本机
+func isLoopbackHost(hostHeader string) bool {
+	host, _, err := net.SplitHostPort(hostHeader)
+	if err != nil {
+		host = hostHeader
+	}
+	host = strings.Trim(host, "[]")
+	return strings.EqualFold(host, "localhost") || isLoopback(host)
+}
+
+// apiError 带 HTTP 状态码的错误
+type apiError struct {
+	status  int
+	message string
+}
+
+// Error 返回错误信息
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// badRequest 创建 400 错误
+func badRequest(format string, args ...any) error {
+	return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// writeError 以 JSON 格式输出错误，非 apiError 视为服务器内部错误
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+	writeJSON(w, apiErr.status, errorView{Error: apiErr.message})
+}
+
+// writeJSON 以 JSON 格式输出响应
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		util.Warn("写入 HTTP API 响应失败: %v", err)
+	}
+}
+
+// TokenFromConfig 返回配置中的访问令牌，未配置时生成随机令牌并写入配置文件
+func TokenFromConfig(configService service.ConfigService) (string, error) {
+	config, err := configService.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	if config.APIToken != "" {
+		return config.APIToken, nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	config.APIToken = token
+	if err := configService.UpdateConfig(config); err != nil {
+		return "", fmt.Errorf("保存访问令牌失败: %w", err)
+	}
+	util.Info("已生成 HTTP API 访问令牌并写入配置文件 (api_token)")
+	return config.APIToken, nil
+}
+
+// generateToken 生成随机访问令牌
+func generateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成访问令牌失败: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+const testToken = "test-token"
+
+// newTestServer 创建使用临时目录的 API 服务
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	tempDir := t.TempDir()
+	dataPath := filepath.Join(tempDir, "tasks")
+	taskService := service.NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	configService := service.NewConfigService(repository.NewFileConfigRepository(filepath.Join(tempDir, "config.json")))
+
+	server := httptest.NewServer(NewServer(taskService, configService, testToken).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// request 发送请求并返回状态码和响应体
+func request(t *testing.T, server *httptest.Server, method, path, token, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("创建请求失败: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("请求 %s %s 失败: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := newTestServer(t)
+
+	if status, _ := request(t, server, http.MethodGet, "/api/tasks/2025-11-10", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("没有令牌应返回 401，实际: %d", status)
+	}
+	if status, _ := request(t, server, http.MethodGet, "/api/tasks/2025-11-10", "wrong", ""); status != http.StatusUnauthorized {
+		t.Errorf("错误的令牌应返回 401，实际: %d", status)
+	}
+	if status, _ := request(t, server, http.MethodGet, "/api/openapi.json", "", ""); status != http.StatusOK {
+		t.Errorf("OpenAPI 文档不需要令牌，实际: %d", status)
+	}
+
+	// 非本机的 Host 头视为 DNS 重绑定
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/openapi.json", nil)
+	req.Host = "evil.example.com"
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("非本机 Host 应返回 403，实际: %d", resp.StatusCode)
+	}
+}
+
+func TestServer_Tasks(t *testing.T) {
+	server := newTestServer(t)
+
+	if status, _ := request(t, server, http.MethodGet, "/api/tasks/2025-11-10", testToken, ""); status != http.StatusNotFound {
+		t.Errorf("没有日报时应返回 404，实际: %d", status)
+	}
+	if status, _ := request(t, server, http.MethodGet, "/api/tasks/20251110", testToken, ""); status != http.StatusBadRequest {
+		t.Errorf("无效日期应返回 400，实际: %d", status)
+	}
+
+	status, body := request(t, server, http.MethodPut, "/api/tasks/2025-11-10", testToken, `{"content": "# 日报\n- [x] 接口联调"}`)
+	if status != http.StatusOK {
+		t.Fatalf("保存日报失败: %d %s", status, body)
+	}
+	var task taskView
+	if err := json.Unmarshal([]byte(body), &task); err != nil || task.Date != "2025-11-10" || !strings.Contains(task.Content, "接口联调") {
+		t.Errorf("保存后应返回日报: %v %s", err, body)
+	}
+	request(t, server, http.MethodPut, "/api/tasks/2025-11-12", testToken, `{"content": "- 编写测试"}`)
+
+	if status, body := request(t, server, http.MethodGet, "/api/tasks/2025-11-10/html", testToken, ""); status != http.StatusOK || !strings.Contains(body, "<h1") {
+		t.Errorf("应返回渲染后的 HTML: %d %s", status, body)
+	}
+
+	status, body = request(t, server, http.MethodGet, "/api/months/2025-11", testToken, "")
+	var month monthView
+	if err := json.Unmarshal([]byte(body), &month); err != nil || status != http.StatusOK ||
+		strings.Join(month.Dates, ",") != "2025-11-10,2025-11-12" {
+		t.Errorf("月份列表不正确: %d %s", status, body)
+	}
+
+	status, body = request(t, server, http.MethodGet, "/api/search?q=%E8%81%94%E8%B0%83", testToken, "")
+	var search searchView
+	if err := json.Unmarshal([]byte(body), &search); err != nil || status != http.StatusOK ||
+		len(search.Results) != 1 || search.Results[0].Date != "2025-11-10" {
+		t.Errorf("搜索结果不正确: %d %s", status, body)
+	}
+
+	if status, _ := request(t, server, http.MethodPut, "/api/tasks/2025-11-10", testToken, `{"text": "x"}`); status != http.StatusBadRequest {
+		t.Errorf("未知字段应返回 400，实际: %d", status)
+	}
+}
+
+func TestServer_Items(t *testing.T) {
+	server := newTestServer(t)
+	request(t, server, http.MethodPut, "/api/tasks/2025-11-10", testToken, `{"content": "# 日报\n\n- [x] 接口联调 #后端 (2h)\n- [ ] 编写测试 ~1h !doing\n"}`)
+
+	status, body := request(t, server, http.MethodGet, "/api/tasks/2025-11-10/items", testToken, "")
+	var items itemsView
+	if err := json.Unmarshal([]byte(body), &items); err != nil || status != http.StatusOK || len(items.Items) != 2 {
+		t.Fatalf("任务项不正确: %d %s", status, body)
+	}
+	if item := items.Items[1]; item.Title != "编写测试" || item.Status != "doing" || item.EstimateMinutes != 60 || item.Line == nil || *item.Line != 3 {
+		t.Errorf("第二个任务项不正确: %+v", item)
+	}
+
+	// 完成第二项并新增一项
+	status, body = request(t, server, http.MethodPut, "/api/tasks/2025-11-10/items", testToken,
+		`{"items": [{"title": "接口联调", "status": "done", "actual_minutes": 120, "tags": ["后端"], "line": 2},
+			{"title": "编写测试", "status": "done", "estimate_minutes": 60, "line": 3},
+			{"title": "代码评审", "status": "todo"}]}`)
+	if status != http.StatusOK {
+		t.Fatalf("写回任务项失败: %d %s", status, body)
+	}
+	_, body = request(t, server, http.MethodGet, "/api/tasks/2025-11-10", testToken, "")
+	var task taskView
+	json.Unmarshal([]byte(body), &task)
+	if task.Content != "# 日报\n\n- [x] 接口联调 #后端 (2h)\n- [x] 编写测试 ~1h\n- [ ] 代码评审\n" {
+		t.Errorf("写回后的日报不正确: %q", task.Content)
+	}
+
+	status, body = request(t, server, http.MethodGet, "/api/items?from=2025-11-10&to=2025-11-16&status=done", testToken, "")
+	var list itemListView
+	if err := json.Unmarshal([]byte(body), &list); err != nil || status != http.StatusOK || len(list.Items) != 2 {
+		t.Errorf("已完成的任务项不正确: %d %s", status, body)
+	}
+
+	if status, _ := request(t, server, http.MethodPut, "/api/tasks/2025-11-10/items", testToken, `{"items": [{"title": "x", "status": "todo", "line": 0}]}`); status != http.StatusBadRequest {
+		t.Errorf("行号不是任务项时应返回 400，实际: %d", status)
+	}
+	if status, _ := request(t, server, http.MethodGet, "/api/items?from=2025-11-10&to=2025-11-16&status=finished", testToken, ""); status != http.StatusBadRequest {
+		t.Errorf("无效状态应返回 400，实际: %d", status)
+	}
+}
+
+func TestServer_ConfigHidesSecrets(t *testing.T) {
+	tempDir := t.TempDir()
+	configService := service.NewConfigService(repository.NewFileConfigRepository(filepath.Join(tempDir, "config.json")))
+	config := &model.Config{ReminderTime: "10:00", DataPath: tempDir, SyncPassword: "secret-password", APIToken: testToken}
+	if err := configService.UpdateConfig(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+	server := httptest.NewServer(NewServer(service.NewTaskService(repository.NewFileTaskRepository(tempDir), tempDir), configService, testToken).Handler())
+	defer server.Close()
+
+	status, body := request(t, server, http.MethodGet, "/api/config", testToken, "")
+	if status != http.StatusOK || !strings.Contains(body, `"reminder_time": "10:00"`) {
+		t.Errorf("应返回配置概要: %d %s", status, body)
+	}
+	if strings.Contains(body, "secret-password") || strings.Contains(body, testToken) {
+		t.Errorf("配置概要不应包含敏感信息: %s", body)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc := OpenAPI()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("序列化 OpenAPI 文档失败: %v", err)
+	}
+
+	// 每个接口都应出现在文档中
+	paths := doc["paths"].(map[string]any)
+	for _, rt := range apiRoutes() {
+		item, ok := paths[rt.path].(map[string]any)
+		if !ok || item[strings.ToLower(rt.method)] == nil {
+			t.Errorf("文档缺少接口 %s %s", rt.method, rt.path)
+		}
+	}
+
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	task, ok := schemas["Task"].(map[string]any)
+	if !ok {
+		t.Fatalf("文档缺少 Task schema: %s", data)
+	}
+	properties := task["properties"].(map[string]any)
+	updatedAt := properties["updated_at"].(map[string]any)
+	if updatedAt["format"] != "date-time" || updatedAt["description"] != "更新时间" {
+		t.Errorf("updated_at 字段描述不正确: %v", updatedAt)
+	}
+	if _, ok := schemas["Search"]; !ok || !strings.Contains(string(data), "#/components/schemas/Task") {
+		t.Errorf("搜索结果应引用 Task schema: %s", data)
+	}
+}
+
+func TestTokenFromConfig(t *testing.T) {
+	configService := service.NewConfigService(repository.NewFileConfigRepository(filepath.Join(t.TempDir(), "config.json")))
+
+	token, err := TokenFromConfig(configService)
+	if err != nil || len(token) != 48 {
+		t.Fatalf("应生成 48 位十六进制令牌: %q %v", token, err)
+	}
+	again, err := TokenFromConfig(configService)
+	if err != nil || again != token {
+		t.Errorf("已有令牌时应返回配置中的令牌: %q %v", again, err)
+	}
+}
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+// DefaultTeamListen 未配置 team_listen 时团队服务器的监听地址
+const DefaultTeamListen = ":17900"
+
+// TeamServer 团队服务器：成员读写自己的日报，组长查看成员的填报情况和日报
+// 与本机 HTTP API 不同，团队服务器监听局域网地址，使用配置中 team_members 各成员的令牌认证，
+// 成员列表在每次请求时重新读取，添加成员后不需要重启
+type TeamServer struct {
+	teamRepo      repository.TeamTaskRepository
+	teamService   service.TeamService
+	configService service.ConfigService
+	handler       http.Handler
+
+	mu         sync.Mutex
+	httpServer *http.Server
+}
+
+// teamRoute 团队服务器接口定义
+type teamRoute struct {
+	method  string
+	path    string
+	lead    bool // 仅组长可用
+	handler func(s *TeamServer, member model.TeamMember, w http.ResponseWriter, r *http.Request) error
+}
+
+// NewTeamServer 创建团队服务器，成员的日报保存在 teamRepo 中
+func NewTeamServer(teamRepo repository.TeamTaskRepository, configService service.ConfigService) *TeamServer {
+	s := &TeamServer{
+		teamRepo:      teamRepo,
+		teamService:   service.NewTeamService(teamRepo, configService),
+		configService: configService,
+	}
+
+	mux := http.NewServeMux()
+	for _, rt := range teamRoutes() {
+		mux.Handle(rt.method+" "+rt.path, s.wrap(rt))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &apiError{status: http.StatusNotFound, message: "接口不存在"})
+	})
+	s.handler = mux
+	return s
+}
+
+// teamRoutes 返回团队服务器的所有接口
+func teamRoutes() []teamRoute {
+	return []teamRoute{
+		{method: http.MethodGet, path: "/team/v1/me", handler: (*TeamServer).handleMe},
+		{method: http.MethodGet, path: "/team/v1/tasks", handler: (*TeamServer).handleListTasks},
+		{method: http.MethodGet, path: "/team/v1/tasks/{date}", handler: (*TeamServer).handleGetTask},
+		{method: http.MethodPut, path: "/team/v1/tasks/{date}", handler: (*TeamServer).handleSaveTask},
+		{method: http.MethodGet, path: "/team/v1/search", handler: (*TeamServer).handleSearch},
+		{method: http.MethodGet, path: "/team/v1/status/{date}", lead: true, handler: (*TeamServer).handleStatus},
+		{method: http.MethodGet, path: "/team/v1/members/{name}/tasks/{date}", lead: true, handler: (*TeamServer).handleMemberTask},
+	}
+}
+
+// Handler 返回处理所有接口的 http.Handler
+func (s *TeamServer) Handler() http.Handler {
+	return s.handler
+}
+
+// Start 在指定地址上启动服务，addr 为空时使用 DefaultTeamListen，返回实际监听地址
+func (s *TeamServer) Start(addr string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return "", fmt.Errorf("团队服务器已在运行")
+	}
+	if addr == "" {
+		addr = DefaultTeamListen
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("监听 %s 失败: %w", addr, err)
+	}
+	s.httpServer = &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func(httpServer *http.Server) {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			util.Error("团队服务器异常退出: %v", err)
+		}
+	}(s.httpServer)
+
+	actual := listener.Addr().String()
+	util.Info("团队服务器已启动: http://%s", actual)
+	return actual, nil
+}
+
+// Stop 停止服务，等待正在处理的请求完成
+func (s *TeamServer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		util.Warn("停止团队服务器失败: %v", err)
+	}
+	s.httpServer = nil
+	util.Info("团队服务器已停止")
+}
+
+// wrap 为接口加上令牌校验、组长权限检查和错误处理
+func (s *TeamServer) wrap(rt teamRoute) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		member, err := s.authenticate(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if rt.lead && !member.Lead {
+			writeError(w, &apiError{status: http.StatusForbidden, message: "只有组长可以查看团队成员的填报情况和日报"})
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+		if err := rt.handler(s, member, w, r); err != nil {
+			util.Warn("团队服务器 %s %s (%s) 失败: %v", r.Method, r.URL.Path, member.Name, err)
+			writeError(w, err)
+		}
+	})
+}
+
+// authenticate 按 Authorization: Bearer <令牌> 查找成员
+func (s *TeamServer) authenticate(r *http.Request) (model.TeamMember, error) {
+	unauthorized := &apiError{status: http.StatusUnauthorized, message: "缺少或错误的访问令牌"}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return model.TeamMember{}, unauthorized
+	}
+
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return model.TeamMember{}, err
+	}
+	for _, member := range config.TeamMembers {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(member.Token)) == 1 {
+			return member, nil
+		}
+	}
+	return model.TeamMember{}, unauthorized
+}
+
+// teamMemberView 令牌对应的成员
+type teamMemberView struct {
+	Name string `json:"name"`
+	Lead bool   `json:"lead"`
+}
+
+// teamDatesView 有日报的日期
+type teamDatesView struct {
+	Dates []string `json:"dates"`
+}
+
+// teamSearchView 搜索结果
+type teamSearchView struct {
+	Results []*model.Task `json:"results"`
+}
+
+// teamStatusView 团队填报情况
+type teamStatusView struct {
+	Date    string             `json:"date"`
+	Members []memberStatusView `json:"members"`
+}
+
+// memberStatusView 一个成员的填报情况
+type memberStatusView struct {
+	Name      string    `json:"name"`
+	Mention   string    `json:"mention,omitempty"`
+	Lead      bool      `json:"lead,omitempty"`
+	Filed     bool      `json:"filed"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// handleMe 返回令牌对应的成员
+func (s *TeamServer) handleMe(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, teamMemberView{Name: member.Name, Lead: member.Lead})
+	return nil
+}
+
+// handleGetTask 获取本人的日报，不存在时返回 404
+func (s *TeamServer) handleGetTask(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	return s.writeTask(w, member.Name, date)
+}
+
+// handleSaveTask 保存本人的日报，日期以路径为准
+func (s *TeamServer) handleSaveTask(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	var task model.Task
+	if err := decodeBody(r, &task); err != nil {
+		return err
+	}
+	task.Date = date
+
+	repo, err := s.teamRepo.ForUser(member.Name)
+	if err != nil {
+		return err
+	}
+	if err := repo.Save(&task); err != nil {
+		return err
+	}
+	util.Info("团队服务器: %s 保存了 %s 的日报", member.Name, date.Format("2006-01-02"))
+	writeJSON(w, http.StatusOK, &task)
+	return nil
+}
+
+// handleListTasks 列出本人在日期范围内有日报的日期
+func (s *TeamServer) handleListTasks(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	from, err := time.ParseInLocation("2006-01-02", query.Get("from"), time.Local)
+	if err != nil {
+		return badRequest("无效的开始日期 %q，格式应为 YYYY-MM-DD", query.Get("from"))
+	}
+	to, err := time.ParseInLocation("2006-01-02", query.Get("to"), time.Local)
+	if err != nil {
+		return badRequest("无效的结束日期 %q，格式应为 YYYY-MM-DD", query.Get("to"))
+	}
+
+	repo, err := s.teamRepo.ForUser(member.Name)
+	if err != nil {
+		return err
+	}
+	dates, err := repo.GetTaskDates(from, to)
+	if err != nil {
+		return err
+	}
+	view := teamDatesView{Dates: make([]string, 0, len(dates))}
+	for _, date := range dates {
+		view.Dates = append(view.Dates, date.Format("2006-01-02"))
+	}
+	writeJSON(w, http.StatusOK, view)
+	return nil
+}
+
+// handleSearch 搜索本人的日报
+func (s *TeamServer) handleSearch(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	repo, err := s.teamRepo.ForUser(member.Name)
+	if err != nil {
+		return err
+	}
+	tasks, err := repo.Search(r.URL.Query().Get("q"))
+	if err != nil {
+		return err
+	}
+	if tasks == nil {
+		tasks = []*model.Task{}
+	}
+	writeJSON(w, http.StatusOK, teamSearchView{Results: tasks})
+	return nil
+}
+
+// handleStatus 返回团队在指定日期的填报情况
+func (s *TeamServer) handleStatus(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	status, err := s.teamService.Status(date)
+	if err != nil {
+		return err
+	}
+
+	view := teamStatusView{Date: date.Format("2006-01-02"), Members: make([]memberStatusView, 0, len(status.Members))}
+	for _, memberStatus := range status.Members {
+		view.Members = append(view.Members, memberStatusView{
+			Name:      memberStatus.Name,
+			Mention:   memberStatus.Mention,
+			Lead:      memberStatus.Lead,
+			Filed:     memberStatus.Filed,
+			UpdatedAt: memberStatus.UpdatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, view)
+	return nil
+}
+
+// handleMemberTask 获取成员的日报，成员不存在或没有日报时返回 404
+func (s *TeamServer) handleMemberTask(member model.TeamMember, w http.ResponseWriter, r *http.Request) error {
+	date, err := pathDate(r)
+	if err != nil {
+		return err
+	}
+	name := r.PathValue("name")
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return err
+	}
+	for _, teamMember := range config.TeamMembers {
+		if teamMember.Name == name {
+			return s.writeTask(w, name, date)
+		}
+	}
+	return &apiError{status: http.StatusNotFound, message: "成员不存在: " + name}
+}
+
+// writeTask 输出成员指定日期的日报，不存在时返回 404
+func (s *TeamServer) writeTask(w http.ResponseWriter, name string, date time.Time) error {
+	task, err := s.teamService.GetMemberTask(name, date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return &apiError{status: http.StatusNotFound, message: date.Format("2006-01-02") + " 没有日报"}
+	}
+	writeJSON(w, http.StatusOK, task)
+	return nil
+}
+
+// AddTeamMember 在团队服务器的配置中添加成员并生成访问令牌，成员已存在时重新生成令牌（旧令牌失效）
+func AddTeamMember(configService service.ConfigService, member model.TeamMember) (string, error) {
+	config, err := configService.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	member.Token = token
+
+	replaced := false
+	for i, existing := range config.TeamMembers {
+		if existing.Name == member.Name {
+			config.TeamMembers[i] = member
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		config.TeamMembers = append(config.TeamMembers, member)
+	}
+	if err := configService.UpdateConfig(config); err != nil {
+		return "", fmt.Errorf("保存团队成员失败: %w", err)
+	}
+	if replaced {
+		util.Info("已重新生成团队成员的访问令牌: %s", member.Name)
+	} else {
+		util.Info("已添加团队成员: %s", member.Name)
+	}
+	return token, nil
+}
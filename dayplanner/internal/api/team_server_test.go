@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/teamclient"
+)
+
+// newTestTeamServer 创建有一名组长和两名成员的团队服务器
+func newTestTeamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	tempDir := t.TempDir()
+	configService := service.NewConfigService(repository.NewFileConfigRepository(filepath.Join(tempDir, "config.json")))
+	config, err := configService.GetConfig()
+	if err != nil {
+		t.Fatalf("获取配置失败: %v", err)
+	}
+	config.TeamMembers = []model.TeamMember{
+		{Name: "lead", Token: "lead-token", Lead: true},
+		{Name: "alice", Token: "alice-token", Mention: "alice"},
+		{Name: "bob", Token: "bob-token"},
+	}
+	if err := configService.UpdateConfig(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+
+	server := httptest.NewServer(NewTeamServer(repository.NewFileTeamTaskRepository(filepath.Join(tempDir, "team")), configService).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTeamClient 创建使用指定令牌的团队服务器客户端
+func newTeamClient(t *testing.T, server *httptest.Server, token string) *teamclient.Client {
+	t.Helper()
+	client, err := teamclient.NewClient(server.URL, token)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	return client
+}
+
+func TestTeamServer(t *testing.T) {
+	server := newTestTeamServer(t)
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	// 成员通过团队服务器存储读写自己的日报
+	aliceDir := t.TempDir()
+	aliceTasks := service.NewTaskService(repository.NewRemoteTaskRepository(newTeamClient(t, server, "alice-token")), aliceDir)
+	if err := aliceTasks.SaveTask(date, "- [x] 完成登录接口"); err != nil {
+		t.Fatalf("保存日报失败: %v", err)
+	}
+	task, err := aliceTasks.GetTask(date)
+	if err != nil || task == nil || task.Content != "- [x] 完成登录接口" || !task.Date.Equal(date) {
+		t.Fatalf("读取日报不正确: %+v, %v", task, err)
+	}
+	dates, err := aliceTasks.GetMonthTaskDates(2025, time.November)
+	if err != nil || len(dates) != 1 || !dates[0].Equal(date) {
+		t.Errorf("有日报的日期不正确: %v, %v", dates, err)
+	}
+	results, err := aliceTasks.Search("登录")
+	if err != nil || len(results) != 1 || !results[0].Date.Equal(date) {
+		t.Errorf("搜索结果不正确: %+v, %v", results, err)
+	}
+
+	// 成员之间互相看不到日报
+	bob := repository.NewRemoteTaskRepository(newTeamClient(t, server, "bob-token"))
+	if task, err := bob.GetByDate(date); err != nil || task != nil {
+		t.Errorf("其他成员不应读到 alice 的日报: %+v, %v", task, err)
+	}
+	if _, err := service.NewRemoteTeamService(newTeamClient(t, server, "bob-token")).Status(date); !errors.Is(err, teamclient.ErrForbidden) {
+		t.Errorf("非组长查看填报情况应返回 ErrForbidden，实际: %v", err)
+	}
+	if _, err := newTeamClient(t, server, "wrong").Me(); !errors.Is(err, teamclient.ErrUnauthorized) {
+		t.Errorf("错误的令牌应返回 ErrUnauthorized，实际: %v", err)
+	}
+
+	// 组长查看填报情况和成员的日报
+	leadClient := newTeamClient(t, server, "lead-token")
+	me, err := leadClient.Me()
+	if err != nil || me.Name != "lead" || !me.Lead {
+		t.Errorf("组长身份不正确: %+v, %v", me, err)
+	}
+	teamService := service.NewRemoteTeamService(leadClient)
+	status, err := teamService.Status(date)
+	if err != nil {
+		t.Fatalf("获取填报情况失败: %v", err)
+	}
+	if filed := status.Filed(); len(filed) != 1 || filed[0].Name != "alice" || filed[0].UpdatedAt.IsZero() {
+		t.Errorf("已填写成员不正确: %+v", filed)
+	}
+	if missing := status.Missing(); len(missing) != 2 || missing[0].Name != "lead" || missing[1].Name != "bob" {
+		t.Errorf("未填写成员不正确: %+v", missing)
+	}
+	task, err = teamService.GetMemberTask("alice", date)
+	if err != nil || task == nil || task.Content != "- [x] 完成登录接口" {
+		t.Errorf("组长读取成员日报不正确: %+v, %v", task, err)
+	}
+	if task, err := teamService.GetMemberTask("nobody", date); err != nil || task != nil {
+		t.Errorf("成员不存在时应没有日报: %+v, %v", task, err)
+	}
+
+	if status, _ := request(t, server, http.MethodPut, "/team/v1/tasks/2025-13-01", "alice-token", `{"content":"x"}`); status != http.StatusBadRequest {
+		t.Errorf("无效日期应返回 400，实际: %d", status)
+	}
+}
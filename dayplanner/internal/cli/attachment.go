@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "attachment",
+		summary: "管理日报附件：add 添加文件并插入日报，list 列出附件，rm 删除附件，gc 清理未被引用的附件",
+		run:     (*App).runAttachment,
+	})
+}
+
+// runAttachment 执行 attachment 子命令
+func (a *App) runAttachment(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法: daily-report attachment add|list|rm|gc [参数]")
+	}
+	switch args[0] {
+	case "add":
+		return a.runAttachmentAdd(args[1:])
+	case "list":
+		return a.runAttachmentList(args[1:])
+	case "rm":
+		return a.runAttachmentRemove(args[1:])
+	case "gc":
+		return a.runAttachmentGC(args[1:])
+	default:
+		return fmt.Errorf("未知的 attachment 子命令: %s (可选: add、list、rm、gc)", args[0])
+	}
+}
+
+// openAttachments 初始化服务，不支持附件时返回错误
+func (a *App) openAttachments() (*services, error) {
+	svc, err := a.openServices()
+	if err != nil {
+		return nil, err
+	}
+	if svc.attachmentService == nil {
+		svc.Close()
+		return nil, fmt.Errorf("启用加密或使用团队服务器存储时不支持附件")
+	}
+	return svc, nil
+}
+
+// runAttachmentAdd 添加附件，并在当天日报末尾追加引用它的列表项
+func (a *App) runAttachmentAdd(args []string) error {
+	fs := a.newFlagSet("attachment add")
+	dateFlag := fs.String("date", "", "日期 (YYYY-MM-DD)，默认今天")
+	noInsert := fs.Bool("no-insert", false, "只保存附件并输出链接，不修改日报")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("用法: daily-report attachment add [--date YYYY-MM-DD] [--no-insert] <文件>...")
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openAttachments()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	var links []string
+	for _, file := range fs.Args() {
+		_, markdown, err := svc.attachmentService.AddFile(date, file)
+		if err != nil {
+			return err
+		}
+		links = append(links, markdown)
+		fmt.Fprintln(a.stdout, markdown)
+	}
+	if *noInsert {
+		return nil
+	}
+
+	task, err := svc.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	content := ""
+	if task != nil {
+		content = strings.TrimRight(task.Content, "\n")
+	}
+	if content != "" {
+		content += "\n"
+	}
+	for _, link := range links {
+		content += "- " + link + "\n"
+	}
+	if err := svc.taskService.SaveTask(date, content); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已添加到 %s 的日报\n", date.Format("2006-01-02"))
+	return nil
+}
+
+// runAttachmentList 列出指定日期的附件
+func (a *App) runAttachmentList(args []string) error {
+	fs := a.newFlagSet("attachment list")
+	dateFlag := fs.String("date", "", "日期 (YYYY-MM-DD)，默认今天")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openAttachments()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	attachments, err := svc.attachmentService.List(date)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		fmt.Fprintf(a.stdout, "%s 没有附件\n", date.Format("2006-01-02"))
+		return nil
+	}
+	for _, attachment := range attachments {
+		fmt.Fprintf(a.stdout, "%s\t%s\n", attachment.Link, formatSize(attachment.Size))
+	}
+	return nil
+}
+
+// runAttachmentRemove 删除附件，并删除日报中对它的引用
+func (a *App) runAttachmentRemove(args []string) error {
+	fs := a.newFlagSet("attachment rm")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("用法: daily-report attachment rm <附件链接>...")
+	}
+
+	svc, err := a.openAttachments()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	for _, link := range fs.Args() {
+		if err := svc.attachmentService.Delete(link); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "已删除 %s\n", link)
+	}
+	return nil
+}
+
+// runAttachmentGC 清理没有被任何日报引用的附件
+func (a *App) runAttachmentGC(args []string) error {
+	fs := a.newFlagSet("attachment gc")
+	dryRun := fs.Bool("dry-run", false, "只列出将被删除的附件，不删除")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := a.openAttachments()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	orphans, err := svc.attachmentService.CollectGarbage(*dryRun)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, attachment := range orphans {
+		total += attachment.Size
+		fmt.Fprintf(a.stdout, "%s\t%s\n", attachment.Link, formatSize(attachment.Size))
+	}
+	switch {
+	case len(orphans) == 0:
+		fmt.Fprintln(a.stdout, "没有未被引用的附件")
+	case *dryRun:
+		fmt.Fprintf(a.stdout, "共 %d 个未被引用的附件 (%s)，去掉 --dry-run 后删除\n", len(orphans), formatSize(total))
+	default:
+		fmt.Fprintf(a.stdout, "已删除 %d 个未被引用的附件 (%s)\n", len(orphans), formatSize(total))
+	}
+	return nil
+}
+
+// formatSize 将字节数格式化为便于阅读的大小
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "check",
+		summary: "检查数据文件，隔离无法解析的日报，列出或恢复未保存的修改",
+		run:     (*App).runCheck,
+	})
+}
+
+// runCheck 执行 check 子命令：与图形界面启动时相同检查数据完整性，并处理保存日志中未保存的修改
+func (a *App) runCheck(args []string) error {
+	fs := a.newFlagSet("check")
+	recoverJournal := fs.Bool("recover", false, "将保存日志中未保存的修改保存为日报")
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, "用法: daily-report check [--recover]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// 先检查日报文件，配置文件损坏时下面加载配置会报错
+	report, err := repository.CheckIntegrity(a.dataPath, a.configPath, repository.DefaultQuarantinePath(a.dataPath))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已检查 %d 个文件\n", report.Checked)
+	for _, path := range report.Quarantined {
+		fmt.Fprintf(a.stdout, "已隔离损坏的文件: %s\n", path)
+	}
+	if len(report.Quarantined) > 0 {
+		fmt.Fprintf(a.stdout, "隔离目录: %s\n", report.QuarantineDir)
+	}
+	if report.TempRemoved > 0 {
+		fmt.Fprintf(a.stdout, "已删除 %d 个写入中断留下的临时文件\n", report.TempRemoved)
+	}
+
+	// 启用加密时不记录保存日志，无需解锁
+	config, err := repository.NewFileConfigRepository(a.configPath).Load()
+	if err != nil {
+		return err
+	}
+	if config.Encryption != "" {
+		return nil
+	}
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	journal := service.NewJournalServiceFromConfig(svc.config, a.dataPath, svc.taskService)
+	pending, err := journal.Pending()
+	if err != nil {
+		return err
+	}
+	for _, entry := range pending {
+		if !*recoverJournal {
+			fmt.Fprintf(a.stdout, "未保存的修改: %s（%s）\n", entry.Date, entry.UpdatedAt.Local().Format("2006-01-02 15:04"))
+			continue
+		}
+		if err := journal.Recover(entry); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "已恢复: %s\n", entry.Date)
+	}
+	if len(pending) > 0 && !*recoverJournal {
+		fmt.Fprintln(a.stdout, "使用 --recover 恢复这些修改，或在图形界面启动时选择恢复")
+	}
+	return nil
+}
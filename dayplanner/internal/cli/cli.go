@@ -0,0 +1,107 @@
+// Package cli 实现 daily-report 的命令行子命令
+// 子命令直接复用 repository/service 层，不依赖 Fyne，可以在服务器和脚本中使用
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command 子命令定义
+type command struct {
+	name    string
+	summary string
+	run     func(a *App, args []string) error
+}
+
+// commands 所有可用的子命令
+var commands = map[string]command{}
+
+// registerCommand 注册子命令，由各子命令文件在 init 中调用
+func registerCommand(cmd command) {
+	commands[cmd.name] = cmd
+}
+
+// IsCommand 判断参数是否为命令行子命令（包括帮助参数）
+func IsCommand(name string) bool {
+	if name == "help" || name == "-h" || name == "--help" {
+		return true
+	}
+	_, ok := commands[name]
+	return ok
+}
+
+// App 命令行应用
+type App struct {
+	configPath string
+	dataPath   string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// NewApp 创建新的命令行应用
+func NewApp(configPath, dataPath string) *App {
+	return &App{
+		configPath: configPath,
+		dataPath:   dataPath,
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+	}
+}
+
+// Run 执行子命令并返回进程退出码
+func (a *App) Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.printUsage()
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "未知命令: %s\n\n", args[0])
+		a.printUsage()
+		return 2
+	}
+
+	if err := cmd.run(a, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(a.stderr, "%s 失败: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// newFlagSet 创建子命令的参数解析器，错误信息输出到标准错误
+func (a *App) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// printUsage 输出命令帮助
+func (a *App) printUsage() {
+	fmt.Fprintln(a.stdout, "用法: daily-report [命令] [参数]")
+	fmt.Fprintln(a.stdout, "不带命令启动时打开图形界面。")
+	fmt.Fprintln(a.stdout)
+	fmt.Fprintln(a.stdout, "命令:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(a.stdout, "  %-10s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(a.stdout)
+	fmt.Fprintln(a.stdout, "使用 daily-report <命令> -h 查看命令参数。")
+}
@@ -0,0 +1,614 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/api"
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+// newTestApp 创建使用临时目录和内存输出的命令行应用
+func newTestApp(t *testing.T) (*App, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	tempDir := t.TempDir()
+	app := NewApp(filepath.Join(tempDir, "config", "config.json"), filepath.Join(tempDir, "data", "tasks"))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	app.stdin = strings.NewReader("")
+	app.stdout = stdout
+	app.stderr = stderr
+	return app, stdout, stderr
+}
+
+func TestApp_UnknownCommand(t *testing.T) {
+	app, _, stderr := newTestApp(t)
+
+	if code := app.Run([]string{"unknown"}); code != 2 {
+		t.Errorf("未知命令应返回退出码 2，实际: %d", code)
+	}
+	if !strings.Contains(stderr.String(), "未知命令") {
+		t.Errorf("未知命令应输出错误提示，实际: %s", stderr.String())
+	}
+}
+
+func TestApp_Migrate(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	source := repository.NewFileTaskRepository(app.dataPath)
+	for day := 1; day <= 3; day++ {
+		task := &model.Task{
+			Date:      time.Date(2025, 11, day, 0, 0, 0, 0, time.UTC),
+			Content:   "- 迁移测试",
+			CreatedAt: time.Date(2025, 11, day, 9, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2025, 11, day, 18, 0, 0, 0, time.UTC),
+		}
+		if err := source.Save(task); err != nil {
+			t.Fatalf("写入测试任务失败: %v", err)
+		}
+	}
+
+	if code := app.Run([]string{"migrate", "--to", "sqlite"}); code != 0 {
+		t.Fatalf("迁移命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "校验通过") {
+		t.Errorf("迁移输出缺少校验结果: %s", stdout.String())
+	}
+
+	target, err := repository.NewSQLiteTaskRepository(repository.DefaultDatabasePath(app.dataPath))
+	if err != nil {
+		t.Fatalf("打开目标数据库失败: %v", err)
+	}
+	defer target.Close()
+
+	hasTask, err := target.HasTask(time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil || !hasTask {
+		t.Errorf("目标数据库缺少迁移的任务: %v", err)
+	}
+
+	// 源和目标相同时应拒绝
+	if code := app.Run([]string{"migrate", "--from", "sqlite", "--to", "sqlite"}); code == 0 {
+		t.Error("源和目标相同时应该失败")
+	}
+}
+
+func TestApp_Report(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	taskRepo := repository.NewFileTaskRepository(app.dataPath)
+	task := &model.Task{
+		Date:      time.Date(2025, 11, 11, 0, 0, 0, 0, time.Local),
+		Content:   "- [x] 发布 1.1 版本",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := taskRepo.Save(task); err != nil {
+		t.Fatalf("写入测试任务失败: %v", err)
+	}
+
+	if code := app.Run([]string{"report", "--week", "2025-11-13"}); code != 0 {
+		t.Fatalf("report 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "# 周报 (2025-11-10 ~ 2025-11-16)") ||
+		!strings.Contains(stdout.String(), "发布 1.1 版本") {
+		t.Errorf("周报输出不正确:\n%s", stdout.String())
+	}
+
+	output := filepath.Join(t.TempDir(), "month.md")
+	if code := app.Run([]string{"report", "--month", "2025-11", "-o", output}); code != 0 {
+		t.Fatalf("report --month 失败，退出码 %d: %s", code, stderr.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("读取报告文件失败: %v", err)
+	}
+	if !strings.Contains(string(data), "2025年11月 月报") {
+		t.Errorf("月报内容不正确:\n%s", data)
+	}
+
+	if code := app.Run([]string{"report", "--from", "2025-11-10"}); code == 0 {
+		t.Error("只指定 --from 时应该失败")
+	}
+}
+
+func TestApp_Export(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	taskRepo := repository.NewFileTaskRepository(app.dataPath)
+	task := &model.Task{
+		Date:      time.Date(2025, 11, 11, 0, 0, 0, 0, time.Local),
+		Content:   "- [x] 发布 1.1 版本",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := taskRepo.Save(task); err != nil {
+		t.Fatalf("写入测试任务失败: %v", err)
+	}
+
+	if code := app.Run([]string{"export", "--date", "2025-11-11", "-o", "-"}); code != 0 {
+		t.Fatalf("export 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "<!DOCTYPE html>") || !strings.Contains(stdout.String(), "发布 1.1 版本") {
+		t.Errorf("HTML 输出不正确:\n%s", stdout.String())
+	}
+
+	output := filepath.Join(t.TempDir(), "month.docx")
+	if code := app.Run([]string{"export", "--format", "docx", "--month", "2025-11", "-o", output}); code != 0 {
+		t.Fatalf("export --format docx 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("DOCX 文件未生成: %v", err)
+	}
+
+	if code := app.Run([]string{"export", "--week", "2025-11-10", "--month", "2025-11"}); code == 0 {
+		t.Error("同时指定多种日期范围时应该失败")
+	}
+	if code := app.Run([]string{"export", "--format", "epub"}); code == 0 {
+		t.Error("不支持的格式应该失败")
+	}
+}
+
+func TestApp_Import(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	vault := t.TempDir()
+	if err := os.WriteFile(filepath.Join(vault, "2025-11-10.md"), []byte("- [x] 从 Obsidian 导入\n"), 0644); err != nil {
+		t.Fatalf("写入笔记失败: %v", err)
+	}
+
+	if code := app.Run([]string{"import", "--dry-run", vault}); code != 0 {
+		t.Fatalf("import --dry-run 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2025-11-10  新建") || !strings.Contains(stdout.String(), "试运行") {
+		t.Errorf("试运行输出不正确:\n%s", stdout.String())
+	}
+	if has, _ := repository.NewFileTaskRepository(app.dataPath).HasTask(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)); has {
+		t.Error("试运行不应写入数据")
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"import", "--mode", "skip", vault}); code != 0 {
+		t.Fatalf("import 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "已导入 1 天") {
+		t.Errorf("导入输出不正确:\n%s", stdout.String())
+	}
+
+	if code := app.Run([]string{"import"}); code == 0 {
+		t.Error("未指定导入来源时应该失败")
+	}
+}
+
+func TestApp_AddShowList(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	if code := app.Run([]string{"add", "--date", "2025-11-10", "#", "今日工作"}); code != 0 {
+		t.Fatalf("add 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	app.stdin = strings.NewReader("- [x] 从标准输入追加\n")
+	if code := app.Run([]string{"add", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("add 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"show", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("show 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "# 今日工作\n- [x] 从标准输入追加\n" {
+		t.Errorf("show 输出不正确: %q", got)
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"show", "--date", "2025-11-10", "--html"}); code != 0 {
+		t.Fatalf("show --html 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "<h1") {
+		t.Errorf("show --html 应输出 HTML: %s", stdout.String())
+	}
+
+	if code := app.Run([]string{"show", "--date", "2025-11-11"}); code != 1 {
+		t.Errorf("没有日报时 show 应返回退出码 1，实际: %d", code)
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"list", "--month", "2025-11"}); code != 0 {
+		t.Fatalf("list 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "2025-11-10 星期一  # 今日工作\n" {
+		t.Errorf("list 输出不正确: %q", got)
+	}
+}
+
+func TestApp_Stats(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	days := map[string]string{
+		"2025-11-10": "- [x] 登录接口 #后端 +支付 (2h)\n",
+		"2025-11-11": "- [x] 联调 #后端 +支付 (1.5h)\n- 周会 (30m)\n",
+	}
+	for date, content := range days {
+		app.stdin = strings.NewReader(content)
+		if code := app.Run([]string{"add", "--date", date}); code != 0 {
+			t.Fatalf("add 命令失败，退出码 %d: %s", code, stderr.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"stats", "--from", "2025-11-10", "--to", "2025-11-11"}); code != 0 {
+		t.Fatalf("stats 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	for _, s := range []string{"总耗时: 4h（3 条记录）", "后端", "3.5h"} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Errorf("stats 输出缺少 %q:\n%s", s, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"stats", "--week", "2025-11-10", "--format", "csv"}); code != 0 {
+		t.Fatalf("stats --format csv 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "项目,支付,3.5,2,") {
+		t.Errorf("CSV 输出不正确:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"stats", "--month", "2025-11", "--format", "json"}); code != 0 {
+		t.Fatalf("stats --format json 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"total_hours": 4`) {
+		t.Errorf("JSON 输出不正确:\n%s", stdout.String())
+	}
+
+	if code := app.Run([]string{"stats", "--format", "xml"}); code != 1 {
+		t.Errorf("不支持的格式应返回退出码 1，实际: %d", code)
+	}
+}
+
+func TestApp_Items(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	app.stdin = strings.NewReader("- [x] 完成登录接口 #后端 (2h)\n- [ ] 联调支付 ~1h !blocked\n")
+	if code := app.Run([]string{"add", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("add 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"items", "--week", "2025-11-12", "--status", "blocked"}); code != 0 {
+		t.Fatalf("items 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "2025-11-10  [ ] 联调支付 ~1h !blocked\n" {
+		t.Errorf("items 输出不正确: %q", got)
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"items", "--date", "2025-11-10", "--json"}); code != 0 {
+		t.Fatalf("items --json 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"actual_minutes": 120`) {
+		t.Errorf("items --json 输出不正确: %s", stdout.String())
+	}
+}
+
+func TestApp_Carry(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	app.stdin = strings.NewReader("- [x] 完成评审\n- [ ] 编写文档\n")
+	if code := app.Run([]string{"add", "--date", "2025-11-14"}); code != 0 {
+		t.Fatalf("add 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"carry", "--date", "2025-11-17", "--dry-run"}); code != 0 {
+		t.Fatalf("carry --dry-run 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "2025-11-14 未完成的任务项:\n- [ ] 编写文档\n" {
+		t.Errorf("carry --dry-run 输出不正确: %q", got)
+	}
+
+	if code := app.Run([]string{"carry", "--date", "2025-11-17"}); code != 0 {
+		t.Fatalf("carry 失败，退出码 %d: %s", code, stderr.String())
+	}
+	stdout.Reset()
+	app.Run([]string{"show", "--date", "2025-11-17"})
+	if got := stdout.String(); got != "- [ ] 编写文档\n" {
+		t.Errorf("顺延结果不正确: %q", got)
+	}
+
+	// 再次执行时没有可顺延的任务项
+	stdout.Reset()
+	app.Run([]string{"carry", "--date", "2025-11-17"})
+	if !strings.Contains(stdout.String(), "没有可顺延") {
+		t.Errorf("重复顺延应提示没有可顺延的任务项: %q", stdout.String())
+	}
+}
+
+func TestApp_Edit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("测试使用 shell 脚本模拟编辑器")
+	}
+	app, stdout, stderr := newTestApp(t)
+
+	// 用脚本模拟编辑器：在文件末尾追加一行
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho '- 编辑器追加' >> \"$1\"\n"), 0755); err != nil {
+		t.Fatalf("创建编辑器脚本失败: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	if code := app.Run([]string{"edit", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("edit 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	app.Run([]string{"show", "--date", "2025-11-10"})
+	if got := stdout.String(); got != "- 编辑器追加\n" {
+		t.Errorf("编辑结果不正确: %q", got)
+	}
+
+	// 空白日期预填模板
+	templateDir := filepath.Join(filepath.Dir(app.configPath), "templates")
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("创建模板目录失败: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "plan.md"), []byte("# {{.Date}} {{.Weekday}}\n"), 0644); err != nil {
+		t.Fatalf("写入模板失败: %v", err)
+	}
+	if code := app.Run([]string{"edit", "--date", "2025-11-12", "--template", "plan"}); code != 0 {
+		t.Fatalf("edit --template 命令失败，退出码 %d: %s", code, stderr.String())
+	}
+	stdout.Reset()
+	app.Run([]string{"show", "--date", "2025-11-12"})
+	if got := stdout.String(); got != "# 2025-11-12 星期三\n- 编辑器追加\n" {
+		t.Errorf("模板编辑结果不正确: %q", got)
+	}
+}
+
+func TestApp_RemindOnce(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	configRepo := repository.NewFileConfigRepository(app.configPath)
+	config := &model.Config{WebhookURL: server.URL, ReminderTime: "10:00", DataPath: app.dataPath}
+	if err := configRepo.Save(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+
+	if code := app.Run([]string{"remind", "--once"}); code != 0 {
+		t.Fatalf("remind --once 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if requests != 1 || !strings.Contains(stdout.String(), "已发送提醒") {
+		t.Errorf("今天没有日报时应发送提醒: 请求 %d 次, 输出 %s", requests, stdout.String())
+	}
+}
+
+func TestApp_Sync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("未安装 git")
+	}
+	app, stdout, stderr := newTestApp(t)
+
+	if code := app.Run([]string{"sync"}); code != 1 || !strings.Contains(stderr.String(), "未启用同步") {
+		t.Errorf("未启用同步时应返回错误，退出码 %d: %s", code, stderr.String())
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("创建裸仓库失败: %v: %s", err, output)
+	}
+	configRepo := repository.NewFileConfigRepository(app.configPath)
+	config := &model.Config{ReminderTime: "10:00", DataPath: app.dataPath, SyncBackend: model.SyncBackendGit, SyncRemote: remote}
+	if err := configRepo.Save(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+
+	if code := app.Run([]string{"add", "--date", "2025-11-10", "命令行添加"}); code != 0 {
+		t.Fatalf("add 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if code := app.Run([]string{"sync"}); code != 0 || !strings.Contains(stdout.String(), "同步完成") {
+		t.Fatalf("sync 失败，退出码 %d: %s", code, stderr.String())
+	}
+	output, err := exec.Command("git", "-C", remote, "show", "main:2025-11-10.json").CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "命令行添加") {
+		t.Errorf("远端应包含命令行添加的日报: %v: %s", err, output)
+	}
+}
+
+func TestApp_Rekey(t *testing.T) {
+	original := keystore.DefaultParams
+	keystore.DefaultParams = keystore.Params{Time: 1, Memory: 64, Threads: 1}
+	t.Cleanup(func() { keystore.DefaultParams = original })
+	app, stdout, stderr := newTestApp(t)
+
+	configRepo := repository.NewFileConfigRepository(app.configPath)
+	config := &model.Config{ReminderTime: "10:00", DataPath: app.dataPath, Encryption: model.EncryptionPassphrase}
+	if err := configRepo.Save(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+
+	t.Setenv(passphraseEnv, "旧口令")
+	if code := app.Run([]string{"add", "--date", "2025-11-10", "加密的日报"}); code != 0 {
+		t.Fatalf("add 失败，退出码 %d: %s", code, stderr.String())
+	}
+	data, err := os.ReadFile(filepath.Join(app.dataPath, "2025-11-10.json"))
+	if err != nil || strings.Contains(string(data), "加密的日报") {
+		t.Fatalf("日报文件应已加密: %v: %s", err, data)
+	}
+
+	t.Setenv(newPassphraseEnv, "新口令")
+	if code := app.Run([]string{"rekey"}); code != 0 || !strings.Contains(stdout.String(), "已更换密钥") {
+		t.Fatalf("rekey 失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	stderr.Reset()
+	if code := app.Run([]string{"show", "--date", "2025-11-10"}); code != 1 || !strings.Contains(stderr.String(), "口令错误") {
+		t.Errorf("旧口令应无法解锁，退出码 %d: %s", code, stderr.String())
+	}
+	t.Setenv(passphraseEnv, "新口令")
+	stdout.Reset()
+	if code := app.Run([]string{"show", "--date", "2025-11-10"}); code != 0 || !strings.Contains(stdout.String(), "加密的日报") {
+		t.Errorf("新口令应能读取日报，退出码 %d: %s", code, stderr.String())
+	}
+}
+
+func TestApp_Team(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	if code := app.Run([]string{"team", "add-member", "--lead", "alice"}); code != 0 {
+		t.Fatalf("add-member 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if code := app.Run([]string{"team", "add-member", "--mention", "13800000000", "bob"}); code != 0 {
+		t.Fatalf("add-member 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if code := app.Run([]string{"team", "add-member", "../evil"}); code == 0 {
+		t.Error("无效的成员名应返回错误")
+	}
+	config, err := repository.NewFileConfigRepository(app.configPath).Load()
+	if err != nil {
+		t.Fatalf("读取配置失败: %v", err)
+	}
+	if len(config.TeamMembers) != 2 || !config.TeamMembers[0].Lead || config.TeamMembers[1].Mention != "13800000000" {
+		t.Fatalf("团队成员不正确: %+v", config.TeamMembers)
+	}
+	aliceToken := config.TeamMembers[0].Token
+
+	// 成员通过 migrate --to team 将本机日报上传到团队服务器
+	teamRepo := repository.NewFileTeamTaskRepository(repository.DefaultTeamDataPath(app.dataPath))
+	server := httptest.NewServer(api.NewTeamServer(teamRepo, service.NewConfigService(repository.NewFileConfigRepository(app.configPath))).Handler())
+	defer server.Close()
+	config.TeamServer = server.URL
+	config.TeamToken = aliceToken
+	if err := repository.NewFileConfigRepository(app.configPath).Save(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+	local := repository.NewFileTaskRepository(app.dataPath)
+	if err := local.Save(&model.Task{Date: time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local), Content: "- 团队日报"}); err != nil {
+		t.Fatalf("写入测试任务失败: %v", err)
+	}
+	stdout.Reset()
+	if code := app.Run([]string{"migrate", "--to", "team"}); code != 0 || !strings.Contains(stdout.String(), "校验通过") {
+		t.Fatalf("migrate --to team 失败，退出码 %d: %s%s", code, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"team", "status", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("team status 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "已填写 1/2") || !strings.Contains(stdout.String(), "未填写: bob") {
+		t.Errorf("填报情况输出不正确:\n%s", stdout.String())
+	}
+}
+
+func TestApp_Attachment(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+
+	dir := t.TempDir()
+	image := filepath.Join(dir, "截图.png")
+	orphan := filepath.Join(dir, "旧文件.txt")
+	os.WriteFile(image, []byte("png"), 0644)
+	os.WriteFile(orphan, []byte("txt"), 0644)
+
+	if code := app.Run([]string{"attachment", "add", "--date", "2025-11-10", image}); code != 0 {
+		t.Fatalf("attachment add 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if code := app.Run([]string{"attachment", "add", "--date", "2025-11-10", "--no-insert", orphan}); code != 0 {
+		t.Fatalf("attachment add --no-insert 失败，退出码 %d: %s", code, stderr.String())
+	}
+	task, err := repository.NewFileTaskRepository(app.dataPath).GetByDate(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local))
+	if err != nil || task == nil || !strings.HasPrefix(task.Content, "- ![截图-") || strings.Contains(task.Content, "旧文件") {
+		t.Fatalf("日报中应只插入第一个附件: %+v, %v", task, err)
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"attachment", "list", "--date", "2025-11-10"}); code != 0 {
+		t.Fatalf("attachment list 失败，退出码 %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "\t3 B") {
+		t.Fatalf("attachment list 输出不正确:\n%s", stdout.String())
+	}
+
+	// 超过保留时间的未引用附件被清理
+	orphanLink, _, _ := strings.Cut(lines[1], "\t")
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(filepath.Join(app.dataPath, filepath.FromSlash(orphanLink)), old, old)
+	stdout.Reset()
+	if code := app.Run([]string{"attachment", "gc", "--dry-run"}); code != 0 {
+		t.Fatalf("attachment gc --dry-run 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), orphanLink) || !strings.Contains(stdout.String(), "共 1 个未被引用的附件") {
+		t.Errorf("试运行输出不正确:\n%s", stdout.String())
+	}
+	if code := app.Run([]string{"attachment", "gc"}); code != 0 {
+		t.Fatalf("attachment gc 失败，退出码 %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(app.dataPath, filepath.FromSlash(orphanLink))); !os.IsNotExist(err) {
+		t.Errorf("未引用的附件应删除: %v", err)
+	}
+
+	imageLink, _, _ := strings.Cut(lines[0], "\t")
+	if code := app.Run([]string{"attachment", "rm", imageLink}); code != 0 {
+		t.Fatalf("attachment rm 失败，退出码 %d: %s", code, stderr.String())
+	}
+	task, _ = repository.NewFileTaskRepository(app.dataPath).GetByDate(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local))
+	if task == nil || strings.Contains(task.Content, "截图") {
+		t.Errorf("删除附件后应删除日报中的引用: %+v", task)
+	}
+	if code := app.Run([]string{"attachment", "rm", "../config.json"}); code == 0 {
+		t.Error("无效的附件链接应该失败")
+	}
+}
+
+func TestApp_Check(t *testing.T) {
+	app, stdout, stderr := newTestApp(t)
+	if code := app.Run([]string{"add", "--date", "2025-11-10", "页面改版"}); code != 0 {
+		t.Fatalf("add 失败，退出码 %d: %s", code, stderr.String())
+	}
+
+	// 写入中断的日报和上次没有保存的修改
+	if err := os.WriteFile(filepath.Join(app.dataPath, "2025-11-11.json"), []byte(`{"date":`), 0600); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	journalRepo := repository.NewFileJournalRepository(repository.DefaultJournalPath(app.dataPath))
+	if err := journalRepo.Save([]*model.JournalEntry{{Date: "2025-11-10", Content: "- 页面改版\n- 修复登录", UpdatedAt: time.Now()}}); err != nil {
+		t.Fatalf("写入保存日志失败: %v", err)
+	}
+
+	if code := app.Run([]string{"check"}); code != 0 {
+		t.Fatalf("check 失败，退出码 %d: %s", code, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "已隔离损坏的文件") || !strings.Contains(output, "2025-11-11.json") {
+		t.Errorf("应隔离损坏的日报: %s", output)
+	}
+	if !strings.Contains(output, "未保存的修改: 2025-11-10") {
+		t.Errorf("应列出未保存的修改: %s", output)
+	}
+
+	stdout.Reset()
+	if code := app.Run([]string{"check", "--recover"}); code != 0 || !strings.Contains(stdout.String(), "已恢复: 2025-11-10") {
+		t.Fatalf("check --recover 失败，退出码 %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	if code := app.Run([]string{"show", "--date", "2025-11-10"}); code != 0 || !strings.Contains(stdout.String(), "修复登录") {
+		t.Errorf("恢复后日报应包含未保存的修改: %s", stdout.String())
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+const (
+	// passphraseEnv 提供加密口令的环境变量，设置后不再交互输入
+	passphraseEnv = "DAILY_REPORT_PASSPHRASE"
+
+	// newPassphraseEnv rekey 命令使用的新口令
+	newPassphraseEnv = "DAILY_REPORT_NEW_PASSPHRASE"
+)
+
+// openEncrypted 按配置为任务仓库加上加密装饰器，使用口令时从环境变量或终端读取
+func (a *App) openEncrypted(config *model.Config, taskRepo repository.TaskRepository) (*repository.EncryptedTaskRepository, error) {
+	passphrase := ""
+	if config.Encryption == model.EncryptionPassphrase {
+		// 首次启用加密时要求输入两次，避免口令输错后无法解密
+		creating := !keystore.Exists(keystore.KeyFilePath(a.dataPath))
+		var err error
+		passphrase, err = a.readPassphrase(passphraseEnv, "请输入加密口令: ", creating)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := repository.EncryptionKeySource(config, a.dataPath, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := repository.OpenEncryptedTaskRepository(taskRepo, a.dataPath, source)
+	if err != nil {
+		return nil, fmt.Errorf("解锁加密数据失败: %w", err)
+	}
+	return encrypted, nil
+}
+
+// readPassphrase 读取口令：优先使用环境变量，否则在终端中提示输入（不回显），confirm 为 true 时需要输入两次
+func (a *App) readPassphrase(envName, prompt string, confirm bool) (string, error) {
+	if value := os.Getenv(envName); value != "" {
+		return value, nil
+	}
+
+	file, ok := a.stdin.(*os.File)
+	if !ok || !isTerminal(file) {
+		return "", fmt.Errorf("无法交互输入口令，请设置环境变量 %s", envName)
+	}
+
+	passphrase, err := a.promptHidden(file, prompt)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("口令不能为空")
+	}
+	if confirm {
+		again, err := a.promptHidden(file, "请再次输入口令: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("两次输入的口令不一致")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptHidden 在标准错误输出提示并读取一行输入，尽量关闭终端回显
+func (a *App) promptHidden(file *os.File, prompt string) (string, error) {
+	fmt.Fprint(a.stderr, prompt)
+	if setEcho(file, false) {
+		defer setEcho(file, true)
+	}
+	// 按字节读取，避免缓冲区吞掉后续输入
+	line, err := bufio.NewReaderSize(file, 16).ReadString('\n')
+	fmt.Fprintln(a.stderr)
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("读取口令失败: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// isTerminal 判断文件是否为终端
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// setEcho 通过 stty 开关终端回显，不支持时返回 false（如 Windows），此时口令会回显
+func setEcho(file *os.File, on bool) bool {
+	mode := "-echo"
+	if on {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = file
+	return cmd.Run() == nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/export"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "export",
+		summary: "导出日报为 html、site、pdf 或 docx，如 --format pdf --month 2025-11",
+		run:     (*App).runExport,
+	})
+}
+
+// runExport 执行 export 子命令
+func (a *App) runExport(args []string) error {
+	fs := a.newFlagSet("export")
+	formatName := fs.String("format", "html", "导出格式: html（单个 HTML 文件）、site（静态网站目录）、pdf、docx")
+	date := fs.String("date", "", "导出指定日期的日报 (YYYY-MM-DD)，默认今天")
+	week := fs.String("week", "", "导出该日期所在周的日报 (YYYY-MM-DD)")
+	month := fs.String("month", "", "导出指定月份的日报 (YYYY-MM)")
+	from := fs.String("from", "", "自定义范围的开始日期 (YYYY-MM-DD)")
+	to := fs.String("to", "", "自定义范围的结束日期 (YYYY-MM-DD)")
+	output := fs.String("o", "", "输出文件路径（site 格式为目录），- 表示标准输出，默认按日期范围命名")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	startDate, endDate, err := exportRange(*date, *week, *month, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	exportService := service.NewExportService(svc.taskService)
+	if svc.attachmentService != nil {
+		exportService.SetAttachmentRepository(repository.NewFileAttachmentRepository(a.dataPath))
+	}
+
+	if *output == "-" {
+		if format == export.FormatSite {
+			return fmt.Errorf("site 格式需要输出到目录")
+		}
+		return exportService.Render(a.stdout, format, startDate, endDate)
+	}
+
+	path := *output
+	if path == "" {
+		path = service.ExportFileName(format, startDate, endDate)
+	}
+	if err := exportService.Export(format, startDate, endDate, path); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已导出到 %s\n", path)
+	return nil
+}
+
+// exportRange 按 --date、--week、--month、--from/--to 参数确定导出的日期范围，最多指定一种
+func exportRange(date, week, month, from, to string) (time.Time, time.Time, error) {
+	specified := 0
+	for _, value := range []string{date, week, month, from + to} {
+		if value != "" {
+			specified++
+		}
+	}
+	if specified > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--date、--week、--month 和 --from/--to 只能指定一种")
+	}
+
+	switch {
+	case from != "" || to != "":
+		if from == "" || to == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--from 和 --to 必须同时指定")
+		}
+		startDate, err := parseDate(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		endDate, err := parseDate(to)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if endDate.Before(startDate) {
+			return time.Time{}, time.Time{}, fmt.Errorf("结束日期不能早于开始日期")
+		}
+		return startDate, endDate, nil
+	case month != "":
+		year, m, err := parseMonth(month)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		startDate, endDate := util.MonthRange(year, m, time.Local)
+		return startDate, endDate, nil
+	case week != "":
+		day, err := parseDate(week)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		startDate, endDate := util.WeekRange(day)
+		return startDate, endDate, nil
+	default:
+		day, err := parseDate(date)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return day, day, nil
+	}
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "import",
+		summary: "从 Markdown 文件夹、Obsidian 日记或 Joplin 导出导入日报，--dry-run 只输出导入计划",
+		run:     (*App).runImport,
+	})
+}
+
+// runImport 执行 import 子命令
+func (a *App) runImport(args []string) error {
+	fs := a.newFlagSet("import")
+	modeName := fs.String("mode", string(service.ImportModeMerge), "已有日报的日期如何处理: merge（追加到已有内容之后）或 skip（跳过）")
+	dryRun := fs.Bool("dry-run", false, "只输出导入计划，不写入任何数据")
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, "用法: daily-report import [参数] <目录或 .jex 文件>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定一个导入目录或 .jex 文件")
+	}
+
+	mode, err := service.ParseImportMode(*modeName)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	importService := service.NewImportService(svc.taskService, svc.taskRepo)
+
+	plan, err := importService.Plan(fs.Arg(0), mode)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(a.stdout, plan.Report())
+
+	if *dryRun {
+		fmt.Fprintln(a.stdout, "试运行，未写入任何数据")
+		return nil
+	}
+	written, err := importService.Apply(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已导入 %d 天的日报\n", written)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "items",
+		summary: "列出任务项，可按状态过滤，如 --week 2025-11-10 --status done",
+		run:     (*App).runItems,
+	})
+}
+
+// itemJSON items --json 输出的任务项，耗时以分钟表示
+type itemJSON struct {
+	Date            string       `json:"date"`
+	Title           string       `json:"title"`
+	Status          string       `json:"status"`
+	EstimateMinutes int          `json:"estimate_minutes,omitempty"`
+	ActualMinutes   int          `json:"actual_minutes,omitempty"`
+	Tags            []string     `json:"tags,omitempty"`
+	Projects        []string     `json:"projects,omitempty"`
+	Links           []model.Link `json:"links,omitempty"`
+	Line            int          `json:"line"`
+}
+
+// runItems 执行 items 子命令
+func (a *App) runItems(args []string) error {
+	fs := a.newFlagSet("items")
+	date := fs.String("date", "", "列出指定日期的任务项 (YYYY-MM-DD)，默认今天")
+	week := fs.String("week", "", "列出该日期所在周的任务项 (YYYY-MM-DD)")
+	month := fs.String("month", "", "列出指定月份的任务项 (YYYY-MM)")
+	from := fs.String("from", "", "自定义范围的开始日期 (YYYY-MM-DD)")
+	to := fs.String("to", "", "自定义范围的结束日期 (YYYY-MM-DD)")
+	status := fs.String("status", "", "只列出指定状态: todo、doing、done、blocked")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	startDate, endDate, err := exportRange(*date, *week, *month, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	items, err := service.NewItemService(svc.taskService).ListItems(startDate, endDate, model.ItemStatus(*status))
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		output := make([]itemJSON, 0, len(items))
+		for _, dated := range items {
+			item := dated.Item
+			output = append(output, itemJSON{
+				Date:            dated.Date.Format("2006-01-02"),
+				Title:           item.Title,
+				Status:          string(item.Status),
+				EstimateMinutes: int(item.Estimate / time.Minute),
+				ActualMinutes:   int(item.Actual / time.Minute),
+				Tags:            item.Tags,
+				Projects:        item.Projects,
+				Links:           item.Links,
+				Line:            item.Line,
+			})
+		}
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+	for _, dated := range items {
+		fmt.Fprintf(a.stdout, "%s  %s\n", dated.Date.Format("2006-01-02"), util.RenderItem(dated.Item))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+// migrateProgressInterval 迁移进度输出间隔（任务数）
+const migrateProgressInterval = 100
+
+func init() {
+	registerCommand(command{
+		name:    "migrate",
+		summary: "在存储后端之间迁移全部任务，如 --from file:./data/tasks --to sqlite:./data/tasks.db 或 --to team",
+		run:     (*App).runMigrate,
+	})
+}
+
+// runMigrate 执行 migrate 子命令
+func (a *App) runMigrate(args []string) error {
+	fs := a.newFlagSet("migrate")
+	from := fs.String("from", "file:"+a.dataPath, "源存储，格式为 file:<目录>、sqlite:<数据库文件> 或 team:<服务器地址>")
+	to := fs.String("to", "", "目标存储，格式同 --from，省略位置时使用默认路径；团队服务器默认使用配置中的 team_server 和 team_token")
+	statePath := fs.String("state", "", "断点续传状态文件路径（默认为目标位置加 .migrate-state.json）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("必须指定 --to")
+	}
+
+	sourceBackend, sourceLocation, err := a.resolveStorageSpec(*from)
+	if err != nil {
+		return err
+	}
+	targetBackend, targetLocation, err := a.resolveStorageSpec(*to)
+	if err != nil {
+		return err
+	}
+	sourceName := sourceBackend + ":" + sourceLocation
+	targetName := targetBackend + ":" + targetLocation
+	if sourceName == targetName {
+		return fmt.Errorf("源存储和目标存储相同: %s", sourceName)
+	}
+	if *statePath == "" {
+		*statePath = targetLocation + ".migrate-state.json"
+		if targetBackend == model.StorageBackendTeam {
+			*statePath = filepath.Join(filepath.Dir(a.dataPath), "team.migrate-state.json")
+		}
+	}
+
+	source, err := a.openStorage(sourceBackend, sourceLocation)
+	if err != nil {
+		return fmt.Errorf("打开源存储失败: %w", err)
+	}
+	defer closeRepository(source)
+	target, err := a.openStorage(targetBackend, targetLocation)
+	if err != nil {
+		return fmt.Errorf("打开目标存储失败: %w", err)
+	}
+	defer closeRepository(target)
+
+	fmt.Fprintf(a.stdout, "迁移任务: %s -> %s\n", sourceName, targetName)
+	migration := service.NewMigrationService(source, target, sourceName, targetName, *statePath)
+	result, err := migration.Migrate(func(done, total int) {
+		if done%migrateProgressInterval == 0 || done == total {
+			fmt.Fprintf(a.stdout, "  进度: %d/%d\n", done, total)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(a.stderr, "迁移中断，再次执行相同命令即可从断点继续 (状态文件: %s)\n", *statePath)
+		return err
+	}
+	fmt.Fprintf(a.stdout, "复制 %d 个任务，跳过 %d 个已迁移任务\n", result.Copied, result.Skipped)
+
+	if err := migration.Verify(); err != nil {
+		return fmt.Errorf("校验失败: %w", err)
+	}
+	if err := migration.Finish(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "校验通过: 共 %d 个任务，数量和内容哈希一致\n", result.Total)
+	fmt.Fprintf(a.stdout, "如需切换到新存储，请在 %s 中设置 storage_backend 为 %q\n", a.configPath, targetBackend)
+	return nil
+}
+
+// resolveStorageSpec 解析存储描述，位置省略时使用默认路径
+func (a *App) resolveStorageSpec(spec string) (backend, location string, err error) {
+	backend, location, err = repository.ParseStorageSpec(spec)
+	if err != nil {
+		return "", "", err
+	}
+	if location == "" {
+		switch backend {
+		case model.StorageBackendFile:
+			location = a.dataPath
+		case model.StorageBackendSQLite:
+			location = repository.DefaultDatabasePath(a.dataPath)
+		case model.StorageBackendTeam:
+			config, err := a.loadConfig()
+			if err != nil {
+				return "", "", err
+			}
+			if config.TeamServer == "" {
+				return "", "", fmt.Errorf("未配置团队服务器地址 (team_server)")
+			}
+			location = config.TeamServer
+		}
+	}
+	return backend, location, nil
+}
+
+// openStorage 打开迁移使用的存储，团队服务器使用配置中本人的访问令牌
+func (a *App) openStorage(backend, location string) (repository.TaskRepository, error) {
+	if backend != model.StorageBackendTeam {
+		return repository.OpenTaskRepository(backend, location)
+	}
+	config, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return repository.NewTeamClientRepository(location, config.TeamToken)
+}
+
+// loadConfig 读取配置文件
+func (a *App) loadConfig() (*model.Config, error) {
+	return service.NewConfigService(repository.NewFileConfigRepository(a.configPath)).GetConfig()
+}
+
+// closeRepository 关闭需要释放资源的仓库（如 SQLite 连接）
+func closeRepository(repo repository.TaskRepository) {
+	if closer, ok := repo.(io.Closer); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "rekey",
+		summary: "更换加密口令，或在口令和系统钥匙串之间切换，如 --to keyring",
+		run:     (*App).runRekey,
+	})
+}
+
+// runRekey 执行 rekey 子命令，只重新加密密钥文件中的数据密钥，日报内容不需要重写
+func (a *App) runRekey(args []string) error {
+	fs := a.newFlagSet("rekey")
+	to := fs.String("to", "", "新的密钥来源: passphrase 或 keyring（默认与当前相同）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	configService := service.NewConfigService(repository.NewFileConfigRepository(a.configPath))
+	config, err := configService.GetConfig()
+	if err != nil {
+		return err
+	}
+	if config.Encryption == "" {
+		return fmt.Errorf("未启用加密，请在配置文件中设置 encryption")
+	}
+	keyPath := keystore.KeyFilePath(a.dataPath)
+	if !keystore.Exists(keyPath) {
+		return fmt.Errorf("%w: %s，请先运行一次图形界面或其他命令完成加密", keystore.ErrNoKeyFile, keyPath)
+	}
+	target := *to
+	if target == "" {
+		target = config.Encryption
+	}
+	if target != model.EncryptionPassphrase && target != model.EncryptionKeyring {
+		return fmt.Errorf("不支持的加密方式: %s (可选值: %s, %s)", target, model.EncryptionPassphrase, model.EncryptionKeyring)
+	}
+
+	oldPassphrase := ""
+	if config.Encryption == model.EncryptionPassphrase {
+		if oldPassphrase, err = a.readPassphrase(passphraseEnv, "请输入当前口令: ", false); err != nil {
+			return err
+		}
+	}
+	oldSource, err := repository.EncryptionKeySource(config, a.dataPath, oldPassphrase)
+	if err != nil {
+		return err
+	}
+
+	newPassphrase := ""
+	if target == model.EncryptionPassphrase {
+		if newPassphrase, err = a.readPassphrase(newPassphraseEnv, "请输入新口令: ", true); err != nil {
+			return err
+		}
+	}
+	newConfig := *config
+	newConfig.Encryption = target
+	newSource, err := repository.EncryptionKeySource(&newConfig, a.dataPath, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := keystore.Rekey(keyPath, oldSource, newSource); err != nil {
+		return fmt.Errorf("更换密钥失败: %w", err)
+	}
+	if target != config.Encryption {
+		if err := configService.UpdateConfig(&newConfig); err != nil {
+			return fmt.Errorf("密钥文件已改用 %s，但更新配置失败，请手动将 encryption 设置为 %s: %w", target, target, err)
+		}
+	}
+	fmt.Fprintf(a.stdout, "已更换密钥，当前密钥来源: %s\n", target)
+	return nil
+}
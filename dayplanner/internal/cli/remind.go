@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "remind",
+		summary: "运行提醒服务；--once 立即检查一次今天的日报并在未填写时发送提醒，--team 提醒团队中未填写的成员",
+		run:     (*App).runRemind,
+	})
+}
+
+// runRemind 执行 remind 子命令
+func (a *App) runRemind(args []string) error {
+	fs := a.newFlagSet("remind")
+	once := fs.Bool("once", false, "只检查一次并退出，适合由 cron 或计划任务调用")
+	team := fs.Bool("team", false, "与 --once 一起使用：检查团队所有成员，只 @ 还没有填写日报的成员")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *team && !*once {
+		return fmt.Errorf("--team 需要与 --once 一起使用，定时的团队提醒请在提醒规则中设置 \"team\": true")
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	reminderService := service.NewReminderService(svc.configService, svc.taskService)
+	reminderService.SetStateRepository(repository.NewFileReminderStateRepository(repository.DefaultReminderStatePath(a.dataPath)))
+	if teamService := a.teamServiceFor(svc); teamService != nil {
+		reminderService.SetTeamService(teamService)
+	}
+
+	if *team {
+		names, err := reminderService.RemindTeamOnce()
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			fmt.Fprintf(a.stdout, "已提醒未填写日报的成员: %s\n", strings.Join(names, "、"))
+		} else {
+			fmt.Fprintln(a.stdout, "团队成员今天都已填写日报，无需提醒")
+		}
+		return nil
+	}
+
+	if *once {
+		sent, err := reminderService.RemindOnce()
+		if err != nil {
+			return err
+		}
+		if sent {
+			fmt.Fprintln(a.stdout, "今天还没有日报，已发送提醒")
+		} else {
+			fmt.Fprintln(a.stdout, "今天已填写日报，无需提醒")
+		}
+		return nil
+	}
+
+	// 前台运行提醒服务，直到收到中断信号
+	if !svc.config.ReminderEnabled {
+		return fmt.Errorf("提醒未启用，请在 %s 中设置 reminder_enabled 为 true", a.configPath)
+	}
+	if err := reminderService.Start(); err != nil {
+		return err
+	}
+	defer reminderService.Stop()
+	fmt.Fprintf(a.stdout, "提醒服务已启动，提醒时间 %s，按 Ctrl+C 退出\n", svc.config.ReminderTime)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	fmt.Fprintln(a.stdout, "提醒服务已停止")
+	return nil
+}
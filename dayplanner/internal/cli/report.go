@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "report",
+		summary: "生成周报或月报，如 --week 2025-11-10、--month 2025-11、--from/--to",
+		run:     (*App).runReport,
+	})
+}
+
+// runReport 执行 report 子命令
+func (a *App) runReport(args []string) error {
+	fs := a.newFlagSet("report")
+	week := fs.String("week", "", "生成该日期所在周的周报 (YYYY-MM-DD)，默认本周")
+	month := fs.String("month", "", "生成指定月份的月报 (YYYY-MM)")
+	from := fs.String("from", "", "自定义范围的开始日期 (YYYY-MM-DD)")
+	to := fs.String("to", "", "自定义范围的结束日期 (YYYY-MM-DD)")
+	output := fs.String("o", "", "输出文件路径，默认输出到标准输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	reportService := service.NewReportService(svc.taskService)
+
+	var report string
+	switch {
+	case *from != "" || *to != "":
+		if *from == "" || *to == "" {
+			return fmt.Errorf("--from 和 --to 必须同时指定")
+		}
+		startDate, err := parseDate(*from)
+		if err != nil {
+			return err
+		}
+		endDate, err := parseDate(*to)
+		if err != nil {
+			return err
+		}
+		if endDate.Before(startDate) {
+			return fmt.Errorf("结束日期不能早于开始日期")
+		}
+		report, err = reportService.GenerateReport("工作报告", startDate, endDate)
+		if err != nil {
+			return err
+		}
+	case *month != "":
+		year, m, err := parseMonth(*month)
+		if err != nil {
+			return err
+		}
+		report, err = reportService.GenerateMonthlyReport(year, m)
+		if err != nil {
+			return err
+		}
+	default:
+		date, err := parseDate(*week)
+		if err != nil {
+			return err
+		}
+		report, err = reportService.GenerateWeeklyReport(date)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *output == "" {
+		fmt.Fprint(a.stdout, report)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(report), 0644); err != nil {
+		return fmt.Errorf("写入报告文件失败: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "报告已保存到 %s\n", *output)
+	return nil
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"daily-report-tool/internal/api"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "serve",
+		summary: "在前台运行本机 HTTP API（仅监听 127.0.0.1），--openapi 输出 OpenAPI 文档",
+		run:     (*App).runServe,
+	})
+}
+
+// runServe 执行 serve 子命令，直到收到中断信号
+func (a *App) runServe(args []string) error {
+	fs := a.newFlagSet("serve")
+	port := fs.Int("port", 0, fmt.Sprintf("监听端口（默认使用配置中的 api_port，未配置时为 %d）", api.DefaultPort))
+	openapi := fs.Bool("openapi", false, "只输出 OpenAPI 文档并退出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *openapi {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(api.OpenAPI())
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	token, err := api.TokenFromConfig(svc.configService)
+	if err != nil {
+		return err
+	}
+	if *port == 0 {
+		*port = svc.config.APIPort
+	}
+	server := api.NewServer(svc.taskService, svc.configService, token)
+	addr, err := server.Start(*port)
+	if err != nil {
+		return err
+	}
+	defer server.Stop()
+	fmt.Fprintf(a.stdout, "HTTP API 已启动: http://%s，访问令牌见 %s 中的 api_token，按 Ctrl+C 退出\n", addr, a.configPath)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	fmt.Fprintln(a.stdout, "HTTP API 已停止")
+	return nil
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+// services 命令行子命令共用的仓库和服务
+type services struct {
+	config            *model.Config
+	taskRepo          repository.TaskRepository
+	configService     *service.ConfigServiceImpl
+	taskService       *service.TaskServiceImpl
+	templateService   *service.TemplateServiceImpl
+	carryOverService  *service.CarryOverServiceImpl
+	syncService       service.SyncService            // 未启用同步时为 nil
+	teamService       service.TeamService            // 未使用团队服务器存储时为 nil
+	attachmentService *service.AttachmentServiceImpl // 启用加密或使用团队服务器存储时为 nil
+}
+
+// openServices 按配置初始化仓库和服务，与图形界面使用相同的配置和数据目录
+func (a *App) openServices() (*services, error) {
+	if err := os.MkdirAll(a.dataPath, 0755); err != nil {
+		return nil, fmt.Errorf("创建数据目录失败: %w", err)
+	}
+
+	configService := service.NewConfigService(repository.NewFileConfigRepository(a.configPath))
+	config, err := configService.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	taskRepo, err := repository.NewTaskRepositoryFromConfig(config, a.dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("初始化任务仓库失败: %w", err)
+	}
+	var encrypted *repository.EncryptedTaskRepository
+	if config.Encryption != "" {
+		encrypted, err = a.openEncrypted(config, taskRepo)
+		if err != nil {
+			closeRepository(taskRepo)
+			return nil, err
+		}
+		taskRepo = encrypted
+	}
+
+	// 命令行修改同样记录历史版本
+	taskService := service.NewTaskService(taskRepo, a.dataPath)
+	taskService.SetRevisionRepository(repository.NewRevisionRepository(taskRepo, a.dataPath))
+
+	// 启用同步时命令行修改同样提交到数据目录的仓库，推送由 sync 命令或图形界面完成
+	syncService, err := service.NewSyncServiceFromConfig(config, a.dataPath, a.configPath)
+	if err != nil {
+		closeRepository(taskRepo)
+		return nil, fmt.Errorf("初始化同步服务失败: %w", err)
+	}
+	if syncService != nil {
+		taskService.SetSyncService(syncService)
+		// git 同步合并冲突时需要解密两边的内容
+		if codecSetter, ok := syncService.(interface{ SetTaskCodec(service.TaskCodec) }); ok && encrypted != nil {
+			codecSetter.SetTaskCodec(encrypted)
+		}
+	}
+
+	teamService, err := service.NewTeamServiceFromConfig(config)
+	if err != nil {
+		closeRepository(taskRepo)
+		return nil, fmt.Errorf("初始化团队服务失败: %w", err)
+	}
+
+	attachmentService := service.NewAttachmentServiceFromConfig(config, a.dataPath, taskService)
+	if attachmentService != nil && syncService != nil {
+		attachmentService.SetSyncService(syncService)
+	}
+
+	return &services{
+		config:            config,
+		taskRepo:          taskRepo,
+		configService:     configService,
+		taskService:       taskService,
+		templateService:   service.NewTemplateService(service.DefaultTemplateDir(a.configPath), taskService),
+		carryOverService:  service.NewCarryOverService(taskService, taskRepo, configService),
+		syncService:       syncService,
+		teamService:       teamService,
+		attachmentService: attachmentService,
+	}, nil
+}
+
+// Close 释放仓库占用的资源
+func (s *services) Close() {
+	closeRepository(s.taskRepo)
+}
+
+// parseDate 解析 YYYY-MM-DD 格式的日期，空字符串表示今天
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("无效的日期 %q，格式应为 YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// parseMonth 解析 YYYY-MM 格式的月份，空字符串表示本月
+func parseMonth(value string) (int, time.Month, error) {
+	if value == "" {
+		now := time.Now()
+		return now.Year(), now.Month(), nil
+	}
+	date, err := time.ParseInLocation("2006-01", value, time.Local)
+	if err != nil {
+		return 0, 0, fmt.Errorf("无效的月份 %q，格式应为 YYYY-MM", value)
+	}
+	return date.Year(), date.Month(), nil
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "stats",
+		summary: "按 #标签、+项目 和周统计耗时及填报情况，--format csv 或 json 导出",
+		run:     (*App).runStats,
+	})
+}
+
+// runStats 执行 stats 子命令
+func (a *App) runStats(args []string) error {
+	fs := a.newFlagSet("stats")
+	formatName := fs.String("format", "text", "输出格式: text、csv、json")
+	week := fs.String("week", "", "统计该日期所在周 (YYYY-MM-DD)")
+	month := fs.String("month", "", "统计指定月份 (YYYY-MM)，默认本月")
+	year := fs.String("year", "", "统计指定年份 (YYYY)")
+	from := fs.String("from", "", "自定义范围的开始日期 (YYYY-MM-DD)")
+	to := fs.String("to", "", "自定义范围的结束日期 (YYYY-MM-DD)")
+	output := fs.String("o", "", "输出文件路径，默认输出到标准输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var startDate, endDate time.Time
+	var err error
+	if *year != "" {
+		if *week != "" || *month != "" || *from != "" || *to != "" {
+			return fmt.Errorf("--year 不能与其他范围参数同时指定")
+		}
+		y, err := strconv.Atoi(*year)
+		if err != nil || y < 1 {
+			return fmt.Errorf("无效的年份: %s，格式应为 YYYY", *year)
+		}
+		startDate = time.Date(y, time.January, 1, 0, 0, 0, 0, time.Local)
+		endDate = time.Date(y, time.December, 31, 0, 0, 0, 0, time.Local)
+	} else {
+		if *week == "" && *month == "" && *from == "" && *to == "" {
+			*month = time.Now().Format("2006-01")
+		}
+		startDate, endDate, err = exportRange("", *week, *month, *from, *to)
+		if err != nil {
+			return err
+		}
+	}
+
+	var write func(io.Writer, *service.Stats) error
+	switch *formatName {
+	case "text":
+		write = writeStatsText
+	case "csv":
+		write = service.WriteStatsCSV
+	case "json":
+		write = service.WriteStatsJSON
+	default:
+		return fmt.Errorf("不支持的输出格式: %s，可选 text、csv、json", *formatName)
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	stats, err := service.NewStatsService(svc.taskService, svc.configService).Compute(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return write(a.stdout, stats)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("创建输出文件失败: %w", err)
+	}
+	if err := write(file, stats); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("写入输出文件失败: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "统计已保存到 %s\n", *output)
+	return nil
+}
+
+// writeStatsText 以便于阅读的文本格式输出统计结果
+func writeStatsText(w io.Writer, stats *service.Stats) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "统计范围: %s ~ %s\n", stats.Start.Format("2006-01-02"), stats.End.Format("2006-01-02"))
+	fmt.Fprintf(&sb, "总耗时: %s（%d 条记录）\n", formatHours(stats.TotalDuration), stats.Entries)
+	fmt.Fprintf(&sb, "填报: %d 天，工作日 %d 天，缺报 %d 天\n", stats.ReportedDays, stats.Workdays, len(stats.MissedDays))
+	fmt.Fprintf(&sb, "连续填报: 当前 %d 个工作日，最长 %d 个工作日\n", stats.CurrentStreak, stats.LongestStreak)
+
+	writeSection := func(title string, items []service.StatsItem) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "  %-16s %8s  %d 条\n", item.Name, formatHours(item.Duration), item.Entries)
+		}
+	}
+	writeSection("按标签", stats.Tags)
+	writeSection("按项目", stats.Projects)
+
+	sb.WriteString("\n按周:\n")
+	for _, week := range stats.Weeks {
+		fmt.Fprintf(&sb, "  %s 起  %8s  %d 天\n", week.Start.Format("2006-01-02"), formatHours(week.Duration), week.ReportedDays)
+	}
+
+	if len(stats.MissedDays) > 0 {
+		var days []string
+		for _, day := range stats.MissedDays {
+			days = append(days, day.Format("01-02"))
+		}
+		fmt.Fprintf(&sb, "\n缺报日期: %s\n", strings.Join(days, ", "))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// formatHours 将耗时格式化为小时数，如 "6.5h"
+func formatHours(duration time.Duration) string {
+	return strconv.FormatFloat(math.Round(duration.Hours()*100)/100, 'f', -1, 64) + "h"
+}
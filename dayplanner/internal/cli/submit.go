@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "submit",
+		summary: "将日报以 Markdown 消息提交到通知渠道，已提交过的渠道跳过，--force 重新提交",
+		run:     (*App).runSubmit,
+	})
+}
+
+// runSubmit 执行 submit 子命令
+func (a *App) runSubmit(args []string) error {
+	fs := a.newFlagSet("submit")
+	dateFlag := fs.String("date", "", "日报日期 (YYYY-MM-DD)，默认今天")
+	force := fs.Bool("force", false, "已提交过的渠道也重新提交")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	result, err := service.NewSubmitService(svc.taskService, svc.configService).Submit(date, *force)
+	if errors.Is(err, service.ErrAlreadySubmitted) {
+		return fmt.Errorf("%s 的日报已提交到 %s，使用 --force 重新提交", date.Format("2006-01-02"), strings.Join(result.Skipped, ", "))
+	}
+	if result != nil && len(result.Sent) > 0 {
+		fmt.Fprintf(a.stdout, "已提交到: %s\n", strings.Join(result.Sent, ", "))
+	}
+	if result != nil && len(result.Skipped) > 0 {
+		fmt.Fprintf(a.stdout, "之前已提交，已跳过: %s\n", strings.Join(result.Skipped, ", "))
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "sync",
+		summary: "立即同步数据目录：提交本机修改，拉取合并远端修改并推送",
+		run:     (*App).runSync,
+	})
+}
+
+// runSync 执行 sync 子命令，冲突需要在图形界面中合并
+func (a *App) runSync(args []string) error {
+	fs := a.newFlagSet("sync")
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, "用法: daily-report sync")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	if svc.syncService == nil {
+		return fmt.Errorf("未启用同步，请在配置文件中设置 sync_backend 和 sync_remote")
+	}
+
+	err = svc.syncService.Sync()
+	if errors.Is(err, service.ErrSyncConflict) {
+		for _, conflict := range svc.syncService.Conflicts() {
+			fmt.Fprintf(a.stdout, "冲突: %s\n", conflict.Date.Format("2006-01-02"))
+		}
+		return fmt.Errorf("%w，请在图形界面中通过 文件 → 解决同步冲突... 合并", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stdout, "同步完成")
+	return nil
+}
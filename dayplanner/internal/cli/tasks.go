@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "add",
+		summary: "向日报追加内容，内容来自参数或标准输入",
+		run:     (*App).runAdd,
+	})
+	registerCommand(command{
+		name:    "edit",
+		summary: "使用 $EDITOR 编辑日报，空白日期预填模板，--template 指定模板名称",
+		run:     (*App).runEdit,
+	})
+	registerCommand(command{
+		name:    "show",
+		summary: "输出日报内容，如 --date 2025-11-10",
+		run:     (*App).runShow,
+	})
+	registerCommand(command{
+		name:    "carry",
+		summary: "将上一个工作日未完成的任务项顺延到日报，--dry-run 只列出不修改",
+		run:     (*App).runCarry,
+	})
+	registerCommand(command{
+		name:    "list",
+		summary: "列出月份内有日报的日期，如 --month 2025-11",
+		run:     (*App).runList,
+	})
+}
+
+// runAdd 执行 add 子命令
+func (a *App) runAdd(args []string) error {
+	fs := a.newFlagSet("add")
+	dateFlag := fs.String("date", "", "日报日期 (YYYY-MM-DD)，默认今天")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	// 没有参数时从标准输入读取，便于管道调用
+	text := strings.Join(fs.Args(), " ")
+	if text == "" {
+		data, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return fmt.Errorf("读取标准输入失败: %w", err)
+		}
+		text = string(data)
+	}
+	text = strings.TrimRight(text, "\r\n")
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("没有要追加的内容")
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	task, err := svc.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	content := text
+	if task != nil && strings.TrimSpace(task.Content) != "" {
+		content = strings.TrimRight(task.Content, "\r\n") + "\n" + text
+	}
+
+	if err := svc.taskService.SaveTask(date, content); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已追加到 %s 的日报\n", date.Format("2006-01-02"))
+	return nil
+}
+
+// runEdit 执行 edit 子命令
+func (a *App) runEdit(args []string) error {
+	fs := a.newFlagSet("edit")
+	dateFlag := fs.String("date", "", "日报日期 (YYYY-MM-DD)，默认今天")
+	templateFlag := fs.String("template", "", "空白日期使用的模板名称，默认按星期选择")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	task, err := svc.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	original := ""
+	if task != nil {
+		original = task.Content
+	}
+
+	// 空白日期预填模板，未修改模板内容时不保存
+	initial := original
+	if original == "" {
+		if *templateFlag != "" {
+			initial, err = svc.templateService.Render(*templateFlag, date)
+		} else {
+			initial, err = svc.templateService.RenderForDate(date)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// 将内容写入临时文件交给编辑器
+	tempFile, err := os.CreateTemp("", "daily-report-"+date.Format("2006-01-02")+"-*.md")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.WriteString(initial); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+
+	editor := editorCommand()
+	util.Info("使用编辑器编辑日报: %s %s", strings.Join(editor, " "), tempPath)
+	cmd := exec.Command(editor[0], append(editor[1:], tempPath)...)
+	cmd.Stdin = a.stdin
+	cmd.Stdout = a.stdout
+	cmd.Stderr = a.stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("运行编辑器 %s 失败: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(tempPath)
+	if err != nil {
+		return fmt.Errorf("读取编辑结果失败: %w", err)
+	}
+	content := string(data)
+	if content == initial {
+		fmt.Fprintln(a.stdout, "内容未修改")
+		return nil
+	}
+
+	if err := svc.taskService.SaveTask(date, content); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已保存 %s 的日报\n", date.Format("2006-01-02"))
+	return nil
+}
+
+// runShow 执行 show 子命令
+func (a *App) runShow(args []string) error {
+	fs := a.newFlagSet("show")
+	dateFlag := fs.String("date", "", "日报日期 (YYYY-MM-DD)，默认今天")
+	asHTML := fs.Bool("html", false, "输出渲染后的 HTML")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	task, err := svc.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("%s 没有日报", date.Format("2006-01-02"))
+	}
+
+	content := task.Content
+	if *asHTML {
+		content, err = util.MarkdownToHTML(task.Content)
+		if err != nil {
+			return fmt.Errorf("渲染 HTML 失败: %w", err)
+		}
+	}
+	fmt.Fprint(a.stdout, content)
+	if !strings.HasSuffix(content, "\n") {
+		fmt.Fprintln(a.stdout)
+	}
+	return nil
+}
+
+// runList 执行 list 子命令
+func (a *App) runList(args []string) error {
+	fs := a.newFlagSet("list")
+	monthFlag := fs.String("month", "", "月份 (YYYY-MM)，默认本月")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	year, month, err := parseMonth(*monthFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	first, last := util.MonthRange(year, month, time.Local)
+	tasks, err := svc.taskService.GetTasksInRange(first, last)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintf(a.stdout, "%d年%d月没有日报\n", year, month)
+		return nil
+	}
+
+	for _, task := range tasks {
+		fmt.Fprintf(a.stdout, "%s %s  %s\n",
+			task.Date.Format("2006-01-02"), util.ChineseWeekday(task.Date), firstLine(task.Content))
+	}
+	return nil
+}
+
+// runCarry 执行 carry 子命令
+func (a *App) runCarry(args []string) error {
+	fs := a.newFlagSet("carry")
+	dateFlag := fs.String("date", "", "顺延到的日期 (YYYY-MM-DD)，默认今天")
+	dryRun := fs.Bool("dry-run", false, "只列出可顺延的任务项")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	candidate, err := svc.carryOverService.FindUnfinished(date)
+	if err != nil {
+		return err
+	}
+	if candidate == nil {
+		fmt.Fprintln(a.stdout, "没有可顺延的未完成任务项")
+		return nil
+	}
+
+	fmt.Fprintf(a.stdout, "%s 未完成的任务项:\n", candidate.From.Format("2006-01-02"))
+	for _, item := range candidate.Items {
+		fmt.Fprintf(a.stdout, "- [ ] %s\n", item)
+	}
+	if *dryRun {
+		return nil
+	}
+
+	if err := svc.carryOverService.CarryOver(date, candidate.From, candidate.Items); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已顺延 %d 项到 %s 的日报\n", len(candidate.Items), date.Format("2006-01-02"))
+	return nil
+}
+
+// editorCommand 返回用户配置的编辑器命令（支持带参数，如 "code -w"）
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// firstLine 返回内容中第一行非空文本，用于列表摘要
+func firstLine(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
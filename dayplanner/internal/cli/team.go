@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"daily-report-tool/internal/api"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "team",
+		summary: "团队模式：serve 运行团队服务器，add-member 添加成员，status 查看今天谁还没有填写日报",
+		run:     (*App).runTeam,
+	})
+}
+
+// runTeam 执行 team 子命令
+func (a *App) runTeam(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法: daily-report team serve|add-member|status [参数]")
+	}
+	switch args[0] {
+	case "serve":
+		return a.runTeamServe(args[1:])
+	case "add-member":
+		return a.runTeamAddMember(args[1:])
+	case "status":
+		return a.runTeamStatus(args[1:])
+	default:
+		return fmt.Errorf("未知的 team 子命令: %s (可选: serve、add-member、status)", args[0])
+	}
+}
+
+// runTeamServe 在前台运行团队服务器，启用提醒时同时运行团队提醒，直到收到中断信号
+func (a *App) runTeamServe(args []string) error {
+	fs := a.newFlagSet("team serve")
+	listen := fs.String("listen", "", fmt.Sprintf("监听地址（默认使用配置中的 team_listen，未配置时为 %s）", api.DefaultTeamListen))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	if len(svc.config.TeamMembers) == 0 {
+		return fmt.Errorf("还没有团队成员，请先使用 daily-report team add-member 添加")
+	}
+	if *listen == "" {
+		*listen = svc.config.TeamListen
+	}
+
+	teamRepo := repository.NewFileTeamTaskRepository(repository.DefaultTeamDataPath(a.dataPath))
+	server := api.NewTeamServer(teamRepo, svc.configService)
+	addr, err := server.Start(*listen)
+	if err != nil {
+		return err
+	}
+	defer server.Stop()
+	fmt.Fprintf(a.stdout, "团队服务器已启动: http://%s，成员 %d 人，按 Ctrl+C 退出\n", addr, len(svc.config.TeamMembers))
+
+	// 团队提醒规则 (team: true) 在服务器上检查所有成员
+	if svc.config.ReminderEnabled {
+		reminderService := service.NewReminderService(svc.configService, svc.taskService)
+		reminderService.SetStateRepository(repository.NewFileReminderStateRepository(repository.DefaultReminderStatePath(a.dataPath)))
+		reminderService.SetTeamService(service.NewTeamService(teamRepo, svc.configService))
+		if err := reminderService.Start(); err != nil {
+			return err
+		}
+		defer reminderService.Stop()
+		fmt.Fprintln(a.stdout, "提醒服务已启动")
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	fmt.Fprintln(a.stdout, "团队服务器已停止")
+	return nil
+}
+
+// runTeamAddMember 在团队服务器的配置中添加成员并输出访问令牌
+func (a *App) runTeamAddMember(args []string) error {
+	fs := a.newFlagSet("team add-member")
+	lead := fs.Bool("lead", false, "设为组长，可以查看成员的填报情况和日报")
+	mention := fs.String("mention", "", "在通知渠道中 @ 该成员使用的 ID：企业微信 userid、钉钉手机号、飞书 open_id、Slack 成员 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("用法: daily-report team add-member [--lead] [--mention ID] <成员名>")
+	}
+	name := fs.Arg(0)
+	if !model.ValidMemberName(name) {
+		return fmt.Errorf("无效的成员名 %q，只能包含字母、数字、下划线、连字符和点", name)
+	}
+
+	configService := service.NewConfigService(repository.NewFileConfigRepository(a.configPath))
+	token, err := api.AddTeamMember(configService, model.TeamMember{Name: name, Mention: *mention, Lead: *lead})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "已添加成员 %s，访问令牌: %s\n", name, token)
+	fmt.Fprintln(a.stdout, "请成员在自己的 config.json 中设置 storage_backend 为 \"team\"，team_server 为本服务器地址，team_token 为上面的令牌")
+	return nil
+}
+
+// runTeamStatus 输出团队在指定日期的填报情况
+// 在客户端上通过团队服务器查询（需要组长令牌），在服务器上直接读取成员目录
+func (a *App) runTeamStatus(args []string) error {
+	fs := a.newFlagSet("team status")
+	dateFlag := fs.String("date", "", "日期 (YYYY-MM-DD)，默认今天")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	date, err := parseDate(*dateFlag)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.openServices()
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	teamService := a.teamServiceFor(svc)
+	if teamService == nil {
+		return fmt.Errorf("未配置团队模式：客户端请将 storage_backend 设置为 team，服务器请先使用 team add-member 添加成员")
+	}
+
+	status, err := teamService.Status(date)
+	if err != nil {
+		return err
+	}
+	filed, missing := status.Filed(), status.Missing()
+	fmt.Fprintf(a.stdout, "%s 已填写 %d/%d\n", date.Format("2006-01-02"), len(filed), len(status.Members))
+	if len(filed) > 0 {
+		names := make([]string, len(filed))
+		for i, member := range filed {
+			names[i] = fmt.Sprintf("%s (%s)", member.Name, member.UpdatedAt.Local().Format("15:04"))
+		}
+		fmt.Fprintf(a.stdout, "已填写: %s\n", strings.Join(names, "、"))
+	}
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, member := range missing {
+			names[i] = member.Name
+		}
+		fmt.Fprintf(a.stdout, "未填写: %s\n", strings.Join(names, "、"))
+	}
+	return nil
+}
+
+// teamServiceFor 返回查看团队填报情况使用的团队服务：
+// 使用团队服务器存储的客户端通过服务器查询，配置了成员的团队服务器直接读取成员目录，都不是时返回 nil
+func (a *App) teamServiceFor(svc *services) service.TeamService {
+	if svc.teamService != nil {
+		return svc.teamService
+	}
+	if len(svc.config.TeamMembers) > 0 {
+		return service.NewTeamService(repository.NewFileTeamTaskRepository(repository.DefaultTeamDataPath(a.dataPath)), svc.configService)
+	}
+	return nil
+}
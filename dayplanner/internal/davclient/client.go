@@ -0,0 +1,305 @@
+// Package davclient 实现同步所需的最小 WebDAV 客户端：列目录、读写、删除文件和创建目录
+// 使用 ETag 检测远端修改，写入时通过 If-Match / If-None-Match 避免覆盖其他机器的修改（服务器支持时）
+package davclient
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound 远端文件或目录不存在
+	ErrNotFound = errors.New("远端文件不存在")
+
+	// ErrPreconditionFailed 远端文件在此期间被修改，ETag 不匹配
+	ErrPreconditionFailed = errors.New("远端文件已被修改")
+)
+
+// Resource 远端目录中的一个文件
+type Resource struct {
+	Name    string    // 文件名，不含目录
+	ETag    string    // 服务器返回的 ETag，包含引号
+	Size    int64     // 文件大小
+	ModTime time.Time // 最后修改时间
+}
+
+// Client WebDAV 客户端，所有路径都相对于 baseURL
+type Client struct {
+	base     *url.URL
+	username string
+	password string
+	http     *http.Client
+}
+
+// NewClient 创建 WebDAV 客户端，username 为空时不使用基本认证
+func NewClient(baseURL, username, password string) (*Client, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("无效的 WebDAV 地址: %s", baseURL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return &Client{
+		base:     base,
+		username: username,
+		password: password,
+		http:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// List 列出目录中的文件（不包括子目录），目录不存在时返回 ErrNotFound
+func (c *Client) List(dir string) ([]Resource, error) {
+	entries, err := c.propfind(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	for _, e := range entries {
+		if e.prop.ResourceType.Collection != nil {
+			continue
+		}
+		modTime, _ := http.ParseTime(e.prop.LastModified)
+		resources = append(resources, Resource{
+			Name:    path.Base(e.href),
+			ETag:    e.prop.ETag,
+			Size:    e.prop.ContentLength,
+			ModTime: modTime,
+		})
+	}
+	return resources, nil
+}
+
+// ListDirs 列出目录中的子目录名，目录不存在时返回 ErrNotFound
+func (c *Client) ListDirs(dir string) ([]string, error) {
+	entries, err := c.propfind(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	self := strings.TrimSuffix(c.base.ResolveReference(&url.URL{Path: dirPath(dir)}).Path, "/")
+	var dirs []string
+	for _, e := range entries {
+		href := strings.TrimSuffix(e.href, "/")
+		if e.prop.ResourceType.Collection == nil || href == self {
+			continue
+		}
+		dirs = append(dirs, path.Base(href))
+	}
+	return dirs, nil
+}
+
+// propfindEntry PROPFIND 响应中的一项，href 为解码后的路径
+type propfindEntry struct {
+	href string
+	prop prop
+}
+
+// propfind 列出目录本身和其中的文件、子目录
+func (c *Client) propfind(dir string) ([]propfindEntry, error) {
+	resp, err := c.do("PROPFIND", dirPath(dir), strings.NewReader(propfindBody), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml; charset=utf-8",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusMultiStatus); err != nil {
+		return nil, err
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("解析 PROPFIND 响应失败: %w", err)
+	}
+
+	var entries []propfindEntry
+	for _, r := range ms.Responses {
+		prop, ok := r.okProp()
+		if !ok {
+			continue
+		}
+		href, err := url.PathUnescape(r.Href)
+		if err != nil {
+			href = r.Href
+		}
+		if u, err := url.Parse(href); err == nil && u.Path != "" {
+			href = u.Path
+		}
+		entries = append(entries, propfindEntry{href: href, prop: prop})
+	}
+	return entries, nil
+}
+
+// Get 读取文件内容和 ETag
+func (c *Client) Get(name string) ([]byte, string, error) {
+	resp, err := c.do(http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusOK); err != nil {
+		return nil, "", err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("读取远端文件失败: %w", err)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// Put 写入文件并返回新的 ETag
+// etag 为空时要求远端文件不存在，否则要求远端文件的 ETag 与之相同，不满足时返回 ErrPreconditionFailed
+func (c *Client) Put(name string, data []byte, etag string) (string, error) {
+	headers := map[string]string{"Content-Type": "application/octet-stream"}
+	if etag == "" {
+		headers["If-None-Match"] = "*"
+	} else {
+		headers["If-Match"] = etag
+	}
+	resp, err := c.do(http.MethodPut, name, bytes.NewReader(data), headers)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return "", err
+	}
+	if newETag := resp.Header.Get("ETag"); newETag != "" {
+		return newETag, nil
+	}
+
+	// 服务器没有返回 ETag 时重新读取
+	_, newETag, err := c.Get(name)
+	return newETag, err
+}
+
+// Delete 删除文件，etag 不为空时要求远端文件未被修改；文件不存在时视为成功
+func (c *Client) Delete(name, etag string) error {
+	headers := map[string]string{}
+	if etag != "" {
+		headers["If-Match"] = etag
+	}
+	resp, err := c.do(http.MethodDelete, name, nil, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusOK, http.StatusNoContent); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Mkdir 创建目录，目录已存在时视为成功
+func (c *Client) Mkdir(dir string) error {
+	resp, err := c.do("MKCOL", dirPath(dir), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 405 表示目录已存在
+	return checkStatus(resp, http.StatusCreated, http.StatusMethodNotAllowed)
+}
+
+// do 发送请求
+func (c *Client) do(method, name string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	target := c.base.ResolveReference(&url.URL{Path: strings.TrimPrefix(name, "/")})
+	req, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("创建 WebDAV 请求失败: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("WebDAV %s %s 失败: %w", method, name, err)
+	}
+	return resp, nil
+}
+
+// checkStatus 检查响应状态码，常见的失败状态转换为对应的错误
+func checkStatus(resp *http.Response, expected ...int) error {
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("WebDAV 认证失败 (%s)，请检查用户名和密码", resp.Status)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("WebDAV %s %s 返回 %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// dirPath 返回以 / 结尾的目录路径
+func dirPath(dir string) string {
+	if dir == "" || strings.HasSuffix(dir, "/") {
+		return dir
+	}
+	return dir + "/"
+}
+
+// propfindBody 只请求同步需要的属性
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:">
+  <d:prop>
+    <d:resourcetype/>
+    <d:getetag/>
+    <d:getcontentlength/>
+    <d:getlastmodified/>
+  </d:prop>
+</d:propfind>`
+
+// multistatus PROPFIND 响应
+type multistatus struct {
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href     string     `xml:"DAV: href"`
+	Propstat []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Prop   prop   `xml:"DAV: prop"`
+	Status string `xml:"DAV: status"`
+}
+
+type prop struct {
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+	} `xml:"DAV: resourcetype"`
+	ETag          string `xml:"DAV: getetag"`
+	ContentLength int64  `xml:"DAV: getcontentlength"`
+	LastModified  string `xml:"DAV: getlastmodified"`
+}
+
+// okProp 返回状态为 200 的属性
+func (r response) okProp() (prop, bool) {
+	for _, ps := range r.Propstat {
+		if strings.Contains(ps.Status, " 200 ") {
+			return ps.Prop, true
+		}
+	}
+	return prop{}, false
+}
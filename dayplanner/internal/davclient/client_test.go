@@ -0,0 +1,79 @@
+package davclient
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/webdav"
+)
+
+// newServer 创建进程内的 WebDAV 服务器
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(&webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	})
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	server := newServer(t)
+	client, err := NewClient(server.URL+"/dav", "", "")
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	if _, err := client.List(""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("目录不存在时应返回 ErrNotFound，实际 %v", err)
+	}
+	if err := client.Mkdir(""); err != nil {
+		t.Fatalf("创建根目录失败: %v", err)
+	}
+	if err := client.Mkdir("tasks"); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := client.Mkdir("tasks"); err != nil {
+		t.Errorf("目录已存在时应视为成功: %v", err)
+	}
+
+	etag, err := client.Put("tasks/2025-11-10.json", []byte("周一"), "")
+	if err != nil || etag == "" {
+		t.Fatalf("写入失败: %q, %v", etag, err)
+	}
+	data, got, err := client.Get("tasks/2025-11-10.json")
+	if err != nil || string(data) != "周一" || got != etag {
+		t.Errorf("读取结果不正确: %q, %q (期望 %q), %v", data, got, etag, err)
+	}
+
+	resources, err := client.List("tasks")
+	if err != nil || len(resources) != 1 {
+		t.Fatalf("列目录失败: %+v, %v", resources, err)
+	}
+	if resources[0].Name != "2025-11-10.json" || resources[0].ETag != etag || resources[0].Size != int64(len("周一")) {
+		t.Errorf("列目录结果不正确: %+v", resources[0])
+	}
+	if dirs, err := client.ListDirs(""); err != nil || len(dirs) != 1 || dirs[0] != "tasks" {
+		t.Errorf("列子目录结果不正确: %v, %v", dirs, err)
+	}
+
+	if err := client.Delete("tasks/2025-11-10.json", etag); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	if _, _, err := client.Get("tasks/2025-11-10.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("删除后应返回 ErrNotFound，实际 %v", err)
+	}
+	if err := client.Delete("tasks/2025-11-10.json", ""); err != nil {
+		t.Errorf("删除不存在的文件应视为成功: %v", err)
+	}
+}
+
+func TestNewClient_InvalidURL(t *testing.T) {
+	for _, raw := range []string{"", "nas/dav", "ftp://nas/dav"} {
+		if _, err := NewClient(raw, "", ""); err == nil {
+			t.Errorf("%q 应该是无效地址", raw)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package export 将 Markdown 日报渲染为 HTML、静态网站、PDF 和 DOCX 等可分发的文档格式
+package export
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"daily-report-tool/internal/util"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// Format 导出格式
+type Format string
+
+const (
+	FormatHTML Format = "html" // 单个带样式的 HTML 文件
+	FormatSite Format = "site" // 按月份索引的多页面静态网站（输出为目录）
+	FormatPDF  Format = "pdf"  // PDF 文档
+	FormatDOCX Format = "docx" // Word 文档
+)
+
+// Formats 所有支持的导出格式
+var Formats = []Format{FormatHTML, FormatSite, FormatPDF, FormatDOCX}
+
+// ParseFormat 解析导出格式名称（不区分大小写）
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, f := range Formats {
+		if f == format {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的导出格式: %s（可选 html、site、pdf、docx）", name)
+}
+
+// Extension 返回格式对应的文件扩展名，site 格式输出为目录，返回空字符串
+func (f Format) Extension() string {
+	switch f {
+	case FormatHTML:
+		return ".html"
+	case FormatPDF:
+		return ".pdf"
+	case FormatDOCX:
+		return ".docx"
+	}
+	return ""
+}
+
+// BlockKind 文档块类型
+type BlockKind int
+
+const (
+	BlockHeading   BlockKind = iota // 标题
+	BlockParagraph                  // 段落（表格的每一行也作为段落）
+	BlockListItem                   // 列表项
+	BlockCode                       // 代码块
+	BlockQuote                      // 引用
+	BlockRule                       // 分隔线
+)
+
+// Block PDF 和 DOCX 使用的简化文档块，只保留纯文本和结构
+type Block struct {
+	Kind   BlockKind
+	Level  int    // 标题级别（1~6）；列表项和引用的嵌套深度（从 0 开始）
+	Marker string // 列表项标记，如 "•"、"1."、"[ ]"、"[x]"；列表项的后续段落为空
+	Text   string // 纯文本，换行表示段落内的换行；代码块保留原始换行
+}
+
+// ParseBlocks 使用 goldmark 解析 Markdown，转换为简化的文档块
+// 行内格式（粗体、链接等）只保留文字，段落内的换行按 MarkdownToHTML 的硬换行规则保留
+func ParseBlocks(markdown string) []Block {
+	source := []byte(markdown)
+	doc := util.ParseMarkdown(source)
+
+	var blocks []Block
+	appendBlocks(&blocks, doc, source, 0, false)
+	return blocks
+}
+
+// appendBlocks 依次转换容器节点的子块
+func appendBlocks(blocks *[]Block, parent ast.Node, source []byte, depth int, quoted bool) {
+	for node := parent.FirstChild(); node != nil; node = node.NextSibling() {
+		appendBlock(blocks, node, source, depth, quoted)
+	}
+}
+
+// appendBlock 转换单个块节点，HTML 块等不支持的节点被忽略
+func appendBlock(blocks *[]Block, node ast.Node, source []byte, depth int, quoted bool) {
+	switch n := node.(type) {
+	case *ast.Heading:
+		*blocks = append(*blocks, Block{Kind: BlockHeading, Level: n.Level, Text: inlineText(n, source)})
+	case *ast.Paragraph, *ast.TextBlock:
+		kind := BlockParagraph
+		if quoted {
+			kind = BlockQuote
+		}
+		*blocks = append(*blocks, Block{Kind: kind, Level: depth, Text: inlineText(n, source)})
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		*blocks = append(*blocks, Block{Kind: BlockCode, Level: depth, Text: codeText(n, source)})
+	case *ast.Blockquote:
+		appendBlocks(blocks, n, source, depth, true)
+	case *ast.ThematicBreak:
+		*blocks = append(*blocks, Block{Kind: BlockRule})
+	case *ast.List:
+		appendList(blocks, n, source, depth, quoted)
+	case *extast.Table:
+		appendTable(blocks, n, source, depth)
+	}
+}
+
+// appendList 转换列表，列表项的第一个块带标记，其余块和嵌套列表缩进一级
+func appendList(blocks *[]Block, list *ast.List, source []byte, depth int, quoted bool) {
+	number := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "•"
+		if list.IsOrdered() {
+			marker = fmt.Sprintf("%d.", number)
+			number++
+		}
+
+		for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+			switch c := child.(type) {
+			case *ast.Paragraph, *ast.TextBlock:
+				if checkbox, ok := c.FirstChild().(*extast.TaskCheckBox); ok {
+					marker = "[ ]"
+					if checkbox.IsChecked {
+						marker = "[x]"
+					}
+				}
+				*blocks = append(*blocks, Block{Kind: BlockListItem, Level: depth, Marker: marker, Text: inlineText(c, source)})
+				marker = "" // 同一列表项的后续段落不再重复标记
+			default:
+				appendBlock(blocks, c, source, depth+1, quoted)
+			}
+		}
+	}
+}
+
+// appendTable 将表格的每一行转换为以 " | " 分隔单元格的段落
+func appendTable(blocks *[]Block, table *extast.Table, source []byte, depth int) {
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, inlineText(cell, source))
+		}
+		*blocks = append(*blocks, Block{Kind: BlockParagraph, Level: depth, Text: strings.Join(cells, " | ")})
+	}
+}
+
+// inlineText 提取内联节点的纯文本，软换行保留为换行，与 MarkdownToHTML 的硬换行一致
+func inlineText(node ast.Node, source []byte) string {
+	var sb strings.Builder
+	writeInline(&sb, node, source)
+	return strings.TrimSpace(sb.String())
+}
+
+// writeInline 递归写入内联节点的文本
+func writeInline(sb *strings.Builder, node ast.Node, source []byte) {
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *extast.TaskCheckBox:
+			// 复选框由列表项标记表示
+		case *ast.Text:
+			sb.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				sb.WriteByte('\n')
+			}
+		case *ast.String:
+			// Typographer 扩展生成的是 HTML 实体，如 &ldquo;
+			sb.WriteString(html.UnescapeString(string(n.Value)))
+		case *ast.AutoLink:
+			sb.Write(n.URL(source))
+		case *ast.RawHTML:
+			// 忽略行内 HTML 标签
+		default:
+			writeInline(sb, child, source)
+		}
+	}
+}
+
+// codeText 提取代码块的原始文本
+func codeText(node ast.Node, source []byte) string {
+	var sb strings.Builder
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		sb.Write(segment.Value(source))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// docxParts DOCX 包中除正文和文档属性之外的固定部件
+var docxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`},
+	{"word/_rels/document.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`},
+	{"word/styles.xml", docxStyles},
+}
+
+// docxStyles 文档样式：正文、标题、各级标题、代码和引用
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Microsoft YaHei"/><w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="en-US" w:eastAsia="zh-CN"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="80" w:line="300" w:lineRule="auto"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:b/><w:sz w:val="44"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="300" w:after="100"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="27"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading4"><w:name w:val="heading 4"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="200" w:after="80"/><w:outlineLvl w:val="3"/></w:pPr><w:rPr><w:b/><w:sz w:val="25"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading5"><w:name w:val="heading 5"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="160" w:after="60"/><w:outlineLvl w:val="4"/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading6"><w:name w:val="heading 6"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="160" w:after="60"/><w:outlineLvl w:val="5"/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F2F4F7"/><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr><w:rPr><w:rFonts w:ascii="Consolas" w:hAnsi="Consolas"/><w:sz w:val="19"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:pBdr><w:left w:val="single" w:sz="18" w:space="8" w:color="D0D7DE"/></w:pBdr><w:ind w:left="240"/></w:pPr><w:rPr><w:color w:val="57606A"/></w:rPr></w:style>
+</w:styles>`
+
+// docxIndent 每级嵌套的缩进（单位为 twip，1/20 点）
+const docxIndent = 360
+
+// WriteDOCX 将文档块写入 Word 文档（Office Open XML），只使用标准样式，Word、WPS 和 LibreOffice 均可打开
+func WriteDOCX(w io.Writer, title string, blocks []Block) error {
+	zw := zip.NewWriter(w)
+
+	for _, part := range docxParts {
+		if err := writeZipFile(zw, part.name, part.content); err != nil {
+			return err
+		}
+	}
+	if err := writeZipFile(zw, "docProps/core.xml", docxCoreProperties(title)); err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "word/document.xml", docxDocument(title, blocks)); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("写入 DOCX 失败: %w", err)
+	}
+	return nil
+}
+
+// writeZipFile 向 DOCX 包中写入一个部件
+func writeZipFile(zw *zip.Writer, name, content string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("写入 DOCX 部件 %s 失败: %w", name, err)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		return fmt.Errorf("写入 DOCX 部件 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// docxCoreProperties 生成包含标题和创建时间的文档属性
+func docxCoreProperties(title string) string {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<dc:title>` + escapeXML(title) + `</dc:title>
+<dc:creator>daily-report-tool</dc:creator>
+<dcterms:created xsi:type="dcterms:W3CDTF">` + now + `</dcterms:created>
+<dcterms:modified xsi:type="dcterms:W3CDTF">` + now + `</dcterms:modified>
+</cp:coreProperties>`
+}
+
+// docxDocument 生成正文，每个文档块对应一个段落，代码块每行一个段落
+func docxDocument(title string, blocks []Block) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+
+	writeDocxParagraph(&sb, "Title", 0, "", title)
+	for _, block := range blocks {
+		indent := block.Level * docxIndent
+		switch block.Kind {
+		case BlockHeading:
+			level := block.Level
+			if level < 1 || level > 6 {
+				level = 6
+			}
+			writeDocxParagraph(&sb, fmt.Sprintf("Heading%d", level), 0, "", block.Text)
+		case BlockParagraph:
+			writeDocxParagraph(&sb, "", indent, "", block.Text)
+		case BlockListItem:
+			writeDocxParagraph(&sb, "", indent+docxIndent, docxMarker(block.Marker), block.Text)
+		case BlockCode:
+			for _, line := range strings.Split(block.Text, "\n") {
+				writeDocxParagraph(&sb, "Code", indent, "", line)
+			}
+		case BlockQuote:
+			writeDocxParagraph(&sb, "Quote", indent+240, "", block.Text)
+		case BlockRule:
+			sb.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="D0D7DE"/></w:pBdr></w:pPr></w:p>`)
+		}
+	}
+
+	// A4 纸张，页边距 2 厘米
+	sb.WriteString(`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="709" w:footer="709" w:gutter="0"/></w:sectPr>`)
+	sb.WriteString(`</w:body></w:document>`)
+	return sb.String()
+}
+
+// writeDocxParagraph 写入一个段落，marker 不为空时作为悬挂缩进的列表标记，段落内的换行转换为软换行
+func writeDocxParagraph(sb *strings.Builder, style string, indent int, marker, text string) {
+	sb.WriteString("<w:p>")
+	if style != "" || indent > 0 {
+		sb.WriteString("<w:pPr>")
+		if style != "" {
+			fmt.Fprintf(sb, `<w:pStyle w:val="%s"/>`, style)
+		}
+		if indent > 0 {
+			if marker != "" {
+				fmt.Fprintf(sb, `<w:ind w:left="%d" w:hanging="%d"/>`, indent, docxIndent)
+			} else {
+				fmt.Fprintf(sb, `<w:ind w:left="%d"/>`, indent)
+			}
+		}
+		sb.WriteString("</w:pPr>")
+	}
+
+	if marker != "" {
+		fmt.Fprintf(sb, `<w:r><w:t xml:space="preserve">%s</w:t></w:r><w:r><w:tab/></w:r>`, escapeXML(marker))
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			sb.WriteString("<w:r><w:br/></w:r>")
+		}
+		if line != "" {
+			fmt.Fprintf(sb, `<w:r><w:t xml:space="preserve">%s</w:t></w:r>`, escapeXML(line))
+		}
+	}
+	sb.WriteString("</w:p>")
+}
+
+// docxMarker 将任务列表的复选框标记转换为符号
+func docxMarker(marker string) string {
+	switch marker {
+	case "[ ]":
+		return "☐"
+	case "[x]":
+		return "☑"
+	}
+	return marker
+}
+
+// escapeXML 转义 XML 文本，XML 中不允许出现的控制字符替换为 U+FFFD
+func escapeXML(s string) string {
+	var sb strings.Builder
+	if err := xml.EscapeText(&sb, []byte(s)); err != nil {
+		return ""
+	}
+	return sb.String()
+}
@@ -0,0 +1,208 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleMarkdown = `## 今日工作
+
+- [x] 完成**导出**功能
+- [ ] 编写文档
+  1. 安装说明
+  2. 使用说明
+
+> 明天评审
+
+` + "```go\nfmt.Println(\"hi\")\n```" + `
+
+---
+
+| 项目 | 进度 |
+|------|------|
+| 导出 | 80% |
+`
+
+func TestParseBlocks(t *testing.T) {
+	blocks := ParseBlocks(sampleMarkdown)
+
+	expected := []Block{
+		{Kind: BlockHeading, Level: 2, Text: "今日工作"},
+		{Kind: BlockListItem, Level: 0, Marker: "[x]", Text: "完成导出功能"},
+		{Kind: BlockListItem, Level: 0, Marker: "[ ]", Text: "编写文档"},
+		{Kind: BlockListItem, Level: 1, Marker: "1.", Text: "安装说明"},
+		{Kind: BlockListItem, Level: 1, Marker: "2.", Text: "使用说明"},
+		{Kind: BlockQuote, Level: 0, Text: "明天评审"},
+		{Kind: BlockCode, Level: 0, Text: "fmt.Println(\"hi\")"},
+		{Kind: BlockRule},
+		{Kind: BlockParagraph, Level: 0, Text: "项目 | 进度"},
+		{Kind: BlockParagraph, Level: 0, Text: "导出 | 80%"},
+	}
+	if len(blocks) != len(expected) {
+		t.Fatalf("期望 %d 个块，实际 %d 个: %+v", len(expected), len(blocks), blocks)
+	}
+	for i := range expected {
+		if blocks[i] != expected[i] {
+			t.Errorf("第 %d 个块期望 %+v，实际 %+v", i, expected[i], blocks[i])
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat(" PDF "); err != nil || format != FormatPDF {
+		t.Errorf("期望解析为 pdf，实际 %q, %v", format, err)
+	}
+	if _, err := ParseFormat("epub"); err == nil {
+		t.Error("不支持的格式应该返回错误")
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, "日报 <测试>", "<p>内容</p>"); err != nil {
+		t.Fatalf("写入 HTML 失败: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{"<title>日报 &lt;测试&gt;</title>", "<style>", "<p>内容</p>"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML 中缺少 %q", want)
+		}
+	}
+}
+
+func TestWriteSite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "site")
+	pages := []SitePage{
+		{Date: time.Date(2025, 10, 31, 0, 0, 0, 0, time.Local), Summary: "十月最后一天", Body: "<p>十月</p>"},
+		{Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.Local), Summary: "十一月第一天", Body: "<p>十一月</p>"},
+	}
+	if err := WriteSite(dir, "我的日报", pages); err != nil {
+		t.Fatalf("生成静态网站失败: %v", err)
+	}
+
+	for _, name := range []string{"style.css", "index.html", "2025-10.html", "2025-11.html", "2025-10-31.html", "2025-11-03.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("缺少文件 %s: %v", name, err)
+		}
+	}
+
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if strings.Index(string(index), "2025年11月") > strings.Index(string(index), "2025年10月") {
+		t.Error("目录中最近的月份应该在前")
+	}
+	day, _ := os.ReadFile(filepath.Join(dir, "2025-11-03.html"))
+	if !strings.Contains(string(day), `href="2025-10-31.html"`) || !strings.Contains(string(day), "<p>十一月</p>") {
+		t.Errorf("日报页面内容不正确:\n%s", day)
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	// 足够多的内容以产生多个页面
+	blocks := ParseBlocks(strings.Repeat(sampleMarkdown+"\n", 15))
+
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, "2025-11-10 日报", blocks); err != nil {
+		t.Fatalf("写入 PDF 失败: %v", err)
+	}
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("PDF 文件头或文件尾不正确")
+	}
+
+	// 交叉引用表中的偏移量必须指向对应的对象
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if startxref == nil {
+		t.Fatal("缺少 startxref")
+	}
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	xref := string(data[offset:])
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(xref, -1)
+	if len(entries) < 8 {
+		t.Fatalf("交叉引用表条目过少: %d", len(entries))
+	}
+	for i, entry := range entries {
+		objectOffset, _ := strconv.Atoi(entry[1])
+		if !bytes.HasPrefix(data[objectOffset:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Errorf("对象 %d 的偏移量不正确", i+1)
+		}
+	}
+
+	if count := bytes.Count(data, []byte("/Type /Page ")); count < 2 {
+		t.Errorf("期望内容分为多页，实际 %d 页", count)
+	}
+
+	// 第一页内容流中应包含 UTF-16 编码的标题
+	streamStart := bytes.Index(data, []byte("stream\n")) + len("stream\n")
+	streamEnd := bytes.Index(data, []byte("\nendstream"))
+	reader, err := zlib.NewReader(bytes.NewReader(data[streamStart:streamEnd]))
+	if err != nil {
+		t.Fatalf("解压内容流失败: %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	if !strings.Contains(string(content), "<"+encodeUTF16Hex("2025-11-10 日报", false)+">") {
+		t.Error("内容流中缺少标题")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	// 11 号字下宽度 110 可容纳 10 个汉字或 20 个 ASCII 字符
+	lines := wrapText("一二三四五六七八九十一二三", 11, 110)
+	if len(lines) != 2 || lines[0] != "一二三四五六七八九十" {
+		t.Errorf("中文折行不正确: %q", lines)
+	}
+
+	lines = wrapText("hello world exporting", 11, 110)
+	if len(lines) != 2 || lines[0] != "hello world" || lines[1] != "exporting" {
+		t.Errorf("英文应在空格处折行: %q", lines)
+	}
+
+	lines = wrapText("第一行\n第二行", 11, 110)
+	if len(lines) != 2 {
+		t.Errorf("换行符应强制换行: %q", lines)
+	}
+}
+
+func TestWriteDOCX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOCX(&buf, "周报 & 总结", ParseBlocks(sampleMarkdown)); err != nil {
+		t.Fatalf("写入 DOCX 失败: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("DOCX 不是有效的 zip 文件: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("打开 %s 失败: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/_rels/document.xml.rels", "docProps/core.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("缺少部件 %s", name)
+		}
+	}
+
+	document := files["word/document.xml"]
+	for _, want := range []string{"周报 &amp; 总结", `<w:pStyle w:val="Heading2"/>`, "☑", "☐", "完成导出功能", `<w:pStyle w:val="Code"/>`} {
+		if !strings.Contains(document, want) {
+			t.Errorf("document.xml 中缺少 %q", want)
+		}
+	}
+}
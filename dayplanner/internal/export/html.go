@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// stylesheet 导出 HTML 使用的样式，单文件导出时内联，静态网站中保存为 style.css
+const stylesheet = `body {
+  margin: 0;
+  background: #f6f7f9;
+  color: #24292f;
+  font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", "Noto Sans CJK SC", sans-serif;
+  line-height: 1.7;
+}
+main {
+  max-width: 860px;
+  margin: 32px auto;
+  padding: 32px 48px;
+  background: #fff;
+  border-radius: 8px;
+  box-shadow: 0 1px 3px rgba(0, 0, 0, .08);
+}
+h1, h2, h3, h4, h5, h6 { line-height: 1.3; margin: 1.4em 0 .6em; }
+h1 { font-size: 1.8em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+h2 { font-size: 1.4em; border-bottom: 1px solid #eaecef; padding-bottom: .2em; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+code { background: #f2f4f7; padding: .15em .35em; border-radius: 4px; font-size: .9em; }
+pre { background: #f2f4f7; padding: 12px 16px; border-radius: 6px; overflow: auto; }
+pre code { background: none; padding: 0; }
+pre .hl-keyword { color: #cf222e; }
+pre .hl-string { color: #0a3069; }
+pre .hl-comment { color: #6e7781; font-style: italic; }
+pre .hl-number { color: #0550ae; }
+pre.mermaid { white-space: pre-wrap; color: #57606a; }
+blockquote { margin: 0; padding: 0 1em; color: #57606a; border-left: 4px solid #d0d7de; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 6px 12px; }
+th { background: #f6f8fa; }
+li > input[type="checkbox"] { margin: 0 .4em 0 0; }
+ul.days { list-style: none; padding: 0; }
+ul.days li { padding: .4em 0; border-bottom: 1px solid #eaecef; }
+ul.days .summary { color: #57606a; margin-left: 1em; }
+hr { border: none; border-top: 1px solid #d0d7de; margin: 2em 0; }
+nav { display: flex; justify-content: space-between; font-size: .9em; margin-bottom: 1em; }
+footer { margin-top: 3em; color: #8c959f; font-size: .85em; text-align: center; }
+@media print {
+  body { background: #fff; }
+  main { box-shadow: none; margin: 0; max-width: none; }
+  nav { display: none; }
+}
+`
+
+// WriteHTML 写入内联样式的独立 HTML 文件，body 为 MarkdownToHTML 生成的 HTML 片段
+func WriteHTML(w io.Writer, title, body string) error {
+	return writePage(w, title, body, "<style>\n"+stylesheet+"</style>")
+}
+
+// writePage 写入完整的 HTML 页面，head 为样式或样式表链接
+func writePage(w io.Writer, title, body, head string) error {
+	_, err := fmt.Fprintf(w, `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8" />
+<meta name="viewport" content="width=device-width, initial-scale=1" />
+<title>%s</title>
+%s
+</head>
+<body>
+<main>
+%s
+</main>
+</body>
+</html>
+`, html.EscapeString(title), head, body)
+	return err
+}
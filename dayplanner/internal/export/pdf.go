@@ -0,0 +1,358 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// PDF 页面尺寸（A4，单位为点）与排版参数
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 56.0
+	pdfIndent     = 18.0 // 每级嵌套的缩进
+	pdfBodySize   = 11.0 // 正文字号
+	pdfCodeSize   = 9.5  // 代码字号
+	pdfLineRatio  = 1.55 // 行高与字号之比
+)
+
+// pdfHeadingSizes 各级标题字号，下标 0 为文档标题
+var pdfHeadingSizes = [...]float64{22, 18, 15, 13.5, 12.5, 12, 12}
+
+// WritePDF 将文档块排版为 A4 PDF
+// 使用 PDF 阅读器内置的 Adobe 标准中文字体 STSong-Light（UniGB-UTF16-H 编码），无需嵌入字体文件；
+// 阅读器需要支持亚洲语言字体（Acrobat、浏览器和系统自带的阅读器均支持）
+func WritePDF(w io.Writer, title string, blocks []Block) error {
+	layout := &pdfLayout{}
+	layout.newPage()
+	layout.heading(title, 0)
+	for _, block := range blocks {
+		layout.block(block)
+	}
+	return layout.write(w, title)
+}
+
+// pdfLayout 简单的流式排版器，按块从上到下排版并自动分页
+type pdfLayout struct {
+	pages []*bytes.Buffer // 每页的内容流
+	page  *bytes.Buffer   // 当前页
+	y     float64         // 当前基线位置（距页面底部）
+	first bool            // 当前页尚未输出内容，用于省略页首的段前间距
+}
+
+// newPage 开始新的一页
+func (l *pdfLayout) newPage() {
+	l.page = &bytes.Buffer{}
+	l.pages = append(l.pages, l.page)
+	l.y = pdfPageHeight - pdfMargin
+	l.first = true
+}
+
+// space 增加垂直间距，页首不增加
+func (l *pdfLayout) space(height float64) {
+	if !l.first {
+		l.y -= height
+	}
+}
+
+// nextLine 为高度为 lineHeight 的一行腾出位置，空间不足时换页，返回该行的基线位置
+func (l *pdfLayout) nextLine(lineHeight, size float64) float64 {
+	if l.y-lineHeight < pdfMargin {
+		l.newPage()
+	}
+	l.first = false
+	l.y -= lineHeight
+	// 基线位于行内偏下的位置
+	return l.y + (lineHeight-size)/2 + size*0.12
+}
+
+// block 排版一个文档块
+func (l *pdfLayout) block(block Block) {
+	indent := float64(block.Level) * pdfIndent
+	switch block.Kind {
+	case BlockHeading:
+		l.heading(block.Text, block.Level)
+	case BlockParagraph:
+		l.space(4)
+		l.paragraph(block.Text, pdfBodySize, indent, 0, 0)
+		l.space(4)
+	case BlockListItem:
+		markerWidth := 0.0
+		if block.Marker != "" {
+			markerWidth = textWidth(block.Marker, pdfBodySize) + 5
+		}
+		if markerWidth < 14 {
+			markerWidth = 14
+		}
+		l.space(1.5)
+		first := true
+		for _, line := range wrapText(block.Text, pdfBodySize, pdfPageWidth-2*pdfMargin-indent-markerWidth) {
+			y := l.nextLine(pdfBodySize*pdfLineRatio, pdfBodySize)
+			if first && block.Marker != "" {
+				l.text(pdfMargin+indent, y, pdfBodySize, block.Marker, 0, false)
+			}
+			l.text(pdfMargin+indent+markerWidth, y, pdfBodySize, line, 0, false)
+			first = false
+		}
+		l.space(1.5)
+	case BlockCode:
+		l.space(4)
+		l.paragraph(block.Text, pdfCodeSize, indent+12, 0.25, 0)
+		l.space(4)
+	case BlockQuote:
+		l.space(4)
+		l.paragraph(block.Text, pdfBodySize, indent+14, 0.4, indent+4)
+		l.space(4)
+	case BlockRule:
+		l.space(8)
+		y := l.nextLine(4, 0)
+		fmt.Fprintf(l.page, "0.8 G 0.5 w %s %s m %s %s l S\n",
+			pdfNumber(pdfMargin), pdfNumber(y), pdfNumber(pdfPageWidth-pdfMargin), pdfNumber(y))
+		l.space(8)
+	}
+}
+
+// heading 排版标题，level 为 0 时为文档标题
+func (l *pdfLayout) heading(text string, level int) {
+	if level < 0 || level >= len(pdfHeadingSizes) {
+		level = len(pdfHeadingSizes) - 1
+	}
+	size := pdfHeadingSizes[level]
+	l.space(size * 0.8)
+	for _, line := range wrapText(text, size, pdfPageWidth-2*pdfMargin) {
+		y := l.nextLine(size*1.35, size)
+		l.text(pdfMargin, y, size, line, 0, true)
+	}
+	// 标题后不单独留在页尾：剩余空间不足两行正文时换页
+	if l.y-pdfBodySize*pdfLineRatio*2 < pdfMargin {
+		l.newPage()
+		return
+	}
+	l.space(size * 0.35)
+}
+
+// paragraph 排版可换行的文本，barX 大于 0 时在左侧绘制引用竖线
+func (l *pdfLayout) paragraph(text string, size, indent, gray, barX float64) {
+	lineHeight := size * pdfLineRatio
+	for _, line := range wrapText(text, size, pdfPageWidth-2*pdfMargin-indent) {
+		y := l.nextLine(lineHeight, size)
+		if barX > 0 {
+			fmt.Fprintf(l.page, "0.8 G 2 w %s %s m %s %s l S\n",
+				pdfNumber(pdfMargin+barX), pdfNumber(l.y), pdfNumber(pdfMargin+barX), pdfNumber(l.y+lineHeight))
+		}
+		l.text(pdfMargin+indent, y, size, line, gray, false)
+	}
+}
+
+// text 在指定位置输出一行文字，bold 时使用描边模拟粗体
+func (l *pdfLayout) text(x, y, size float64, s string, gray float64, bold bool) {
+	if s == "" {
+		return
+	}
+	mode := "0 Tr"
+	if bold {
+		mode = "2 Tr 0.35 w"
+	}
+	fmt.Fprintf(l.page, "BT /F1 %s Tf %s %s g %s G %s %s Td <%s> Tj ET\n",
+		pdfNumber(size), mode, pdfNumber(gray), pdfNumber(gray), pdfNumber(x), pdfNumber(y), encodeUTF16Hex(s, false))
+}
+
+// write 输出完整的 PDF 文件
+func (l *pdfLayout) write(w io.Writer, title string) error {
+	// 页脚页码
+	for i, page := range l.pages {
+		footer := fmt.Sprintf("%d / %d", i+1, len(l.pages))
+		x := (pdfPageWidth - textWidth(footer, 9)) / 2
+		fmt.Fprintf(page, "BT /F1 9 Tf 0 Tr 0.5 g %s %s Td <%s> Tj ET\n",
+			pdfNumber(x), pdfNumber(pdfMargin/2), encodeUTF16Hex(footer, false))
+	}
+
+	pw := &pdfWriter{w: bufio.NewWriter(w)}
+	pw.raw("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 对象编号：1 目录，2 页面树，3~5 字体，6 文档信息，之后每页占两个对象（页面和内容流）
+	const firstPageObject = 7
+	kids := make([]string, len(l.pages))
+	for i := range l.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	pw.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	pw.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(l.pages)))
+	pw.object(3, "<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light /Encoding /UniGB-UTF16-H /DescendantFonts [4 0 R] >>")
+	pw.object(4, "<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 4 >> "+
+		"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>")
+	pw.object(5, "<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 /FontBBox [-25 -254 1000 880] "+
+		"/ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>")
+	pw.object(6, fmt.Sprintf("<< /Title <%s> /Producer (daily-report-tool) /CreationDate (D:%s) >>",
+		encodeUTF16Hex(title, true), time.Now().Format("20060102150405")))
+
+	for i, page := range l.pages {
+		pageObject := firstPageObject + 2*i
+		pw.object(pageObject, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfNumber(pdfPageWidth), pdfNumber(pdfPageHeight), pageObject+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return fmt.Errorf("压缩 PDF 内容失败: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("压缩 PDF 内容失败: %w", err)
+		}
+		pw.stream(pageObject+1, compressed.Bytes())
+	}
+
+	pw.trailer()
+	if pw.err != nil {
+		return fmt.Errorf("写入 PDF 失败: %w", pw.err)
+	}
+	if err := pw.w.Flush(); err != nil {
+		return fmt.Errorf("写入 PDF 失败: %w", err)
+	}
+	return nil
+}
+
+// pdfWriter 按顺序写入 PDF 对象并记录偏移量，用于生成交叉引用表
+type pdfWriter struct {
+	w       *bufio.Writer
+	offset  int
+	offsets []int // 下标为对象编号减 1
+	err     error
+}
+
+// raw 写入原始内容
+func (pw *pdfWriter) raw(s string) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.WriteString(s)
+	pw.offset += n
+	pw.err = err
+}
+
+// begin 开始一个对象，记录其偏移量
+func (pw *pdfWriter) begin(number int) {
+	for len(pw.offsets) < number {
+		pw.offsets = append(pw.offsets, 0)
+	}
+	pw.offsets[number-1] = pw.offset
+	pw.raw(fmt.Sprintf("%d 0 obj\n", number))
+}
+
+// object 写入字典对象
+func (pw *pdfWriter) object(number int, body string) {
+	pw.begin(number)
+	pw.raw(body + "\nendobj\n")
+}
+
+// stream 写入 FlateDecode 压缩的流对象
+func (pw *pdfWriter) stream(number int, data []byte) {
+	pw.begin(number)
+	pw.raw(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n", len(data)))
+	pw.raw(string(data))
+	pw.raw("\nendstream\nendobj\n")
+}
+
+// trailer 写入交叉引用表和文件尾
+func (pw *pdfWriter) trailer() {
+	xref := pw.offset
+	pw.raw(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1))
+	for _, offset := range pw.offsets {
+		pw.raw(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	pw.raw(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, xref))
+}
+
+// wrapText 按宽度折行，英文单词尽量在空格处断开，换行符强制换行
+func wrapText(text string, size, maxWidth float64) []string {
+	var lines []string
+	for _, hardLine := range strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n") {
+		runes := []rune(strings.TrimRight(hardLine, "\r"))
+		if len(runes) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		start, lastSpace := 0, -1
+		width := 0.0
+		for i := 0; i < len(runes); i++ {
+			charWidth := runeWidth(runes[i]) * size / 1000
+			if width+charWidth > maxWidth && i > start {
+				end := i
+				if lastSpace > start && runes[i] != ' ' && isWordRune(runes[i]) {
+					end = lastSpace + 1 // 在最近的空格后断开
+				}
+				lines = append(lines, strings.TrimRight(string(runes[start:end]), " "))
+				start, lastSpace = end, -1
+				// 新行开头不保留空格
+				for start < len(runes) && runes[start] == ' ' && start < i {
+					start++
+				}
+				width = 0
+				for _, r := range runes[start:i] {
+					width += runeWidth(r) * size / 1000
+				}
+			}
+			if runes[i] == ' ' {
+				lastSpace = i
+			}
+			width += charWidth
+		}
+		lines = append(lines, string(runes[start:]))
+	}
+	return lines
+}
+
+// isWordRune 判断字符是否属于需要整体换行的英文单词
+func isWordRune(r rune) bool {
+	return r > ' ' && r < 0x7f
+}
+
+// runeWidth 返回字符宽度（千分之一字号），与字体的 /W 定义一致：ASCII 为半角，其余为全角
+func runeWidth(r rune) float64 {
+	if r >= 0x20 && r <= 0x7e {
+		return 500
+	}
+	return 1000
+}
+
+// textWidth 返回文本在指定字号下的宽度
+func textWidth(s string, size float64) float64 {
+	width := 0.0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width * size / 1000
+}
+
+// encodeUTF16Hex 将文本编码为 UTF-16BE 十六进制字符串，bom 为 true 时添加字节序标记（用于文档信息）
+func encodeUTF16Hex(s string, bom bool) string {
+	var sb strings.Builder
+	if bom {
+		sb.WriteString("FEFF")
+	}
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&sb, "%04X", unit)
+	}
+	return sb.String()
+}
+
+// pdfNumber 格式化坐标等数值，最多保留两位小数
+func pdfNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
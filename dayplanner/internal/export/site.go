@@ -0,0 +1,124 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+// SitePage 静态网站中一天的日报
+type SitePage struct {
+	Date    time.Time
+	Summary string // 摘要，显示在月份页面的列表中
+	Body    string // MarkdownToHTML 生成的 HTML 片段
+}
+
+// WriteSite 在目录中生成多页面静态网站：
+// index.html 按月份索引，YYYY-MM.html 列出当月日报，YYYY-MM-DD.html 为每天的日报，样式保存在 style.css
+// pages 需按日期升序排列
+func WriteSite(dir, title string, pages []SitePage) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建导出目录失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), []byte(stylesheet), 0644); err != nil {
+		return fmt.Errorf("写入样式表失败: %w", err)
+	}
+
+	// 按月份分组，保持日期顺序
+	var months []string
+	byMonth := make(map[string][]int)
+	for i, page := range pages {
+		month := page.Date.Format("2006-01")
+		if _, ok := byMonth[month]; !ok {
+			months = append(months, month)
+		}
+		byMonth[month] = append(byMonth[month], i)
+	}
+
+	// 每天的日报页面，带前后导航
+	for i, page := range pages {
+		var sb strings.Builder
+		sb.WriteString("<nav>")
+		if i > 0 {
+			fmt.Fprintf(&sb, `<a href="%s.html">&larr; %s</a>`, dayKey(pages[i-1].Date), dayKey(pages[i-1].Date))
+		} else {
+			sb.WriteString("<span></span>")
+		}
+		month := page.Date.Format("2006-01")
+		fmt.Fprintf(&sb, `<a href="%s.html">%s</a>`, month, monthTitle(page.Date))
+		if i < len(pages)-1 {
+			fmt.Fprintf(&sb, `<a href="%s.html">%s &rarr;</a>`, dayKey(pages[i+1].Date), dayKey(pages[i+1].Date))
+		} else {
+			sb.WriteString("<span></span>")
+		}
+		sb.WriteString("</nav>\n")
+		fmt.Fprintf(&sb, "<h1>%s %s</h1>\n", dayKey(page.Date), util.ChineseWeekday(page.Date))
+		sb.WriteString(page.Body)
+
+		if err := writeSiteFile(dir, dayKey(page.Date)+".html", dayKey(page.Date)+" - "+title, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	// 月份页面
+	for _, month := range months {
+		indexes := byMonth[month]
+		first := pages[indexes[0]].Date
+
+		var sb strings.Builder
+		sb.WriteString(`<nav><a href="index.html">&larr; 返回目录</a></nav>` + "\n")
+		fmt.Fprintf(&sb, "<h1>%s</h1>\n<ul class=\"days\">\n", monthTitle(first))
+		for _, i := range indexes {
+			page := pages[i]
+			fmt.Fprintf(&sb, `<li><a href="%s.html">%s %s</a><span class="summary">%s</span></li>`+"\n",
+				dayKey(page.Date), dayKey(page.Date), util.ChineseWeekday(page.Date), html.EscapeString(page.Summary))
+		}
+		sb.WriteString("</ul>\n")
+
+		if err := writeSiteFile(dir, month+".html", monthTitle(first)+" - "+title, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	// 目录页面，最近的月份在前
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n<ul class=\"days\">\n", html.EscapeString(title))
+	for i := len(months) - 1; i >= 0; i-- {
+		month := months[i]
+		fmt.Fprintf(&sb, `<li><a href="%s.html">%s</a><span class="summary">%d 篇日报</span></li>`+"\n",
+			month, monthTitle(pages[byMonth[month][0]].Date), len(byMonth[month]))
+	}
+	sb.WriteString("</ul>\n")
+	fmt.Fprintf(&sb, "<footer>共 %d 篇日报</footer>\n", len(pages))
+
+	return writeSiteFile(dir, "index.html", title, sb.String())
+}
+
+// writeSiteFile 写入引用 style.css 的网站页面
+func writeSiteFile(dir, name, title, body string) error {
+	file, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return fmt.Errorf("创建页面 %s 失败: %w", name, err)
+	}
+	defer file.Close()
+
+	if err := writePage(file, title, body, `<link rel="stylesheet" href="style.css" />`); err != nil {
+		return fmt.Errorf("写入页面 %s 失败: %w", name, err)
+	}
+	return file.Close()
+}
+
+// dayKey 返回日期的页面名称
+func dayKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// monthTitle 返回月份标题，如 2025年11月
+func monthTitle(date time.Time) string {
+	return fmt.Sprintf("%d年%d月", date.Year(), date.Month())
+}
@@ -0,0 +1,255 @@
+// Package gitsync 通过 git 命令行把任务数据目录同步到远程仓库
+// 只依赖系统中安装的 git，远程仓库可以是任意 git 地址，包括本地的裸仓库
+package gitsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"daily-report-tool/internal/util"
+)
+
+// remoteName 同步使用的远程仓库名称
+const remoteName = "origin"
+
+// gitignore 数据目录中不同步的内容：历史版本为每台机器各自记录
+const gitignore = "history/\n*.tmp\n"
+
+// Conflict 合并时两边都修改了的文件，内容为 nil 表示该侧删除了文件或共同祖先中没有该文件
+type Conflict struct {
+	Path   string // 相对于数据目录的路径，使用 / 分隔
+	Base   []byte // 共同祖先中的内容
+	Ours   []byte // 本机内容
+	Theirs []byte // 远端内容
+}
+
+// Repo 作为 git 仓库管理的数据目录
+type Repo struct {
+	dir    string
+	remote string
+	branch string
+}
+
+// Open 打开数据目录对应的 git 仓库，目录还不是仓库时初始化，并将远程仓库设置为 remote
+func Open(dir, remote, branch string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("未找到 git 命令，请先安装 git: %w", err)
+	}
+	if branch == "" {
+		branch = "main"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建数据目录失败: %w", err)
+	}
+	r := &Repo{dir: dir, remote: remote, branch: branch}
+
+	// 只检查数据目录本身，数据目录位于其他 git 仓库中时仍单独初始化
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		util.Info("初始化数据目录的 git 仓库: %s", dir)
+		if _, err := r.git("init", "-q"); err != nil {
+			return nil, err
+		}
+		if _, err := r.git("symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+			return nil, err
+		}
+	}
+
+	ignorePath := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignorePath); os.IsNotExist(err) {
+		if err := os.WriteFile(ignorePath, []byte(gitignore), 0644); err != nil {
+			return nil, fmt.Errorf("写入 .gitignore 失败: %w", err)
+		}
+	}
+
+	// 没有配置提交者时使用默认身份，避免提交失败
+	if email, _ := r.git("config", "user.email"); strings.TrimSpace(email) == "" {
+		if _, err := r.git("config", "user.email", "daily-report@localhost"); err != nil {
+			return nil, err
+		}
+		if _, err := r.git("config", "user.name", "daily-report"); err != nil {
+			return nil, err
+		}
+	}
+
+	if remote != "" {
+		current, err := r.git("remote", "get-url", remoteName)
+		switch {
+		case err != nil:
+			_, err = r.git("remote", "add", remoteName, remote)
+		case strings.TrimSpace(current) != remote:
+			_, err = r.git("remote", "set-url", remoteName, remote)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Dir 返回数据目录
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// CommitAll 提交数据目录中的所有修改，没有修改时返回 false
+func (r *Repo) CommitAll(message string) (bool, error) {
+	if _, err := r.git("add", "-A"); err != nil {
+		return false, err
+	}
+	if _, err := r.git("diff", "--cached", "--quiet"); err == nil {
+		return false, nil
+	}
+	if _, err := r.git("commit", "-q", "--no-verify", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Fetch 获取远端分支，远端还没有该分支（如空仓库）时返回 false
+func (r *Repo) Fetch() (bool, error) {
+	heads, err := r.git("ls-remote", "--heads", remoteName, r.branch)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(heads) == "" {
+		return false, nil
+	}
+	refspec := fmt.Sprintf("+refs/heads/%s:%s", r.branch, r.trackingRef())
+	if _, err := r.git("fetch", "-q", remoteName, refspec); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Merge 合并已获取的远端分支，返回本地内容是否发生变化
+// 出现冲突时读取冲突文件各方的内容后放弃合并，工作区保持合并前的状态，由调用方解决后调用 MergeResolved
+func (r *Repo) Merge() (changed bool, conflicts []Conflict, err error) {
+	before := r.head()
+	conflicts, err = r.merge(nil)
+	if err != nil || len(conflicts) > 0 {
+		return false, conflicts, err
+	}
+	return r.head() != before, nil, nil
+}
+
+// MergeResolved 再次合并远端分支，冲突文件使用 resolved 中的内容（nil 表示删除）并提交
+// 远端在此期间产生了 resolved 之外的新冲突时放弃合并并返回这些冲突
+func (r *Repo) MergeResolved(resolved map[string][]byte) ([]Conflict, error) {
+	return r.merge(resolved)
+}
+
+// Push 将本地分支推送到远端
+func (r *Repo) Push() error {
+	_, err := r.git("push", "-q", remoteName, "HEAD:refs/heads/"+r.branch)
+	return err
+}
+
+// merge 合并远端分支，resolved 覆盖所有冲突时写入解决后的内容并完成合并
+func (r *Repo) merge(resolved map[string][]byte) ([]Conflict, error) {
+	_, mergeErr := r.git("merge", "-q", "--no-edit", "--allow-unrelated-histories",
+		"-m", "合并远端修改", r.trackingRef())
+	if mergeErr == nil {
+		return nil, nil
+	}
+
+	output, err := r.git("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	paths := strings.Fields(output)
+	if len(paths) == 0 {
+		// 不是内容冲突（如工作区有未提交的修改），恢复后返回原始错误
+		r.git("merge", "--abort")
+		return nil, mergeErr
+	}
+
+	var conflicts []Conflict
+	for _, path := range paths {
+		if _, ok := resolved[path]; !ok {
+			conflicts = append(conflicts, Conflict{
+				Path:   path,
+				Base:   r.stage(1, path),
+				Ours:   r.stage(2, path),
+				Theirs: r.stage(3, path),
+			})
+		}
+	}
+	if len(conflicts) > 0 {
+		if _, err := r.git("merge", "--abort"); err != nil {
+			return nil, err
+		}
+		return conflicts, nil
+	}
+
+	for _, path := range paths {
+		content := resolved[path]
+		if content == nil {
+			if _, err := r.git("rm", "-q", "--", path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(r.dir, filepath.FromSlash(path)), content, 0644); err != nil {
+			r.git("merge", "--abort")
+			return nil, fmt.Errorf("写入合并结果失败: %w", err)
+		}
+		if _, err := r.git("add", "--", path); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := r.git("commit", "-q", "--no-verify", "--no-edit"); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// stage 读取冲突文件在合并索引中的某一方内容，该方没有此文件时返回 nil
+func (r *Repo) stage(number int, path string) []byte {
+	content, err := r.git("show", fmt.Sprintf(":%d:%s", number, path))
+	if err != nil {
+		return nil
+	}
+	return []byte(content)
+}
+
+// head 返回当前提交，还没有提交时返回空字符串
+func (r *Repo) head() string {
+	output, err := r.git("rev-parse", "-q", "--verify", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// trackingRef 返回远端分支在本地的引用
+func (r *Repo) trackingRef() string {
+	return fmt.Sprintf("refs/remotes/%s/%s", remoteName, r.branch)
+}
+
+// git 在数据目录中执行 git 命令，返回标准输出
+func (r *Repo) git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.dir, "-c", "core.quotepath=false"}, args...)...)
+	// 禁止交互式输入凭据，使用固定语言以便日志可读
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "LC_ALL=C")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(stdout.String())
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && message != "" {
+			return stdout.String(), fmt.Errorf("git %s 失败: %s", args[0], message)
+		}
+		return stdout.String(), fmt.Errorf("git %s 失败: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
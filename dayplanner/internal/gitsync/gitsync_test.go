@@ -0,0 +1,128 @@
+package gitsync
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newRemote 创建用作远端的本地裸仓库
+func newRemote(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("未安装 git")
+	}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("创建裸仓库失败: %v: %s", err, output)
+	}
+	return remote
+}
+
+// openRepo 打开一个新的数据目录
+func openRepo(t *testing.T, remote string) *Repo {
+	t.Helper()
+	repo, err := Open(filepath.Join(t.TempDir(), "tasks"), remote, "main")
+	if err != nil {
+		t.Fatalf("打开仓库失败: %v", err)
+	}
+	return repo
+}
+
+// writeFile 在数据目录中写入文件
+func writeFile(t *testing.T, repo *Repo, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repo.Dir(), name), []byte(content), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+}
+
+// readFile 读取数据目录中的文件
+func readFile(t *testing.T, repo *Repo, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(repo.Dir(), name))
+	if err != nil {
+		t.Fatalf("读取文件失败: %v", err)
+	}
+	return string(data)
+}
+
+// syncRepo 提交、获取、合并并推送，返回冲突
+func syncRepo(t *testing.T, repo *Repo) []Conflict {
+	t.Helper()
+	if _, err := repo.CommitAll("同步"); err != nil {
+		t.Fatalf("提交失败: %v", err)
+	}
+	fetched, err := repo.Fetch()
+	if err != nil {
+		t.Fatalf("获取失败: %v", err)
+	}
+	if fetched {
+		_, conflicts, err := repo.Merge()
+		if err != nil {
+			t.Fatalf("合并失败: %v", err)
+		}
+		if len(conflicts) > 0 {
+			return conflicts
+		}
+	}
+	if err := repo.Push(); err != nil {
+		t.Fatalf("推送失败: %v", err)
+	}
+	return nil
+}
+
+func TestRepo_SyncAndConflict(t *testing.T) {
+	remote := newRemote(t)
+	a := openRepo(t, remote)
+	b := openRepo(t, remote)
+
+	// 空远端：第一台机器推送，第二台机器合并无关历史
+	writeFile(t, a, "2025-11-10.json", "A 的周一\n")
+	syncRepo(t, a)
+	writeFile(t, b, "2025-11-11.json", "B 的周二\n")
+	if conflicts := syncRepo(t, b); conflicts != nil {
+		t.Fatalf("不同文件不应冲突: %+v", conflicts)
+	}
+	syncRepo(t, a)
+	if readFile(t, a, "2025-11-11.json") != "B 的周二\n" {
+		t.Error("A 应该拉取到 B 的修改")
+	}
+
+	// 历史版本目录不同步
+	os.MkdirAll(filepath.Join(a.Dir(), "history"), 0755)
+	writeFile(t, a, "history/2025-11-10.jsonl", "{}\n")
+	if committed, err := a.CommitAll("历史"); err != nil || committed {
+		t.Errorf("history 目录不应提交: %v, %v", committed, err)
+	}
+
+	// 两边修改同一天
+	writeFile(t, a, "2025-11-10.json", "A 修改的周一\n")
+	syncRepo(t, a)
+	writeFile(t, b, "2025-11-10.json", "B 修改的周一\n")
+	conflicts := syncRepo(t, b)
+	if len(conflicts) != 1 || conflicts[0].Path != "2025-11-10.json" {
+		t.Fatalf("期望 2025-11-10.json 冲突，实际 %+v", conflicts)
+	}
+	c := conflicts[0]
+	if string(c.Base) != "A 的周一\n" || string(c.Ours) != "B 修改的周一\n" || string(c.Theirs) != "A 修改的周一\n" {
+		t.Errorf("冲突内容不正确: %q %q %q", c.Base, c.Ours, c.Theirs)
+	}
+	// 放弃合并后工作区保持本机内容
+	if readFile(t, b, "2025-11-10.json") != "B 修改的周一\n" {
+		t.Error("冲突后工作区应保持本机内容")
+	}
+
+	remaining, err := b.MergeResolved(map[string][]byte{"2025-11-10.json": []byte("合并后的周一\n")})
+	if err != nil || len(remaining) > 0 {
+		t.Fatalf("解决冲突失败: %v, %+v", err, remaining)
+	}
+	if err := b.Push(); err != nil {
+		t.Fatalf("推送失败: %v", err)
+	}
+	syncRepo(t, a)
+	if readFile(t, a, "2025-11-10.json") != "合并后的周一\n" {
+		t.Error("A 应该拉取到合并结果")
+	}
+}
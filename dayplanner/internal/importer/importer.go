@@ -0,0 +1,135 @@
+// Package importer 读取其他笔记工具中的每日笔记：Markdown 文件夹（包括 Obsidian 日记）以及 Joplin 的 JSON、RAW 和 JEX 导出
+// 每个笔记按日期映射为一篇日报，写入由 service.ImportService 完成
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+// Note 从外部来源读取的一篇每日笔记
+type Note struct {
+	Date      time.Time // 日报日期（本地时区零点）
+	Content   string    // 去掉 front matter 后的 Markdown 正文
+	CreatedAt time.Time // 创建时间，来源中没有时为零值
+	UpdatedAt time.Time // 更新时间，来源中没有时为零值
+	Source    string    // 来源文件路径，用于报告
+}
+
+// Ignored 无法导入的文件及原因
+type Ignored struct {
+	Source string
+	Reason string
+}
+
+// Result 读取结果，笔记按日期和来源路径排序
+type Result struct {
+	Notes   []Note
+	Ignored []Ignored
+}
+
+// Load 读取 path 中的笔记：目录中的 .md 和 .json 文件逐个识别格式，.jex 文件按 Joplin 导出包读取
+func Load(path string) (*Result, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取导入来源失败: %w", err)
+	}
+
+	result := &Result{}
+	switch {
+	case !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".jex"):
+		if err := loadJEX(path, result); err != nil {
+			return nil, err
+		}
+	case !info.IsDir():
+		if err := loadFile(path, info, result); err != nil {
+			return nil, err
+		}
+	default:
+		if err := loadDir(path, result); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(result.Notes, func(i, j int) bool {
+		if !result.Notes[i].Date.Equal(result.Notes[j].Date) {
+			return result.Notes[i].Date.Before(result.Notes[j].Date)
+		}
+		return result.Notes[i].Source < result.Notes[j].Source
+	})
+	util.Info("读取导入来源: %s, %d 篇笔记, %d 个文件无法导入", path, len(result.Notes), len(result.Ignored))
+	return result, nil
+}
+
+// loadDir 递归读取目录，跳过隐藏目录（如 .obsidian、.trash）
+func loadDir(root string, result *Result) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("遍历导入目录失败: %w", err)
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".md" && ext != ".markdown" && ext != ".json" {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("读取文件信息失败: %w", err)
+		}
+		return loadFile(path, info, result)
+	})
+}
+
+// loadFile 按内容识别格式并读取单个文件，无法识别日期的文件记入 Ignored
+func loadFile(path string, info fs.FileInfo, result *Result) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %w", path, err)
+	}
+	addNote(result, path, info.ModTime(), data)
+	return nil
+}
+
+// addNote 解析一个文件的内容并加入结果，modTime 在来源中没有时间戳时作为创建和更新时间
+func addNote(result *Result, path string, modTime time.Time, data []byte) {
+	var (
+		note    *Note
+		skipped bool // 不是笔记的 Joplin 条目（笔记本、标签、资源等），不计入 Ignored
+		err     error
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		note, skipped, err = parseJoplinJSON(data)
+	default:
+		if isJoplinRaw(data) {
+			note, skipped, err = parseJoplinRaw(data)
+		} else {
+			note, err = parseMarkdown(filepath.Base(path), data)
+			if note != nil && note.CreatedAt.IsZero() && note.UpdatedAt.IsZero() {
+				note.CreatedAt, note.UpdatedAt = modTime, modTime
+			}
+		}
+	}
+
+	switch {
+	case err != nil:
+		result.Ignored = append(result.Ignored, Ignored{Source: path, Reason: err.Error()})
+	case skipped:
+		util.Debug("跳过非笔记条目: %s", path)
+	default:
+		note.Source = path
+		result.Notes = append(result.Notes, *note)
+	}
+}
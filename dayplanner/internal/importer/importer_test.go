@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile 在目录中写入测试文件，自动创建上级目录
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+	return path
+}
+
+func TestLoad_MarkdownFolder(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "2025-11-10.md", "---\ncreated: 2025-11-10 09:00\nupdated: \"2025-11-10T18:30:00+08:00\"\ntags: [daily]\n---\n# 周一\n\n- [x] 评审\n")
+	writeFile(t, dir, "journal/2025_11_11.md", "- [ ] 联调\n")
+	writeFile(t, dir, "meeting.md", "---\ndate: 2025-11-12\n---\n会议纪要\n")
+	writeFile(t, dir, "README.md", "没有日期的笔记\n")
+	writeFile(t, dir, ".obsidian/2025-11-13.md", "配置目录中的文件不导入\n")
+
+	result, err := Load(dir)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Notes) != 3 {
+		t.Fatalf("期望 3 篇笔记，实际 %d: %+v", len(result.Notes), result.Notes)
+	}
+	if len(result.Ignored) != 1 || !strings.HasSuffix(result.Ignored[0].Source, "README.md") {
+		t.Errorf("期望忽略 README.md，实际 %+v", result.Ignored)
+	}
+
+	first := result.Notes[0]
+	if !first.Date.Equal(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("日期不正确: %v", first.Date)
+	}
+	if first.Content != "# 周一\n\n- [x] 评审" {
+		t.Errorf("front matter 应被去掉: %q", first.Content)
+	}
+	if !first.CreatedAt.Equal(time.Date(2025, 11, 10, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("创建时间不正确: %v", first.CreatedAt)
+	}
+	if !first.UpdatedAt.Equal(time.Date(2025, 11, 10, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("更新时间不正确: %v", first.UpdatedAt)
+	}
+
+	// 没有 front matter 时间戳的文件使用修改时间
+	if second := result.Notes[1]; second.CreatedAt.IsZero() || second.Content != "- [ ] 联调" {
+		t.Errorf("第二篇笔记不正确: %+v", second)
+	}
+	if third := result.Notes[2]; !third.Date.Equal(time.Date(2025, 11, 12, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("应使用 front matter 中的日期: %v", third.Date)
+	}
+}
+
+func TestLoad_JoplinJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", `{"id":"a","title":"日报 2025-11-10","body":"- [x] 发布","type_":1,"created_time":1762736400000,"updated_time":1762770000000,"user_created_time":1762732800000}`)
+	writeFile(t, dir, "b.json", `{"id":"b","title":"工作笔记本","type_":2}`)
+	writeFile(t, dir, "c.json", `{"id":"c","title":"没有日期","body":"内容","type_":1}`)
+
+	result, err := Load(dir)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(result.Notes) != 1 || len(result.Ignored) != 1 {
+		t.Fatalf("期望 1 篇笔记和 1 个忽略项，实际 %+v", result)
+	}
+	note := result.Notes[0]
+	if note.Content != "- [x] 发布" || !note.CreatedAt.Equal(time.UnixMilli(1762732800000)) ||
+		!note.UpdatedAt.Equal(time.UnixMilli(1762770000000)) {
+		t.Errorf("笔记不正确: %+v", note)
+	}
+}
+
+const joplinRaw = `2025-11-11
+
+- [ ] 写文档
+- [x] 修复问题
+
+id: 0123456789abcdef0123456789abcdef
+parent_id: fedcba9876543210fedcba9876543210
+created_time: 2025-11-11T01:00:00.000Z
+updated_time: 2025-11-11T10:00:00.000Z
+user_created_time: 2025-11-11T01:00:00.000Z
+user_updated_time: 2025-11-11T10:00:00.000Z
+is_todo: 0
+type_: 1`
+
+const joplinFolder = `工作
+
+id: 11111111111111111111111111111111
+created_time: 2025-11-01T01:00:00.000Z
+type_: 2`
+
+func TestLoad_JoplinRawAndJEX(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "raw/0123456789abcdef0123456789abcdef.md", joplinRaw)
+	writeFile(t, dir, "raw/11111111111111111111111111111111.md", joplinFolder)
+
+	result, err := Load(filepath.Join(dir, "raw"))
+	if err != nil {
+		t.Fatalf("读取 RAW 导出失败: %v", err)
+	}
+	if len(result.Notes) != 1 || len(result.Ignored) != 0 {
+		t.Fatalf("期望 1 篇笔记，实际 %+v", result)
+	}
+	note := result.Notes[0]
+	if !note.Date.Equal(time.Date(2025, 11, 11, 0, 0, 0, 0, time.Local)) || note.Content != "- [ ] 写文档\n- [x] 修复问题" {
+		t.Errorf("RAW 笔记不正确: %+v", note)
+	}
+	if !note.CreatedAt.Equal(time.Date(2025, 11, 11, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("创建时间不正确: %v", note.CreatedAt)
+	}
+
+	// JEX 是 RAW 条目打包成的 tar 文件
+	jexPath := filepath.Join(dir, "export.jex")
+	file, err := os.Create(jexPath)
+	if err != nil {
+		t.Fatalf("创建 JEX 失败: %v", err)
+	}
+	tw := tar.NewWriter(file)
+	for name, content := range map[string]string{
+		"0123456789abcdef0123456789abcdef.md": joplinRaw,
+		"resources/image.md":                  joplinRaw,
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("写入 JEX 失败: %v", err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	file.Close()
+
+	result, err = Load(jexPath)
+	if err != nil {
+		t.Fatalf("读取 JEX 失败: %v", err)
+	}
+	if len(result.Notes) != 1 || result.Notes[0].Content != note.Content {
+		t.Errorf("JEX 读取结果不正确: %+v", result)
+	}
+}
+
+func TestParseDateText(t *testing.T) {
+	cases := map[string]string{
+		"2025-11-10":       "2025-11-10",
+		"2025.11.10 周一":    "2025-11-10",
+		"Daily 20251110":   "2025-11-10",
+		"日报 2025/1/5":      "2025-01-05",
+		"v1.2.3":           "",
+		"2025-02-30":       "",
+		"2025-11_10":       "",
+		"Meeting notes 12": "",
+	}
+	for text, want := range cases {
+		date, ok := parseDateText(text)
+		got := ""
+		if ok {
+			got = date.Format("2006-01-02")
+		}
+		if got != want {
+			t.Errorf("parseDateText(%q) = %q，期望 %q", text, got, want)
+		}
+	}
+}
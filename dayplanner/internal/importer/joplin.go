@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// joplinTypeNote Joplin 条目类型中的笔记，其他类型（笔记本、资源、标签等）不导入
+const joplinTypeNote = 1
+
+// joplinMetaPattern 匹配 Joplin RAW 导出末尾的元数据行，如 "created_time: 2025-11-10T01:00:00.000Z"
+var joplinMetaPattern = regexp.MustCompile(`^([a-z_]+):\s?(.*)$`)
+
+// joplinIDPattern 匹配 Joplin 条目 ID（32 位十六进制）
+var joplinIDPattern = regexp.MustCompile(`(?m)^id: [0-9a-f]{32}$`)
+
+// joplinJSON Joplin JSON 导出中一个条目的字段，时间为毫秒时间戳
+type joplinJSON struct {
+	Title           string `json:"title"`
+	Body            string `json:"body"`
+	Type            int    `json:"type_"`
+	CreatedTime     int64  `json:"created_time"`
+	UpdatedTime     int64  `json:"updated_time"`
+	UserCreatedTime int64  `json:"user_created_time"`
+	UserUpdatedTime int64  `json:"user_updated_time"`
+}
+
+// parseJoplinJSON 解析 Joplin JSON 导出的一个条目，skipped 表示条目不是笔记
+func parseJoplinJSON(data []byte) (note *Note, skipped bool, err error) {
+	var item joplinJSON
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, false, fmt.Errorf("不是有效的 Joplin JSON 文件: %w", err)
+	}
+	if item.Type == 0 {
+		return nil, false, errors.New("不是 Joplin 导出的条目（缺少 type_ 字段）")
+	}
+	if item.Type != joplinTypeNote {
+		return nil, true, nil
+	}
+
+	note, err = joplinNote(item.Title, item.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	note.CreatedAt = joplinMillis(item.UserCreatedTime, item.CreatedTime)
+	note.UpdatedAt = joplinMillis(item.UserUpdatedTime, item.UpdatedTime)
+	return note, false, nil
+}
+
+// isJoplinRaw 判断 Markdown 文件是否为 Joplin RAW 导出格式（末尾带 id 和 type_ 元数据）
+func isJoplinRaw(data []byte) bool {
+	return joplinIDPattern.Match(data) && strings.Contains(string(data), "\ntype_: ")
+}
+
+// parseJoplinRaw 解析 Joplin RAW 导出的一个条目：第一行为标题，空行后为正文，末尾为元数据
+func parseJoplinRaw(data []byte) (note *Note, skipped bool, err error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// 从末尾向前读取元数据，直到空行
+	meta := make(map[string]string)
+	end := len(lines)
+	for end > 0 {
+		match := joplinMetaPattern.FindStringSubmatch(lines[end-1])
+		if match == nil {
+			break
+		}
+		meta[match[1]] = match[2]
+		end--
+	}
+	if meta["type_"] != fmt.Sprint(joplinTypeNote) {
+		return nil, true, nil
+	}
+	if end == 0 {
+		return nil, false, errors.New("Joplin 笔记缺少标题")
+	}
+
+	title := lines[0]
+	body := strings.Join(lines[1:end], "\n")
+	note, err = joplinNote(title, body)
+	if err != nil {
+		return nil, false, err
+	}
+	if note.CreatedAt, err = joplinTimestamp(meta, "user_created_time", "created_time"); err != nil {
+		return nil, false, err
+	}
+	if note.UpdatedAt, err = joplinTimestamp(meta, "user_updated_time", "updated_time"); err != nil {
+		return nil, false, err
+	}
+	return note, false, nil
+}
+
+// loadJEX 读取 Joplin 导出包（.jex，包含 RAW 格式条目的 tar 文件）
+func loadJEX(path string, result *Result) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("打开 Joplin 导出包失败: %w", err)
+	}
+	defer file.Close()
+
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("读取 Joplin 导出包失败: %w", err)
+		}
+		// 资源文件位于 resources/ 目录中，只读取根目录下的条目
+		if header.Typeflag != tar.TypeReg || strings.Contains(header.Name, "/") || !strings.HasSuffix(header.Name, ".md") {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("读取 Joplin 导出包失败: %w", err)
+		}
+		addNote(result, path+":"+header.Name, header.ModTime, data)
+	}
+}
+
+// joplinNote 按标题中的日期创建笔记，Joplin 笔记通常以日期作为标题
+func joplinNote(title, body string) (*Note, error) {
+	date, ok := parseDateText(title)
+	if !ok {
+		return nil, fmt.Errorf("笔记标题 %q 中没有日期", title)
+	}
+	return &Note{Date: date, Content: strings.TrimSpace(body)}, nil
+}
+
+// joplinTimestamp 读取 RAW 元数据中的时间，优先使用用户可修改的 user_* 字段
+func joplinTimestamp(meta map[string]string, keys ...string) (time.Time, error) {
+	for _, key := range keys {
+		value := meta[key]
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Joplin 元数据中 %s 的时间格式无法识别: %s", key, value)
+		}
+		return t.Local(), nil
+	}
+	return time.Time{}, nil
+}
+
+// joplinMillis 返回第一个非零的毫秒时间戳对应的时间
+func joplinMillis(values ...int64) time.Time {
+	for _, value := range values {
+		if value > 0 {
+			return time.UnixMilli(value)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,170 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// datePattern 匹配文件名或标题中的日期，支持 2025-11-10、2025_11_10、2025.11.10、2025/11/10 和 20251110
+var datePattern = regexp.MustCompile(`(?:^|[^0-9])(\d{4})([-_./]?)(\d{1,2})([-_./]?)(\d{1,2})(?:[^0-9]|$)`)
+
+// 各类笔记工具 front matter 中常用的时间字段，按优先级排列
+var (
+	createdKeys = []string{"created", "created_at", "createdat", "created_time", "date_created", "ctime"}
+	updatedKeys = []string{"updated", "updated_at", "updatedat", "updated_time", "modified", "modified_at", "date_modified", "mtime"}
+)
+
+// timestampLayouts front matter 中时间值支持的格式，没有时区的按本地时间解析
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+}
+
+// parseMarkdown 解析 Markdown 笔记：日期取自文件名，文件名中没有时使用 front matter 的 date 字段
+func parseMarkdown(name string, data []byte) (*Note, error) {
+	fields, body := splitFrontMatter(string(bytes.TrimPrefix(data, []byte("\ufeff"))))
+
+	date, ok := parseDateText(strings.TrimSuffix(name, filepath.Ext(name)))
+	if !ok {
+		if value := fields["date"]; value != "" {
+			if t, err := parseTimestamp(value); err == nil {
+				date, ok = dateOnly(t), true
+			}
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("文件名和 front matter 中都没有日期")
+	}
+
+	note := &Note{Date: date, Content: strings.TrimSpace(body)}
+	var err error
+	if note.CreatedAt, err = lookupTimestamp(fields, createdKeys); err != nil {
+		return nil, err
+	}
+	if note.UpdatedAt, err = lookupTimestamp(fields, updatedKeys); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// splitFrontMatter 拆分开头的 YAML front matter（--- 包围），只解析单行的 key: value，键转为小写
+// 没有 front matter 时返回空的字段表和原始内容
+func splitFrontMatter(content string) (map[string]string, string) {
+	fields := make(map[string]string)
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return fields, content
+	}
+
+	rest := normalized[len("---\n"):]
+	end := -1
+	for offset := 0; offset <= len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if trimmed := strings.TrimSpace(line); trimmed == "---" || trimmed == "..." {
+			end = offset
+			break
+		}
+		next := strings.IndexByte(rest[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	if end < 0 {
+		return fields, content // 没有结束标记，不是 front matter
+	}
+
+	for _, line := range strings.Split(rest[:end], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = unquote(strings.TrimSpace(value))
+	}
+
+	body := rest[end:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return fields, body
+}
+
+// lookupTimestamp 按优先级查找并解析时间字段，都不存在时返回零值
+func lookupTimestamp(fields map[string]string, keys []string) (time.Time, error) {
+	for _, key := range keys {
+		value := fields[key]
+		if value == "" {
+			continue
+		}
+		t, err := parseTimestamp(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("front matter 中 %s 的时间格式无法识别: %s", key, value)
+		}
+		return t, nil
+	}
+	return time.Time{}, nil
+}
+
+// parseTimestamp 解析时间值，支持常见的日期时间格式和 Unix 时间戳（秒或毫秒）
+func parseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && len(value) >= 9 {
+		if n > 1e11 {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法识别的时间格式: %s", value)
+}
+
+// parseDateText 从文本中提取日期，返回本地时区零点
+func parseDateText(text string) (time.Time, bool) {
+	for _, match := range datePattern.FindAllStringSubmatch(text, -1) {
+		// 分隔符需要前后一致，避免误匹配版本号等内容
+		if match[2] != match[4] || (match[2] == "" && (len(match[3]) != 2 || len(match[5]) != 2)) {
+			continue
+		}
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[3])
+		day, _ := strconv.Atoi(match[5])
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+		// 排除 2025-02-30 这类会被 time.Date 进位的无效日期
+		if date.Year() == year && int(date.Month()) == month && date.Day() == day {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dateOnly 返回时间在本地时区的当天零点
+func dateOnly(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// unquote 去掉 YAML 值两侧的引号
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
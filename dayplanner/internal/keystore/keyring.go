@@ -0,0 +1,109 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService 钥匙串中条目的服务名
+const keyringService = "daily-report-tool"
+
+// keyring 系统钥匙串
+type keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+}
+
+// systemKeyring 当前使用的钥匙串，测试中替换为内存实现
+var systemKeyring keyring = commandKeyring{}
+
+// KeyringSource 使用保存在系统钥匙串中的随机密钥作为密钥加密密钥
+// macOS 使用钥匙串访问（security 命令），Linux 使用 Secret Service（secret-tool 命令）
+type KeyringSource struct {
+	Account string // 钥匙串条目的账户名，通常为数据目录的绝对路径
+}
+
+// Name 返回来源名称
+func (s KeyringSource) Name() string {
+	return "keyring"
+}
+
+// deriveKey 从钥匙串读取密钥，创建时生成新密钥并写入钥匙串
+func (s KeyringSource) deriveKey(file *keyFile, create bool) ([]byte, error) {
+	if create {
+		kek := make([]byte, keySize)
+		if _, err := rand.Read(kek); err != nil {
+			return nil, fmt.Errorf("生成密钥失败: %w", err)
+		}
+		if err := systemKeyring.Set(keyringService, s.Account, base64.StdEncoding.EncodeToString(kek)); err != nil {
+			return nil, fmt.Errorf("写入系统钥匙串失败: %w", err)
+		}
+		return kek, nil
+	}
+
+	secret, err := systemKeyring.Get(keyringService, s.Account)
+	if err != nil {
+		return nil, fmt.Errorf("读取系统钥匙串失败: %w", err)
+	}
+	kek, err := base64.StdEncoding.DecodeString(strings.TrimSpace(secret))
+	if err != nil || len(kek) != keySize {
+		return nil, ErrWrongKey
+	}
+	return kek, nil
+}
+
+// commandKeyring 通过系统命令访问钥匙串
+type commandKeyring struct{}
+
+// Get 读取钥匙串中的密钥
+func (commandKeyring) Get(service, account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runKeyringCommand("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd":
+		return runKeyringCommand("", "secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("%s 不支持系统钥匙串，请改用口令", runtime.GOOS)
+	}
+}
+
+// Set 写入钥匙串，已存在时覆盖；密钥通过标准输入传递，不出现在命令行参数中
+func (commandKeyring) Set(service, account, secret string) error {
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("add-generic-password -U -s %q -a %q -w %q\n", service, account, secret)
+		_, err = runKeyringCommand(script, "security", "-i")
+	case "linux", "freebsd", "openbsd":
+		_, err = runKeyringCommand(secret, "secret-tool", "store", "--label=日报工具加密密钥", "service", service, "account", account)
+	default:
+		err = fmt.Errorf("%s 不支持系统钥匙串，请改用口令", runtime.GOOS)
+	}
+	return err
+}
+
+// runKeyringCommand 执行钥匙串命令并返回标准输出
+func runKeyringCommand(stdin, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("未找到 %s 命令: %w", name, err)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return "", fmt.Errorf("%s 失败: %s", name, strings.TrimSpace(stderr.String()))
+		}
+		return "", fmt.Errorf("%s 失败: %w", name, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,290 @@
+// Package keystore 管理日报加密使用的密钥
+// 日报使用随机生成的数据密钥通过 AES-GCM 加密；数据密钥由口令（Argon2id 派生）或系统钥匙串中的密钥加密后保存在密钥文件中。
+// 密钥文件同时用于校验：口令错误时无法解开数据密钥，启动时即可发现。更换口令只需重新加密数据密钥，不需要重写日报
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"daily-report-tool/internal/util"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// KeyFileName 密钥文件名，位于任务数据目录中
+	KeyFileName = ".encryption-key.json"
+
+	// keySize 数据密钥和密钥加密密钥的长度（AES-256）
+	keySize = 32
+
+	// keyFileVersion 密钥文件格式版本
+	keyFileVersion = 1
+
+	// sealedPrefix 加密内容的前缀，用于区分尚未加密的旧内容
+	sealedPrefix = "enc:v1:"
+)
+
+var (
+	// ErrWrongKey 口令或钥匙串中的密钥无法解开数据密钥
+	ErrWrongKey = errors.New("口令错误或密钥不匹配")
+
+	// ErrNoKeyFile 数据目录中还没有密钥文件
+	ErrNoKeyFile = errors.New("密钥文件不存在")
+)
+
+// Params Argon2id 参数
+type Params struct {
+	Time    uint32 `json:"time"`    // 迭代次数
+	Memory  uint32 `json:"memory"`  // 内存大小（KiB）
+	Threads uint8  `json:"threads"` // 并行度
+}
+
+// DefaultParams 创建密钥文件时使用的 Argon2id 参数（RFC 9106 推荐的低内存配置）
+var DefaultParams = Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// keyFile 密钥文件内容
+type keyFile struct {
+	Version    int    `json:"version"`
+	Source     string `json:"source"`           // 密钥来源: passphrase 或 keyring
+	Params     Params `json:"params,omitempty"` // 口令派生参数，仅 passphrase 使用
+	Salt       string `json:"salt,omitempty"`   // 口令派生使用的盐（base64）
+	WrappedKey string `json:"wrapped_key"`      // 加密后的数据密钥（base64，nonce + 密文）
+}
+
+// KeySource 提供解开数据密钥的密钥加密密钥
+type KeySource interface {
+	// Name 返回来源名称，记录在密钥文件中
+	Name() string
+
+	// deriveKey 根据密钥文件中的参数计算密钥加密密钥，create 为 true 表示正在创建新的密钥文件
+	deriveKey(file *keyFile, create bool) ([]byte, error)
+}
+
+// KeyFilePath 返回数据目录中的密钥文件路径
+func KeyFilePath(dataPath string) string {
+	return filepath.Join(dataPath, KeyFileName)
+}
+
+// Exists 判断密钥文件是否存在
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Create 生成新的数据密钥并用 source 加密后写入密钥文件，密钥文件已存在时返回错误
+func Create(path string, source KeySource) (*Cipher, error) {
+	if Exists(path) {
+		return nil, fmt.Errorf("密钥文件已存在: %s", path)
+	}
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("生成数据密钥失败: %w", err)
+	}
+	if err := writeKeyFile(path, dataKey, source); err != nil {
+		return nil, err
+	}
+	return NewCipher(dataKey)
+}
+
+// Open 读取密钥文件并用 source 解开数据密钥，口令错误时返回 ErrWrongKey
+func Open(path string, source KeySource) (*Cipher, error) {
+	dataKey, err := unwrap(path, source)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(dataKey)
+}
+
+// Rekey 用 oldSource 解开数据密钥后改用 newSource 加密，日报内容不需要重新加密
+func Rekey(path string, oldSource, newSource KeySource) error {
+	dataKey, err := unwrap(path, oldSource)
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(path, dataKey, newSource)
+}
+
+// unwrap 读取密钥文件并解开数据密钥
+func unwrap(path string, source KeySource) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNoKeyFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("解析密钥文件失败: %w", err)
+	}
+	if file.Version != keyFileVersion {
+		return nil, fmt.Errorf("不支持的密钥文件版本: %d", file.Version)
+	}
+	if file.Source != source.Name() {
+		return nil, fmt.Errorf("密钥文件使用 %s 保护，当前配置为 %s", file.Source, source.Name())
+	}
+
+	kek, err := source.deriveKey(&file, false)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(file.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("解析密钥文件失败: %w", err)
+	}
+	wrapper, err := NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := wrapper.open(wrapped, []byte(KeyFileName))
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	return dataKey, nil
+}
+
+// writeKeyFile 用 source 加密数据密钥并写入密钥文件，先写临时文件再替换
+func writeKeyFile(path string, dataKey []byte, source KeySource) error {
+	file := keyFile{Version: keyFileVersion, Source: source.Name()}
+	kek, err := source.deriveKey(&file, true)
+	if err != nil {
+		return err
+	}
+	wrapper, err := NewCipher(kek)
+	if err != nil {
+		return err
+	}
+	wrapped, err := wrapper.seal(dataKey, []byte(KeyFileName))
+	if err != nil {
+		return err
+	}
+	file.WrappedKey = base64.StdEncoding.EncodeToString(wrapped)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化密钥文件失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("创建密钥目录失败: %w", err)
+	}
+	if err := util.WriteFileAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("写入密钥文件失败: %w", err)
+	}
+	return nil
+}
+
+// PassphraseSource 从口令派生密钥加密密钥
+type PassphraseSource struct {
+	Passphrase string
+	Params     Params // 创建密钥文件时使用的参数，为零值时使用 DefaultParams
+}
+
+// Name 返回来源名称
+func (s PassphraseSource) Name() string {
+	return "passphrase"
+}
+
+// deriveKey 使用 Argon2id 派生密钥，创建时生成新的盐
+func (s PassphraseSource) deriveKey(file *keyFile, create bool) ([]byte, error) {
+	if s.Passphrase == "" {
+		return nil, fmt.Errorf("口令不能为空")
+	}
+	if create {
+		file.Params = s.Params
+		if file.Params == (Params{}) {
+			file.Params = DefaultParams
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("生成盐失败: %w", err)
+		}
+		file.Salt = base64.StdEncoding.EncodeToString(salt)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(file.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("密钥文件中的盐无效")
+	}
+	params := file.Params
+	if params.Time == 0 || params.Memory == 0 || params.Threads == 0 {
+		return nil, fmt.Errorf("密钥文件中的 Argon2id 参数无效")
+	}
+	return argon2.IDKey([]byte(s.Passphrase), salt, params.Time, params.Memory, params.Threads, keySize), nil
+}
+
+// Cipher 使用 AES-GCM 加密和解密日报内容
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher 使用 32 字节的密钥创建加密器
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("创建加密器失败: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("创建加密器失败: %w", err)
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal 加密文本，additional 为附加认证数据（如日期），解密时必须一致
+// 结果为带 enc:v1: 前缀的 base64 文本，可以直接保存在 JSON 字符串中
+func (c *Cipher) Seal(plaintext, additional string) (string, error) {
+	sealed, err := c.seal([]byte(plaintext), []byte(additional))
+	if err != nil {
+		return "", err
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open 解密 Seal 生成的文本；没有 enc:v1: 前缀的内容视为尚未加密，原样返回
+func (c *Cipher) Open(text, additional string) (string, error) {
+	if !IsSealed(text) {
+		return text, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("解析加密内容失败: %w", err)
+	}
+	plaintext, err := c.open(sealed, []byte(additional))
+	if err != nil {
+		return "", fmt.Errorf("解密失败，内容可能已损坏或使用了其他密钥: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// IsSealed 判断文本是否为加密内容
+func IsSealed(text string) bool {
+	return strings.HasPrefix(text, sealedPrefix)
+}
+
+// seal 加密，结果为 nonce + 密文
+func (c *Cipher) seal(plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// open 解密 seal 的结果
+func (c *Cipher) open(sealed, additional []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("密文长度不足")
+	}
+	return c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additional)
+}
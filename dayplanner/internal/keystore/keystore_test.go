@@ -0,0 +1,101 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testParams 测试使用的低成本 Argon2id 参数
+var testParams = Params{Time: 1, Memory: 64, Threads: 1}
+
+// memoryKeyring 内存中的钥匙串
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(service, account string) (string, error) {
+	secret, ok := k[service+"/"+account]
+	if !ok {
+		return "", fmt.Errorf("没有找到 %s", account)
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(service, account, secret string) error {
+	k[service+"/"+account] = secret
+	return nil
+}
+
+func TestKeystore_PassphraseAndRekey(t *testing.T) {
+	path := KeyFilePath(t.TempDir())
+	if _, err := Open(path, PassphraseSource{Passphrase: "口令"}); !errors.Is(err, ErrNoKeyFile) {
+		t.Errorf("密钥文件不存在时应返回 ErrNoKeyFile，实际 %v", err)
+	}
+
+	cipher, err := Create(path, PassphraseSource{Passphrase: "旧口令", Params: testParams})
+	if err != nil {
+		t.Fatalf("创建密钥文件失败: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("密钥文件权限应为 0600: %v, %v", info.Mode(), err)
+	}
+	sealed, err := cipher.Seal("客户 A 的故障处理", "2025-11-10")
+	if err != nil || !IsSealed(sealed) {
+		t.Fatalf("加密失败: %q, %v", sealed, err)
+	}
+
+	if _, err := Open(path, PassphraseSource{Passphrase: "错误口令"}); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("口令错误时应返回 ErrWrongKey，实际 %v", err)
+	}
+
+	if err := Rekey(path, PassphraseSource{Passphrase: "旧口令"}, PassphraseSource{Passphrase: "新口令", Params: testParams}); err != nil {
+		t.Fatalf("更换口令失败: %v", err)
+	}
+	if _, err := Open(path, PassphraseSource{Passphrase: "旧口令"}); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("更换后旧口令应失效，实际 %v", err)
+	}
+	reopened, err := Open(path, PassphraseSource{Passphrase: "新口令"})
+	if err != nil {
+		t.Fatalf("使用新口令打开失败: %v", err)
+	}
+	if plaintext, err := reopened.Open(sealed, "2025-11-10"); err != nil || plaintext != "客户 A 的故障处理" {
+		t.Errorf("更换口令后应能解密原有内容: %q, %v", plaintext, err)
+	}
+	if _, err := reopened.Open(sealed, "2025-11-11"); err == nil {
+		t.Error("附加数据不一致时应解密失败")
+	}
+	if plaintext, err := reopened.Open("未加密的旧内容", "2025-11-10"); err != nil || plaintext != "未加密的旧内容" {
+		t.Errorf("未加密内容应原样返回: %q, %v", plaintext, err)
+	}
+}
+
+func TestKeystore_Keyring(t *testing.T) {
+	original := systemKeyring
+	systemKeyring = memoryKeyring{}
+	defer func() { systemKeyring = original }()
+
+	dir := t.TempDir()
+	path := KeyFilePath(dir)
+	source := KeyringSource{Account: dir}
+	if _, err := Create(path, source); err != nil {
+		t.Fatalf("创建密钥文件失败: %v", err)
+	}
+	if _, err := Open(path, source); err != nil {
+		t.Fatalf("从钥匙串打开失败: %v", err)
+	}
+	if _, err := Open(path, PassphraseSource{Passphrase: "口令"}); err == nil {
+		t.Error("密钥来源不一致时应返回错误")
+	}
+
+	// 从钥匙串切换到口令
+	if err := Rekey(path, source, PassphraseSource{Passphrase: "口令", Params: testParams}); err != nil {
+		t.Fatalf("切换到口令失败: %v", err)
+	}
+	if _, err := Open(path, PassphraseSource{Passphrase: "口令"}); err != nil {
+		t.Errorf("切换后应能使用口令打开: %v", err)
+	}
+	if _, err := Create(filepath.Join(dir, KeyFileName), source); err == nil {
+		t.Error("密钥文件已存在时不应覆盖")
+	}
+}
@@ -1,9 +1,101 @@
 package model
 
+// 任务存储后端类型
+const (
+	StorageBackendFile   = "file"   // 每天一个 JSON 文件（默认）
+	StorageBackendSQLite = "sqlite" // 嵌入式 SQLite 数据库，支持全文检索
+	StorageBackendTeam   = "team"   // 团队服务器，日报保存在 team_server 上
+)
+
+// 任务数据同步方式
+const (
+	SyncBackendGit    = "git"    // 将任务目录作为 git 仓库，与远程仓库拉取和推送
+	SyncBackendWebDAV = "webdav" // 将任务文件和配置文件镜像到 WebDAV 服务器
+)
+
+// 任务加密的密钥来源
+const (
+	EncryptionPassphrase = "passphrase" // 从口令派生密钥（Argon2id）
+	EncryptionKeyring    = "keyring"    // 使用保存在系统钥匙串中的密钥
+)
+
+// 预览主题
+const (
+	PreviewThemeLight = "light" // 固定为浅色
+	PreviewThemeDark  = "dark"  // 固定为深色
+)
+
+// 通知渠道类型
+const (
+	ChannelTypeWeCom    = "wecom"    // 企业微信群机器人
+	ChannelTypeDingTalk = "dingtalk" // 钉钉群机器人，支持加签
+	ChannelTypeFeishu   = "feishu"   // 飞书 / Lark 群机器人，支持签名校验
+	ChannelTypeSlack    = "slack"    // Slack Incoming Webhook
+	ChannelTypeWebhook  = "webhook"  // 通用 JSON Webhook
+	ChannelTypeEmail    = "email"    // SMTP 邮件
+)
+
 // Config 表示应用程序的配置信息
 type Config struct {
-	WebhookURL      string `json:"webhook_url"`       // 企业微信 Webhook 地址
-	ReminderTime    string `json:"reminder_time"`     // 提醒时间 (格式: "10:00")
-	ReminderEnabled bool   `json:"reminder_enabled"`  // 是否启用提醒
-	DataPath        string `json:"data_path"`         // 数据存储路径
+	WebhookURL      string          `json:"webhook_url"`               // 企业微信 Webhook 地址
+	ReminderTime    string          `json:"reminder_time"`             // 提醒时间 (格式: "10:00")
+	ReminderEnabled bool            `json:"reminder_enabled"`          // 是否启用提醒
+	DataPath        string          `json:"data_path"`                 // 数据存储路径
+	StorageBackend  string          `json:"storage_backend,omitempty"` // 存储后端: file、sqlite 或 team，默认 file
+	DatabasePath    string          `json:"database_path,omitempty"`   // SQLite 数据库文件路径
+	Encryption      string          `json:"encryption,omitempty"`      // 任务加密的密钥来源: passphrase 或 keyring，为空时不加密
+	Channels        []ChannelConfig `json:"channels,omitempty"`        // 通知渠道，未配置时使用 WebhookURL 作为企业微信渠道
+
+	ReminderWorkdaysOnly bool           `json:"reminder_workdays_only,omitempty"` // 使用 ReminderTime 时是否仅在工作日提醒
+	HolidayFile          string         `json:"holiday_file,omitempty"`           // 节假日日历文件，用于判断工作日
+	Reminders            []ReminderRule `json:"reminders,omitempty"`              // 提醒规则，配置后取代 ReminderTime
+	ReminderCutoff       string         `json:"reminder_cutoff,omitempty"`        // 错过的提醒补发截止时间 (HH:MM)，默认当天结束前
+
+	SyncBackend  string `json:"sync_backend,omitempty"`  // 任务数据同步方式: git 或 webdav，为空时不同步
+	SyncRemote   string `json:"sync_remote,omitempty"`   // 远程仓库地址或 WebDAV 目录地址
+	SyncBranch   string `json:"sync_branch,omitempty"`   // git 同步使用的分支，默认 main
+	SyncUsername string `json:"sync_username,omitempty"` // WebDAV 用户名
+	SyncPassword string `json:"sync_password,omitempty"` // WebDAV 密码
+	SyncInterval int    `json:"sync_interval,omitempty"` // 定时同步间隔（分钟），默认 15
+
+	APIEnabled bool   `json:"api_enabled,omitempty"` // 是否启用本机 HTTP API
+	APIPort    int    `json:"api_port,omitempty"`    // HTTP API 监听端口（仅 127.0.0.1），默认 17800
+	APIToken   string `json:"api_token,omitempty"`   // HTTP API 访问令牌，为空时启动时自动生成并写入配置
+
+	TeamServer  string       `json:"team_server,omitempty"`  // 团队服务器地址，storage_backend 为 team 时日报保存在该服务器
+	TeamToken   string       `json:"team_token,omitempty"`   // 团队服务器上本人的访问令牌，由组长通过 team add-member 生成
+	TeamListen  string       `json:"team_listen,omitempty"`  // 作为团队服务器运行时的监听地址，默认 :17900
+	TeamMembers []TeamMember `json:"team_members,omitempty"` // 作为团队服务器运行时的成员列表
+
+	PreviewTheme string `json:"preview_theme,omitempty"` // 预览主题: light 或 dark，为空时跟随界面主题
+}
+
+// ReminderRule 表示一条提醒规则，到达时间且当天仍未填写日报时发送提醒
+// 多条规则可组成升级提醒：较晚的规则使用更紧急的内容并发送到其他渠道
+type ReminderRule struct {
+	Name         string   `json:"name,omitempty"`          // 规则名称，用于日志和防重复记录
+	Cron         string   `json:"cron"`                    // cron 表达式（分 时 日 月 星期），如 "30 9 * * 1-5"
+	WorkdaysOnly bool     `json:"workdays_only,omitempty"` // 是否仅在工作日（参考节假日日历）提醒
+	Message      string   `json:"message,omitempty"`       // 提醒内容，为空时使用默认内容
+	Channels     []string `json:"channels,omitempty"`      // 发送到的渠道名称，为空时发送到所有渠道
+	Cutoff       string   `json:"cutoff,omitempty"`        // 错过的提醒补发截止时间 (HH:MM)，默认使用 ReminderCutoff
+	Team         bool     `json:"team,omitempty"`          // 团队提醒：检查所有成员，只 @ 还没有填写日报的成员
+}
+
+// ChannelConfig 表示一个通知渠道的配置
+type ChannelConfig struct {
+	Name     string            `json:"name"`               // 渠道名称，用于日志和按名称引用
+	Type     string            `json:"type"`               // 渠道类型，见 ChannelType* 常量
+	Disabled bool              `json:"disabled,omitempty"` // 是否停用该渠道
+	URL      string            `json:"url,omitempty"`      // Webhook 地址
+	Secret   string            `json:"secret,omitempty"`   // 钉钉加签密钥 / 飞书签名校验密钥
+	Headers  map[string]string `json:"headers,omitempty"`  // 通用 Webhook 的附加请求头
+
+	// 以下为邮件渠道配置
+	SMTPHost string   `json:"smtp_host,omitempty"` // SMTP 服务器地址
+	SMTPPort int      `json:"smtp_port,omitempty"` // SMTP 端口，465 使用 TLS 直连，其他端口支持时使用 STARTTLS
+	Username string   `json:"username,omitempty"`  // SMTP 登录用户名
+	Password string   `json:"password,omitempty"`  // SMTP 登录密码或授权码
+	From     string   `json:"from,omitempty"`      // 发件人，默认同用户名
+	To       []string `json:"to,omitempty"`        // 收件人列表
 }
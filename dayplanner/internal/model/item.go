@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// ItemStatus 任务项状态
+type ItemStatus string
+
+const (
+	ItemStatusTodo    ItemStatus = "todo"    // 未开始：- [ ] 任务
+	ItemStatusDoing   ItemStatus = "doing"   // 进行中：- [ ] 任务 !doing
+	ItemStatusDone    ItemStatus = "done"    // 已完成：- [x] 任务
+	ItemStatusBlocked ItemStatus = "blocked" // 阻塞：- [ ] 任务 !blocked
+)
+
+// Item 日报中的一个任务项（带复选框的列表项）的结构化表示
+// Item 由 Task.Content 解析得到，不单独存储；修改后写回 Markdown，未修改的任务项保持原文不变
+type Item struct {
+	Title    string        `json:"title"`              // 去掉状态、耗时、标签和项目标记后的标题（Markdown）
+	Status   ItemStatus    `json:"status"`             // 状态
+	Estimate time.Duration `json:"estimate,omitempty"` // 预估耗时：~2h
+	Actual   time.Duration `json:"actual,omitempty"`   // 实际耗时：(1.5h)
+	Tags     []string      `json:"tags,omitempty"`     // #标签
+	Projects []string      `json:"projects,omitempty"` // +项目
+	Links    []Link        `json:"links,omitempty"`    // 标题中的链接
+	Line     int           `json:"line"`               // 在日报中的行号（从 0 开始），新增的任务项为 -1
+}
+
+// Link 任务项中的链接
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// ValidItemStatus 判断任务项状态是否有效
+func ValidItemStatus(status ItemStatus) bool {
+	switch status {
+	case ItemStatusTodo, ItemStatusDoing, ItemStatusDone, ItemStatusBlocked:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// JournalEntry 保存日志中的一条记录：编辑器中已修改、尚未自动保存的日报内容
+type JournalEntry struct {
+	Date      string    `json:"date"`       // 日报日期 (YYYY-MM-DD)
+	Content   string    `json:"content"`    // 修改后的内容
+	UpdatedAt time.Time `json:"updated_at"` // 修改时间
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// ReminderState 提醒服务的持久化状态，重启后用于防止重复提醒和继续重发
+type ReminderState struct {
+	LastSent map[string]string `json:"last_sent"`        // 各提醒规则上次处理的日期 (YYYY-MM-DD)
+	Outbox   []OutboxMessage   `json:"outbox,omitempty"` // 发送失败、等待重发的消息
+}
+
+// OutboxMessage 发件箱中等待重发的一条消息，每条只对应一个通知渠道
+type OutboxMessage struct {
+	Channel     string    `json:"channel"`              // 通知渠道名称
+	Subject     string    `json:"subject"`              // 消息标题
+	Text        string    `json:"text"`                 // 消息正文
+	Mentions    []string  `json:"mentions,omitempty"`   // 需要 @ 的成员
+	Attempts    int       `json:"attempts"`             // 已尝试发送的次数
+	NextAttempt time.Time `json:"next_attempt"`         // 下次重发时间
+	ExpiresAt   time.Time `json:"expires_at,omitempty"` // 过期时间，过期后不再重发
+	LastError   string    `json:"last_error,omitempty"` // 最近一次发送失败的原因
+}
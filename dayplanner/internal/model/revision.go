@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Revision 表示任务内容的一个历史版本
+type Revision struct {
+	ID        int       `json:"id"`         // 版本号，同一天内从 1 开始递增
+	Date      time.Time `json:"date"`       // 所属任务日期
+	Content   string    `json:"content"`    // 该版本的 Markdown 内容
+	CreatedAt time.Time `json:"created_at"` // 版本创建时间
+}
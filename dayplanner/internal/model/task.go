@@ -4,8 +4,40 @@ import "time"
 
 // Task 表示用户为特定日期创建的工作任务记录
 type Task struct {
-	Date      time.Time `json:"date"`       // 任务日期
-	Content   string    `json:"content"`    // Markdown 内容
-	CreatedAt time.Time `json:"created_at"` // 创建时间
-	UpdatedAt time.Time `json:"updated_at"` // 更新时间
+	Date        time.Time   `json:"date"`                   // 任务日期
+	Content     string      `json:"content"`                // Markdown 内容
+	CreatedAt   time.Time   `json:"created_at"`             // 创建时间
+	UpdatedAt   time.Time   `json:"updated_at"`             // 更新时间
+	CarriedFrom []CarryOver `json:"carried_from,omitempty"` // 从之前日期顺延到本日的未完成任务项
+	CarriedTo   []CarryOver `json:"carried_to,omitempty"`   // 从本日顺延到之后日期的未完成任务项
+	SubmittedAt time.Time   `json:"submitted_at,omitzero"`  // 最近一次提交到通知渠道的时间，未提交时为零值
+	SubmittedTo []string    `json:"submitted_to,omitempty"` // 已提交过的通知渠道名称，用于防止重复提交
+}
+
+// CarryOver 记录一次未完成任务项的顺延
+type CarryOver struct {
+	Date      string    `json:"date"`       // 对方日期 (YYYY-MM-DD)：顺延来源或顺延目标
+	Items     []string  `json:"items"`      // 顺延的任务项（复选框之后的原始 Markdown）
+	CarriedAt time.Time `json:"carried_at"` // 顺延时间
+}
+
+// CarriedItems 返回已顺延到指定日期的任务项
+func (t *Task) CarriedItems(date string) []string {
+	var items []string
+	for _, carry := range t.CarriedTo {
+		if carry.Date == date {
+			items = append(items, carry.Items...)
+		}
+	}
+	return items
+}
+
+// IsSubmittedTo 判断日报是否已提交到指定名称的通知渠道
+func (t *Task) IsSubmittedTo(channel string) bool {
+	for _, name := range t.SubmittedTo {
+		if name == channel {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"regexp"
+	"time"
+)
+
+// TeamMember 团队服务器上的一个成员
+type TeamMember struct {
+	Name    string `json:"name"`              // 成员名，同时作为服务器上该成员日报目录的名称
+	Token   string `json:"token"`             // 访问令牌，成员客户端配置为 team_token
+	Mention string `json:"mention,omitempty"` // 在通知渠道中 @ 该成员使用的 ID：企业微信 userid、钉钉手机号或 userId、飞书 open_id、Slack 成员 ID
+	Lead    bool   `json:"lead,omitempty"`    // 是否为组长，组长可以查看成员的填报情况和日报
+}
+
+// memberNamePattern 成员名只允许字母、数字、下划线、连字符和点，不能以点开头，避免用作目录名时越出数据目录
+var memberNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-][\p{L}\p{N}_.-]*$`)
+
+// ValidMemberName 判断成员名是否有效
+func ValidMemberName(name string) bool {
+	return len(name) <= 64 && memberNamePattern.MatchString(name)
+}
+
+// MemberStatus 一个成员在某天的日报填报情况
+type MemberStatus struct {
+	Name      string    // 成员名
+	Mention   string    // 在通知渠道中 @ 该成员使用的 ID
+	Lead      bool      // 是否为组长
+	Filed     bool      // 是否已填写日报（内容不为空）
+	UpdatedAt time.Time // 日报最后更新时间，未填写时为零值
+}
+
+// TeamStatus 团队在某天的日报填报情况
+type TeamStatus struct {
+	Date    time.Time
+	Members []MemberStatus // 按服务器配置中的成员顺序
+}
+
+// Filed 返回已填写日报的成员
+func (s *TeamStatus) Filed() []MemberStatus {
+	return s.filter(true)
+}
+
+// Missing 返回还没有填写日报的成员
+func (s *TeamStatus) Missing() []MemberStatus {
+	return s.filter(false)
+}
+
+// filter 按是否已填写筛选成员
+func (s *TeamStatus) filter(filed bool) []MemberStatus {
+	var result []MemberStatus
+	for _, member := range s.Members {
+		if member.Filed == filed {
+			result = append(result, member)
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DingTalkNotifier 钉钉群机器人通知渠道
+type DingTalkNotifier struct {
+	name   string
+	url    string
+	secret string // 加签密钥，为空时不签名
+}
+
+// NewDingTalkNotifier 创建钉钉通知渠道，secret 为机器人安全设置中的加签密钥
+func NewDingTalkNotifier(name, webhookURL, secret string) *DingTalkNotifier {
+	return &DingTalkNotifier{name: name, url: webhookURL, secret: secret}
+}
+
+// Name 返回渠道名称
+func (n *DingTalkNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送文本消息，设置了 Markdown 时发送 markdown 消息
+// 文本消息中的 Mentions 放入 at.atMobiles 或 at.atUserIds，并在正文末尾加上 @ 以便高亮显示
+func (n *DingTalkNotifier) Send(message Message) error {
+	if message.Markdown != "" {
+		for _, part := range splitMarkdown(convertMarkdown(message.Markdown, dingtalkDialect), dingtalkMarkdownLimit) {
+			if err := n.post(map[string]any{
+				"msgtype": "markdown",
+				"markdown": map[string]string{
+					"title": message.subjectOrDefault(),
+					"text":  part,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	payload := map[string]any{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": message.Text + mentionSuffix(message.Mentions, "@%s"),
+		},
+	}
+	if len(message.Mentions) > 0 {
+		mobiles, ids := splitMentions(message.Mentions)
+		payload["at"] = map[string][]string{"atMobiles": mobiles, "atUserIds": ids}
+	}
+	return n.post(payload)
+}
+
+// post 签名后发送一条消息并检查返回的错误码，每条消息使用新的时间戳签名
+func (n *DingTalkNotifier) post(payload map[string]any) error {
+	requestURL, err := n.signedURL()
+	if err != nil {
+		return err
+	}
+
+	body, err := postJSON(requestURL, payload, nil)
+	if err != nil {
+		return err
+	}
+	return checkErrCode("钉钉", body)
+}
+
+// signedURL 返回附带 timestamp 和 sign 参数的请求地址
+// 签名为以密钥为 key 对 "毫秒时间戳\n密钥" 做 HmacSHA256 后的 Base64
+func (n *DingTalkNotifier) signedURL() (string, error) {
+	if n.secret == "" {
+		return n.url, nil
+	}
+
+	parsed, err := url.Parse(n.url)
+	if err != nil {
+		return "", fmt.Errorf("无效的钉钉 Webhook 地址: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(now().UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(n.secret))
+	mac.Write([]byte(timestamp + "\n" + n.secret))
+
+	query := parsed.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
@@ -0,0 +1,173 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+const (
+	// defaultSMTPPort 未配置端口时使用的 SMTP 提交端口
+	defaultSMTPPort = 587
+	// implicitTLSPort 使用 TLS 直连的 SMTP 端口
+	implicitTLSPort = 465
+	// smtpTimeout SMTP 会话的整体超时时间
+	smtpTimeout = 30 * time.Second
+)
+
+// EmailNotifier SMTP 邮件通知渠道
+type EmailNotifier struct {
+	name     string
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewEmailNotifier 根据渠道配置创建邮件通知渠道
+func NewEmailNotifier(name string, channel model.ChannelConfig) *EmailNotifier {
+	port := channel.SMTPPort
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	from := channel.From
+	if from == "" {
+		from = channel.Username
+	}
+	return &EmailNotifier{
+		name:     name,
+		host:     channel.SMTPHost,
+		port:     port,
+		username: channel.Username,
+		password: channel.Password,
+		from:     from,
+		to:       channel.To,
+	}
+}
+
+// Name 返回渠道名称
+func (n *EmailNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送纯文本邮件
+// 465 端口使用 TLS 直连，其他端口在服务器支持时升级为 STARTTLS
+func (n *EmailNotifier) Send(message Message) error {
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	tlsConfig := &tls.Config{ServerName: n.host}
+
+	var conn net.Conn
+	var err error
+	if n.port == implicitTLSPort {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("连接 SMTP 服务器失败: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("连接 SMTP 服务器失败: %w", err)
+	}
+	defer client.Close()
+
+	if n.port != implicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("启用 STARTTLS 失败: %w", err)
+			}
+		}
+	}
+
+	if n.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return fmt.Errorf("SMTP 登录失败: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.from); err != nil {
+		return fmt.Errorf("设置发件人失败: %w", err)
+	}
+	for _, to := range n.to {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("设置收件人 %s 失败: %w", to, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("发送邮件内容失败: %w", err)
+	}
+	if _, err := writer.Write(n.buildMessage(message)); err != nil {
+		writer.Close()
+		return fmt.Errorf("发送邮件内容失败: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("发送邮件内容失败: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage 构建 UTF-8 邮件，标题使用 MIME 编码，正文使用 Base64 编码
+// 消息包含 Markdown 时发送 multipart/alternative：纯文本部分为 Markdown 原文，HTML 部分为渲染结果
+func (n *EmailNotifier) buildMessage(message Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", message.subjectOrDefault()))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if message.Markdown == "" {
+		writeBase64Part(&buf, "text/plain", message.Text)
+		return buf.Bytes()
+	}
+
+	html, err := util.MarkdownToHTML(message.Markdown)
+	if err != nil {
+		// 渲染失败时退回纯文本
+		util.Warn("渲染邮件 HTML 失败: %v", err)
+		writeBase64Part(&buf, "text/plain", message.Markdown)
+		return buf.Bytes()
+	}
+	const boundary = "daily-report-alternative"
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writeBase64Part(&buf, "text/plain", message.Markdown)
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writeBase64Part(&buf, "text/html", "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"></head><body>\n"+html+"</body></html>\n")
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes()
+}
+
+// writeBase64Part 写入内容类型头和 Base64 编码的正文，正文按 76 个字符换行
+func writeBase64Part(buf *bytes.Buffer, contentType, body string) {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=UTF-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+}
@@ -0,0 +1,133 @@
+package notifier
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"daily-report-tool/internal/model"
+)
+
+// fakeSMTPServer 最小化的 SMTP 测试服务器，记录收到的信封和邮件内容
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+// newFakeSMTPServer 启动只处理一次会话的 SMTP 测试服务器
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("启动测试 SMTP 服务器失败: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			s.to = append(s.to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, _ := text.ReadDotBytes()
+			s.data = string(data)
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	notifier, err := New(model.ChannelConfig{
+		Name:     "邮件",
+		Type:     model.ChannelTypeEmail,
+		SMTPHost: host,
+		SMTPPort: portNumber,
+		From:     "bot@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("创建邮件渠道失败: %v", err)
+	}
+
+	if err := notifier.Send(Message{Subject: "日报提醒", Text: "今天还没有填写日报"}); err != nil {
+		t.Fatalf("发送邮件失败: %v", err)
+	}
+	<-server.done
+
+	if server.from != "bot@example.com" || strings.Join(server.to, ",") != "a@example.com,b@example.com" {
+		t.Errorf("邮件信封不正确: from=%s to=%v", server.from, server.to)
+	}
+
+	header, body, _ := strings.Cut(server.data, "\n\n")
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(header + "\n\n")))
+	mimeHeader, err := reader.ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("解析邮件头失败: %v", err)
+	}
+	if mimeHeader.Get("Subject") != "=?UTF-8?b?"+base64.StdEncoding.EncodeToString([]byte("日报提醒"))+"?=" {
+		t.Errorf("邮件主题编码不正确: %s", mimeHeader.Get("Subject"))
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(strings.TrimSpace(body), "\n", ""))
+	if err != nil || string(decoded) != "今天还没有填写日报" {
+		t.Errorf("邮件正文不正确: %q, %v", decoded, err)
+	}
+}
+
+func TestEmailNotifier_MarkdownMessage(t *testing.T) {
+	n := NewEmailNotifier("email", model.ChannelConfig{SMTPHost: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}})
+	data := string(n.buildMessage(Message{Subject: "日报", Markdown: "# 今日完成\n- [x] 联调"}))
+
+	if !strings.Contains(data, "multipart/alternative") || strings.Count(data, "Content-Type: text/") != 2 {
+		t.Fatalf("Markdown 邮件应包含纯文本和 HTML 两部分: %s", data)
+	}
+	htmlStart := strings.Index(data, "Content-Type: text/html")
+	body := data[strings.Index(data[htmlStart:], "\r\n\r\n")+htmlStart+4:]
+	body = body[:strings.Index(body, "--")]
+	html, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\r\n", ""))
+	if err != nil || !strings.Contains(string(html), "<h1") {
+		t.Errorf("HTML 部分应为渲染后的日报: %v %s", err, html)
+	}
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// FeishuNotifier 飞书 / Lark 群机器人通知渠道
+type FeishuNotifier struct {
+	name   string
+	url    string
+	secret string // 签名校验密钥，为空时不签名
+}
+
+// NewFeishuNotifier 创建飞书通知渠道，secret 为机器人安全设置中的签名校验密钥
+func NewFeishuNotifier(name, url, secret string) *FeishuNotifier {
+	return &FeishuNotifier{name: name, url: url, secret: secret}
+}
+
+// Name 返回渠道名称
+func (n *FeishuNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送文本消息，设置了 Markdown 时发送带 markdown 元素的消息卡片
+// 文本消息中的 Mentions 以 <at user_id="open_id"></at> 附加在正文末尾
+func (n *FeishuNotifier) Send(message Message) error {
+	if message.Markdown != "" {
+		for _, part := range splitMarkdown(convertMarkdown(message.Markdown, feishuDialect), feishuMarkdownLimit) {
+			if err := n.post(map[string]any{
+				"msg_type": "interactive",
+				"card": map[string]any{
+					"header": map[string]any{
+						"title": map[string]string{"tag": "plain_text", "content": message.subjectOrDefault()},
+					},
+					"elements": []any{
+						map[string]string{"tag": "markdown", "content": part},
+					},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return n.post(map[string]any{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": message.Text + mentionSuffix(message.Mentions, `<at user_id="%s"></at>`),
+		},
+	})
+}
+
+// post 签名后发送一条消息并检查返回的错误码
+func (n *FeishuNotifier) post(payload map[string]any) error {
+	// 签名为以 "秒级时间戳\n密钥" 为 key 对空串做 HmacSHA256 后的 Base64
+	if n.secret != "" {
+		timestamp := strconv.FormatInt(now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(timestamp+"\n"+n.secret))
+		payload["timestamp"] = timestamp
+		payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	body, err := postJSON(n.url, payload, nil)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析飞书响应失败: %w", err)
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("飞书返回错误: %s (错误码: %d)", result.Msg, result.Code)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package notifier
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 各渠道单条 Markdown 消息的长度上限（字节），超出时拆分为多条发送
+const (
+	wecomMarkdownLimit    = 4096
+	dingtalkMarkdownLimit = 20000
+	feishuMarkdownLimit   = 20000
+	slackTextLimit        = 40000
+)
+
+// partMarkerReserve 为分段标记（如 "（2/3）"）预留的长度
+const partMarkerReserve = 32
+
+// markdownDialect 渠道支持的 Markdown 语法，不支持的语法会被转换
+type markdownDialect struct {
+	headings   bool // 支持 # 标题，不支持时转换为加粗
+	codeBlocks bool // 支持 ``` 代码块，不支持时转换为引用
+	images     bool // 支持 ![](url) 图片，不支持时转换为链接
+	slack      bool // 使用 Slack mrkdwn 语法：*加粗*、<url|文字>、~删除线~
+}
+
+// 各渠道的 Markdown 方言
+var (
+	// 企业微信群机器人只支持标题、加粗、链接、行内代码、引用和字体颜色
+	wecomDialect = markdownDialect{headings: true}
+	// 钉钉支持标题、引用、加粗、斜体、链接、图片和列表
+	dingtalkDialect = markdownDialect{headings: true, images: true}
+	// 飞书消息卡片的 markdown 元素支持加粗、斜体、链接、列表和代码块，不支持标题
+	feishuDialect = markdownDialect{codeBlocks: true}
+	// Slack mrkdwn 不支持标题
+	slackDialect = markdownDialect{codeBlocks: true, slack: true}
+)
+
+var (
+	checkboxPattern  = regexp.MustCompile(`^(\s*[-*+]\s+)\[([ xX])\]\s+`)
+	headingPattern   = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	imagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	boldPattern      = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	strikePattern    = regexp.MustCompile(`~~(.+?)~~`)
+	tableRulePattern = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+
+	slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// convertMarkdown 将日报 Markdown 转换为渠道支持的语法
+// 所有渠道都不支持任务复选框和表格：复选框转换为 ✅ / ⬜，表格转换为以 | 分隔的文本行
+func convertMarkdown(text string, dialect markdownDialect) string {
+	var out []string
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if dialect.slack {
+			// Slack 要求转义 &、< 和 >，转换生成的链接在转义之后添加
+			line = slackEscaper.Replace(line)
+			if rest, ok := strings.CutPrefix(line, "&gt; "); ok {
+				line = "> " + rest // 保留引用
+			}
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			if dialect.codeBlocks {
+				out = append(out, line)
+			}
+			continue
+		}
+		if inFence {
+			if dialect.codeBlocks {
+				out = append(out, line)
+			} else {
+				out = append(out, "> "+line)
+			}
+			continue
+		}
+
+		// 表格：去掉分隔行，单元格以 | 分隔
+		if strings.HasPrefix(trimmed, "|") {
+			if tableRulePattern.MatchString(trimmed) {
+				continue
+			}
+			cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			line = strings.Join(cells, " | ")
+		}
+
+		line = checkboxPattern.ReplaceAllStringFunc(line, func(match string) string {
+			groups := checkboxPattern.FindStringSubmatch(match)
+			if groups[2] == " " {
+				return groups[1] + "⬜ "
+			}
+			return groups[1] + "✅ "
+		})
+
+		if groups := headingPattern.FindStringSubmatch(line); groups != nil && !dialect.headings {
+			if dialect.slack {
+				line = "*" + groups[1] + "*"
+			} else {
+				line = "**" + groups[1] + "**"
+			}
+		}
+
+		if !dialect.images {
+			line = imagePattern.ReplaceAllStringFunc(line, func(match string) string {
+				groups := imagePattern.FindStringSubmatch(match)
+				alt := groups[1]
+				if alt == "" {
+					alt = "图片"
+				}
+				if dialect.slack {
+					return "<" + groups[2] + "|" + alt + ">"
+				}
+				return "[" + alt + "](" + groups[2] + ")"
+			})
+		}
+
+		if dialect.slack {
+			line = linkPattern.ReplaceAllString(line, "<$2|$1>")
+			line = boldPattern.ReplaceAllString(line, "*$1$2*")
+			line = strikePattern.ReplaceAllString(line, "~$1~")
+		}
+		out = append(out, line)
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// splitMarkdown 按行将 Markdown 拆分为不超过 limit 字节的多段，多段时在每段末尾加上序号
+// 拆分点位于代码块内时，在段末补上结束标记并在下一段重新打开代码块；超长的单行按字符截断
+func splitMarkdown(text string, limit int) []string {
+	if len(text) <= limit {
+		return []string{text}
+	}
+	budget := limit - partMarkerReserve
+
+	var parts []string
+	var current strings.Builder
+	fence := "" // 当前所在代码块的起始行，不在代码块中时为空
+	flush := func() {
+		part := strings.TrimRight(current.String(), "\n")
+		if fence != "" {
+			part += "\n" + fence[:3]
+		}
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+		current.Reset()
+		if fence != "" {
+			current.WriteString(fence + "\n")
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		// 在代码块内拆分时，段末需要为结束标记预留空间，下一段开头需要重复起始行
+		closing := 0
+		if fence != "" {
+			closing = len("\n```")
+		}
+		maxLine := budget - closing - len(fence) - 2
+		for len(line) > maxLine {
+			cut := truncateBytes(line, maxLine)
+			if current.Len()+len(cut)+1+closing > budget {
+				flush()
+			}
+			current.WriteString(cut + "\n")
+			line = line[len(cut):]
+		}
+		if current.Len()+len(line)+1+closing > budget {
+			flush()
+		}
+		current.WriteString(line + "\n")
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if fence == "" {
+				fence = trimmed
+			} else {
+				fence = ""
+			}
+		}
+	}
+	fence = ""
+	flush()
+
+	if len(parts) > 1 {
+		for i := range parts {
+			parts[i] += fmt.Sprintf("\n\n（%d/%d）", i+1, len(parts))
+		}
+	}
+	return parts
+}
+
+// truncateBytes 返回不超过 n 字节且不截断 UTF-8 字符的前缀
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,261 @@
+// Package notifier 提供可插拔的消息通知渠道：企业微信、钉钉、飞书、Slack、通用 Webhook 和 SMTP 邮件
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// defaultSubject 消息未指定标题时使用的默认标题（邮件主题等）
+const defaultSubject = "日报工具通知"
+
+// httpClient 各 Webhook 渠道共用的 HTTP 客户端
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// now 返回当前时间，测试中可替换以固定签名时间戳
+var now = time.Now
+
+// Message 表示一条待发送的通知消息
+type Message struct {
+	Subject  string   // 标题，用于邮件主题和通用 Webhook，聊天类渠道忽略
+	Text     string   // 纯文本正文
+	Markdown string   // Markdown 正文，设置后各渠道发送富文本消息，不支持的语法会被转换，超长时拆分为多条
+	Mentions []string // 纯文本消息中需要 @ 的成员 ID，如企业微信 userid、钉钉手机号，邮件渠道忽略
+}
+
+// subjectOrDefault 返回消息标题，未设置时返回默认标题
+func (m Message) subjectOrDefault() string {
+	if m.Subject == "" {
+		return defaultSubject
+	}
+	return m.Subject
+}
+
+// Notifier 定义通知渠道接口
+type Notifier interface {
+	// Name 返回渠道名称
+	Name() string
+
+	// Send 发送一条消息
+	Send(message Message) error
+}
+
+// New 根据渠道配置创建通知渠道，配置不完整时返回错误
+func New(channel model.ChannelConfig) (Notifier, error) {
+	name := channel.Name
+	if name == "" {
+		name = channel.Type
+	}
+
+	switch channel.Type {
+	case model.ChannelTypeWeCom:
+		if err := requireURL(name, channel.URL); err != nil {
+			return nil, err
+		}
+		return NewWeComNotifier(name, channel.URL), nil
+	case model.ChannelTypeDingTalk:
+		if err := requireURL(name, channel.URL); err != nil {
+			return nil, err
+		}
+		return NewDingTalkNotifier(name, channel.URL, channel.Secret), nil
+	case model.ChannelTypeFeishu:
+		if err := requireURL(name, channel.URL); err != nil {
+			return nil, err
+		}
+		return NewFeishuNotifier(name, channel.URL, channel.Secret), nil
+	case model.ChannelTypeSlack:
+		if err := requireURL(name, channel.URL); err != nil {
+			return nil, err
+		}
+		return NewSlackNotifier(name, channel.URL), nil
+	case model.ChannelTypeWebhook:
+		if err := requireURL(name, channel.URL); err != nil {
+			return nil, err
+		}
+		return NewWebhookNotifier(name, channel.URL, channel.Headers), nil
+	case model.ChannelTypeEmail:
+		if channel.SMTPHost == "" {
+			return nil, fmt.Errorf("通知渠道 %s 缺少 SMTP 服务器地址", name)
+		}
+		if len(channel.To) == 0 {
+			return nil, fmt.Errorf("通知渠道 %s 缺少收件人", name)
+		}
+		if channel.From == "" && channel.Username == "" {
+			return nil, fmt.Errorf("通知渠道 %s 缺少发件人", name)
+		}
+		return NewEmailNotifier(name, channel), nil
+	default:
+		return nil, fmt.Errorf("通知渠道 %s 的类型无效: %q", name, channel.Type)
+	}
+}
+
+// FromConfig 根据配置创建所有启用的通知渠道
+// 未配置 channels 时，如果设置了 WebhookURL 则将其作为企业微信渠道，兼容旧配置
+func FromConfig(config *model.Config) (Multi, error) {
+	channels := config.Channels
+	if len(channels) == 0 && config.WebhookURL != "" {
+		channels = []model.ChannelConfig{{
+			Name: model.ChannelTypeWeCom,
+			Type: model.ChannelTypeWeCom,
+			URL:  config.WebhookURL,
+		}}
+	}
+
+	var notifiers Multi
+	names := make(map[string]bool)
+	for _, channel := range channels {
+		notifier, err := New(channel)
+		if err != nil {
+			return nil, err
+		}
+		if names[notifier.Name()] {
+			return nil, fmt.Errorf("通知渠道名称重复: %s", notifier.Name())
+		}
+		names[notifier.Name()] = true
+
+		if channel.Disabled {
+			continue
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers, nil
+}
+
+// Multi 将消息分发到多个通知渠道
+type Multi []Notifier
+
+// Name 返回所有渠道名称，以逗号分隔
+func (m Multi) Name() string {
+	names := make([]string, len(m))
+	for i, notifier := range m {
+		names[i] = notifier.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// Send 依次向所有渠道发送消息，单个渠道失败不影响其他渠道，返回合并后的错误
+func (m Multi) Send(message Message) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Send(message); err != nil {
+			util.Error("通知渠道 %s 发送失败: %v", notifier.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+			continue
+		}
+		util.Info("通知渠道 %s 发送成功", notifier.Name())
+	}
+	return errors.Join(errs...)
+}
+
+// Select 按名称筛选渠道，names 为空时返回全部渠道，名称不存在时返回错误
+func (m Multi) Select(names []string) (Multi, error) {
+	if len(names) == 0 {
+		return m, nil
+	}
+
+	selected := make(Multi, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, notifier := range m {
+			if notifier.Name() == name {
+				selected = append(selected, notifier)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("通知渠道不存在或已停用: %s", name)
+		}
+	}
+	return selected, nil
+}
+
+// splitMentions 将 @ 的成员 ID 分为手机号和其他 ID
+func splitMentions(mentions []string) (mobiles, ids []string) {
+	for _, mention := range mentions {
+		if isMobile(mention) {
+			mobiles = append(mobiles, mention)
+		} else {
+			ids = append(ids, mention)
+		}
+	}
+	return mobiles, ids
+}
+
+// mentionSuffix 按格式生成附加在正文末尾的 @ 文本，没有需要 @ 的成员时返回空串
+func mentionSuffix(mentions []string, format string) string {
+	if len(mentions) == 0 {
+		return ""
+	}
+	parts := make([]string, len(mentions))
+	for i, mention := range mentions {
+		parts[i] = fmt.Sprintf(format, mention)
+	}
+	return "\n" + strings.Join(parts, " ")
+}
+
+// isMobile 判断成员 ID 是否为手机号（可带 + 前缀的纯数字）
+func isMobile(value string) bool {
+	digits := strings.TrimPrefix(value, "+")
+	if digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// requireURL 检查 Webhook 类渠道是否配置了地址
+func requireURL(name, url string) error {
+	if url == "" {
+		return fmt.Errorf("通知渠道 %s 缺少 Webhook 地址", name)
+	}
+	return nil
+}
+
+// postJSON 以 JSON 格式发送 POST 请求，返回响应体；非 2xx 状态码视为失败
+func postJSON(url string, payload any, headers map[string]string) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("序列化消息失败: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送 Webhook 请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Webhook 请求失败，状态码: %d, 响应: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
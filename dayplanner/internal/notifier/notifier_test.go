@@ -0,0 +1,344 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"daily-report-tool/internal/model"
+)
+
+// capturedRequest 测试服务器收到的请求
+type capturedRequest struct {
+	query   map[string]string
+	headers http.Header
+	body    map[string]any
+}
+
+// newTestServer 创建记录请求并返回固定响应的测试服务器
+func newTestServer(t *testing.T, status int, response string) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	captured := &capturedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.query = make(map[string]string)
+		for key := range r.URL.Query() {
+			captured.query[key] = r.URL.Query().Get(key)
+		}
+		captured.headers = r.Header
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &captured.body)
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, captured
+}
+
+// fixNow 固定签名使用的当前时间
+func fixNow(t *testing.T, fixed time.Time) {
+	t.Helper()
+	original := now
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = original })
+}
+
+func TestWeComNotifier(t *testing.T) {
+	server, captured := newTestServer(t, http.StatusOK, `{"errcode":0,"errmsg":"ok"}`)
+
+	if err := NewWeComNotifier("wecom", server.URL).Send(Message{Text: "你好"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if captured.body["msgtype"] != "text" || captured.body["text"].(map[string]any)["content"] != "你好" {
+		t.Errorf("企业微信消息格式不正确: %v", captured.body)
+	}
+
+	errorServer, _ := newTestServer(t, http.StatusOK, `{"errcode":93000,"errmsg":"invalid webhook url"}`)
+	err := NewWeComNotifier("wecom", errorServer.URL).Send(Message{Text: "你好"})
+	if err == nil || !strings.Contains(err.Error(), "93000") {
+		t.Errorf("应返回企业微信错误码，实际: %v", err)
+	}
+}
+
+func TestDingTalkNotifier_Sign(t *testing.T) {
+	fixNow(t, time.UnixMilli(1700000000123))
+	server, captured := newTestServer(t, http.StatusOK, `{"errcode":0,"errmsg":"ok"}`)
+
+	if err := NewDingTalkNotifier("dingtalk", server.URL+"/robot/send?access_token=abc", "SECtest").Send(Message{Text: "你好"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("SECtest"))
+	mac.Write([]byte("1700000000123\nSECtest"))
+	wantSign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if captured.query["access_token"] != "abc" || captured.query["timestamp"] != "1700000000123" || captured.query["sign"] != wantSign {
+		t.Errorf("钉钉签名参数不正确: %v", captured.query)
+	}
+	if captured.body["text"].(map[string]any)["content"] != "你好" {
+		t.Errorf("钉钉消息格式不正确: %v", captured.body)
+	}
+}
+
+func TestFeishuNotifier_Sign(t *testing.T) {
+	fixNow(t, time.Unix(1700000000, 0))
+	server, captured := newTestServer(t, http.StatusOK, `{"code":0,"msg":"success"}`)
+
+	if err := NewFeishuNotifier("feishu", server.URL, "secret").Send(Message{Text: "你好"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("1700000000\nsecret"))
+	wantSign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if captured.body["timestamp"] != "1700000000" || captured.body["sign"] != wantSign {
+		t.Errorf("飞书签名不正确: %v", captured.body)
+	}
+	if captured.body["msg_type"] != "text" || captured.body["content"].(map[string]any)["text"] != "你好" {
+		t.Errorf("飞书消息格式不正确: %v", captured.body)
+	}
+
+	errorServer, _ := newTestServer(t, http.StatusOK, `{"code":19021,"msg":"sign match fail"}`)
+	if err := NewFeishuNotifier("feishu", errorServer.URL, "secret").Send(Message{Text: "你好"}); err == nil {
+		t.Error("飞书返回错误码时应该失败")
+	}
+}
+
+func TestSlackAndWebhookNotifier(t *testing.T) {
+	slackServer, slackCaptured := newTestServer(t, http.StatusOK, "ok")
+	if err := NewSlackNotifier("slack", slackServer.URL).Send(Message{Text: "hello"}); err != nil {
+		t.Fatalf("Slack 发送失败: %v", err)
+	}
+	if slackCaptured.body["text"] != "hello" {
+		t.Errorf("Slack 消息格式不正确: %v", slackCaptured.body)
+	}
+
+	webhookServer, webhookCaptured := newTestServer(t, http.StatusAccepted, "")
+	headers := map[string]string{"Authorization": "Bearer token"}
+	if err := NewWebhookNotifier("hook", webhookServer.URL, headers).Send(Message{Subject: "标题", Text: "正文"}); err != nil {
+		t.Fatalf("Webhook 发送失败: %v", err)
+	}
+	if webhookCaptured.body["title"] != "标题" || webhookCaptured.body["text"] != "正文" ||
+		webhookCaptured.headers.Get("Authorization") != "Bearer token" {
+		t.Errorf("Webhook 请求不正确: %v %v", webhookCaptured.body, webhookCaptured.headers)
+	}
+
+	failServer, _ := newTestServer(t, http.StatusForbidden, "invalid_token")
+	err := NewSlackNotifier("slack", failServer.URL).Send(Message{Text: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("非 2xx 状态码应该失败，实际: %v", err)
+	}
+}
+
+func TestNotifier_Mentions(t *testing.T) {
+	message := Message{Text: "还没有填写日报", Mentions: []string{"zhangsan", "13800000000"}}
+
+	wecomServer, wecomCaptured := newTestServer(t, http.StatusOK, `{"errcode":0,"errmsg":"ok"}`)
+	if err := NewWeComNotifier("wecom", wecomServer.URL).Send(message); err != nil {
+		t.Fatalf("企业微信发送失败: %v", err)
+	}
+	text := wecomCaptured.body["text"].(map[string]any)
+	if fmt.Sprint(text["mentioned_list"]) != "[zhangsan]" || fmt.Sprint(text["mentioned_mobile_list"]) != "[13800000000]" {
+		t.Errorf("企业微信 @ 成员不正确: %v", text)
+	}
+
+	dingServer, dingCaptured := newTestServer(t, http.StatusOK, `{"errcode":0,"errmsg":"ok"}`)
+	if err := NewDingTalkNotifier("dingtalk", dingServer.URL, "").Send(message); err != nil {
+		t.Fatalf("钉钉发送失败: %v", err)
+	}
+	at := dingCaptured.body["at"].(map[string]any)
+	content := dingCaptured.body["text"].(map[string]any)["content"]
+	if fmt.Sprint(at["atMobiles"]) != "[13800000000]" || fmt.Sprint(at["atUserIds"]) != "[zhangsan]" ||
+		content != "还没有填写日报\n@zhangsan @13800000000" {
+		t.Errorf("钉钉 @ 成员不正确: %v", dingCaptured.body)
+	}
+
+	slackServer, slackCaptured := newTestServer(t, http.StatusOK, "ok")
+	if err := NewSlackNotifier("slack", slackServer.URL).Send(Message{Text: "hi", Mentions: []string{"U123"}}); err != nil {
+		t.Fatalf("Slack 发送失败: %v", err)
+	}
+	if slackCaptured.body["text"] != "hi\n<@U123>" {
+		t.Errorf("Slack @ 成员不正确: %v", slackCaptured.body)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	// 未配置渠道时使用旧的 WebhookURL
+	notifiers, err := FromConfig(&model.Config{WebhookURL: "https://example.com/hook"})
+	if err != nil || len(notifiers) != 1 || notifiers.Name() != "wecom" {
+		t.Fatalf("应回退为企业微信渠道: %v, %v", notifiers, err)
+	}
+
+	notifiers, err = FromConfig(&model.Config{
+		WebhookURL: "https://example.com/hook",
+		Channels: []model.ChannelConfig{
+			{Name: "团队群", Type: model.ChannelTypeDingTalk, URL: "https://example.com/ding"},
+			{Type: model.ChannelTypeSlack, URL: "https://example.com/slack"},
+			{Name: "备用", Type: model.ChannelTypeFeishu, URL: "https://example.com/feishu", Disabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建通知渠道失败: %v", err)
+	}
+	if notifiers.Name() != "团队群,slack" {
+		t.Errorf("应只包含启用的渠道，实际: %s", notifiers.Name())
+	}
+
+	invalid := []model.Config{
+		{Channels: []model.ChannelConfig{{Name: "a", Type: "pager"}}},
+		{Channels: []model.ChannelConfig{{Name: "a", Type: model.ChannelTypeWeCom}}},
+		{Channels: []model.ChannelConfig{{Name: "mail", Type: model.ChannelTypeEmail, SMTPHost: "smtp.example.com"}}},
+		{Channels: []model.ChannelConfig{
+			{Name: "a", Type: model.ChannelTypeSlack, URL: "https://example.com/1"},
+			{Name: "a", Type: model.ChannelTypeSlack, URL: "https://example.com/2"},
+		}},
+	}
+	for i, config := range invalid {
+		if _, err := FromConfig(&config); err == nil {
+			t.Errorf("第 %d 个无效配置应该报错", i)
+		}
+	}
+}
+
+// fakeNotifier 记录发送次数的测试渠道
+type fakeNotifier struct {
+	name string
+	err  error
+	sent int
+}
+
+func (n *fakeNotifier) Name() string { return n.name }
+
+func (n *fakeNotifier) Send(message Message) error {
+	n.sent++
+	return n.err
+}
+
+func TestMulti_Send(t *testing.T) {
+	first := &fakeNotifier{name: "first", err: io.ErrUnexpectedEOF}
+	second := &fakeNotifier{name: "second"}
+
+	err := Multi{first, second}.Send(Message{Text: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "first") {
+		t.Errorf("应返回失败渠道的错误，实际: %v", err)
+	}
+	if second.sent != 1 {
+		t.Error("单个渠道失败不应影响其他渠道")
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	var delays []time.Duration
+	original := sleep
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	t.Cleanup(func() { sleep = original })
+
+	failing := &fakeNotifier{name: "flaky", err: io.ErrUnexpectedEOF}
+	if err := WithRetry(failing, 3, time.Second).Send(Message{Text: "hello"}); err == nil {
+		t.Error("全部尝试失败时应返回错误")
+	}
+	if failing.sent != 3 || len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("应按指数退避重试: 发送 %d 次, 等待 %v", failing.sent, delays)
+	}
+
+	ok := &fakeNotifier{name: "ok"}
+	if err := WithRetry(ok, 3, time.Second).Send(Message{Text: "hello"}); err != nil || ok.sent != 1 {
+		t.Errorf("成功时不应重试: %v, %d", err, ok.sent)
+	}
+
+	if got := Backoff(1, time.Minute, time.Hour); got != time.Minute {
+		t.Errorf("首次退避应为 1 分钟，实际 %v", got)
+	}
+	if got := Backoff(4, time.Minute, time.Hour); got != 8*time.Minute {
+		t.Errorf("第 4 次退避应为 8 分钟，实际 %v", got)
+	}
+	if got := Backoff(20, time.Minute, time.Hour); got != time.Hour {
+		t.Errorf("退避时间不应超过上限，实际 %v", got)
+	}
+}
+
+func TestWeComNotifier_Markdown(t *testing.T) {
+	var contents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			MsgType  string            `json:"msgtype"`
+			Markdown map[string]string `json:"markdown"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.MsgType == "markdown" {
+			contents = append(contents, body.Markdown["content"])
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	// 超过企业微信 4096 字节上限的日报应拆分为多条
+	markdown := "# 2025-11-10 日报\n- [x] 完成接口联调\n- [ ] 编写测试\n" + strings.Repeat("- 处理工单，记录排查过程\n", 300)
+	if err := NewWeComNotifier("wecom", server.URL).Send(Message{Text: "纯文本", Markdown: markdown}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(contents) < 2 {
+		t.Fatalf("超长日报应拆分为多条消息，实际: %d", len(contents))
+	}
+	for i, content := range contents {
+		if len(content) > wecomMarkdownLimit {
+			t.Errorf("第 %d 条消息超过长度上限: %d", i+1, len(content))
+		}
+	}
+	if !strings.HasPrefix(contents[0], "# 2025-11-10 日报\n- ✅ 完成接口联调\n- ⬜ 编写测试") {
+		t.Errorf("复选框应转换为符号: %q", contents[0][:80])
+	}
+	if !strings.HasSuffix(contents[len(contents)-1], fmt.Sprintf("（%d/%d）", len(contents), len(contents))) {
+		t.Errorf("分段消息应带序号: %q", contents[len(contents)-1])
+	}
+}
+
+func TestConvertMarkdown(t *testing.T) {
+	markdown := "## 今日完成\n- [x] 修复 **登录** 问题，见 [工单](https://example.com/1)\n" +
+		"| 项目 | 进度 |\n|---|---|\n| A | 80% |\n```go\nif a < b {}\n```\n![截图](https://example.com/a.png)"
+
+	slack := convertMarkdown(markdown, slackDialect)
+	want := "*今日完成*\n- ✅ 修复 *登录* 问题，见 <https://example.com/1|工单>\n" +
+		"项目 | 进度\nA | 80%\n```go\nif a &lt; b {}\n```\n<https://example.com/a.png|截图>"
+	if slack != want {
+		t.Errorf("Slack 转换结果不正确:\n%s\n期望:\n%s", slack, want)
+	}
+
+	wecom := convertMarkdown(markdown, wecomDialect)
+	if !strings.Contains(wecom, "## 今日完成") || !strings.Contains(wecom, "> if a < b {}") || strings.Contains(wecom, "```") {
+		t.Errorf("企业微信应保留标题并将代码块转换为引用: %s", wecom)
+	}
+	if !strings.Contains(wecom, "[截图](https://example.com/a.png)") {
+		t.Errorf("企业微信不支持图片，应转换为链接: %s", wecom)
+	}
+}
+
+func TestSplitMarkdown_CodeBlock(t *testing.T) {
+	markdown := "```\n" + strings.Repeat("line of code\n", 40) + "```\n结尾"
+	parts := splitMarkdown(markdown, 200)
+	if len(parts) < 2 {
+		t.Fatalf("应拆分为多段，实际: %d", len(parts))
+	}
+	for i, part := range parts {
+		if len(part) > 200 {
+			t.Errorf("第 %d 段超过长度上限: %d", i+1, len(part))
+		}
+		if strings.Count(part, "```")%2 != 0 {
+			t.Errorf("第 %d 段的代码块没有闭合: %q", i+1, part)
+		}
+	}
+
+	long := strings.Repeat("长", 200)
+	for _, part := range splitMarkdown(long, 100) {
+		if len(part) > 100 || !utf8.ValidString(part) {
+			t.Errorf("超长的单行应按字符截断: %d %q", len(part), part)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+// sleep 重试前等待，测试中可替换以避免真实等待
+var sleep = time.Sleep
+
+// retryNotifier 发送失败时按指数退避重试的通知渠道
+type retryNotifier struct {
+	Notifier
+	attempts  int
+	baseDelay time.Duration
+}
+
+// WithRetry 包装通知渠道，发送失败时最多尝试 attempts 次，
+// 第 n 次重试前等待 baseDelay * 2^(n-1)
+func WithRetry(notifier Notifier, attempts int, baseDelay time.Duration) Notifier {
+	if attempts <= 1 {
+		return notifier
+	}
+	return &retryNotifier{
+		Notifier:  notifier,
+		attempts:  attempts,
+		baseDelay: baseDelay,
+	}
+}
+
+// Send 发送消息，失败时重试，返回最后一次的错误
+func (n *retryNotifier) Send(message Message) error {
+	var err error
+	for attempt := 0; attempt < n.attempts; attempt++ {
+		if attempt > 0 {
+			delay := n.baseDelay << (attempt - 1)
+			util.Warn("通知渠道 %s 发送失败，%v 后重试 (%d/%d): %v", n.Name(), delay, attempt, n.attempts-1, err)
+			sleep(delay)
+		}
+		if err = n.Notifier.Send(message); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Backoff 返回第 attempts 次失败后的指数退避等待时间，不超过 max
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
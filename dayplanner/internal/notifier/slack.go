@@ -0,0 +1,33 @@
+package notifier
+
+// SlackNotifier Slack Incoming Webhook 通知渠道
+type SlackNotifier struct {
+	name string
+	url  string
+}
+
+// NewSlackNotifier 创建 Slack 通知渠道
+func NewSlackNotifier(name, url string) *SlackNotifier {
+	return &SlackNotifier{name: name, url: url}
+}
+
+// Name 返回渠道名称
+func (n *SlackNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送文本消息，设置了 Markdown 时转换为 mrkdwn 格式；Slack 成功时返回 200 和 "ok"，失败时返回非 2xx 状态码
+// 文本消息中的 Mentions 以 <@成员 ID> 附加在正文末尾
+func (n *SlackNotifier) Send(message Message) error {
+	if message.Markdown != "" {
+		for _, part := range splitMarkdown(convertMarkdown(message.Markdown, slackDialect), slackTextLimit) {
+			if _, err := postJSON(n.url, map[string]any{"text": part, "mrkdwn": true}, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	_, err := postJSON(n.url, map[string]string{"text": message.Text + mentionSuffix(message.Mentions, "<@%s>")}, nil)
+	return err
+}
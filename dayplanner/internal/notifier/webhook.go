@@ -0,0 +1,39 @@
+package notifier
+
+import "time"
+
+// WebhookNotifier 通用 JSON Webhook 通知渠道
+// 请求体格式: {"title": "...", "text": "...", "sent_at": "RFC3339 时间"}，消息包含 Markdown 时附加原样的 "markdown" 字段，
+// 需要 @ 成员时附加 "mentions" 数组
+type WebhookNotifier struct {
+	name    string
+	url     string
+	headers map[string]string // 附加请求头，如鉴权用的 Authorization
+}
+
+// NewWebhookNotifier 创建通用 Webhook 通知渠道
+func NewWebhookNotifier(name, url string, headers map[string]string) *WebhookNotifier {
+	return &WebhookNotifier{name: name, url: url, headers: headers}
+}
+
+// Name 返回渠道名称
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送消息，任意 2xx 状态码视为成功
+func (n *WebhookNotifier) Send(message Message) error {
+	payload := map[string]any{
+		"title":   message.subjectOrDefault(),
+		"text":    message.Text,
+		"sent_at": now().Format(time.RFC3339),
+	}
+	if message.Markdown != "" {
+		payload["markdown"] = message.Markdown
+	}
+	if len(message.Mentions) > 0 {
+		payload["mentions"] = message.Mentions
+	}
+	_, err := postJSON(n.url, payload, n.headers)
+	return err
+}
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WeComNotifier 企业微信群机器人通知渠道
+type WeComNotifier struct {
+	name string
+	url  string
+}
+
+// NewWeComNotifier 创建企业微信通知渠道
+func NewWeComNotifier(name, url string) *WeComNotifier {
+	return &WeComNotifier{name: name, url: url}
+}
+
+// Name 返回渠道名称
+func (n *WeComNotifier) Name() string {
+	return n.name
+}
+
+// Send 发送文本消息，设置了 Markdown 时发送 markdown 消息
+// 文本消息中的 Mentions 按手机号或 userid 分别放入 mentioned_mobile_list 和 mentioned_list
+func (n *WeComNotifier) Send(message Message) error {
+	if message.Markdown != "" {
+		for _, part := range splitMarkdown(convertMarkdown(message.Markdown, wecomDialect), wecomMarkdownLimit) {
+			if err := n.post(map[string]any{
+				"msgtype":  "markdown",
+				"markdown": map[string]string{"content": part},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	text := map[string]any{"content": message.Text}
+	mobiles, ids := splitMentions(message.Mentions)
+	if len(ids) > 0 {
+		text["mentioned_list"] = ids
+	}
+	if len(mobiles) > 0 {
+		text["mentioned_mobile_list"] = mobiles
+	}
+	return n.post(map[string]any{
+		"msgtype": "text",
+		"text":    text,
+	})
+}
+
+// post 发送一条消息并检查返回的错误码
+func (n *WeComNotifier) post(payload map[string]any) error {
+	body, err := postJSON(n.url, payload, nil)
+	if err != nil {
+		return err
+	}
+	return checkErrCode("企业微信", body)
+}
+
+// checkErrCode 检查企业微信、钉钉返回的 errcode，非 0 时返回错误
+func checkErrCode(platform string, body []byte) error {
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析%s响应失败: %w", platform, err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("%s返回错误: %s (错误码: %d)", platform, result.ErrMsg, result.ErrCode)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"daily-report-tool/internal/util"
+)
+
+// MaxAttachmentSize 单个附件的大小上限
+const MaxAttachmentSize = 20 << 20
+
+// Attachment 日报的一个附件
+type Attachment struct {
+	Link    string    // 日报中引用的相对链接，如 attachments/2025-11-10/dashboard-1a2b3c4d.png
+	Date    time.Time // 附件所属的日期
+	Name    string    // 文件名
+	Size    int64
+	ModTime time.Time
+}
+
+// AttachmentRepository 定义附件数据访问接口
+type AttachmentRepository interface {
+	// Save 将文件保存为指定日期的附件，内容相同的文件只保存一份
+	Save(date time.Time, name string, data []byte) (*Attachment, error)
+
+	// Path 返回附件链接对应的本机文件路径，链接无效时返回错误
+	Path(link string) (string, error)
+
+	// List 列出指定日期的附件，按文件名排列
+	List(date time.Time) ([]*Attachment, error)
+
+	// ListAll 列出所有附件，按日期和文件名排列
+	ListAll() ([]*Attachment, error)
+
+	// Delete 删除附件，附件不存在时视为成功
+	Delete(link string) error
+}
+
+// FileAttachmentRepository 基于文件系统的附件仓库实现
+// 每天的附件保存在数据目录的 attachments/YYYY-MM-DD/ 中，文件名带有内容哈希，
+// 避免两台机器同步时同名附件互相覆盖
+type FileAttachmentRepository struct {
+	dataPath string
+}
+
+// NewFileAttachmentRepository 创建新的文件附件仓库，dataPath 为日报数据目录
+func NewFileAttachmentRepository(dataPath string) *FileAttachmentRepository {
+	return &FileAttachmentRepository{
+		dataPath: dataPath,
+	}
+}
+
+// Save 将文件保存为指定日期的附件
+func (r *FileAttachmentRepository) Save(date time.Time, name string, data []byte) (*Attachment, error) {
+	if len(data) > MaxAttachmentSize {
+		return nil, fmt.Errorf("附件 %s 超过 %d MB 的大小上限", name, MaxAttachmentSize>>20)
+	}
+
+	sum := sha256.Sum256(data)
+	fileName := attachmentFileName(name, hex.EncodeToString(sum[:4]))
+	link := path.Join(util.AttachmentDir, date.Format("2006-01-02"), fileName)
+	filePath, err := r.Path(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return nil, fmt.Errorf("创建附件目录失败: %w", err)
+		}
+		// 先写临时文件再重命名，避免留下写了一半的附件
+		if err := util.WriteFileAtomic(filePath, data, 0600); err != nil {
+			return nil, fmt.Errorf("写入附件失败: %w", err)
+		}
+		util.Info("已保存附件: %s (%d 字节)", link, len(data))
+	} else if err != nil {
+		return nil, fmt.Errorf("读取附件失败: %w", err)
+	} else {
+		util.Debug("附件已存在: %s", link)
+	}
+
+	return r.stat(link)
+}
+
+// Path 返回附件链接对应的本机文件路径
+func (r *FileAttachmentRepository) Path(link string) (string, error) {
+	parts := strings.Split(link, "/")
+	if len(parts) != 3 || parts[0] != util.AttachmentDir {
+		return "", fmt.Errorf("无效的附件链接: %s", link)
+	}
+	if _, err := time.Parse("2006-01-02", parts[1]); err != nil {
+		return "", fmt.Errorf("无效的附件链接: %s", link)
+	}
+	if parts[2] == "" || parts[2] == "." || parts[2] == ".." || strings.ContainsAny(parts[2], `\:`) {
+		return "", fmt.Errorf("无效的附件链接: %s", link)
+	}
+	return filepath.Join(r.dataPath, util.AttachmentDir, parts[1], parts[2]), nil
+}
+
+// List 列出指定日期的附件
+func (r *FileAttachmentRepository) List(date time.Time) ([]*Attachment, error) {
+	day := date.Format("2006-01-02")
+	entries, err := os.ReadDir(filepath.Join(r.dataPath, util.AttachmentDir, day))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取附件目录失败: %w", err)
+	}
+
+	var attachments []*Attachment
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".tmp") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		attachment, err := r.stat(path.Join(util.AttachmentDir, day, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// ListAll 列出所有附件
+func (r *FileAttachmentRepository) ListAll() ([]*Attachment, error) {
+	entries, err := os.ReadDir(filepath.Join(r.dataPath, util.AttachmentDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取附件目录失败: %w", err)
+	}
+
+	var days []time.Time
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if date, err := time.ParseInLocation("2006-01-02", entry.Name(), time.Local); err == nil {
+			days = append(days, date)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	var attachments []*Attachment
+	for _, day := range days {
+		list, err := r.List(day)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, list...)
+	}
+	return attachments, nil
+}
+
+// Delete 删除附件，当天的附件目录为空时一并删除
+func (r *FileAttachmentRepository) Delete(link string) error {
+	filePath, err := r.Path(link)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除附件失败: %w", err)
+	}
+	// 目录不为空时删除失败，忽略即可
+	os.Remove(filepath.Dir(filePath))
+	util.Info("已删除附件: %s", link)
+	return nil
+}
+
+// stat 读取附件的文件信息
+func (r *FileAttachmentRepository) stat(link string) (*Attachment, error) {
+	filePath, err := r.Path(link)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取附件失败: %w", err)
+	}
+	date, _ := time.ParseInLocation("2006-01-02", path.Base(path.Dir(link)), time.Local)
+	return &Attachment{
+		Link:    link,
+		Date:    date,
+		Name:    info.Name(),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+// attachmentFileName 生成附件文件名：原文件名中的空白、括号等字符替换为 "-"，再加上内容哈希，
+// 如 "Dashboard (1).PNG" 保存为 "Dashboard-1-1a2b3c4d.png"
+func attachmentFileName(name, hash string) string {
+	base := filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	ext := strings.ToLower(filepath.Ext(base))
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+
+	var sb strings.Builder
+	lastDash := false
+	for _, r := range stem {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' {
+			sb.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			sb.WriteRune('-')
+			lastDash = true
+		}
+	}
+	cleaned := strings.Trim(sb.String(), "-.")
+	if len([]rune(cleaned)) > 60 {
+		cleaned = string([]rune(cleaned)[:60])
+	}
+	if cleaned == "" {
+		cleaned = "attachment"
+	}
+
+	var cleanExt strings.Builder
+	for _, r := range ext {
+		if r == '.' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) {
+			cleanExt.WriteRune(r)
+		}
+	}
+	return cleaned + "-" + hash + cleanExt.String()
+}
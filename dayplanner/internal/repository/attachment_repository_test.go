@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileAttachmentRepository(t *testing.T) {
+	dataPath := t.TempDir()
+	repo := NewFileAttachmentRepository(dataPath)
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	attachment, err := repo.Save(date, "Dashboard (1).PNG", []byte("png"))
+	if err != nil {
+		t.Fatalf("保存附件失败: %v", err)
+	}
+	if !strings.HasPrefix(attachment.Link, "attachments/2025-11-10/Dashboard-1-") || !strings.HasSuffix(attachment.Link, ".png") {
+		t.Errorf("附件链接不正确: %s", attachment.Link)
+	}
+	if !attachment.Date.Equal(date) || attachment.Size != 3 {
+		t.Errorf("附件信息不正确: %+v", attachment)
+	}
+
+	// 内容相同的文件只保存一份，内容不同的同名文件不会覆盖
+	again, err := repo.Save(date, "Dashboard (1).PNG", []byte("png"))
+	if err != nil || again.Link != attachment.Link {
+		t.Errorf("相同内容应复用已有附件: %+v, %v", again, err)
+	}
+	other, err := repo.Save(date, "Dashboard (1).PNG", []byte("png2"))
+	if err != nil || other.Link == attachment.Link {
+		t.Errorf("不同内容应保存为新附件: %+v, %v", other, err)
+	}
+	if _, err := repo.Save(date, "big.bin", make([]byte, MaxAttachmentSize+1)); err == nil {
+		t.Error("超过大小上限时应返回错误")
+	}
+
+	filePath, err := repo.Path(attachment.Link)
+	if err != nil || filePath != filepath.Join(dataPath, "attachments", "2025-11-10", filepath.Base(attachment.Link)) {
+		t.Errorf("附件路径不正确: %s, %v", filePath, err)
+	}
+	for _, link := range []string{"attachments/../config.json", "attachments/2025-11-10/../../x", "tasks/2025-11-10/a.png", "attachments/2025-11-10/.."} {
+		if _, err := repo.Path(link); err == nil {
+			t.Errorf("%s 应该是无效链接", link)
+		}
+	}
+
+	// 临时文件不列出
+	os.WriteFile(filePath+".tmp", []byte("x"), 0600)
+	list, err := repo.List(date)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("列出附件失败: %v, %v", list, err)
+	}
+	if _, err := repo.Save(date.AddDate(0, 0, 1), "notes.txt", []byte("txt")); err != nil {
+		t.Fatalf("保存附件失败: %v", err)
+	}
+	all, err := repo.ListAll()
+	if err != nil || len(all) != 3 || !strings.HasPrefix(all[2].Link, "attachments/2025-11-11/notes-") {
+		t.Errorf("列出所有附件结果不正确: %v, %v", all, err)
+	}
+
+	// 删除最后一个附件时删除当天的目录
+	next := all[2]
+	if err := repo.Delete(next.Link); err != nil {
+		t.Fatalf("删除附件失败: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, "attachments", "2025-11-11")); !os.IsNotExist(err) {
+		t.Errorf("空的附件目录应删除: %v", err)
+	}
+	if err := repo.Delete(next.Link); err != nil {
+		t.Errorf("删除不存在的附件应视为成功: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
 )
 
 // ConfigRepository 定义配置数据访问接口
@@ -33,7 +34,8 @@ func NewFileConfigRepository(configPath string) *FileConfigRepository {
 // Load 加载配置
 func (r *FileConfigRepository) Load() (*model.Config, error) {
 	// 检查配置文件是否存在
-	if _, err := os.Stat(r.configPath); os.IsNotExist(err) {
+	info, err := os.Stat(r.configPath)
+	if os.IsNotExist(err) {
 		// 配置文件不存在，创建默认配置
 		defaultConfig := r.createDefaultConfig()
 		if err := r.Save(defaultConfig); err != nil {
@@ -42,6 +44,13 @@ func (r *FileConfigRepository) Load() (*model.Config, error) {
 		return defaultConfig, nil
 	}
 
+	// 旧版本以 0644 写入配置文件，其中的令牌和密码对其他用户可读，加载时收紧权限
+	if err == nil && info.Mode().Perm()&0077 != 0 {
+		if err := os.Chmod(r.configPath, 0600); err != nil {
+			util.Warn("收紧配置文件权限失败: %s, 错误: %v", r.configPath, err)
+		}
+	}
+
 	data, err := os.ReadFile(r.configPath)
 	if err != nil {
 		return nil, fmt.Errorf("读取配置文件失败: %w", err)
@@ -68,7 +77,9 @@ func (r *FileConfigRepository) Save(config *model.Config) error {
 		return fmt.Errorf("序列化配置数据失败: %w", err)
 	}
 
-	if err := os.WriteFile(r.configPath, data, 0644); err != nil {
+	// 先写临时文件再重命名，写入中断时不会留下不完整的配置文件
+	// 配置中保存了 API 令牌、同步密码等凭据，只允许当前用户读写
+	if err := util.WriteFileAtomic(r.configPath, data, 0600); err != nil {
 		return fmt.Errorf("写入配置文件失败: %w", err)
 	}
 
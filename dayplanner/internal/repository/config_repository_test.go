@@ -78,6 +78,11 @@ func TestFileConfigRepository_SaveAndLoad(t *testing.T) {
 		t.Fatalf("加载配置失败: %v", err)
 	}
 
+	// 配置中包含凭据，只允许当前用户读写
+	if info, err := os.Stat(configPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("配置文件权限应为 0600: %v, %v", info.Mode(), err)
+	}
+
 	// 验证配置内容
 	if loadedConfig.WebhookURL != testConfig.WebhookURL {
 		t.Errorf("Webhook URL 不匹配: 期望 %s, 实际 %s", testConfig.WebhookURL, loadedConfig.WebhookURL)
@@ -131,3 +136,17 @@ func TestFileConfigRepository_SaveCreatesDirectory(t *testing.T) {
 		t.Error("配置目录应该已创建")
 	}
 }
+
+func TestFileConfigRepository_LoadTightensPermissions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"api_token":"secret"}`), 0644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+
+	if _, err := NewFileConfigRepository(configPath).Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if info, err := os.Stat(configPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("加载后配置文件权限应收紧为 0600: %v, %v", info.Mode(), err)
+	}
+}
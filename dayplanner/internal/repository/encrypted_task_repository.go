@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// EncryptedTaskRepository 加密任务仓库装饰器
+// 保存前使用 AES-GCM 加密日报内容和顺延的任务项，读取时解密；日期和时间戳保持明文，以便按日期查询和同步比较。
+// 尚未加密的旧内容可以正常读取，下次保存时加密
+type EncryptedTaskRepository struct {
+	inner    TaskRepository
+	cipher   *keystore.Cipher
+	dataPath string
+}
+
+// NewEncryptedTaskRepository 创建加密任务仓库，dataPath 用于定位文件后端的历史版本目录
+func NewEncryptedTaskRepository(inner TaskRepository, cipher *keystore.Cipher, dataPath string) *EncryptedTaskRepository {
+	return &EncryptedTaskRepository{
+		inner:    inner,
+		cipher:   cipher,
+		dataPath: dataPath,
+	}
+}
+
+// GetByDate 获取并解密指定日期的任务
+func (r *EncryptedTaskRepository) GetByDate(date time.Time) (*model.Task, error) {
+	task, err := r.inner.GetByDate(date)
+	if err != nil || task == nil {
+		return task, err
+	}
+	return r.DecryptTask(task)
+}
+
+// Save 加密后保存任务，不修改调用方传入的任务
+func (r *EncryptedTaskRepository) Save(task *model.Task) error {
+	encrypted, err := r.EncryptTask(task)
+	if err != nil {
+		return err
+	}
+	return r.inner.Save(encrypted)
+}
+
+// GetTaskDates 获取日期范围内有任务的日期列表
+func (r *EncryptedTaskRepository) GetTaskDates(startDate, endDate time.Time) ([]time.Time, error) {
+	return r.inner.GetTaskDates(startDate, endDate)
+}
+
+// HasTask 检查指定日期是否有任务
+func (r *EncryptedTaskRepository) HasTask(date time.Time) (bool, error) {
+	return r.inner.HasTask(date)
+}
+
+// Search 搜索内容包含所有关键词的任务，按日期倒序返回
+// 内容已加密，底层仓库的索引无法使用，需要逐个解密后匹配
+func (r *EncryptedTaskRepository) Search(query string) ([]*model.Task, error) {
+	terms := splitSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	dates, err := r.inner.GetTaskDates(time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Task
+	for _, date := range dates {
+		task, err := r.GetByDate(date)
+		if err != nil {
+			return nil, err
+		}
+		if task != nil && matchSearchTerms(task.Content, terms) {
+			results = append(results, task)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date.After(results[j].Date)
+	})
+	util.Info("加密仓库搜索完成: %s, 匹配 %d 个任务", query, len(results))
+	return results, nil
+}
+
+// Revisions 返回同样加密的历史版本仓库
+func (r *EncryptedTaskRepository) Revisions() RevisionRepository {
+	return &EncryptedRevisionRepository{
+		inner:  NewRevisionRepository(r.inner, r.dataPath),
+		cipher: r.cipher,
+	}
+}
+
+// revisionRewriter 支持整体改写历史版本的仓库，用于启用加密时重新加密已有的历史版本
+type revisionRewriter interface {
+	Dates() ([]time.Time, error)
+	Replace(date time.Time, revisions []*model.Revision) error
+}
+
+// EncryptAll 加密所有尚未加密的任务及其历史版本，返回加密的任务数；启用加密后调用一次
+// 已加密的内容会被跳过，中途失败后可以重复调用
+func (r *EncryptedTaskRepository) EncryptAll() (int, error) {
+	dates, err := r.inner.GetTaskDates(time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, date := range dates {
+		task, err := r.inner.GetByDate(date)
+		if err != nil {
+			return count, err
+		}
+		if task == nil || keystore.IsSealed(task.Content) {
+			continue
+		}
+		if err := r.Save(task); err != nil {
+			return count, fmt.Errorf("加密 %s 的日报失败: %w", date.Format("2006-01-02"), err)
+		}
+		count++
+	}
+
+	revisionCount, err := r.encryptRevisions()
+	if err != nil {
+		return count, err
+	}
+	util.Info("已加密 %d 个已有的历史版本", revisionCount)
+	return count, nil
+}
+
+// encryptRevisions 加密底层仓库中尚未加密的历史版本，返回加密的历史版本数
+func (r *EncryptedTaskRepository) encryptRevisions() (int, error) {
+	revisions := NewRevisionRepository(r.inner, r.dataPath)
+	rewriter, ok := revisions.(revisionRewriter)
+	if !ok {
+		return 0, nil
+	}
+	dates, err := rewriter.Dates()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, date := range dates {
+		list, err := revisions.List(date)
+		if err != nil {
+			return count, err
+		}
+		additional := date.Format("2006-01-02")
+		sealed := 0
+		for _, revision := range list {
+			if keystore.IsSealed(revision.Content) {
+				continue
+			}
+			if revision.Content, err = r.cipher.Seal(revision.Content, additional); err != nil {
+				return count, fmt.Errorf("加密 %s 的历史版本失败: %w", additional, err)
+			}
+			sealed++
+		}
+		if sealed == 0 {
+			continue
+		}
+		if err := rewriter.Replace(date, list); err != nil {
+			return count, fmt.Errorf("加密 %s 的历史版本失败: %w", additional, err)
+		}
+		count += sealed
+	}
+	return count, nil
+}
+
+// Close 关闭底层仓库占用的资源
+func (r *EncryptedTaskRepository) Close() error {
+	if closer, ok := r.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// EncryptTask 返回内容和顺延任务项加密后的任务副本，以日期作为附加认证数据，防止不同日期的内容被互换
+func (r *EncryptedTaskRepository) EncryptTask(task *model.Task) (*model.Task, error) {
+	encrypted := *task
+	additional := task.Date.Format("2006-01-02")
+
+	var err error
+	if encrypted.Content, err = r.cipher.Seal(task.Content, additional); err != nil {
+		return nil, fmt.Errorf("加密日报失败: %w", err)
+	}
+	if encrypted.CarriedFrom, err = r.transformCarryOvers(task.CarriedFrom, additional, r.cipher.Seal); err != nil {
+		return nil, fmt.Errorf("加密日报失败: %w", err)
+	}
+	if encrypted.CarriedTo, err = r.transformCarryOvers(task.CarriedTo, additional, r.cipher.Seal); err != nil {
+		return nil, fmt.Errorf("加密日报失败: %w", err)
+	}
+	return &encrypted, nil
+}
+
+// DecryptTask 解密任务内容和顺延任务项，未加密的内容原样保留
+func (r *EncryptedTaskRepository) DecryptTask(task *model.Task) (*model.Task, error) {
+	additional := task.Date.Format("2006-01-02")
+
+	var err error
+	if task.Content, err = r.cipher.Open(task.Content, additional); err != nil {
+		return nil, fmt.Errorf("%s 的日报%w", additional, err)
+	}
+	if task.CarriedFrom, err = r.transformCarryOvers(task.CarriedFrom, additional, r.cipher.Open); err != nil {
+		return nil, fmt.Errorf("%s 的日报%w", additional, err)
+	}
+	if task.CarriedTo, err = r.transformCarryOvers(task.CarriedTo, additional, r.cipher.Open); err != nil {
+		return nil, fmt.Errorf("%s 的日报%w", additional, err)
+	}
+	return task, nil
+}
+
+// transformCarryOvers 对顺延记录中的每个任务项执行加密或解密，返回新的切片
+func (r *EncryptedTaskRepository) transformCarryOvers(carries []model.CarryOver, additional string, transform func(text, additional string) (string, error)) ([]model.CarryOver, error) {
+	if carries == nil {
+		return nil, nil
+	}
+	result := make([]model.CarryOver, len(carries))
+	for i, carry := range carries {
+		result[i] = carry
+		result[i].Items = make([]string, len(carry.Items))
+		for j, item := range carry.Items {
+			text, err := transform(item, additional)
+			if err != nil {
+				return nil, err
+			}
+			result[i].Items[j] = text
+		}
+	}
+	return result, nil
+}
+
+// EncryptedRevisionRepository 加密历史版本仓库装饰器
+type EncryptedRevisionRepository struct {
+	inner  RevisionRepository
+	cipher *keystore.Cipher
+}
+
+// Add 加密后追加历史版本，ID 由底层仓库分配并回写到 revision
+func (r *EncryptedRevisionRepository) Add(revision *model.Revision) error {
+	encrypted := *revision
+	content, err := r.cipher.Seal(revision.Content, revision.Date.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("加密历史版本失败: %w", err)
+	}
+	encrypted.Content = content
+	if err := r.inner.Add(&encrypted); err != nil {
+		return err
+	}
+	revision.ID = encrypted.ID
+	return nil
+}
+
+// List 列出并解密指定日期的所有历史版本
+func (r *EncryptedRevisionRepository) List(date time.Time) ([]*model.Revision, error) {
+	revisions, err := r.inner.List(date)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		if err := r.decrypt(revision); err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+// Get 获取并解密指定日期的某个历史版本
+func (r *EncryptedRevisionRepository) Get(date time.Time, id int) (*model.Revision, error) {
+	revision, err := r.inner.Get(date, id)
+	if err != nil || revision == nil {
+		return revision, err
+	}
+	if err := r.decrypt(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// decrypt 解密历史版本内容
+func (r *EncryptedRevisionRepository) decrypt(revision *model.Revision) error {
+	content, err := r.cipher.Open(revision.Content, revision.Date.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("%s 的历史版本 %d %w", revision.Date.Format("2006-01-02"), revision.ID, err)
+	}
+	revision.Content = content
+	return nil
+}
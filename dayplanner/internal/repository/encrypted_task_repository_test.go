@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+)
+
+// useTestKeyParams 测试期间使用低成本的 Argon2id 参数
+func useTestKeyParams(t *testing.T) {
+	t.Helper()
+	original := keystore.DefaultParams
+	keystore.DefaultParams = keystore.Params{Time: 1, Memory: 64, Threads: 1}
+	t.Cleanup(func() { keystore.DefaultParams = original })
+}
+
+func TestEncryptedTaskRepository(t *testing.T) {
+	useTestKeyParams(t)
+	dataPath := t.TempDir()
+	fileRepo := NewFileTaskRepository(dataPath)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	// 启用加密前已有的明文日报
+	if err := fileRepo.Save(&model.Task{Date: monday, Content: "客户 A 的故障复盘", CreatedAt: monday, UpdatedAt: monday}); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if err := NewFileRevisionRepository(dataPath).Add(&model.Revision{Date: monday, Content: "客户 A 的旧版本", CreatedAt: monday}); err != nil {
+		t.Fatalf("追加历史版本失败: %v", err)
+	}
+
+	source := keystore.PassphraseSource{Passphrase: "正确口令"}
+	repo, err := OpenEncryptedTaskRepository(fileRepo, dataPath, source)
+	if err != nil {
+		t.Fatalf("启用加密失败: %v", err)
+	}
+
+	// 首次启用时已有日报被加密，文件中不再有明文
+	data, err := os.ReadFile(filepath.Join(dataPath, "2025-11-10.json"))
+	if err != nil || strings.Contains(string(data), "客户 A") {
+		t.Errorf("已有日报应被加密: %s, %v", data, err)
+	}
+	data, err = os.ReadFile(filepath.Join(dataPath, "history", "2025-11-10.jsonl"))
+	if err != nil || strings.Contains(string(data), "客户 A") {
+		t.Errorf("已有历史版本应被加密: %s, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, encryptionPendingFileName)); !os.IsNotExist(err) {
+		t.Errorf("加密完成后应删除加密标记: %v", err)
+	}
+	if revision, err := repo.Revisions().Get(monday, 1); err != nil || revision.Content != "客户 A 的旧版本" {
+		t.Errorf("已有历史版本解密结果不正确: %+v, %v", revision, err)
+	}
+
+	task := &model.Task{
+		Date:      tuesday,
+		Content:   "- [ ] 联系客户 B",
+		CarriedTo: []model.CarryOver{{Date: "2025-11-12", Items: []string{"联系客户 B"}}},
+	}
+	if err := repo.Save(task); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if task.Content != "- [ ] 联系客户 B" {
+		t.Error("保存不应修改调用方的任务")
+	}
+	data, _ = os.ReadFile(filepath.Join(dataPath, "2025-11-11.json"))
+	if strings.Contains(string(data), "客户 B") {
+		t.Errorf("日报内容和顺延任务项应被加密: %s", data)
+	}
+	if info, err := os.Stat(filepath.Join(dataPath, "2025-11-11.json")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("任务文件权限应为 0600: %v, %v", info.Mode(), err)
+	}
+
+	loaded, err := repo.GetByDate(tuesday)
+	if err != nil || loaded.Content != "- [ ] 联系客户 B" || loaded.CarriedTo[0].Items[0] != "联系客户 B" {
+		t.Fatalf("解密结果不正确: %+v, %v", loaded, err)
+	}
+	results, err := repo.Search("客户")
+	if err != nil || len(results) != 2 || !results[0].Date.Equal(tuesday) {
+		t.Errorf("应能搜索加密的内容: %d 条, %v", len(results), err)
+	}
+
+	// 历史版本同样加密
+	revisions := NewRevisionRepository(repo, dataPath)
+	if err := revisions.Add(&model.Revision{Date: tuesday, Content: "客户 B 的历史版本", CreatedAt: tuesday}); err != nil {
+		t.Fatalf("追加历史版本失败: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dataPath, "history", "2025-11-11.jsonl"))
+	if len(data) == 0 || strings.Contains(string(data), "客户 B") {
+		t.Errorf("历史版本应被加密: %s", data)
+	}
+	if revision, err := revisions.Get(tuesday, 1); err != nil || revision.Content != "客户 B 的历史版本" {
+		t.Errorf("历史版本解密结果不正确: %+v, %v", revision, err)
+	}
+
+	// 口令错误时无法打开
+	if _, err := OpenEncryptedTaskRepository(fileRepo, dataPath, keystore.PassphraseSource{Passphrase: "错误口令"}); !errors.Is(err, keystore.ErrWrongKey) {
+		t.Errorf("口令错误时应返回 ErrWrongKey，实际 %v", err)
+	}
+	reopened, err := OpenEncryptedTaskRepository(fileRepo, dataPath, source)
+	if err != nil {
+		t.Fatalf("重新打开失败: %v", err)
+	}
+	if loaded, err := reopened.GetByDate(monday); err != nil || loaded.Content != "客户 A 的故障复盘" {
+		t.Errorf("重新打开后应能读取: %+v, %v", loaded, err)
+	}
+}
+
+func TestOpenEncryptedTaskRepository_ResumeAfterFailure(t *testing.T) {
+	useTestKeyParams(t)
+	dataPath := t.TempDir()
+	fileRepo := NewFileTaskRepository(dataPath)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	if err := fileRepo.Save(&model.Task{Date: monday, Content: "客户 A 的故障复盘", CreatedAt: monday, UpdatedAt: monday}); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	historyPath := filepath.Join(dataPath, "history", "2025-11-10.jsonl")
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0755); err != nil {
+		t.Fatalf("创建历史版本目录失败: %v", err)
+	}
+	if err := os.WriteFile(historyPath, []byte("{损坏的历史版本\n"), 0600); err != nil {
+		t.Fatalf("写入历史版本失败: %v", err)
+	}
+
+	// 历史版本加密失败时不能完成启用，加密标记保留
+	source := keystore.PassphraseSource{Passphrase: "正确口令"}
+	if _, err := OpenEncryptedTaskRepository(fileRepo, dataPath, source); err == nil {
+		t.Fatal("历史版本加密失败时应返回错误")
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, encryptionPendingFileName)); err != nil {
+		t.Fatalf("加密失败时应保留加密标记: %v", err)
+	}
+
+	// 修复后重新打开，继续加密剩余的历史版本
+	if err := os.WriteFile(historyPath, []byte(`{"id":1,"date":"2025-11-10T00:00:00Z","content":"客户 A 的旧版本"}`+"\n"), 0600); err != nil {
+		t.Fatalf("写入历史版本失败: %v", err)
+	}
+	repo, err := OpenEncryptedTaskRepository(fileRepo, dataPath, source)
+	if err != nil {
+		t.Fatalf("重新打开失败: %v", err)
+	}
+	data, _ := os.ReadFile(historyPath)
+	if strings.Contains(string(data), "客户 A") {
+		t.Errorf("重新打开后历史版本应被加密: %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, encryptionPendingFileName)); !os.IsNotExist(err) {
+		t.Errorf("加密完成后应删除加密标记: %v", err)
+	}
+	if loaded, err := repo.GetByDate(monday); err != nil || loaded.Content != "客户 A 的故障复盘" {
+		t.Errorf("重新打开后应能读取: %+v, %v", loaded, err)
+	}
+}
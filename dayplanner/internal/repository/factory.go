@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/teamclient"
+	"daily-report-tool/internal/util"
+)
+
+// NewTaskRepositoryFromConfig 根据配置中的存储后端创建任务仓库
+// dataPath 为文件后端的任务目录，SQLite 后端未配置数据库路径时默认放在其上级目录
+func NewTaskRepositoryFromConfig(config *model.Config, dataPath string) (TaskRepository, error) {
+	switch config.StorageBackend {
+	case "", model.StorageBackendFile:
+		return OpenTaskRepository(model.StorageBackendFile, dataPath)
+	case model.StorageBackendSQLite:
+		dbPath := config.DatabasePath
+		if dbPath == "" {
+			dbPath = DefaultDatabasePath(dataPath)
+		}
+		return OpenTaskRepository(model.StorageBackendSQLite, dbPath)
+	case model.StorageBackendTeam:
+		return NewTeamClientRepository(config.TeamServer, config.TeamToken)
+	default:
+		return nil, fmt.Errorf("不支持的存储后端: %s", config.StorageBackend)
+	}
+}
+
+// NewTeamClientRepository 创建保存到团队服务器的任务仓库，token 为本人在服务器上的访问令牌
+func NewTeamClientRepository(serverURL, token string) (*RemoteTaskRepository, error) {
+	client, err := teamclient.NewClient(serverURL, token)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteTaskRepository(client), nil
+}
+
+// DefaultDatabasePath 返回默认的 SQLite 数据库路径
+func DefaultDatabasePath(dataPath string) string {
+	return filepath.Join(filepath.Dir(dataPath), "tasks.db")
+}
+
+// ParseStorageSpec 解析 "后端:位置" 形式的存储描述，如 "file:./data/tasks"、"sqlite:./data/tasks.db"、
+// "team:http://team.example.com:17900"；位置可以省略（如 "sqlite"），此时返回空位置，由调用方决定默认值
+func ParseStorageSpec(spec string) (backend, location string, err error) {
+	backend, location, _ = strings.Cut(spec, ":")
+	switch backend {
+	case model.StorageBackendFile, model.StorageBackendSQLite, model.StorageBackendTeam:
+		return backend, location, nil
+	default:
+		return "", "", fmt.Errorf("无效的存储描述 %q，格式应为 file:<目录>、sqlite:<数据库文件> 或 team:<服务器地址>", spec)
+	}
+}
+
+// OpenTaskRepository 按存储后端和位置打开任务仓库
+func OpenTaskRepository(backend, location string) (TaskRepository, error) {
+	switch backend {
+	case model.StorageBackendFile:
+		return NewFileTaskRepository(location), nil
+	case model.StorageBackendSQLite:
+		return NewSQLiteTaskRepository(location)
+	default:
+		return nil, fmt.Errorf("不支持的存储后端: %s", backend)
+	}
+}
+
+// NewRevisionRepository 为任务仓库创建配套的历史版本仓库
+// 任务仓库自带历史版本存储时（如 SQLite）直接使用，否则保存在任务目录的 history 子目录下
+func NewRevisionRepository(taskRepo TaskRepository, dataPath string) RevisionRepository {
+	if provider, ok := taskRepo.(interface{ Revisions() RevisionRepository }); ok {
+		return provider.Revisions()
+	}
+	return NewFileRevisionRepository(dataPath)
+}
+
+// EncryptionKeySource 按配置创建任务加密的密钥来源，passphrase 仅在使用口令时需要
+func EncryptionKeySource(config *model.Config, dataPath, passphrase string) (keystore.KeySource, error) {
+	switch config.Encryption {
+	case model.EncryptionPassphrase:
+		return keystore.PassphraseSource{Passphrase: passphrase}, nil
+	case model.EncryptionKeyring:
+		// 以数据目录的绝对路径区分不同数据目录的密钥
+		account, err := filepath.Abs(dataPath)
+		if err != nil {
+			return nil, fmt.Errorf("解析数据目录失败: %w", err)
+		}
+		return keystore.KeyringSource{Account: account}, nil
+	default:
+		return nil, fmt.Errorf("不支持的加密方式: %s", config.Encryption)
+	}
+}
+
+// encryptionPendingFileName 启用加密后、已有数据加密完成前存在的标记文件，位于数据目录中
+const encryptionPendingFileName = "encryption.pending"
+
+// OpenEncryptedTaskRepository 使用 source 解开数据目录中的密钥并为任务仓库加上加密装饰器
+// 密钥文件不存在时（首次启用加密）创建密钥文件并加密已有的日报和历史版本；口令错误时返回 keystore.ErrWrongKey
+// 已有数据加密失败时返回错误，加密标记保留，下次打开时继续加密，直到全部完成后才能正常使用
+func OpenEncryptedTaskRepository(taskRepo TaskRepository, dataPath string, source keystore.KeySource) (*EncryptedTaskRepository, error) {
+	keyPath := keystore.KeyFilePath(dataPath)
+	pendingPath := filepath.Join(dataPath, encryptionPendingFileName)
+
+	var cipher *keystore.Cipher
+	var err error
+	if !keystore.Exists(keyPath) {
+		// 先写入标记再创建密钥，保证中途退出后下次打开时仍会加密剩余的数据
+		if err := os.MkdirAll(dataPath, 0755); err != nil {
+			return nil, fmt.Errorf("创建数据目录失败: %w", err)
+		}
+		if err := util.WriteFileAtomic(pendingPath, nil, 0600); err != nil {
+			return nil, fmt.Errorf("写入加密标记失败: %w", err)
+		}
+		if cipher, err = keystore.Create(keyPath, source); err != nil {
+			return nil, fmt.Errorf("创建密钥文件失败: %w", err)
+		}
+	} else if cipher, err = keystore.Open(keyPath, source); err != nil {
+		return nil, err
+	}
+
+	encrypted := NewEncryptedTaskRepository(taskRepo, cipher, dataPath)
+	if _, err := os.Stat(pendingPath); err != nil {
+		return encrypted, nil
+	}
+
+	count, err := encrypted.EncryptAll()
+	if err != nil {
+		util.Error("加密已有日报失败: %v", err)
+		return nil, fmt.Errorf("加密已有日报失败，加密尚未启用完成: %w", err)
+	}
+	if err := os.Remove(pendingPath); err != nil {
+		return nil, fmt.Errorf("删除加密标记失败: %w", err)
+	}
+	util.Info("已启用任务加密，加密了 %d 篇已有日报", count)
+	return encrypted, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// IntegrityReport 数据完整性检查的结果
+type IntegrityReport struct {
+	Checked       int      // 检查的文件数
+	Quarantined   []string // 无法解析、已移到隔离目录的文件（原路径）
+	QuarantineDir string   // 本次检查使用的隔离目录，没有隔离文件时为空
+	TempRemoved   int      // 删除的写入中断留下的临时文件数
+}
+
+// DefaultQuarantinePath 返回损坏文件的隔离目录，与提醒状态文件相同位于数据目录之外，不会被同步到其他机器
+func DefaultQuarantinePath(dataPath string) string {
+	return filepath.Join(filepath.Dir(dataPath), "corrupted")
+}
+
+// CheckIntegrity 检查数据目录中的日报和历史版本文件
+// 无法解析的文件移到隔离目录中以检查时间命名的子目录，保留原来的文件名和相对位置，可以手动修复后放回；
+// 数据目录和配置文件旁写入中断留下的 .tmp 临时文件直接删除。
+// 配置文件不会被隔离：隔离后会以默认配置启动，丢失加密、存储后端等设置，因此损坏时由加载配置报错并停止启动
+func CheckIntegrity(dataPath, configPath, quarantinePath string) (*IntegrityReport, error) {
+	report := &IntegrityReport{}
+	quarantineDir := filepath.Join(quarantinePath, time.Now().Format("20060102-150405"))
+
+	check := func(filePath, relPath string, valid func(data []byte) bool) error {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("读取文件失败: %w", err)
+		}
+		report.Checked++
+		if valid(data) {
+			return nil
+		}
+
+		target := filepath.Join(quarantineDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return fmt.Errorf("创建隔离目录失败: %w", err)
+		}
+		if err := os.Rename(filePath, target); err != nil {
+			return fmt.Errorf("隔离损坏的文件失败: %w", err)
+		}
+		util.Warn("文件已损坏，已移到隔离目录: %s -> %s", filePath, target)
+		report.Quarantined = append(report.Quarantined, filePath)
+		report.QuarantineDir = quarantineDir
+		return nil
+	}
+	removeTemp := func(filePath string) {
+		if err := os.Remove(filePath); err == nil {
+			util.Info("已删除写入中断留下的临时文件: %s", filePath)
+			report.TempRemoved++
+		}
+	}
+
+	// 日报文件
+	for _, dir := range []string{"", historyDirName} {
+		entries, err := os.ReadDir(filepath.Join(dataPath, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取数据目录失败: %w", err)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			filePath := filepath.Join(dataPath, dir, name)
+			if entry.IsDir() {
+				continue
+			}
+			if strings.HasSuffix(name, ".tmp") {
+				removeTemp(filePath)
+				continue
+			}
+			if !isTaskFileName(name, dir) {
+				continue
+			}
+			valid := validTaskJSON
+			if dir == historyDirName {
+				valid = validRevisionJSONL
+			}
+			if err := check(filePath, filepath.Join(dir, name), valid); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 配置文件只清理临时文件
+	if configPath != "" {
+		removeTemp(configPath + ".tmp")
+	}
+
+	if len(report.Quarantined) > 0 || report.TempRemoved > 0 {
+		util.Info("数据检查完成: 检查 %d 个文件，隔离 %d 个，删除临时文件 %d 个",
+			report.Checked, len(report.Quarantined), report.TempRemoved)
+	}
+	return report, nil
+}
+
+// isTaskFileName 判断是否为日报文件（YYYY-MM-DD.json）或历史版本文件（history/YYYY-MM-DD.jsonl）
+func isTaskFileName(name, dir string) bool {
+	ext := ".json"
+	if dir == historyDirName {
+		ext = ".jsonl"
+	}
+	if filepath.Ext(name) != ext {
+		return false
+	}
+	_, err := time.Parse("2006-01-02", strings.TrimSuffix(name, ext))
+	return err == nil
+}
+
+// validTaskJSON 判断日报文件能否解析
+func validTaskJSON(data []byte) bool {
+	var task model.Task
+	return json.Unmarshal(data, &task) == nil
+}
+
+// validRevisionJSONL 判断历史版本文件的每一行能否解析
+func validRevisionJSONL(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var revision model.Revision
+		err := decoder.Decode(&revision)
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
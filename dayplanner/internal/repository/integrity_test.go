@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	tempDir := t.TempDir()
+	dataPath := filepath.Join(tempDir, "data", "tasks")
+	configPath := filepath.Join(tempDir, "config", "config.json")
+	quarantinePath := DefaultQuarantinePath(dataPath)
+
+	// 正常保存的日报和写入中断留下的文件
+	repo := NewFileTaskRepository(dataPath)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	if err := repo.Save(&model.Task{Date: monday, Content: "- 页面改版"}); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(dataPath, "2025-11-11.json"):                  `{"date":"2025-11-11T00:00:00Z","content":"- 联`,
+		filepath.Join(dataPath, "2025-11-12.json"):                  "",
+		filepath.Join(dataPath, "2025-11-10.json.tmp"):              `{"date":`,
+		filepath.Join(dataPath, "notes.json"):                       "not json",
+		filepath.Join(dataPath, historyDirName, "2025-11-10.jsonl"): "{\"id\":1}\n{\"id\":2,\"content\":\"半",
+		configPath: `{"reminder_time": "10:`,
+	}
+	for path, content := range files {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+
+	report, err := CheckIntegrity(dataPath, configPath, quarantinePath)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if report.Checked != 4 || len(report.Quarantined) != 3 || report.TempRemoved != 1 {
+		t.Errorf("检查结果错误: %+v", report)
+	}
+
+	// 损坏的文件保留原来的相对位置移到隔离目录，正常的文件和无关的文件不受影响
+	for _, rel := range []string{"2025-11-11.json", "2025-11-12.json", filepath.Join(historyDirName, "2025-11-10.jsonl")} {
+		if _, err := os.Stat(filepath.Join(report.QuarantineDir, rel)); err != nil {
+			t.Errorf("%s 应移到隔离目录: %v", rel, err)
+		}
+	}
+	if filepath.Dir(report.QuarantineDir) != quarantinePath {
+		t.Errorf("隔离目录错误: %s", report.QuarantineDir)
+	}
+	if task, err := repo.GetByDate(monday); err != nil || task.Content != "- 页面改版" {
+		t.Errorf("正常的日报不应受影响: %v, %v", task, err)
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, "notes.json")); err != nil {
+		t.Error("不是日报的文件不应被隔离")
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, "2025-11-10.json.tmp")); !os.IsNotExist(err) {
+		t.Error("临时文件应被删除")
+	}
+
+	// 损坏的日报被隔离后可以重新保存
+	if err := repo.Save(&model.Task{Date: monday.AddDate(0, 0, 1), Content: "- 联调"}); err != nil {
+		t.Errorf("隔离后应可以重新保存: %v", err)
+	}
+
+	// 损坏的配置文件保留在原处，加载时报错，不会被默认配置覆盖
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("配置文件不应被隔离: %v", err)
+	}
+	if _, err := NewFileConfigRepository(configPath).Load(); err == nil {
+		t.Error("损坏的配置文件应加载失败")
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != `{"reminder_time": "10:` {
+		t.Errorf("损坏的配置文件不应被覆盖: %s", data)
+	}
+
+	// 再次检查时没有需要处理的文件
+	report, err = CheckIntegrity(dataPath, configPath, quarantinePath)
+	if err != nil || len(report.Quarantined) != 0 || report.TempRemoved != 0 {
+		t.Errorf("再次检查不应有需要处理的文件: %+v, %v", report, err)
+	}
+}
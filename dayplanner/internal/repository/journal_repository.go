@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// JournalRepository 定义保存日志的数据访问接口
+type JournalRepository interface {
+	// Load 加载日志中的记录，没有日志时返回空列表
+	Load() ([]*model.JournalEntry, error)
+
+	// Save 用 entries 替换日志中的所有记录，entries 为空时删除日志文件
+	Save(entries []*model.JournalEntry) error
+}
+
+// DefaultJournalPath 返回默认的保存日志路径，与提醒状态文件相同位于数据目录之外，不会被同步
+func DefaultJournalPath(dataPath string) string {
+	return filepath.Join(filepath.Dir(dataPath), "journal.json")
+}
+
+// FileJournalRepository 基于 JSON 文件的保存日志仓库
+type FileJournalRepository struct {
+	journalPath string
+}
+
+// NewFileJournalRepository 创建新的保存日志仓库
+func NewFileJournalRepository(journalPath string) *FileJournalRepository {
+	return &FileJournalRepository{
+		journalPath: journalPath,
+	}
+}
+
+// Load 加载日志中的记录
+func (r *FileJournalRepository) Load() ([]*model.JournalEntry, error) {
+	data, err := os.ReadFile(r.journalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取保存日志失败: %w", err)
+	}
+
+	var entries []*model.JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("解析保存日志失败: %w", err)
+	}
+	return entries, nil
+}
+
+// Save 保存日志，与日报文件相同先写临时文件再重命名
+func (r *FileJournalRepository) Save(entries []*model.JournalEntry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(r.journalPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("删除保存日志失败: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.journalPath), 0755); err != nil {
+		return fmt.Errorf("创建保存日志目录失败: %w", err)
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化保存日志失败: %w", err)
+	}
+	// 日志中是日报原文，与日报文件相同只允许当前用户读写
+	if err := util.WriteFileAtomic(r.journalPath, data, 0600); err != nil {
+		return fmt.Errorf("写入保存日志失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+func TestFileJournalRepository_SaveAndLoad(t *testing.T) {
+	journalPath := DefaultJournalPath(filepath.Join(t.TempDir(), "data", "tasks"))
+	repo := NewFileJournalRepository(journalPath)
+
+	// 没有日志时返回空列表
+	entries, err := repo.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("没有日志时应返回空列表: %v, %v", entries, err)
+	}
+
+	updatedAt := time.Date(2025, 11, 10, 18, 30, 0, 0, time.UTC)
+	if err := repo.Save([]*model.JournalEntry{{Date: "2025-11-10", Content: "- 页面改版", UpdatedAt: updatedAt}}); err != nil {
+		t.Fatalf("保存日志失败: %v", err)
+	}
+	entries, err = repo.Load()
+	if err != nil || len(entries) != 1 || entries[0].Content != "- 页面改版" || !entries[0].UpdatedAt.Equal(updatedAt) {
+		t.Errorf("加载的日志错误: %+v, %v", entries, err)
+	}
+
+	// 没有记录时删除日志文件
+	if err := repo.Save(nil); err != nil {
+		t.Fatalf("清空日志失败: %v", err)
+	}
+	if _, err := os.Stat(journalPath); !os.IsNotExist(err) {
+		t.Error("没有记录时应删除日志文件")
+	}
+
+	// 无法解析的日志返回错误
+	os.WriteFile(journalPath, []byte(`[{"date":`), 0600)
+	if _, err := repo.Load(); err == nil {
+		t.Error("无法解析的日志应返回错误")
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// ReminderStateRepository 定义提醒服务状态的数据访问接口
+type ReminderStateRepository interface {
+	// Load 加载状态，尚未保存过时返回空状态
+	Load() (*model.ReminderState, error)
+
+	// Save 保存状态
+	Save(state *model.ReminderState) error
+}
+
+// DefaultReminderStatePath 返回默认的提醒状态文件路径，与任务目录位于同一数据目录下
+func DefaultReminderStatePath(dataPath string) string {
+	return filepath.Join(filepath.Dir(dataPath), "reminder_state.json")
+}
+
+// FileReminderStateRepository 基于 JSON 文件的提醒状态仓库
+type FileReminderStateRepository struct {
+	statePath string
+}
+
+// NewFileReminderStateRepository 创建新的提醒状态仓库
+func NewFileReminderStateRepository(statePath string) *FileReminderStateRepository {
+	return &FileReminderStateRepository{
+		statePath: statePath,
+	}
+}
+
+// Load 加载状态，尚未保存过时返回空状态
+func (r *FileReminderStateRepository) Load() (*model.ReminderState, error) {
+	state := &model.ReminderState{LastSent: make(map[string]string)}
+
+	data, err := os.ReadFile(r.statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("读取提醒状态失败: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("解析提醒状态失败: %w", err)
+	}
+	if state.LastSent == nil {
+		state.LastSent = make(map[string]string)
+	}
+	return state, nil
+}
+
+// Save 保存状态，先写临时文件再重命名，避免写入中断导致状态损坏
+func (r *FileReminderStateRepository) Save(state *model.ReminderState) error {
+	if err := os.MkdirAll(filepath.Dir(r.statePath), 0755); err != nil {
+		return fmt.Errorf("创建提醒状态目录失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化提醒状态失败: %w", err)
+	}
+
+	if err := util.WriteFileAtomic(r.statePath, data, 0644); err != nil {
+		return fmt.Errorf("写入提醒状态失败: %w", err)
+	}
+	return nil
+}
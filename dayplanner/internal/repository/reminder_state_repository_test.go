@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+func TestFileReminderStateRepository_SaveAndLoad(t *testing.T) {
+	repo := NewFileReminderStateRepository(filepath.Join(t.TempDir(), "data", "reminder_state.json"))
+
+	// 尚未保存时返回空状态
+	state, err := repo.Load()
+	if err != nil {
+		t.Fatalf("加载空状态失败: %v", err)
+	}
+	if state.LastSent == nil || len(state.Outbox) != 0 {
+		t.Errorf("空状态不正确: %+v", state)
+	}
+
+	nextAttempt := time.Date(2025, 11, 14, 10, 5, 0, 0, time.UTC)
+	state.LastSent["default"] = "2025-11-14"
+	state.Outbox = []model.OutboxMessage{{
+		Channel:     "team",
+		Subject:     "日报提醒",
+		Text:        "请填写日报",
+		Attempts:    2,
+		NextAttempt: nextAttempt,
+		LastError:   "timeout",
+	}}
+	if err := repo.Save(state); err != nil {
+		t.Fatalf("保存状态失败: %v", err)
+	}
+
+	loaded, err := repo.Load()
+	if err != nil {
+		t.Fatalf("加载状态失败: %v", err)
+	}
+	if loaded.LastSent["default"] != "2025-11-14" {
+		t.Errorf("LastSent 不正确: %+v", loaded.LastSent)
+	}
+	if len(loaded.Outbox) != 1 || loaded.Outbox[0].Attempts != 2 || !loaded.Outbox[0].NextAttempt.Equal(nextAttempt) {
+		t.Errorf("发件箱不正确: %+v", loaded.Outbox)
+	}
+}
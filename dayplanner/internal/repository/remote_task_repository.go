@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/teamclient"
+	"daily-report-tool/internal/util"
+)
+
+// RemoteTaskRepository 将本人的日报保存在团队服务器上的任务仓库
+type RemoteTaskRepository struct {
+	client *teamclient.Client
+}
+
+// NewRemoteTaskRepository 创建团队服务器任务仓库
+func NewRemoteTaskRepository(client *teamclient.Client) *RemoteTaskRepository {
+	return &RemoteTaskRepository{client: client}
+}
+
+// GetByDate 获取指定日期的任务
+func (r *RemoteTaskRepository) GetByDate(date time.Time) (*model.Task, error) {
+	util.Debug("从团队服务器读取任务: %s", date.Format("2006-01-02"))
+	return r.client.GetTask(date)
+}
+
+// Save 保存任务
+func (r *RemoteTaskRepository) Save(task *model.Task) error {
+	if err := r.client.SaveTask(task); err != nil {
+		util.Error("保存任务到团队服务器失败: %s, 错误: %v", task.Date.Format("2006-01-02"), err)
+		return err
+	}
+	util.Info("成功保存任务到团队服务器: %s", task.Date.Format("2006-01-02"))
+	return nil
+}
+
+// GetTaskDates 获取日期范围内有任务的日期列表
+func (r *RemoteTaskRepository) GetTaskDates(startDate, endDate time.Time) ([]time.Time, error) {
+	return r.client.TaskDates(startDate, endDate)
+}
+
+// HasTask 检查指定日期是否有任务
+func (r *RemoteTaskRepository) HasTask(date time.Time) (bool, error) {
+	task, err := r.client.GetTask(date)
+	if err != nil {
+		return false, err
+	}
+	return task != nil, nil
+}
+
+// Search 搜索内容包含所有关键词的任务，按日期倒序返回
+func (r *RemoteTaskRepository) Search(query string) ([]*model.Task, error) {
+	return r.client.Search(query)
+}
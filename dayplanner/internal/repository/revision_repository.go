@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// MaxRevisionsPerDay 每天最多保留的历史版本数，超出后删除最早的版本
+const MaxRevisionsPerDay = 200
+
+// historyDirName 历史版本目录名，位于任务数据目录下
+const historyDirName = "history"
+
+// RevisionRepository 定义任务历史版本的数据访问接口
+type RevisionRepository interface {
+	// Add 追加一个历史版本，ID 由仓库分配
+	Add(revision *model.Revision) error
+
+	// List 列出指定日期的所有历史版本，按版本号升序
+	List(date time.Time) ([]*model.Revision, error)
+
+	// Get 获取指定日期的某个历史版本，不存在时返回 nil
+	Get(date time.Time, id int) (*model.Revision, error)
+}
+
+// FileRevisionRepository 基于文件系统的历史版本仓库
+// 每天的历史版本以 JSON Lines 格式保存在任务目录下的 history/YYYY-MM-DD.jsonl 中
+type FileRevisionRepository struct {
+	historyPath string
+}
+
+// NewFileRevisionRepository 创建新的文件历史版本仓库，dataPath 为任务数据目录
+func NewFileRevisionRepository(dataPath string) *FileRevisionRepository {
+	return &FileRevisionRepository{
+		historyPath: filepath.Join(dataPath, historyDirName),
+	}
+}
+
+// Add 追加一个历史版本，ID 由仓库分配
+func (r *FileRevisionRepository) Add(revision *model.Revision) error {
+	revisions, err := r.List(revision.Date)
+	if err != nil {
+		return err
+	}
+
+	revision.ID = 1
+	if len(revisions) > 0 {
+		revision.ID = revisions[len(revisions)-1].ID + 1
+	}
+	revisions = append(revisions, revision)
+
+	// 超出上限时删除最早的版本
+	if len(revisions) > MaxRevisionsPerDay {
+		revisions = revisions[len(revisions)-MaxRevisionsPerDay:]
+	}
+
+	if err := r.write(revision.Date, revisions); err != nil {
+		return err
+	}
+
+	util.Debug("已记录历史版本: %s #%d", revision.Date.Format("2006-01-02"), revision.ID)
+	return nil
+}
+
+// Replace 用 revisions 整体替换指定日期的历史版本，保留原有的版本号，用于重新加密已有的历史版本
+func (r *FileRevisionRepository) Replace(date time.Time, revisions []*model.Revision) error {
+	return r.write(date, revisions)
+}
+
+// Dates 列出有历史版本的日期，按日期升序
+func (r *FileRevisionRepository) Dates() ([]time.Time, error) {
+	entries, err := os.ReadDir(r.historyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取历史版本目录失败: %w", err)
+	}
+
+	var dates []time.Time
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".jsonl" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSuffix(name, ".jsonl"))
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
+
+// write 将历史版本以 JSON Lines 格式原子写入指定日期的历史版本文件
+func (r *FileRevisionRepository) write(date time.Time, revisions []*model.Revision) error {
+	if err := os.MkdirAll(r.historyPath, 0755); err != nil {
+		util.Error("创建历史版本目录失败: %s, 错误: %v", r.historyPath, err)
+		return fmt.Errorf("创建历史版本目录失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, rev := range revisions {
+		if err := encoder.Encode(rev); err != nil {
+			return fmt.Errorf("序列化历史版本失败: %w", err)
+		}
+	}
+
+	filePath := r.getHistoryFilePath(date)
+	if err := util.WriteFileAtomic(filePath, buf.Bytes(), 0600); err != nil {
+		util.Error("写入历史版本文件失败: %s, 错误: %v", filePath, err)
+		return fmt.Errorf("写入历史版本文件失败: %w", err)
+	}
+	return nil
+}
+
+// List 列出指定日期的所有历史版本，按版本号升序
+func (r *FileRevisionRepository) List(date time.Time) ([]*model.Revision, error) {
+	filePath := r.getHistoryFilePath(date)
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		util.Error("读取历史版本文件失败: %s, 错误: %v", filePath, err)
+		return nil, fmt.Errorf("读取历史版本文件失败: %w", err)
+	}
+
+	var revisions []*model.Revision
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var revision model.Revision
+		if err := json.Unmarshal(line, &revision); err != nil {
+			util.Error("解析历史版本失败: %s, 错误: %v", filePath, err)
+			return nil, fmt.Errorf("解析历史版本失败: %w", err)
+		}
+		revisions = append(revisions, &revision)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取历史版本文件失败: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// Get 获取指定日期的某个历史版本，不存在时返回 nil
+func (r *FileRevisionRepository) Get(date time.Time, id int) (*model.Revision, error) {
+	revisions, err := r.List(date)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		if revision.ID == id {
+			return revision, nil
+		}
+	}
+	return nil, nil
+}
+
+// getHistoryFilePath 获取历史版本文件路径
+func (r *FileRevisionRepository) getHistoryFilePath(date time.Time) string {
+	return filepath.Join(r.historyPath, date.Format("2006-01-02")+".jsonl")
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+// testRevisionRepository 对历史版本仓库执行通用的读写和上限检查
+func testRevisionRepository(t *testing.T, repo RevisionRepository) {
+	t.Helper()
+	testDate := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+
+	revisions, err := repo.List(testDate)
+	if err != nil {
+		t.Fatalf("列出历史版本失败: %v", err)
+	}
+	if len(revisions) != 0 {
+		t.Fatalf("新仓库不应有历史版本，实际: %d", len(revisions))
+	}
+
+	for i := 1; i <= MaxRevisionsPerDay+5; i++ {
+		revision := &model.Revision{
+			Date:      testDate,
+			Content:   fmt.Sprintf("版本 %d", i),
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		}
+		if err := repo.Add(revision); err != nil {
+			t.Fatalf("追加历史版本失败: %v", err)
+		}
+		if revision.ID != i {
+			t.Fatalf("版本号应为 %d，实际: %d", i, revision.ID)
+		}
+	}
+
+	revisions, err = repo.List(testDate)
+	if err != nil {
+		t.Fatalf("列出历史版本失败: %v", err)
+	}
+	if len(revisions) != MaxRevisionsPerDay {
+		t.Fatalf("应只保留 %d 个版本，实际: %d", MaxRevisionsPerDay, len(revisions))
+	}
+	if revisions[0].ID != 6 || revisions[len(revisions)-1].ID != MaxRevisionsPerDay+5 {
+		t.Errorf("应删除最早的版本，实际范围: #%d ~ #%d", revisions[0].ID, revisions[len(revisions)-1].ID)
+	}
+
+	revision, err := repo.Get(testDate, 10)
+	if err != nil {
+		t.Fatalf("获取历史版本失败: %v", err)
+	}
+	if revision == nil || revision.Content != "版本 10" || !revision.CreatedAt.Equal(createdAt.Add(10*time.Minute)) {
+		t.Errorf("历史版本内容不正确: %+v", revision)
+	}
+
+	revision, err = repo.Get(testDate, 1)
+	if err != nil || revision != nil {
+		t.Errorf("已删除的版本应返回 nil: %+v, %v", revision, err)
+	}
+}
+
+func TestFileRevisionRepository(t *testing.T) {
+	tempDir := t.TempDir()
+	testRevisionRepository(t, NewFileRevisionRepository(tempDir))
+
+	// 历史版本目录不应被识别为任务
+	taskRepo := NewFileTaskRepository(tempDir)
+	dates, err := taskRepo.GetTaskDates(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("获取任务日期失败: %v", err)
+	}
+	if len(dates) != 0 {
+		t.Errorf("历史版本不应出现在任务日期中: %v", dates)
+	}
+}
+
+func TestSQLiteRevisionRepository(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+	testRevisionRepository(t, repo.Revisions())
+
+	if _, ok := NewRevisionRepository(repo, t.TempDir()).(*SQLiteRevisionRepository); !ok {
+		t.Error("SQLite 任务仓库应使用数据库中的历史版本表")
+	}
+}
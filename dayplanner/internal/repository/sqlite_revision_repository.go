@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// SQLiteRevisionRepository 基于 SQLite 的历史版本仓库，与任务共用同一个数据库
+type SQLiteRevisionRepository struct {
+	db *sql.DB
+}
+
+// Add 追加一个历史版本，ID 由仓库分配
+func (r *SQLiteRevisionRepository) Add(revision *model.Revision) error {
+	key := revision.Date.Format("2006-01-02")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var lastID int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM task_revisions WHERE date = ?`, key).Scan(&lastID); err != nil {
+		util.Error("查询历史版本失败: %s, 错误: %v", key, err)
+		return fmt.Errorf("查询历史版本失败: %w", err)
+	}
+	revision.ID = lastID + 1
+
+	_, err = tx.Exec(`INSERT INTO task_revisions (date, id, content, created_at) VALUES (?, ?, ?, ?)`,
+		key, revision.ID, revision.Content, revision.CreatedAt.Format(time.RFC3339Nano))
+	if err != nil {
+		util.Error("写入历史版本失败: %s, 错误: %v", key, err)
+		return fmt.Errorf("写入历史版本失败: %w", err)
+	}
+
+	// 超出上限时删除最早的版本
+	_, err = tx.Exec(`DELETE FROM task_revisions WHERE date = ? AND id <= ?`, key, revision.ID-MaxRevisionsPerDay)
+	if err != nil {
+		return fmt.Errorf("清理历史版本失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+
+	util.Debug("已记录历史版本: %s #%d", key, revision.ID)
+	return nil
+}
+
+// Replace 用 revisions 整体替换指定日期的历史版本，保留原有的版本号，用于重新加密已有的历史版本
+func (r *SQLiteRevisionRepository) Replace(date time.Time, revisions []*model.Revision) error {
+	key := date.Format("2006-01-02")
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM task_revisions WHERE date = ?`, key); err != nil {
+		util.Error("删除历史版本失败: %s, 错误: %v", key, err)
+		return fmt.Errorf("删除历史版本失败: %w", err)
+	}
+	for _, revision := range revisions {
+		_, err := tx.Exec(`INSERT INTO task_revisions (date, id, content, created_at) VALUES (?, ?, ?, ?)`,
+			key, revision.ID, revision.Content, revision.CreatedAt.Format(time.RFC3339Nano))
+		if err != nil {
+			util.Error("写入历史版本失败: %s, 错误: %v", key, err)
+			return fmt.Errorf("写入历史版本失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// Dates 列出有历史版本的日期，按日期升序
+func (r *SQLiteRevisionRepository) Dates() ([]time.Time, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT date FROM task_revisions ORDER BY date`)
+	if err != nil {
+		return nil, fmt.Errorf("查询历史版本日期失败: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("读取历史版本日期失败: %w", err)
+		}
+		date, err := time.Parse("2006-01-02", key)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("查询历史版本日期失败: %w", err)
+	}
+	return dates, nil
+}
+
+// List 列出指定日期的所有历史版本，按版本号升序
+func (r *SQLiteRevisionRepository) List(date time.Time) ([]*model.Revision, error) {
+	key := date.Format("2006-01-02")
+	rows, err := r.db.Query(`SELECT id, content, created_at FROM task_revisions WHERE date = ? ORDER BY id`, key)
+	if err != nil {
+		util.Error("查询历史版本失败: %s, 错误: %v", key, err)
+		return nil, fmt.Errorf("查询历史版本失败: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*model.Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows, date)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("查询历史版本失败: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// Get 获取指定日期的某个历史版本，不存在时返回 nil
+func (r *SQLiteRevisionRepository) Get(date time.Time, id int) (*model.Revision, error) {
+	key := date.Format("2006-01-02")
+	row := r.db.QueryRow(`SELECT id, content, created_at FROM task_revisions WHERE date = ? AND id = ?`, key, id)
+	revision, err := scanRevision(row, date)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return revision, err
+}
+
+// scanRevision 从查询结果中读取一个历史版本
+func scanRevision(scanner interface{ Scan(dest ...any) error }, date time.Time) (*model.Revision, error) {
+	var revision model.Revision
+	var createdAt string
+	if err := scanner.Scan(&revision.ID, &revision.Content, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("读取历史版本失败: %w", err)
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("解析历史版本时间失败: %w", err)
+	}
+	revision.Date = date
+	revision.CreatedAt = parsed
+	return &revision, nil
+}
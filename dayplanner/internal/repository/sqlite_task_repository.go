@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+
+	_ "modernc.org/sqlite" // 纯 Go 实现的 SQLite 驱动，无需 CGO
+)
+
+// sqliteSchema 数据库结构
+// tasks 表保存完整的任务 JSON（data 列），content 列单独存放用于全文索引；
+// tasks_fts 为外部内容 FTS5 表，使用 trigram 分词以支持中文子串检索；
+// task_revisions 保存任务的历史版本
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS tasks (
+	date       TEXT PRIMARY KEY,
+	content    TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+	content,
+	content='tasks',
+	content_rowid='rowid',
+	tokenize='trigram'
+);
+
+CREATE TRIGGER IF NOT EXISTS tasks_ai AFTER INSERT ON tasks BEGIN
+	INSERT INTO tasks_fts(rowid, content) VALUES (new.rowid, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS tasks_ad AFTER DELETE ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS tasks_au AFTER UPDATE ON tasks BEGIN
+	INSERT INTO tasks_fts(tasks_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+	INSERT INTO tasks_fts(rowid, content) VALUES (new.rowid, new.content);
+END;
+
+CREATE TABLE IF NOT EXISTS task_revisions (
+	date       TEXT NOT NULL,
+	id         INTEGER NOT NULL,
+	content    TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (date, id)
+);
+`
+
+// trigramMinLength trigram 分词器可匹配的最短关键词长度（字符数）
+const trigramMinLength = 3
+
+// SQLiteTaskRepository 基于嵌入式 SQLite 的任务仓库实现
+type SQLiteTaskRepository struct {
+	db     *sql.DB
+	dbPath string
+}
+
+// NewSQLiteTaskRepository 打开（必要时创建）SQLite 任务仓库
+func NewSQLiteTaskRepository(dbPath string) (*SQLiteTaskRepository, error) {
+	// 确保数据库所在目录存在
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		util.Error("创建数据库目录失败: %s, 错误: %v", dbPath, err)
+		return nil, fmt.Errorf("创建数据库目录失败: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		util.Error("打开数据库失败: %s, 错误: %v", dbPath, err)
+		return nil, fmt.Errorf("打开数据库失败: %w", err)
+	}
+	// SQLite 只允许单个写连接，限制连接数避免 "database is locked"
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		util.Error("初始化数据库结构失败: %s, 错误: %v", dbPath, err)
+		return nil, fmt.Errorf("初始化数据库结构失败: %w", err)
+	}
+
+	util.Info("SQLite 任务仓库已打开: %s", dbPath)
+	return &SQLiteTaskRepository{
+		db:     db,
+		dbPath: dbPath,
+	}, nil
+}
+
+// Close 关闭数据库连接
+func (r *SQLiteTaskRepository) Close() error {
+	return r.db.Close()
+}
+
+// Revisions 返回与任务保存在同一数据库中的历史版本仓库
+func (r *SQLiteTaskRepository) Revisions() RevisionRepository {
+	return &SQLiteRevisionRepository{db: r.db}
+}
+
+// GetByDate 获取指定日期的任务
+func (r *SQLiteTaskRepository) GetByDate(date time.Time) (*model.Task, error) {
+	key := date.Format("2006-01-02")
+	util.Debug("查询任务: %s", key)
+
+	var data string
+	err := r.db.QueryRow(`SELECT data FROM tasks WHERE date = ?`, key).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.Debug("任务不存在: %s", key)
+			return nil, nil // 记录不存在返回 nil，不是错误
+		}
+		util.Error("查询任务失败: %s, 错误: %v", key, err)
+		return nil, fmt.Errorf("查询任务失败: %w", err)
+	}
+
+	var task model.Task
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		util.Error("解析任务数据失败: %s, 错误: %v", key, err)
+		return nil, fmt.Errorf("解析任务数据失败: %w", err)
+	}
+
+	util.Info("成功读取任务: %s", key)
+	return &task, nil
+}
+
+// Save 保存任务
+func (r *SQLiteTaskRepository) Save(task *model.Task) error {
+	key := task.Date.Format("2006-01-02")
+	util.Debug("保存任务: %s", key)
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		util.Error("序列化任务数据失败: 错误: %v", err)
+		return fmt.Errorf("序列化任务数据失败: %w", err)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO tasks (date, content, created_at, updated_at, data)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(date) DO UPDATE SET
+			content    = excluded.content,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at,
+			data       = excluded.data`,
+		key,
+		task.Content,
+		task.CreatedAt.Format(time.RFC3339Nano),
+		task.UpdatedAt.Format(time.RFC3339Nano),
+		string(data),
+	)
+	if err != nil {
+		util.Error("写入任务失败: %s, 错误: %v", key, err)
+		return fmt.Errorf("写入任务失败: %w", err)
+	}
+
+	util.Info("成功保存任务: %s", key)
+	return nil
+}
+
+// GetTaskDates 获取日期范围内有任务的日期列表
+func (r *SQLiteTaskRepository) GetTaskDates(startDate, endDate time.Time) ([]time.Time, error) {
+	util.Debug("查询任务日期范围: %s 到 %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	rows, err := r.db.Query(
+		`SELECT date FROM tasks WHERE date >= ? AND date <= ? ORDER BY date`,
+		startDate.Format("2006-01-02"),
+		endDate.Format("2006-01-02"),
+	)
+	if err != nil {
+		util.Error("查询任务日期失败: %v", err)
+		return nil, fmt.Errorf("查询任务日期失败: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var dateStr string
+		if err := rows.Scan(&dateStr); err != nil {
+			return nil, fmt.Errorf("读取任务日期失败: %w", err)
+		}
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			continue // 跳过无效的日期
+		}
+		dates = append(dates, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取任务日期失败: %w", err)
+	}
+
+	return dates, nil
+}
+
+// HasTask 检查指定日期是否有任务
+func (r *SQLiteTaskRepository) HasTask(date time.Time) (bool, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(1) FROM tasks WHERE date = ?`, date.Format("2006-01-02")).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("检查任务失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Search 搜索内容包含所有关键词的任务，按日期倒序返回
+// 关键词均不少于 3 个字符时走 FTS5 索引，否则退化为 LIKE 扫描
+func (r *SQLiteTaskRepository) Search(query string) ([]*model.Task, error) {
+	terms := splitSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	util.Debug("搜索任务: %s", query)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if canUseTrigramIndex(terms) {
+		rows, err = r.db.Query(`
+			SELECT t.data FROM tasks_fts f
+			JOIN tasks t ON t.rowid = f.rowid
+			WHERE tasks_fts MATCH ?
+			ORDER BY t.date DESC`, buildFTSQuery(terms))
+	} else {
+		conditions := make([]string, len(terms))
+		args := make([]interface{}, len(terms))
+		for i, term := range terms {
+			conditions[i] = `content LIKE ? ESCAPE '\'`
+			args[i] = "%" + escapeLike(term) + "%"
+		}
+		rows, err = r.db.Query(
+			`SELECT data FROM tasks WHERE `+strings.Join(conditions, " AND ")+` ORDER BY date DESC`,
+			args...,
+		)
+	}
+	if err != nil {
+		util.Error("搜索任务失败: %s, 错误: %v", query, err)
+		return nil, fmt.Errorf("搜索任务失败: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*model.Task
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("读取搜索结果失败: %w", err)
+		}
+		var task model.Task
+		if err := json.Unmarshal([]byte(data), &task); err != nil {
+			return nil, fmt.Errorf("解析任务数据失败: %w", err)
+		}
+		results = append(results, &task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取搜索结果失败: %w", err)
+	}
+
+	util.Info("搜索完成: %s, 匹配 %d 个任务", query, len(results))
+	return results, nil
+}
+
+// canUseTrigramIndex 检查所有关键词是否都能被 trigram 索引匹配
+func canUseTrigramIndex(terms []string) bool {
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) < trigramMinLength {
+			return false
+		}
+	}
+	return true
+}
+
+// buildFTSQuery 将关键词转换为 FTS5 查询，每个关键词作为短语匹配，多个关键词之间为 AND
+func buildFTSQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(term)
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+// newTestSQLiteRepository 创建测试用的 SQLite 仓库
+func newTestSQLiteRepository(t *testing.T) *SQLiteTaskRepository {
+	t.Helper()
+	repo, err := NewSQLiteTaskRepository(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("打开 SQLite 仓库失败: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestSQLiteTaskRepository_SaveAndGetByDate(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	testDate := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	task := &model.Task{
+		Date:      testDate,
+		Content:   "# 测试任务\n\n- 任务1\n- 任务2",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	if err := repo.Save(task); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	// 更新同一天的任务
+	task.Content = "# 更新后的任务"
+	task.UpdatedAt = createdAt.Add(time.Hour)
+	if err := repo.Save(task); err != nil {
+		t.Fatalf("更新任务失败: %v", err)
+	}
+
+	loadedTask, err := repo.GetByDate(testDate)
+	if err != nil {
+		t.Fatalf("读取任务失败: %v", err)
+	}
+	if loadedTask == nil {
+		t.Fatal("读取的任务为 nil")
+	}
+	if loadedTask.Content != task.Content {
+		t.Errorf("任务内容不匹配: 期望 %s, 实际 %s", task.Content, loadedTask.Content)
+	}
+	if !loadedTask.CreatedAt.Equal(createdAt) {
+		t.Errorf("创建时间不匹配: 期望 %v, 实际 %v", createdAt, loadedTask.CreatedAt)
+	}
+	if !loadedTask.UpdatedAt.Equal(task.UpdatedAt) {
+		t.Errorf("更新时间不匹配: 期望 %v, 实际 %v", task.UpdatedAt, loadedTask.UpdatedAt)
+	}
+}
+
+func TestSQLiteTaskRepository_GetByDate_NotExists(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	task, err := repo.GetByDate(time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("读取不存在的任务应该返回 nil 而不是错误: %v", err)
+	}
+	if task != nil {
+		t.Error("不存在的任务应该返回 nil")
+	}
+}
+
+func TestSQLiteTaskRepository_HasTaskAndGetTaskDates(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	dates := []time.Time{
+		time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC),
+	}
+	for _, date := range dates {
+		task := &model.Task{Date: date, Content: "测试内容", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if err := repo.Save(task); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	exists, err := repo.HasTask(dates[1])
+	if err != nil {
+		t.Fatalf("检查任务失败: %v", err)
+	}
+	if !exists {
+		t.Error("存在的任务应该返回 true")
+	}
+
+	exists, err = repo.HasTask(time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("检查任务失败: %v", err)
+	}
+	if exists {
+		t.Error("不存在的任务应该返回 false")
+	}
+
+	taskDates, err := repo.GetTaskDates(
+		time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatalf("获取任务日期失败: %v", err)
+	}
+	if len(taskDates) != 3 {
+		t.Errorf("任务日期数量不匹配: 期望 3, 实际 %d", len(taskDates))
+	}
+}
+
+func TestSQLiteTaskRepository_Search(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	contents := map[time.Time]string{
+		time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC): "# 今日工作\n\n- 修复支付服务超时问题\n- Code Review",
+		time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC): "# 今日工作\n\n- 支付服务上线\n- 编写部署文档",
+		time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC): "# 今日工作\n\n- 团队周会",
+	}
+	for date, content := range contents {
+		task := &model.Task{Date: date, Content: content, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if err := repo.Save(task); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantDates []string
+	}{
+		{name: "中文子串走全文索引", query: "支付服务", wantDates: []string{"2025-11-11", "2025-11-10"}},
+		{name: "多个关键词取交集", query: "支付服务 上线", wantDates: []string{"2025-11-11"}},
+		{name: "短关键词退化为 LIKE", query: "周会", wantDates: []string{"2025-11-12"}},
+		{name: "英文不区分大小写", query: "code review", wantDates: []string{"2025-11-10"}},
+		{name: "无匹配", query: "不存在的内容", wantDates: nil},
+		{name: "空查询", query: "   ", wantDates: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.Search(tt.query)
+			if err != nil {
+				t.Fatalf("搜索失败: %v", err)
+			}
+			if len(results) != len(tt.wantDates) {
+				t.Fatalf("搜索结果数量不匹配: 期望 %d, 实际 %d", len(tt.wantDates), len(results))
+			}
+			for i, task := range results {
+				if got := task.Date.Format("2006-01-02"); got != tt.wantDates[i] {
+					t.Errorf("第 %d 个结果日期不匹配: 期望 %s, 实际 %s", i, tt.wantDates[i], got)
+				}
+			}
+		})
+	}
+
+	// 更新后旧内容不应再被索引命中
+	updated := &model.Task{
+		Date:      time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC),
+		Content:   "# 今日工作\n\n- 团队周会",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := repo.Save(updated); err != nil {
+		t.Fatalf("更新任务失败: %v", err)
+	}
+	results, err := repo.Search("支付服务")
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("更新后搜索结果数量不匹配: 期望 1, 实际 %d", len(results))
+	}
+}
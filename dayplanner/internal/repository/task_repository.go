@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"daily-report-tool/internal/model"
@@ -24,6 +26,9 @@ type TaskRepository interface {
 
 	// HasTask 检查指定日期是否有任务
 	HasTask(date time.Time) (bool, error)
+
+	// Search 搜索内容包含所有关键词的任务，按日期倒序返回
+	Search(query string) ([]*model.Task, error)
 }
 
 // FileTaskRepository 基于文件系统的任务仓库实现
@@ -80,7 +85,9 @@ func (r *FileTaskRepository) Save(task *model.Task) error {
 		return fmt.Errorf("序列化任务数据失败: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	// 日报可能包含客户和故障信息，只允许当前用户读写
+	// 先写临时文件再重命名，写入中断时不会留下不完整的日报
+	if err := util.WriteFileAtomic(filePath, data, 0600); err != nil {
 		util.Error("写入任务文件失败: %s, 错误: %v", filePath, err)
 		return fmt.Errorf("写入任务文件失败: %w", err)
 	}
@@ -146,6 +153,55 @@ func (r *FileTaskRepository) HasTask(date time.Time) (bool, error) {
 	return true, nil
 }
 
+// Search 搜索内容包含所有关键词的任务，按日期倒序返回
+// 文件仓库没有索引，需要逐个读取任务文件进行匹配
+func (r *FileTaskRepository) Search(query string) ([]*model.Task, error) {
+	terms := splitSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	util.Debug("搜索任务: %s", query)
+
+	dates, err := r.GetTaskDates(time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Task
+	for _, date := range dates {
+		task, err := r.GetByDate(date)
+		if err != nil {
+			return nil, err
+		}
+		if task != nil && matchSearchTerms(task.Content, terms) {
+			results = append(results, task)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date.After(results[j].Date)
+	})
+
+	util.Info("搜索完成: %s, 匹配 %d 个任务", query, len(results))
+	return results, nil
+}
+
+// splitSearchTerms 将搜索语句按空白拆分为关键词
+func splitSearchTerms(query string) []string {
+	return strings.Fields(query)
+}
+
+// matchSearchTerms 检查内容是否包含所有关键词（不区分大小写）
+func matchSearchTerms(content string, terms []string) bool {
+	lowerContent := strings.ToLower(content)
+	for _, term := range terms {
+		if !strings.Contains(lowerContent, strings.ToLower(term)) {
+			return false
+		}
+	}
+	return true
+}
+
 // getTaskFilePath 获取任务文件路径
 func (r *FileTaskRepository) getTaskFilePath(date time.Time) string {
 	fileName := date.Format("2006-01-02") + ".json"
@@ -179,3 +179,39 @@ func TestFileTaskRepository_GetTaskDates_EmptyDirectory(t *testing.T) {
 		t.Errorf("空目录应该返回空列表: 实际 %d", len(taskDates))
 	}
 }
+
+func TestFileTaskRepository_Search(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewFileTaskRepository(tempDir)
+
+	contents := map[time.Time]string{
+		time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC): "- 修复支付服务超时问题",
+		time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC): "- 支付服务上线",
+		time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC): "- 团队周会",
+	}
+	for date, content := range contents {
+		task := &model.Task{Date: date, Content: content, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if err := repo.Save(task); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	results, err := repo.Search("支付")
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("搜索结果数量不匹配: 期望 2, 实际 %d", len(results))
+	}
+	if results[0].Date.Format("2006-01-02") != "2025-11-11" {
+		t.Errorf("搜索结果应按日期倒序排列，实际第一个为 %s", results[0].Date.Format("2006-01-02"))
+	}
+
+	results, err = repo.Search("支付 上线")
+	if err != nil {
+		t.Fatalf("搜索失败: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("多关键词搜索结果数量不匹配: 期望 1, 实际 %d", len(results))
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"daily-report-tool/internal/model"
+)
+
+// TeamTaskRepository 定义按成员区分的任务数据访问接口，供团队服务器使用
+type TeamTaskRepository interface {
+	// ForUser 返回指定成员的任务仓库，成员名无效时返回错误
+	ForUser(user string) (TaskRepository, error)
+}
+
+// FileTeamTaskRepository 基于文件系统的多成员任务仓库，每个成员的日报保存在根目录下以成员名命名的子目录中
+type FileTeamTaskRepository struct {
+	rootPath string
+
+	mu    sync.Mutex
+	repos map[string]*FileTaskRepository
+}
+
+// NewFileTeamTaskRepository 创建新的多成员任务仓库
+func NewFileTeamTaskRepository(rootPath string) *FileTeamTaskRepository {
+	return &FileTeamTaskRepository{
+		rootPath: rootPath,
+		repos:    make(map[string]*FileTaskRepository),
+	}
+}
+
+// DefaultTeamDataPath 返回团队服务器默认的数据目录，与任务目录同级
+func DefaultTeamDataPath(dataPath string) string {
+	return filepath.Join(filepath.Dir(dataPath), "team")
+}
+
+// ForUser 返回指定成员的任务仓库
+func (r *FileTeamTaskRepository) ForUser(user string) (TaskRepository, error) {
+	if !model.ValidMemberName(user) {
+		return nil, fmt.Errorf("无效的成员名: %q", user)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repo, ok := r.repos[user]
+	if !ok {
+		repo = NewFileTaskRepository(filepath.Join(r.rootPath, user))
+		r.repos[user] = repo
+	}
+	return repo, nil
+}
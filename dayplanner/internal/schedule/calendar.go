@@ -0,0 +1,102 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+// Calendar 节假日日历，用于判断某天是否为工作日
+// 节假日不上班，调休补班的周末需要上班，其余按周一至周五为工作日
+type Calendar struct {
+	holidays map[string]bool
+	workdays map[string]bool
+}
+
+// calendarFile 节假日日历文件格式，日期为 YYYY-MM-DD，也可以写成 "YYYY-MM-DD~YYYY-MM-DD" 表示连续多天
+type calendarFile struct {
+	Holidays []string `json:"holidays"` // 放假的日期
+	Workdays []string `json:"workdays"` // 调休补班的日期
+}
+
+// NewCalendar 创建只按周一至周五判断工作日的空日历
+func NewCalendar() *Calendar {
+	return &Calendar{
+		holidays: make(map[string]bool),
+		workdays: make(map[string]bool),
+	}
+}
+
+// LoadCalendar 从 JSON 文件加载节假日日历，路径为空或文件不存在时返回空日历
+func LoadCalendar(path string) (*Calendar, error) {
+	calendar := NewCalendar()
+	if path == "" {
+		return calendar, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			util.Warn("节假日日历文件不存在: %s，仅按周一至周五判断工作日", path)
+			return calendar, nil
+		}
+		return nil, fmt.Errorf("读取节假日日历失败: %w", err)
+	}
+
+	var file calendarFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("解析节假日日历失败: %w", err)
+	}
+	if err := addDates(calendar.holidays, file.Holidays); err != nil {
+		return nil, fmt.Errorf("节假日日历中的 holidays 无效: %w", err)
+	}
+	if err := addDates(calendar.workdays, file.Workdays); err != nil {
+		return nil, fmt.Errorf("节假日日历中的 workdays 无效: %w", err)
+	}
+
+	util.Debug("已加载节假日日历: %s, 假日 %d 天, 补班 %d 天", path, len(calendar.holidays), len(calendar.workdays))
+	return calendar, nil
+}
+
+// IsWorkday 判断日期是否为工作日
+func (c *Calendar) IsWorkday(date time.Time) bool {
+	key := date.Format("2006-01-02")
+	if c.workdays[key] {
+		return true
+	}
+	if c.holidays[key] {
+		return false
+	}
+	weekday := date.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// IsHoliday 判断日期是否为日历中登记的节假日
+func (c *Calendar) IsHoliday(date time.Time) bool {
+	return c.holidays[date.Format("2006-01-02")]
+}
+
+// addDates 将日期或日期范围加入集合
+func addDates(set map[string]bool, values []string) error {
+	for _, value := range values {
+		startText, endText, isRange := strings.Cut(value, "~")
+		start, err := time.Parse("2006-01-02", strings.TrimSpace(startText))
+		if err != nil {
+			return fmt.Errorf("日期格式应为 YYYY-MM-DD: %q", value)
+		}
+		end := start
+		if isRange {
+			if end, err = time.Parse("2006-01-02", strings.TrimSpace(endText)); err != nil || end.Before(start) {
+				return fmt.Errorf("日期范围无效: %q", value)
+			}
+		}
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			set[day.Format("2006-01-02")] = true
+		}
+	}
+	return nil
+}
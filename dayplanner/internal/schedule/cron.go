@@ -0,0 +1,166 @@
+// Package schedule 提供提醒计划：类 cron 的时间表达式、节假日日历和提醒规则
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField 描述 cron 表达式中一个字段的取值范围
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int // 可用的英文名称，如月份和星期
+}
+
+var (
+	minuteField = cronField{name: "分钟", min: 0, max: 59}
+	hourField   = cronField{name: "小时", min: 0, max: 23}
+	domField    = cronField{name: "日", min: 1, max: 31}
+	monthField  = cronField{name: "月", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dowField = cronField{name: "星期", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Cron 解析后的 cron 表达式（分 时 日 月 星期）
+type Cron struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool // 日字段为 * 时只按星期匹配
+	dowStar bool // 星期字段为 * 时只按日匹配
+}
+
+// ParseCron 解析标准 5 字段 cron 表达式，如 "30 9 * * 1-5"
+// 每个字段支持 *、数字、范围 a-b、列表 a,b 和步长 */n、a-b/n；月份和星期支持英文缩写，星期 0 和 7 都表示周日
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q：需要 5 个字段（分 时 日 月 星期）", expr)
+	}
+
+	c := &Cron{expr: expr}
+	var err error
+	if c.minute, err = parseCronField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q: %w", expr, err)
+	}
+	if c.hour, err = parseCronField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q: %w", expr, err)
+	}
+	if c.dom, err = parseCronField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q: %w", expr, err)
+	}
+	if c.month, err = parseCronField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q: %w", expr, err)
+	}
+	if c.dow, err = parseCronField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("无效的 cron 表达式 %q: %w", expr, err)
+	}
+	// 7 与 0 都表示周日
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = fields[2] == "*"
+	c.dowStar = fields[4] == "*"
+	return c, nil
+}
+
+// String 返回原始表达式
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Matches 判断时间（精确到分钟）是否符合表达式
+// 与标准 cron 一致：日和星期都有限制时，满足其一即可
+func (c *Cron) Matches(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 ||
+		c.hour&(1<<uint(t.Hour())) == 0 ||
+		c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dowMatch
+	case c.dowStar:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// parseCronField 将一个字段解析为位集合
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s字段的步长无效: %q", field.name, part)
+			}
+			step = n
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			lo, hi, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(lo, field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(hi, field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%s字段的范围无效: %q", field.name, part)
+			}
+		default:
+			n, err := parseCronValue(rangePart, field)
+			if err != nil {
+				return 0, err
+			}
+			start = n
+			if hasStep {
+				end = field.max // "a/n" 表示从 a 开始每隔 n
+			} else {
+				end = n
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue 解析字段中的单个值（数字或英文缩写）
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("%s字段的值无效: %q (范围 %d-%d)", field.name, value, field.min, field.max)
+	}
+	return n, nil
+}
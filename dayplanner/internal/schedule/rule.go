@@ -0,0 +1,125 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+// DefaultRuleName 由 ReminderTime 生成的默认规则名称
+const DefaultRuleName = "default"
+
+// Rule 解析后的提醒规则
+type Rule struct {
+	Name         string
+	Cron         *Cron
+	WorkdaysOnly bool
+	Message      string   // 为空时由调用方使用默认提醒内容
+	Channels     []string // 为空表示所有渠道
+	Cutoff       int      // 补发截止时间，距零点的分钟数（含该分钟）
+	Team         bool     // 团队提醒：检查所有成员并 @ 未填写的成员
+}
+
+// Due 判断规则在指定时间（精确到分钟）是否应当触发
+func (r *Rule) Due(t time.Time, calendar *Calendar) bool {
+	if !r.Cron.Matches(t) {
+		return false
+	}
+	return !r.WorkdaysOnly || calendar.IsWorkday(t)
+}
+
+// LastDue 返回当天 now 之前（含 now 所在分钟）最近一次应触发的时间
+// 用于在休眠或程序未运行错过提醒时间后补发提醒
+func (r *Rule) LastDue(now time.Time, calendar *Calendar) (time.Time, bool) {
+	if r.WorkdaysOnly && !calendar.IsWorkday(now) {
+		return time.Time{}, false
+	}
+
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for t := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, now.Location()); !t.Before(start); t = t.Add(-time.Minute) {
+		if r.Cron.Matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CutoffTime 返回指定日期的补发截止时间，在此时间（不含）之后不再补发
+func (r *Rule) CutoffTime(date time.Time) time.Time {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return start.Add(time.Duration(r.Cutoff+1) * time.Minute)
+}
+
+// RulesFromConfig 根据配置生成提醒规则
+// 未配置 reminders 时，使用 ReminderTime 生成一条每天（或仅工作日）触发的默认规则
+func RulesFromConfig(config *model.Config) ([]*Rule, error) {
+	defaultCutoff, err := parseCutoff(config.ReminderCutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.Reminders) == 0 {
+		hour, minute, ok := strings.Cut(config.ReminderTime, ":")
+		if !ok {
+			return nil, fmt.Errorf("无效的提醒时间: %q", config.ReminderTime)
+		}
+		cron, err := ParseCron(fmt.Sprintf("%s %s * * *", minute, hour))
+		if err != nil {
+			return nil, fmt.Errorf("无效的提醒时间: %q", config.ReminderTime)
+		}
+		return []*Rule{{
+			Name:         DefaultRuleName,
+			Cron:         cron,
+			WorkdaysOnly: config.ReminderWorkdaysOnly,
+			Cutoff:       defaultCutoff,
+		}}, nil
+	}
+
+	rules := make([]*Rule, 0, len(config.Reminders))
+	names := make(map[string]bool)
+	for i, reminder := range config.Reminders {
+		name := reminder.Name
+		if name == "" {
+			name = fmt.Sprintf("reminder-%d", i+1)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("提醒规则名称重复: %s", name)
+		}
+		names[name] = true
+
+		cron, err := ParseCron(reminder.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("提醒规则 %s: %w", name, err)
+		}
+		cutoff := defaultCutoff
+		if reminder.Cutoff != "" {
+			if cutoff, err = parseCutoff(reminder.Cutoff); err != nil {
+				return nil, fmt.Errorf("提醒规则 %s: %w", name, err)
+			}
+		}
+		rules = append(rules, &Rule{
+			Name:         name,
+			Cron:         cron,
+			WorkdaysOnly: reminder.WorkdaysOnly,
+			Message:      reminder.Message,
+			Channels:     reminder.Channels,
+			Cutoff:       cutoff,
+			Team:         reminder.Team,
+		})
+	}
+	return rules, nil
+}
+
+// parseCutoff 解析 HH:MM 格式的截止时间，为空时表示当天结束（23:59）
+func parseCutoff(value string) (int, error) {
+	if value == "" {
+		return 23*60 + 59, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("无效的补发截止时间 %q，格式应为 HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+func TestParseCron_Matches(t *testing.T) {
+	// 2025-11-10 是星期一
+	monday := time.Date(2025, 11, 10, 9, 30, 0, 0, time.Local)
+	saturday := time.Date(2025, 11, 15, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		expr string
+		time time.Time
+		want bool
+	}{
+		{"30 9 * * *", monday, true},
+		{"30 9 * * *", monday.Add(time.Minute), false},
+		{"30 9 * * 1-5", monday, true},
+		{"30 9 * * 1-5", saturday, false},
+		{"30 9 * * MON-FRI", saturday, false},
+		{"30 9 * * sat,sun", saturday, true},
+		{"*/15 9-18 * * *", monday, true},
+		{"*/15 9-18 * * *", monday.Add(5 * time.Minute), false},
+		{"0,30 9,17 * * *", monday, true},
+		{"30 9 * NOV *", monday, true},
+		{"30 9 * 12 *", monday, false},
+		{"30 9 15 * 1", monday, true},   // 日和星期都有限制时满足其一即可
+		{"30 9 15 * *", saturday, true}, // 只限制日
+		{"30 9 * * 7", time.Date(2025, 11, 16, 9, 30, 0, 0, time.Local), true},
+	}
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("解析 %q 失败: %v", tt.expr, err)
+		}
+		if got := cron.Matches(tt.time); got != tt.want {
+			t.Errorf("%q 匹配 %s 应为 %v，实际 %v", tt.expr, tt.time.Format("2006-01-02 15:04 Mon"), tt.want, got)
+		}
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "30 9 * *", "60 9 * * *", "30 24 * * *", "30 9 0 * *", "30 9 * * 8", "5-1 9 * * *", "*/0 9 * * *", "30 9 * * FUNDAY"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("%q 应解析失败", expr)
+		}
+	}
+}
+
+func TestLoadCalendar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	content := `{"holidays": ["2025-10-01~2025-10-08"], "workdays": ["2025-09-28", "2025-10-11"]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入日历文件失败: %v", err)
+	}
+
+	calendar, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("加载日历失败: %v", err)
+	}
+
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2025-09-26", true},  // 普通周五
+		{"2025-09-27", false}, // 普通周六
+		{"2025-09-28", true},  // 调休补班的周日
+		{"2025-10-03", false}, // 国庆假期中的周五
+		{"2025-10-08", false}, // 假期最后一天
+		{"2025-10-09", true},
+		{"2025-10-11", true}, // 调休补班的周六
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := calendar.IsWorkday(date); got != tt.want {
+			t.Errorf("%s 是否工作日应为 %v，实际 %v", tt.date, tt.want, got)
+		}
+	}
+
+	// 文件不存在时按周一至周五判断
+	calendar, err = LoadCalendar(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || !calendar.IsWorkday(time.Date(2025, 10, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("缺少日历文件时应只按星期判断: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"holidays": ["2025/10/01"]}`), 0644); err != nil {
+		t.Fatalf("写入日历文件失败: %v", err)
+	}
+	if _, err := LoadCalendar(path); err == nil {
+		t.Error("日期格式错误时应该失败")
+	}
+}
+
+func TestRulesFromConfig(t *testing.T) {
+	// 未配置规则时由 ReminderTime 生成默认规则
+	rules, err := RulesFromConfig(&model.Config{ReminderTime: "18:05", ReminderWorkdaysOnly: true})
+	if err != nil {
+		t.Fatalf("生成默认规则失败: %v", err)
+	}
+	saturday := time.Date(2025, 11, 15, 18, 5, 0, 0, time.Local)
+	friday := time.Date(2025, 11, 14, 18, 5, 0, 0, time.Local)
+	if len(rules) != 1 || rules[0].Name != DefaultRuleName || rules[0].Due(saturday, NewCalendar()) || !rules[0].Due(friday, NewCalendar()) {
+		t.Errorf("默认规则不正确: %+v", rules)
+	}
+
+	rules, err = RulesFromConfig(&model.Config{
+		ReminderTime: "10:00",
+		Reminders: []model.ReminderRule{
+			{Cron: "0 10 * * *"},
+			{Name: "urgent", Cron: "0 17 * * *", Channels: []string{"leader"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("生成提醒规则失败: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name != "reminder-1" || rules[1].Name != "urgent" || rules[1].Channels[0] != "leader" {
+		t.Errorf("提醒规则不正确: %+v", rules)
+	}
+
+	invalid := []*model.Config{
+		{ReminderTime: "1000"},
+		{Reminders: []model.ReminderRule{{Cron: "0 25 * * *"}}},
+		{Reminders: []model.ReminderRule{{Name: "a", Cron: "0 9 * * *"}, {Name: "a", Cron: "0 10 * * *"}}},
+	}
+	for i, config := range invalid {
+		if _, err := RulesFromConfig(config); err == nil {
+			t.Errorf("第 %d 个无效配置应该报错", i)
+		}
+	}
+}
+
+func TestRule_LastDueAndCutoff(t *testing.T) {
+	rules, err := RulesFromConfig(&model.Config{
+		ReminderTime:   "10:00",
+		ReminderCutoff: "20:00",
+		Reminders: []model.ReminderRule{
+			{Name: "morning", Cron: "0 10 * * *"},
+			{Name: "weekday", Cron: "30 9,14 * * *", WorkdaysOnly: true, Cutoff: "18:00"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("生成提醒规则失败: %v", err)
+	}
+	morning, weekday := rules[0], rules[1]
+
+	// 休眠到下午后仍能找到当天上午错过的触发时间
+	afternoon := time.Date(2025, 11, 14, 15, 20, 30, 0, time.Local)
+	if dueAt, ok := morning.LastDue(afternoon, NewCalendar()); !ok || dueAt.Format("15:04") != "10:00" {
+		t.Errorf("LastDue 应返回 10:00，实际: %v %v", dueAt, ok)
+	}
+	if dueAt, ok := weekday.LastDue(afternoon, NewCalendar()); !ok || dueAt.Format("15:04") != "14:30" {
+		t.Errorf("LastDue 应返回最近一次 14:30，实际: %v %v", dueAt, ok)
+	}
+	if _, ok := morning.LastDue(time.Date(2025, 11, 14, 9, 59, 0, 0, time.Local), NewCalendar()); ok {
+		t.Error("提醒时间之前不应有触发时间")
+	}
+	if _, ok := weekday.LastDue(time.Date(2025, 11, 15, 15, 0, 0, 0, time.Local), NewCalendar()); ok {
+		t.Error("仅工作日的规则在周六不应触发")
+	}
+
+	// 截止时间含该分钟本身
+	if got := morning.CutoffTime(afternoon).Format("15:04"); got != "20:01" {
+		t.Errorf("默认截止时间不正确: %s", got)
+	}
+	if got := weekday.CutoffTime(afternoon).Format("15:04"); got != "18:01" {
+		t.Errorf("规则截止时间不正确: %s", got)
+	}
+
+	if _, err := RulesFromConfig(&model.Config{ReminderTime: "10:00", ReminderCutoff: "24:00"}); err == nil {
+		t.Error("无效的截止时间应该报错")
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// attachmentGCGrace 最近添加的附件在这段时间内不会被清理，插入附件后日报可能还没有自动保存
+const attachmentGCGrace = time.Hour
+
+// AttachmentService 定义日报附件服务接口
+type AttachmentService interface {
+	// Add 将文件保存为指定日期的附件，返回附件和插入日报的 Markdown 链接
+	Add(date time.Time, name string, data []byte) (*repository.Attachment, string, error)
+
+	// AddFile 将本机文件保存为指定日期的附件
+	AddFile(date time.Time, filePath string) (*repository.Attachment, string, error)
+
+	// Path 返回附件链接对应的本机文件路径
+	Path(link string) (string, error)
+
+	// List 列出指定日期的附件
+	List(date time.Time) ([]*repository.Attachment, error)
+
+	// Delete 删除附件，并从所属日期的日报中删除对它的引用
+	Delete(link string) error
+
+	// CollectGarbage 删除没有被任何日报引用的附件，dryRun 为 true 时只返回将被删除的附件
+	CollectGarbage(dryRun bool) ([]*repository.Attachment, error)
+}
+
+// AttachmentServiceImpl 附件服务实现
+type AttachmentServiceImpl struct {
+	attachmentRepo repository.AttachmentRepository
+	taskService    TaskService
+	syncService    SyncService // 可选，设置后添加和删除附件都会提交到同步仓库
+	now            func() time.Time
+}
+
+// NewAttachmentService 创建新的附件服务
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, taskService TaskService) *AttachmentServiceImpl {
+	return &AttachmentServiceImpl{
+		attachmentRepo: attachmentRepo,
+		taskService:    taskService,
+		now:            time.Now,
+	}
+}
+
+// NewAttachmentServiceFromConfig 按配置创建附件服务
+// 附件以明文保存在本机数据目录中，启用加密或使用团队服务器存储时不支持附件，返回 nil
+func NewAttachmentServiceFromConfig(config *model.Config, dataPath string, taskService TaskService) *AttachmentServiceImpl {
+	if config.Encryption != "" || config.StorageBackend == model.StorageBackendTeam {
+		return nil
+	}
+	return NewAttachmentService(repository.NewFileAttachmentRepository(dataPath), taskService)
+}
+
+// SetSyncService 设置同步服务，添加和删除附件后提交修改
+func (s *AttachmentServiceImpl) SetSyncService(syncService SyncService) {
+	s.syncService = syncService
+}
+
+// Add 将文件保存为指定日期的附件
+func (s *AttachmentServiceImpl) Add(date time.Time, name string, data []byte) (*repository.Attachment, string, error) {
+	attachment, err := s.attachmentRepo.Save(date, name, data)
+	if err != nil {
+		return nil, "", err
+	}
+	s.notifySync(date)
+	return attachment, util.AttachmentMarkdown(attachment.Link), nil
+}
+
+// AddFile 读取本机文件并保存为指定日期的附件
+func (s *AttachmentServiceImpl) AddFile(date time.Time, filePath string) (*repository.Attachment, string, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("读取文件失败: %w", err)
+	}
+	if info.IsDir() {
+		return nil, "", fmt.Errorf("%s 是目录，只能添加文件", filePath)
+	}
+	if info.Size() > repository.MaxAttachmentSize {
+		return nil, "", fmt.Errorf("附件 %s 超过 %d MB 的大小上限", filepath.Base(filePath), repository.MaxAttachmentSize>>20)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("读取文件失败: %w", err)
+	}
+	return s.Add(date, filepath.Base(filePath), data)
+}
+
+// Path 返回附件链接对应的本机文件路径
+func (s *AttachmentServiceImpl) Path(link string) (string, error) {
+	return s.attachmentRepo.Path(link)
+}
+
+// List 列出指定日期的附件
+func (s *AttachmentServiceImpl) List(date time.Time) ([]*repository.Attachment, error) {
+	return s.attachmentRepo.List(date)
+}
+
+// Delete 删除附件，并从所属日期的日报中删除引用该附件的图片和链接
+func (s *AttachmentServiceImpl) Delete(link string) error {
+	filePath, err := s.attachmentRepo.Path(link)
+	if err != nil {
+		return err
+	}
+	date, err := time.ParseInLocation("2006-01-02", filepath.Base(filepath.Dir(filePath)), time.Local)
+	if err != nil {
+		return fmt.Errorf("无效的附件链接: %s", link)
+	}
+
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	if task != nil {
+		if content := util.RemoveAttachmentReferences(task.Content, link); content != task.Content {
+			if err := s.taskService.SaveTask(date, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.attachmentRepo.Delete(link); err != nil {
+		return err
+	}
+	s.notifySync(date)
+	return nil
+}
+
+// CollectGarbage 删除没有被任何日报引用的附件
+// 日报可能引用其他日期的附件（如顺延的事项），因此按所有日报中的链接判断；最近一小时内添加的附件不清理
+func (s *AttachmentServiceImpl) CollectGarbage(dryRun bool) ([]*repository.Attachment, error) {
+	attachments, err := s.attachmentRepo.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := s.taskService.GetTasksInRange(time.Date(1, 1, 1, 0, 0, 0, 0, time.Local), time.Date(9999, 12, 31, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		return nil, fmt.Errorf("读取日报失败: %w", err)
+	}
+	referenced := make(map[string]bool)
+	for _, task := range tasks {
+		for _, link := range util.AttachmentLinks(task.Content) {
+			referenced[link] = true
+		}
+	}
+
+	cutoff := s.now().Add(-attachmentGCGrace)
+	var orphans []*repository.Attachment
+	for _, attachment := range attachments {
+		if referenced[attachment.Link] || attachment.ModTime.After(cutoff) {
+			continue
+		}
+		orphans = append(orphans, attachment)
+	}
+	if dryRun || len(orphans) == 0 {
+		return orphans, nil
+	}
+
+	for _, attachment := range orphans {
+		if err := s.attachmentRepo.Delete(attachment.Link); err != nil {
+			return nil, err
+		}
+	}
+	util.Info("已清理 %d 个未被引用的附件", len(orphans))
+	s.notifySync(orphans[0].Date)
+	return orphans, nil
+}
+
+// notifySync 通知同步服务提交附件的修改
+func (s *AttachmentServiceImpl) notifySync(date time.Time) {
+	if s.syncService != nil {
+		s.syncService.NotifySaved(date)
+	}
+}
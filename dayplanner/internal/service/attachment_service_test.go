@@ -0,0 +1,77 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+)
+
+func TestAttachmentService(t *testing.T) {
+	dataPath := t.TempDir()
+	taskService := NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	attachmentService := NewAttachmentService(repository.NewFileAttachmentRepository(dataPath), taskService)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	source := filepath.Join(t.TempDir(), "需求 文档.pdf")
+	if err := os.WriteFile(source, []byte("pdf"), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	doc, docMarkdown, err := attachmentService.AddFile(monday, source)
+	if err != nil {
+		t.Fatalf("添加文件失败: %v", err)
+	}
+	if !strings.HasPrefix(docMarkdown, "[需求-文档-") {
+		t.Errorf("文件应插入普通链接: %s", docMarkdown)
+	}
+	image, imageMarkdown, err := attachmentService.Add(monday, "截图.png", []byte("png"))
+	if err != nil {
+		t.Fatalf("添加图片失败: %v", err)
+	}
+	orphan, _, err := attachmentService.Add(monday, "未引用.png", []byte("orphan"))
+	if err != nil {
+		t.Fatalf("添加图片失败: %v", err)
+	}
+	if _, _, err := attachmentService.AddFile(monday, filepath.Dir(source)); err == nil {
+		t.Error("添加目录应返回错误")
+	}
+
+	if err := taskService.SaveTask(monday, "- 页面改版\n- "+imageMarkdown+"\n- 需求 "+docMarkdown); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+
+	// 刚添加的附件不清理，超过一小时后清理未引用的附件
+	orphans, err := attachmentService.CollectGarbage(true)
+	if err != nil || len(orphans) != 0 {
+		t.Errorf("刚添加的附件不应清理: %v, %v", orphans, err)
+	}
+	attachmentService.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	orphans, err = attachmentService.CollectGarbage(true)
+	if err != nil || len(orphans) != 1 || orphans[0].Link != orphan.Link {
+		t.Fatalf("应找到一个未引用的附件: %v, %v", orphans, err)
+	}
+	if list, _ := attachmentService.List(monday); len(list) != 3 {
+		t.Errorf("试运行不应删除附件，实际剩余 %d 个", len(list))
+	}
+	if _, err := attachmentService.CollectGarbage(false); err != nil {
+		t.Fatalf("清理附件失败: %v", err)
+	}
+	if list, _ := attachmentService.List(monday); len(list) != 2 {
+		t.Errorf("应删除未引用的附件，实际剩余 %d 个", len(list))
+	}
+
+	// 删除附件时一并删除日报中的引用
+	if err := attachmentService.Delete(image.Link); err != nil {
+		t.Fatalf("删除附件失败: %v", err)
+	}
+	task, _ := taskService.GetTask(monday)
+	if task.Content != "- 页面改版\n- 需求 "+docMarkdown {
+		t.Errorf("日报中的图片引用应删除:\n%s", task.Content)
+	}
+	if path, _ := attachmentService.Path(doc.Link); path == "" {
+		t.Error("附件路径不应为空")
+	}
+}
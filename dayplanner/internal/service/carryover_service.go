@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/schedule"
+	"daily-report-tool/internal/util"
+)
+
+// carryOverLookbackDays 查找上一个工作日日报时最多向前查找的天数
+const carryOverLookbackDays = 31
+
+// CarryOverCandidate 可以顺延到某天的未完成任务项
+type CarryOverCandidate struct {
+	From  time.Time // 任务项所在的日报日期
+	Items []string  // 未勾选的任务项（复选框之后的原始 Markdown）
+}
+
+// CarryOverService 定义未完成任务项顺延服务接口
+type CarryOverService interface {
+	// FindUnfinished 查找 date 之前最近一个有日报的工作日中，尚未顺延到 date 的未完成任务项
+	// 没有可顺延的任务项时返回 nil
+	FindUnfinished(date time.Time) (*CarryOverCandidate, error)
+
+	// CarryOver 将 from 日报中的任务项追加到 date 的日报，并在两篇日报中记录顺延
+	// date 中已存在的相同任务项不会重复追加，但同样记录为已顺延
+	CarryOver(date, from time.Time, items []string) error
+}
+
+// CarryOverServiceImpl 顺延服务实现
+type CarryOverServiceImpl struct {
+	taskService   TaskService
+	taskRepo      repository.TaskRepository
+	configService ConfigService
+}
+
+// NewCarryOverService 创建新的顺延服务
+func NewCarryOverService(taskService TaskService, taskRepo repository.TaskRepository, configService ConfigService) *CarryOverServiceImpl {
+	return &CarryOverServiceImpl{
+		taskService:   taskService,
+		taskRepo:      taskRepo,
+		configService: configService,
+	}
+}
+
+// FindUnfinished 查找 date 之前最近一个有日报的工作日中，尚未顺延到 date 的未完成任务项
+// 工作日按配置的节假日日历判断，跳过周末、节假日以及没有写日报的工作日
+func (s *CarryOverServiceImpl) FindUnfinished(date time.Time) (*CarryOverCandidate, error) {
+	calendar, err := s.loadCalendar()
+	if err != nil {
+		return nil, err
+	}
+
+	day := util.StartOfDay(date)
+	for i := 1; i <= carryOverLookbackDays; i++ {
+		previous := day.AddDate(0, 0, -i)
+		if !calendar.IsWorkday(previous) {
+			continue
+		}
+
+		task, err := s.taskService.GetTask(previous)
+		if err != nil {
+			return nil, fmt.Errorf("获取上一个工作日的日报失败: %w", err)
+		}
+		if task == nil || strings.TrimSpace(task.Content) == "" {
+			continue
+		}
+
+		// 排除已经顺延到 date 的任务项
+		carried := make(map[string]bool)
+		for _, item := range task.CarriedItems(day.Format("2006-01-02")) {
+			carried[normalizeItemText(item)] = true
+		}
+
+		candidate := &CarryOverCandidate{From: previous}
+		for _, item := range util.ParseChecklist(task.Content) {
+			key := normalizeItemText(item.Raw)
+			if item.Checked || key == "" || carried[key] {
+				continue
+			}
+			carried[key] = true
+			candidate.Items = append(candidate.Items, item.Raw)
+		}
+
+		if len(candidate.Items) == 0 {
+			return nil, nil
+		}
+		util.Debug("找到可顺延的任务项: %s -> %s, %d 项", previous.Format("2006-01-02"), day.Format("2006-01-02"), len(candidate.Items))
+		return candidate, nil
+	}
+	return nil, nil
+}
+
+// CarryOver 将 from 日报中的任务项追加到 date 的日报，并在两篇日报中记录顺延
+// date 中已存在的相同任务项不会重复追加，但同样记录为已顺延
+func (s *CarryOverServiceImpl) CarryOver(date, from time.Time, items []string) error {
+	if len(items) == 0 {
+		return nil
+	}
+	dateKey := date.Format("2006-01-02")
+	fromKey := from.Format("2006-01-02")
+	util.Info("顺延未完成任务项: %s -> %s, %d 项", fromKey, dateKey, len(items))
+
+	source, err := s.taskRepo.GetByDate(from)
+	if err != nil {
+		return fmt.Errorf("获取来源日报失败: %w", err)
+	}
+	if source == nil {
+		return fmt.Errorf("来源日报不存在: %s", fromKey)
+	}
+
+	// 追加 date 中还没有的任务项
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return err
+	}
+	content := ""
+	if task != nil {
+		content = task.Content
+	}
+	existing := make(map[string]bool)
+	for _, item := range util.ParseChecklist(content) {
+		existing[normalizeItemText(item.Raw)] = true
+	}
+
+	var lines []string
+	for _, item := range items {
+		key := normalizeItemText(item)
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		lines = append(lines, "- [ ] "+item)
+	}
+	if len(lines) > 0 {
+		if strings.TrimSpace(content) != "" {
+			content = strings.TrimRight(content, "\r\n") + "\n"
+		}
+		content += strings.Join(lines, "\n") + "\n"
+		if err := s.taskService.SaveTask(date, content); err != nil {
+			return err
+		}
+	}
+
+	// 在两篇日报中记录顺延
+	now := time.Now()
+	target, err := s.taskRepo.GetByDate(date)
+	if err != nil {
+		return fmt.Errorf("获取目标日报失败: %w", err)
+	}
+	if target == nil {
+		// 所有任务项都已存在且目标日报为空时，仍然创建日报以记录顺延
+		target = &model.Task{Date: date, CreatedAt: now}
+	}
+	target.CarriedFrom = append(target.CarriedFrom, model.CarryOver{Date: fromKey, Items: items, CarriedAt: now})
+	target.UpdatedAt = now
+	if err := s.taskRepo.Save(target); err != nil {
+		return fmt.Errorf("记录顺延失败: %w", err)
+	}
+
+	source.CarriedTo = append(source.CarriedTo, model.CarryOver{Date: dateKey, Items: items, CarriedAt: now})
+	source.UpdatedAt = now
+	if err := s.taskRepo.Save(source); err != nil {
+		return fmt.Errorf("记录顺延失败: %w", err)
+	}
+
+	util.Info("顺延完成: 追加 %d 项，%d 项已存在", len(lines), len(items)-len(lines))
+	return nil
+}
+
+// loadCalendar 按配置加载节假日日历，未配置时按周一至周五判断工作日
+func (s *CarryOverServiceImpl) loadCalendar() (*schedule.Calendar, error) {
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	calendar, err := schedule.LoadCalendar(config.HolidayFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载节假日日历失败: %w", err)
+	}
+	return calendar, nil
+}
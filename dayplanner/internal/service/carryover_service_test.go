@@ -0,0 +1,101 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestCarryOverService(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	taskService := NewTaskService(taskRepo, tempDir)
+	configService := &mockConfigService{config: &model.Config{ReminderTime: "10:00"}}
+	carryOverService := NewCarryOverService(taskService, taskRepo, configService)
+
+	// 周四有日报，周五没有写，周一应顺延周四的未完成任务项
+	thursday := time.Date(2025, 11, 13, 0, 0, 0, 0, time.Local)
+	monday := time.Date(2025, 11, 17, 0, 0, 0, 0, time.Local)
+	if err := taskService.SaveTask(thursday, "- [x] 完成评审\n- [ ] 修复 **登录** 问题\n  - [ ] 补充测试\n- [ ] 编写文档"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if err := taskService.SaveTask(monday, "# 周一\n\n- [ ] 编写文档"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	candidate, err := carryOverService.FindUnfinished(monday)
+	if err != nil {
+		t.Fatalf("查找未完成任务项失败: %v", err)
+	}
+	wantItems := []string{"修复 **登录** 问题", "补充测试", "编写文档"}
+	if candidate == nil || !candidate.From.Equal(thursday) || !reflect.DeepEqual(candidate.Items, wantItems) {
+		t.Fatalf("未完成任务项不正确: %+v", candidate)
+	}
+
+	if err := carryOverService.CarryOver(monday, candidate.From, candidate.Items); err != nil {
+		t.Fatalf("顺延失败: %v", err)
+	}
+
+	// 今天已有的任务项不重复追加
+	target, _ := taskService.GetTask(monday)
+	if want := "# 周一\n\n- [ ] 编写文档\n- [ ] 修复 **登录** 问题\n- [ ] 补充测试\n"; target.Content != want {
+		t.Errorf("顺延后的内容不正确:\n%q", target.Content)
+	}
+	if len(target.CarriedFrom) != 1 || target.CarriedFrom[0].Date != "2025-11-13" || len(target.CarriedFrom[0].Items) != 3 {
+		t.Errorf("目标日报应记录顺延来源: %+v", target.CarriedFrom)
+	}
+	source, _ := taskService.GetTask(thursday)
+	if !reflect.DeepEqual(source.CarriedItems("2025-11-17"), wantItems) {
+		t.Errorf("来源日报应记录顺延目标: %+v", source.CarriedTo)
+	}
+	if strings.Contains(source.Content, "顺延") {
+		t.Errorf("来源日报内容不应被修改: %q", source.Content)
+	}
+
+	// 已顺延的任务项不再提示
+	if candidate, err := carryOverService.FindUnfinished(monday); err != nil || candidate != nil {
+		t.Errorf("已顺延后不应再有可顺延的任务项: %+v, %v", candidate, err)
+	}
+
+	// 修改内容后仍保留顺延记录
+	if err := taskService.SaveTask(monday, "- [x] 编写文档"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if target, _ := taskService.GetTask(monday); len(target.CarriedFrom) != 1 {
+		t.Errorf("保存内容后应保留顺延记录: %+v", target)
+	}
+}
+
+func TestCarryOverService_Holidays(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	taskService := NewTaskService(taskRepo, tempDir)
+	holidayFile := filepath.Join(tempDir, "holidays.json")
+	if err := os.WriteFile(holidayFile, []byte(`{"holidays": ["2025-10-01~2025-10-08"], "workdays": ["2025-09-28"]}`), 0644); err != nil {
+		t.Fatalf("写入节假日日历失败: %v", err)
+	}
+	configService := &mockConfigService{config: &model.Config{HolidayFile: holidayFile}}
+	carryOverService := NewCarryOverService(taskService, taskRepo, configService)
+
+	// 假期中写的日报不算工作日，应找到节前补班日（周日）的日报
+	for date, content := range map[int]string{928: "- [ ] 节前未完成", 1003: "- [ ] 假期记录"} {
+		day := time.Date(2025, time.Month(date/100), date%100, 0, 0, 0, 0, time.Local)
+		if err := taskService.SaveTask(day, content); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	candidate, err := carryOverService.FindUnfinished(time.Date(2025, 10, 9, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("查找未完成任务项失败: %v", err)
+	}
+	if candidate == nil || candidate.From.Format("2006-01-02") != "2025-09-28" || candidate.Items[0] != "节前未完成" {
+		t.Errorf("应跳过节假日找到补班日的日报: %+v", candidate)
+	}
+}
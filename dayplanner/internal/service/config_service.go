@@ -6,8 +6,12 @@ import (
 	"regexp"
 	"strings"
 
+	"daily-report-tool/internal/davclient"
 	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/notifier"
 	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/schedule"
+	"daily-report-tool/internal/teamclient"
 	"daily-report-tool/internal/util"
 )
 
@@ -69,6 +73,55 @@ func (s *ConfigServiceImpl) UpdateConfig(config *model.Config) error {
 	}
 	util.Debug("提醒时间格式验证通过")
 
+	// 验证存储后端
+	if err := s.validateStorageBackend(config); err != nil {
+		util.Warn("存储后端验证失败: %v", err)
+		return err
+	}
+
+	// 验证加密配置
+	if err := s.validateEncryption(config.Encryption); err != nil {
+		util.Warn("加密配置验证失败: %v", err)
+		return err
+	}
+
+	// 验证同步配置
+	if err := s.validateSync(config); err != nil {
+		util.Warn("同步配置验证失败: %v", err)
+		return err
+	}
+
+	// 验证团队成员
+	if err := s.validateTeamMembers(config.TeamMembers); err != nil {
+		util.Warn("团队成员配置验证失败: %v", err)
+		return err
+	}
+
+	// 验证预览主题
+	if err := s.validatePreviewTheme(config.PreviewTheme); err != nil {
+		util.Warn("预览主题验证失败: %v", err)
+		return err
+	}
+
+	// 验证 HTTP API 端口
+	if config.APIPort < 0 || config.APIPort > 65535 {
+		util.Warn("HTTP API 端口无效: %d", config.APIPort)
+		return fmt.Errorf("无效的 HTTP API 端口: %d", config.APIPort)
+	}
+
+	// 验证通知渠道
+	notifiers, err := notifier.FromConfig(config)
+	if err != nil {
+		util.Warn("通知渠道验证失败: %v", err)
+		return err
+	}
+
+	// 验证提醒规则
+	if err := s.validateReminders(config, notifiers); err != nil {
+		util.Warn("提醒规则验证失败: %v", err)
+		return err
+	}
+
 	if err := s.configRepo.Save(config); err != nil {
 		util.Error("保存配置失败: %v", err)
 		return fmt.Errorf("更新配置失败: %w", err)
@@ -138,3 +191,127 @@ func (s *ConfigServiceImpl) validateReminderTime(reminderTime string) error {
 
 	return nil
 }
+
+// validateStorageBackend 验证存储后端配置，团队服务器存储需要服务器地址和访问令牌，且不能与加密同时使用
+func (s *ConfigServiceImpl) validateStorageBackend(config *model.Config) error {
+	switch config.StorageBackend {
+	case "", model.StorageBackendFile, model.StorageBackendSQLite:
+		return nil
+	case model.StorageBackendTeam:
+		if _, err := teamclient.NewClient(config.TeamServer, config.TeamToken); err != nil {
+			return err
+		}
+		if config.Encryption != "" {
+			return fmt.Errorf("团队服务器存储不支持加密，组长需要读取成员的日报")
+		}
+		return nil
+	default:
+		return fmt.Errorf("不支持的存储后端: %s (可选值: %s, %s, %s)",
+			config.StorageBackend, model.StorageBackendFile, model.StorageBackendSQLite, model.StorageBackendTeam)
+	}
+}
+
+// validateEncryption 验证任务加密的密钥来源
+func (s *ConfigServiceImpl) validateEncryption(encryption string) error {
+	switch encryption {
+	case "", model.EncryptionPassphrase, model.EncryptionKeyring:
+		return nil
+	default:
+		return fmt.Errorf("不支持的加密方式: %s (可选值: %s, %s)",
+			encryption, model.EncryptionPassphrase, model.EncryptionKeyring)
+	}
+}
+
+// validatePreviewTheme 验证预览主题
+func (s *ConfigServiceImpl) validatePreviewTheme(previewTheme string) error {
+	switch previewTheme {
+	case "", model.PreviewThemeLight, model.PreviewThemeDark:
+		return nil
+	default:
+		return fmt.Errorf("不支持的预览主题: %s (可选值: %s, %s)",
+			previewTheme, model.PreviewThemeLight, model.PreviewThemeDark)
+	}
+}
+
+// validateSync 验证任务数据同步配置，git 和 WebDAV 同步都只支持文件存储后端
+func (s *ConfigServiceImpl) validateSync(config *model.Config) error {
+	switch config.SyncBackend {
+	case "":
+		return nil
+	case model.SyncBackendGit, model.SyncBackendWebDAV:
+		if config.StorageBackend != "" && config.StorageBackend != model.StorageBackendFile {
+			return fmt.Errorf("%s 同步只支持文件存储后端", config.SyncBackend)
+		}
+	default:
+		return fmt.Errorf("不支持的同步方式: %s (可选值: %s, %s)",
+			config.SyncBackend, model.SyncBackendGit, model.SyncBackendWebDAV)
+	}
+	if strings.TrimSpace(config.SyncRemote) == "" {
+		return fmt.Errorf("启用同步时必须配置远程仓库地址 (sync_remote)")
+	}
+	if config.SyncBackend == model.SyncBackendWebDAV {
+		if _, err := davclient.NewClient(config.SyncRemote, "", ""); err != nil {
+			return err
+		}
+	}
+	if config.SyncInterval < 0 {
+		return fmt.Errorf("同步间隔不能为负数")
+	}
+	return nil
+}
+
+// validateTeamMembers 验证团队服务器的成员列表：成员名有效，成员名和令牌都不能重复
+func (s *ConfigServiceImpl) validateTeamMembers(members []model.TeamMember) error {
+	names := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, member := range members {
+		if !model.ValidMemberName(member.Name) {
+			return fmt.Errorf("无效的团队成员名: %q (只能包含字母、数字、下划线、连字符和点)", member.Name)
+		}
+		if names[member.Name] {
+			return fmt.Errorf("团队成员名重复: %s", member.Name)
+		}
+		names[member.Name] = true
+		if member.Token == "" {
+			return fmt.Errorf("团队成员 %s 缺少访问令牌", member.Name)
+		}
+		if tokens[member.Token] {
+			return fmt.Errorf("团队成员 %s 的访问令牌与其他成员重复", member.Name)
+		}
+		tokens[member.Token] = true
+	}
+	return nil
+}
+
+// validateReminders 验证提醒规则、规则引用的渠道以及节假日日历文件
+func (s *ConfigServiceImpl) validateReminders(config *model.Config, notifiers notifier.Multi) error {
+	rules, err := schedule.RulesFromConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// 规则引用的渠道必须存在（已停用的渠道也允许引用）
+	channelNames := make(map[string]bool)
+	for _, n := range notifiers {
+		channelNames[n.Name()] = true
+	}
+	for _, channel := range config.Channels {
+		if channel.Name != "" {
+			channelNames[channel.Name] = true
+		} else {
+			channelNames[channel.Type] = true
+		}
+	}
+	for _, rule := range rules {
+		for _, name := range rule.Channels {
+			if !channelNames[name] {
+				return fmt.Errorf("提醒规则 %s 引用了不存在的通知渠道: %s", rule.Name, name)
+			}
+		}
+	}
+
+	if _, err := schedule.LoadCalendar(config.HolidayFile); err != nil {
+		return err
+	}
+	return nil
+}
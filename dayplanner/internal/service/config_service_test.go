@@ -212,3 +212,179 @@ func TestConfigService_ValidateReminderTime(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigService_ValidateStorageBackend(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+
+	configRepo := repository.NewFileConfigRepository(configPath)
+	configService := NewConfigService(configRepo)
+
+	tests := []struct {
+		name        string
+		backend     string
+		expectError bool
+	}{
+		{name: "未设置使用默认文件后端", backend: "", expectError: false},
+		{name: "文件后端", backend: model.StorageBackendFile, expectError: false},
+		{name: "SQLite 后端", backend: model.StorageBackendSQLite, expectError: false},
+		{name: "不支持的后端", backend: "mysql", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &model.Config{
+				ReminderTime:   "10:00",
+				DataPath:       "./data/tasks",
+				StorageBackend: tt.backend,
+			}
+
+			err := configService.UpdateConfig(config)
+			if tt.expectError && err == nil {
+				t.Errorf("期望验证失败，但成功了")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("期望验证成功，但失败了: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidateSync(t *testing.T) {
+	configService := NewConfigService(repository.NewFileConfigRepository(filepath.Join(t.TempDir(), "config.json")))
+
+	tests := []struct {
+		name        string
+		config      model.Config
+		expectError bool
+	}{
+		{name: "未启用同步", config: model.Config{}, expectError: false},
+		{name: "git 同步", config: model.Config{SyncBackend: model.SyncBackendGit, SyncRemote: "/srv/reports.git"}, expectError: false},
+		{name: "WebDAV 同步", config: model.Config{SyncBackend: model.SyncBackendWebDAV, SyncRemote: "https://nas.example.com/dav/reports/"}, expectError: false},
+		{name: "WebDAV 地址无效", config: model.Config{SyncBackend: model.SyncBackendWebDAV, SyncRemote: "nas/dav"}, expectError: true},
+		{name: "缺少远程地址", config: model.Config{SyncBackend: model.SyncBackendWebDAV}, expectError: true},
+		{name: "SQLite 后端不支持同步", config: model.Config{StorageBackend: model.StorageBackendSQLite, SyncBackend: model.SyncBackendWebDAV, SyncRemote: "https://nas.example.com/dav/"}, expectError: true},
+		{name: "不支持的同步方式", config: model.Config{SyncBackend: "ftp", SyncRemote: "ftp://nas"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.ReminderTime = "10:00"
+			config.DataPath = "./data/tasks"
+
+			err := configService.UpdateConfig(&config)
+			if tt.expectError && err == nil {
+				t.Errorf("期望验证失败，但成功了")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("期望验证成功，但失败了: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidatePreviewTheme(t *testing.T) {
+	configService := NewConfigService(repository.NewFileConfigRepository(filepath.Join(t.TempDir(), "config.json")))
+
+	for previewTheme, expectError := range map[string]bool{
+		"":                      false,
+		model.PreviewThemeLight: false,
+		model.PreviewThemeDark:  false,
+		"solarized":             true,
+	} {
+		config := &model.Config{ReminderTime: "10:00", DataPath: "./data/tasks", PreviewTheme: previewTheme}
+		if err := configService.UpdateConfig(config); (err != nil) != expectError {
+			t.Errorf("预览主题 %q 验证结果错误: %v", previewTheme, err)
+		}
+	}
+}
+
+func TestConfigService_ValidateChannels(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+
+	configRepo := repository.NewFileConfigRepository(configPath)
+	configService := NewConfigService(configRepo)
+
+	tests := []struct {
+		name        string
+		channels    []model.ChannelConfig
+		expectError bool
+	}{
+		{name: "未配置渠道", channels: nil, expectError: false},
+		{
+			name: "钉钉和邮件渠道",
+			channels: []model.ChannelConfig{
+				{Name: "钉钉", Type: model.ChannelTypeDingTalk, URL: "https://oapi.dingtalk.com/robot/send?access_token=x", Secret: "SEC"},
+				{Name: "邮件", Type: model.ChannelTypeEmail, SMTPHost: "smtp.example.com", Username: "bot@example.com", To: []string{"a@example.com"}},
+			},
+			expectError: false,
+		},
+		{name: "缺少地址", channels: []model.ChannelConfig{{Name: "飞书", Type: model.ChannelTypeFeishu}}, expectError: true},
+		{name: "未知类型", channels: []model.ChannelConfig{{Name: "x", Type: "sms"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &model.Config{
+				ReminderTime: "10:00",
+				DataPath:     "./data/tasks",
+				Channels:     tt.channels,
+			}
+
+			err := configService.UpdateConfig(config)
+			if tt.expectError && err == nil {
+				t.Errorf("期望验证失败，但成功了")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("期望验证成功，但失败了: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidateReminders(t *testing.T) {
+	tempDir := t.TempDir()
+	configService := NewConfigService(repository.NewFileConfigRepository(filepath.Join(tempDir, "config.json")))
+
+	channels := []model.ChannelConfig{
+		{Name: "team", Type: model.ChannelTypeSlack, URL: "https://hooks.slack.com/services/x"},
+		{Name: "leader", Type: model.ChannelTypeSlack, URL: "https://hooks.slack.com/services/y", Disabled: true},
+	}
+
+	tests := []struct {
+		name        string
+		reminders   []model.ReminderRule
+		holidayFile string
+		expectError bool
+	}{
+		{name: "工作日提醒加升级", reminders: []model.ReminderRule{
+			{Cron: "0 10 * * 1-5", WorkdaysOnly: true},
+			{Name: "urgent", Cron: "0 17 * * 1-5", Channels: []string{"leader"}},
+		}, expectError: false},
+		{name: "无效的 cron", reminders: []model.ReminderRule{{Cron: "0 10 * *"}}, expectError: true},
+		{name: "不存在的渠道", reminders: []model.ReminderRule{{Cron: "0 10 * * *", Channels: []string{"boss"}}}, expectError: true},
+		{name: "不存在的日历文件", holidayFile: filepath.Join(tempDir, "missing.json"), expectError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &model.Config{
+				ReminderTime: "10:00",
+				DataPath:     "./data/tasks",
+				Channels:     channels,
+				Reminders:    tt.reminders,
+				HolidayFile:  tt.holidayFile,
+			}
+
+			err := configService.UpdateConfig(config)
+			if tt.expectError && err == nil {
+				t.Errorf("期望验证失败，但成功了")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("期望验证成功，但失败了: %v", err)
+			}
+		})
+	}
+}
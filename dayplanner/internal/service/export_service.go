@@ -0,0 +1,272 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/export"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// exportSummaryLength 静态网站月份列表中摘要的最大字数
+const exportSummaryLength = 60
+
+// ExportService 定义日报导出服务接口
+type ExportService interface {
+	// Render 将日期范围内（含首尾）的日报渲染为单文件格式（html、pdf、docx）写入 w
+	Render(w io.Writer, format export.Format, startDate, endDate time.Time) error
+
+	// Export 将日期范围内（含首尾）的日报导出到 output：单文件格式写入文件，site 格式写入目录
+	Export(format export.Format, startDate, endDate time.Time, output string) error
+}
+
+// ExportServiceImpl 导出服务实现，所有格式都基于 Markdown 渲染管线
+type ExportServiceImpl struct {
+	taskService    TaskService
+	attachmentRepo repository.AttachmentRepository // 可选，设置后导出内容包含日报附件
+}
+
+// NewExportService 创建新的导出服务
+func NewExportService(taskService TaskService) *ExportServiceImpl {
+	return &ExportServiceImpl{
+		taskService: taskService,
+	}
+}
+
+// SetAttachmentRepository 设置附件仓库：HTML 内嵌图片附件，静态网站复制引用的附件
+// PDF 和 Word 不支持图片，图片以文件名显示
+func (s *ExportServiceImpl) SetAttachmentRepository(attachmentRepo repository.AttachmentRepository) {
+	s.attachmentRepo = attachmentRepo
+}
+
+// ExportTitle 返回导出文档的标题：单日为 "2025-11-10 星期一 日报"，多日为 "日报 2025-11-01 ~ 2025-11-30"
+func ExportTitle(startDate, endDate time.Time) string {
+	if sameDay(startDate, endDate) {
+		return fmt.Sprintf("%s %s 日报", startDate.Format("2006-01-02"), util.ChineseWeekday(startDate))
+	}
+	return fmt.Sprintf("日报 %s ~ %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+}
+
+// ExportFileName 返回默认的导出文件名（site 格式为目录名），如 report-2025-11-10.pdf
+func ExportFileName(format export.Format, startDate, endDate time.Time) string {
+	name := "report-" + startDate.Format("2006-01-02")
+	if !sameDay(startDate, endDate) {
+		name += "_" + endDate.Format("2006-01-02")
+	}
+	return name + format.Extension()
+}
+
+// Render 将日期范围内（含首尾）的日报渲染为单文件格式写入 w
+func (s *ExportServiceImpl) Render(w io.Writer, format export.Format, startDate, endDate time.Time) error {
+	if format == export.FormatSite {
+		return fmt.Errorf("静态网站需要导出到目录")
+	}
+
+	tasks, err := s.loadTasks(startDate, endDate)
+	if err != nil {
+		return err
+	}
+	title := ExportTitle(startDate, endDate)
+	markdown := combineTasks(tasks, sameDay(startDate, endDate))
+
+	switch format {
+	case export.FormatHTML:
+		body, err := util.MarkdownToHTML("# " + title + "\n\n" + s.embedImages(markdown))
+		if err != nil {
+			return fmt.Errorf("渲染 Markdown 失败: %w", err)
+		}
+		return export.WriteHTML(w, title, body)
+	case export.FormatPDF:
+		return export.WritePDF(w, title, export.ParseBlocks(markdown))
+	case export.FormatDOCX:
+		return export.WriteDOCX(w, title, export.ParseBlocks(markdown))
+	}
+	return fmt.Errorf("不支持的导出格式: %s", format)
+}
+
+// Export 将日期范围内（含首尾）的日报导出到 output
+// 单文件格式先在内存中渲染，成功后才写入文件，避免失败时留下不完整的文件
+func (s *ExportServiceImpl) Export(format export.Format, startDate, endDate time.Time, output string) error {
+	util.Info("导出日报: %s (%s ~ %s) -> %s", format, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), output)
+
+	if format == export.FormatSite {
+		return s.exportSite(startDate, endDate, output)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Render(&buf, format, startDate, endDate); err != nil {
+		util.Error("导出日报失败: %v", err)
+		return err
+	}
+	if dir := filepath.Dir(output); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建导出目录失败: %w", err)
+		}
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		util.Error("写入导出文件失败: %v", err)
+		return fmt.Errorf("写入导出文件失败: %w", err)
+	}
+
+	util.Info("导出完成: %s (%d 字节)", output, buf.Len())
+	return nil
+}
+
+// exportSite 将每天的日报分别渲染为静态网站页面
+func (s *ExportServiceImpl) exportSite(startDate, endDate time.Time, dir string) error {
+	tasks, err := s.loadTasks(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	pages := make([]export.SitePage, 0, len(tasks))
+	for _, task := range tasks {
+		// 页面标题占用一级标题
+		body, err := util.MarkdownToHTML(demoteHeadings(strings.TrimSpace(task.Content), 1))
+		if err != nil {
+			return fmt.Errorf("渲染 %s 的日报失败: %w", task.Date.Format("2006-01-02"), err)
+		}
+		pages = append(pages, export.SitePage{
+			Date:    task.Date,
+			Summary: taskSummary(task.Content),
+			Body:    body,
+		})
+	}
+
+	if err := export.WriteSite(dir, ExportTitle(startDate, endDate), pages); err != nil {
+		util.Error("导出静态网站失败: %v", err)
+		return err
+	}
+	if err := s.copyAttachments(tasks, dir); err != nil {
+		util.Error("导出静态网站失败: %v", err)
+		return err
+	}
+	util.Info("导出完成: %s (%d 篇日报)", dir, len(pages))
+	return nil
+}
+
+// embedImages 将日报中的图片附件替换为 data URI，导出的 HTML 单文件即可显示图片
+// 读取失败的图片和其他附件保持原链接
+func (s *ExportServiceImpl) embedImages(markdown string) string {
+	if s.attachmentRepo == nil {
+		return markdown
+	}
+	return util.ReplaceAttachmentLinks(markdown, func(link string) string {
+		mimeType := util.ImageMIMEType(link)
+		if mimeType == "" {
+			return link
+		}
+		filePath, err := s.attachmentRepo.Path(link)
+		if err != nil {
+			return link
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			util.Warn("读取附件失败，导出时保留原链接: %s: %v", link, err)
+			return link
+		}
+		return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	})
+}
+
+// copyAttachments 将日报引用的附件复制到静态网站目录，页面中的相对链接保持不变
+func (s *ExportServiceImpl) copyAttachments(tasks []*model.Task, dir string) error {
+	if s.attachmentRepo == nil {
+		return nil
+	}
+	for _, task := range tasks {
+		for _, link := range util.AttachmentLinks(task.Content) {
+			filePath, err := s.attachmentRepo.Path(link)
+			if err != nil {
+				continue
+			}
+			data, err := os.ReadFile(filePath)
+			if os.IsNotExist(err) {
+				util.Warn("附件不存在，已跳过: %s", link)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("读取附件失败: %w", err)
+			}
+			target := filepath.Join(dir, filepath.FromSlash(link))
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("创建附件目录失败: %w", err)
+			}
+			if err := os.WriteFile(target, data, 0644); err != nil {
+				return fmt.Errorf("写入附件失败: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadTasks 获取日期范围内有内容的日报，没有时返回错误
+func (s *ExportServiceImpl) loadTasks(startDate, endDate time.Time) ([]*model.Task, error) {
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("结束日期不能早于开始日期")
+	}
+
+	tasks, err := s.taskService.GetTasksInRange(startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("获取日报失败: %w", err)
+	}
+
+	var result []*model.Task
+	for _, task := range tasks {
+		if strings.TrimSpace(task.Content) != "" {
+			result = append(result, task)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s ~ %s 没有日报记录", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	}
+	return result, nil
+}
+
+// combineTasks 将多天的日报合并为一个 Markdown 文档（不含文档标题）
+// 单日导出时日报内容的标题下移一级；多日导出时每天一个二级标题，内容标题下移两级
+func combineTasks(tasks []*model.Task, single bool) string {
+	if single {
+		return demoteHeadings(strings.TrimSpace(tasks[0].Content), 1) + "\n"
+	}
+
+	var sb strings.Builder
+	for _, task := range tasks {
+		fmt.Fprintf(&sb, "## %s %s\n\n", task.Date.Format("2006-01-02"), util.ChineseWeekday(task.Date))
+		sb.WriteString(demoteHeadings(strings.TrimSpace(task.Content), 2))
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// taskSummary 取日报中第一段非标题文字的第一行作为摘要
+func taskSummary(content string) string {
+	for _, block := range export.ParseBlocks(content) {
+		if block.Kind == export.BlockHeading || block.Kind == export.BlockRule || strings.TrimSpace(block.Text) == "" {
+			continue
+		}
+		line, _, _ := strings.Cut(block.Text, "\n")
+		if block.Marker != "" {
+			line = block.Marker + " " + line
+		}
+		runes := []rune(line)
+		if len(runes) > exportSummaryLength {
+			return string(runes[:exportSummaryLength]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+// sameDay 判断两个时间是否为同一个日历日
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
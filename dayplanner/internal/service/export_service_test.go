@@ -0,0 +1,117 @@
+package service
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/export"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestExportService_Export(t *testing.T) {
+	tempDir := t.TempDir()
+	taskService := NewTaskService(repository.NewFileTaskRepository(tempDir), tempDir)
+	exportService := NewExportService(taskService)
+
+	days := map[int]string{
+		10: "# 今日工作\n\n- [x] 完成需求评审\n- [ ] 开发登录接口",
+		11: "# 今日工作\n\n- [x] 开发登录接口",
+		12: "   ", // 空白日报不导出
+	}
+	for day, content := range days {
+		if err := taskService.SaveTask(time.Date(2025, 11, day, 0, 0, 0, 0, time.Local), content); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	sunday := time.Date(2025, 11, 16, 0, 0, 0, 0, time.Local)
+
+	// 单日 HTML：日报内容的标题下移一级
+	var buf bytes.Buffer
+	if err := exportService.Render(&buf, export.FormatHTML, monday, monday); err != nil {
+		t.Fatalf("导出 HTML 失败: %v", err)
+	}
+	for _, want := range []string{"<title>2025-11-10 星期一 日报</title>", "<h2", "今日工作", `type="checkbox"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("HTML 中缺少 %q", want)
+		}
+	}
+
+	// 多日导出每天一个二级标题
+	markdown := combineTasks(mustLoadTasks(t, exportService, monday, sunday), false)
+	if !strings.Contains(markdown, "## 2025-11-11 星期二") || !strings.Contains(markdown, "### 今日工作") ||
+		strings.Contains(markdown, "2025-11-12") {
+		t.Errorf("合并后的 Markdown 不正确:\n%s", markdown)
+	}
+
+	output := filepath.Join(tempDir, "out", ExportFileName(export.FormatPDF, monday, sunday))
+	if err := exportService.Export(export.FormatPDF, monday, sunday, output); err != nil {
+		t.Fatalf("导出 PDF 失败: %v", err)
+	}
+	if filepath.Base(output) != "report-2025-11-10_2025-11-16.pdf" {
+		t.Errorf("默认文件名不正确: %s", filepath.Base(output))
+	}
+	if data, err := os.ReadFile(output); err != nil || !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Errorf("PDF 文件不正确: %v", err)
+	}
+
+	siteDir := filepath.Join(tempDir, "site")
+	if err := exportService.Export(export.FormatSite, monday, sunday, siteDir); err != nil {
+		t.Fatalf("导出静态网站失败: %v", err)
+	}
+	month, err := os.ReadFile(filepath.Join(siteDir, "2025-11.html"))
+	if err != nil {
+		t.Fatalf("读取月份页面失败: %v", err)
+	}
+	if !strings.Contains(string(month), "[x] 完成需求评审") || strings.Contains(string(month), "2025-11-12") {
+		t.Errorf("月份页面内容不正确:\n%s", month)
+	}
+
+	// HTML 内嵌图片附件，静态网站复制引用的附件
+	attachmentRepo := repository.NewFileAttachmentRepository(tempDir)
+	exportService.SetAttachmentRepository(attachmentRepo)
+	image, err := attachmentRepo.Save(monday, "截图.png", []byte("png"))
+	if err != nil {
+		t.Fatalf("保存附件失败: %v", err)
+	}
+	if err := taskService.SaveTask(monday, days[10]+"\n\n![截图]("+image.Link+")"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	buf.Reset()
+	if err := exportService.Render(&buf, export.FormatHTML, monday, monday); err != nil {
+		t.Fatalf("导出 HTML 失败: %v", err)
+	}
+	if !strings.Contains(buf.String(), `src="data:image/png;base64,cG5n"`) {
+		t.Errorf("HTML 应内嵌图片附件:\n%s", buf.String())
+	}
+	if err := exportService.Export(export.FormatSite, monday, sunday, siteDir); err != nil {
+		t.Fatalf("导出静态网站失败: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(siteDir, filepath.FromSlash(image.Link))); err != nil || string(data) != "png" {
+		t.Errorf("静态网站应包含引用的附件: %q, %v", data, err)
+	}
+
+	// 没有日报的范围返回错误，且不创建文件
+	empty := filepath.Join(tempDir, "empty.docx")
+	if err := exportService.Export(export.FormatDOCX, sunday, sunday, empty); err == nil {
+		t.Error("没有日报时应该返回错误")
+	}
+	if _, err := os.Stat(empty); !os.IsNotExist(err) {
+		t.Error("导出失败时不应创建文件")
+	}
+}
+
+// mustLoadTasks 获取导出范围内的日报
+func mustLoadTasks(t *testing.T, s *ExportServiceImpl, startDate, endDate time.Time) []*model.Task {
+	t.Helper()
+	tasks, err := s.loadTasks(startDate, endDate)
+	if err != nil {
+		t.Fatalf("获取日报失败: %v", err)
+	}
+	return tasks
+}
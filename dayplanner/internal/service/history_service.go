@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// HistoryService 定义任务历史版本服务接口
+type HistoryService interface {
+	// ListRevisions 列出指定日期的所有历史版本，按版本号升序
+	ListRevisions(date time.Time) ([]*model.Revision, error)
+
+	// GetRevision 获取指定日期的某个历史版本
+	GetRevision(date time.Time, id int) (*model.Revision, error)
+
+	// Diff 比较两个历史版本的内容，toID 为 0 时与当前内容比较
+	Diff(date time.Time, fromID, toID int) ([]util.DiffLine, error)
+
+	// Restore 将任务内容恢复为指定历史版本，恢复本身也会记录为新版本
+	Restore(date time.Time, id int) error
+}
+
+// HistoryServiceImpl 历史版本服务实现
+type HistoryServiceImpl struct {
+	taskService  TaskService
+	revisionRepo repository.RevisionRepository
+}
+
+// NewHistoryService 创建新的历史版本服务
+func NewHistoryService(taskService TaskService, revisionRepo repository.RevisionRepository) *HistoryServiceImpl {
+	return &HistoryServiceImpl{
+		taskService:  taskService,
+		revisionRepo: revisionRepo,
+	}
+}
+
+// ListRevisions 列出指定日期的所有历史版本，按版本号升序
+func (s *HistoryServiceImpl) ListRevisions(date time.Time) ([]*model.Revision, error) {
+	revisions, err := s.revisionRepo.List(date)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史版本失败: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision 获取指定日期的某个历史版本
+func (s *HistoryServiceImpl) GetRevision(date time.Time, id int) (*model.Revision, error) {
+	revision, err := s.revisionRepo.Get(date, id)
+	if err != nil {
+		return nil, fmt.Errorf("获取历史版本失败: %w", err)
+	}
+	if revision == nil {
+		return nil, fmt.Errorf("历史版本不存在: %s #%d", date.Format("2006-01-02"), id)
+	}
+	return revision, nil
+}
+
+// Diff 比较两个历史版本的内容，toID 为 0 时与当前内容比较
+func (s *HistoryServiceImpl) Diff(date time.Time, fromID, toID int) ([]util.DiffLine, error) {
+	from, err := s.GetRevision(date, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	var toContent string
+	if toID == 0 {
+		task, err := s.taskService.GetTask(date)
+		if err != nil {
+			return nil, err
+		}
+		if task != nil {
+			toContent = task.Content
+		}
+	} else {
+		to, err := s.GetRevision(date, toID)
+		if err != nil {
+			return nil, err
+		}
+		toContent = to.Content
+	}
+
+	return util.DiffLines(from.Content, toContent), nil
+}
+
+// Restore 将任务内容恢复为指定历史版本，恢复本身也会记录为新版本
+func (s *HistoryServiceImpl) Restore(date time.Time, id int) error {
+	revision, err := s.GetRevision(date, id)
+	if err != nil {
+		return err
+	}
+
+	util.Info("恢复历史版本: %s #%d", date.Format("2006-01-02"), id)
+	if err := s.taskService.SaveTask(date, revision.Content); err != nil {
+		return fmt.Errorf("恢复历史版本失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// newTestHistoryService 创建启用历史版本的任务服务和历史版本服务
+func newTestHistoryService(t *testing.T) (*TaskServiceImpl, *HistoryServiceImpl) {
+	t.Helper()
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	revisionRepo := repository.NewFileRevisionRepository(tempDir)
+
+	taskService := NewTaskService(taskRepo, tempDir)
+	taskService.SetRevisionRepository(revisionRepo)
+	return taskService, NewHistoryService(taskService, revisionRepo)
+}
+
+func TestHistoryService_RecordAndRestore(t *testing.T) {
+	taskService, historyService := newTestHistoryService(t)
+	testDate := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	for _, content := range []string{"- 任务一", "- 任务一\n- 任务二", "- 任务一\n- 任务二", "误粘贴的内容"} {
+		if err := taskService.SaveTask(testDate, content); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	// 内容相同的保存不产生新版本
+	revisions, err := historyService.ListRevisions(testDate)
+	if err != nil {
+		t.Fatalf("列出历史版本失败: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("应有 3 个历史版本，实际: %d", len(revisions))
+	}
+
+	lines, err := historyService.Diff(testDate, 1, 2)
+	if err != nil {
+		t.Fatalf("比较历史版本失败: %v", err)
+	}
+	if got := util.FormatDiff(lines); got != " - 任务一\n+- 任务二\n" {
+		t.Errorf("版本差异不正确: %q", got)
+	}
+
+	// 与当前内容比较
+	lines, err = historyService.Diff(testDate, 2, 0)
+	if err != nil {
+		t.Fatalf("比较当前内容失败: %v", err)
+	}
+	if got := util.FormatDiff(lines); got != "-- 任务一\n-- 任务二\n+误粘贴的内容\n" {
+		t.Errorf("与当前内容的差异不正确: %q", got)
+	}
+
+	if err := historyService.Restore(testDate, 2); err != nil {
+		t.Fatalf("恢复历史版本失败: %v", err)
+	}
+	task, err := taskService.GetTask(testDate)
+	if err != nil {
+		t.Fatalf("获取任务失败: %v", err)
+	}
+	if task.Content != "- 任务一\n- 任务二" {
+		t.Errorf("恢复后的内容不正确: %q", task.Content)
+	}
+
+	// 恢复本身记录为新版本，被覆盖的内容仍可找回
+	revisions, _ = historyService.ListRevisions(testDate)
+	if len(revisions) != 4 || revisions[2].Content != "误粘贴的内容" {
+		t.Errorf("恢复后应新增一个版本: %d", len(revisions))
+	}
+
+	if err := historyService.Restore(testDate, 99); err == nil {
+		t.Error("恢复不存在的版本应该失败")
+	}
+}
+
+func TestHistoryService_Baseline(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	testDate := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	// 启用历史版本前保存的内容
+	if err := NewTaskService(taskRepo, tempDir).SaveTask(testDate, "旧内容"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	revisionRepo := repository.NewFileRevisionRepository(tempDir)
+	taskService := NewTaskService(taskRepo, tempDir)
+	taskService.SetRevisionRepository(revisionRepo)
+	if err := taskService.SaveTask(testDate, "新内容"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	revisions, err := revisionRepo.List(testDate)
+	if err != nil {
+		t.Fatalf("列出历史版本失败: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Content != "旧内容" || revisions[1].Content != "新内容" {
+		t.Errorf("应先记录旧内容作为基线版本: %+v", revisions)
+	}
+}
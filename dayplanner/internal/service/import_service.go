@@ -0,0 +1,260 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/importer"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// ImportMode 导入时如何处理已有日报的日期
+type ImportMode string
+
+const (
+	ImportModeMerge ImportMode = "merge" // 将导入内容追加到已有日报之后
+	ImportModeSkip  ImportMode = "skip"  // 跳过已有日报的日期
+)
+
+// ParseImportMode 解析导入模式名称
+func ParseImportMode(name string) (ImportMode, error) {
+	switch mode := ImportMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case ImportModeMerge, ImportModeSkip:
+		return mode, nil
+	}
+	return "", fmt.Errorf("不支持的导入模式: %s（可选 merge、skip）", name)
+}
+
+// ImportAction 导入计划中某一天的处理方式
+type ImportAction int
+
+const (
+	ImportActionCreate    ImportAction = iota // 新建日报
+	ImportActionMerge                         // 追加到已有日报
+	ImportActionSkip                          // 已有日报，按 skip 模式跳过
+	ImportActionUnchanged                     // 已有日报中已包含导入内容，无需修改
+)
+
+// String 返回处理方式的中文名称
+func (a ImportAction) String() string {
+	switch a {
+	case ImportActionCreate:
+		return "新建"
+	case ImportActionMerge:
+		return "合并"
+	case ImportActionSkip:
+		return "跳过"
+	case ImportActionUnchanged:
+		return "无变化"
+	}
+	return "未知"
+}
+
+// ImportEntry 导入计划中的一天
+type ImportEntry struct {
+	Date      time.Time
+	Action    ImportAction
+	Sources   []string  // 映射到这一天的来源文件
+	Content   string    // 写入后的完整日报内容
+	CreatedAt time.Time // 来源中最早的创建时间，没有时为零值
+	UpdatedAt time.Time // 来源中最晚的更新时间，没有时为零值
+}
+
+// ImportPlan 导入计划，Apply 之前可以作为试运行报告展示
+type ImportPlan struct {
+	Entries []ImportEntry      // 按日期升序
+	Ignored []importer.Ignored // 无法识别日期或格式的文件
+}
+
+// Count 返回指定处理方式的天数
+func (p *ImportPlan) Count(action ImportAction) int {
+	count := 0
+	for _, entry := range p.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Report 生成试运行报告：每天的处理方式和来源，以及无法导入的文件
+func (p *ImportPlan) Report() string {
+	var sb strings.Builder
+	for _, entry := range p.Entries {
+		fmt.Fprintf(&sb, "%s  %s  %s\n", entry.Date.Format("2006-01-02"), entry.Action, strings.Join(entry.Sources, ", "))
+	}
+	for _, ignored := range p.Ignored {
+		fmt.Fprintf(&sb, "忽略  %s: %s\n", ignored.Source, ignored.Reason)
+	}
+	fmt.Fprintf(&sb, "共 %d 天：新建 %d，合并 %d，跳过 %d，无变化 %d；忽略 %d 个文件\n",
+		len(p.Entries), p.Count(ImportActionCreate), p.Count(ImportActionMerge),
+		p.Count(ImportActionSkip), p.Count(ImportActionUnchanged), len(p.Ignored))
+	return sb.String()
+}
+
+// ImportService 定义日报导入服务接口
+type ImportService interface {
+	// Plan 读取 path（Markdown 文件夹、Obsidian 日记目录、Joplin JSON/RAW 导出目录或 .jex 文件）并生成导入计划，不写入任何数据
+	Plan(path string, mode ImportMode) (*ImportPlan, error)
+
+	// Apply 执行导入计划中新建和合并的日报，返回写入的天数
+	Apply(plan *ImportPlan) (int, error)
+}
+
+// ImportServiceImpl 导入服务实现，内容通过 TaskService.SaveTask 写入，保留历史版本
+type ImportServiceImpl struct {
+	taskService TaskService
+	taskRepo    repository.TaskRepository
+}
+
+// NewImportService 创建新的导入服务
+func NewImportService(taskService TaskService, taskRepo repository.TaskRepository) *ImportServiceImpl {
+	return &ImportServiceImpl{
+		taskService: taskService,
+		taskRepo:    taskRepo,
+	}
+}
+
+// Plan 读取导入来源并生成导入计划，同一天的多篇笔记按来源路径顺序合并
+func (s *ImportServiceImpl) Plan(path string, mode ImportMode) (*ImportPlan, error) {
+	result, err := importer.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ImportPlan{Ignored: result.Ignored}
+	for _, note := range result.Notes {
+		if strings.TrimSpace(note.Content) == "" {
+			plan.Ignored = append(plan.Ignored, importer.Ignored{Source: note.Source, Reason: "内容为空"})
+			continue
+		}
+
+		// 笔记已按日期排序，同一天的笔记相邻
+		if n := len(plan.Entries); n > 0 && plan.Entries[n-1].Date.Equal(note.Date) {
+			entry := &plan.Entries[n-1]
+			entry.Sources = append(entry.Sources, note.Source)
+			entry.Content = mergeContent(entry.Content, note.Content)
+			entry.CreatedAt = earlier(entry.CreatedAt, note.CreatedAt)
+			entry.UpdatedAt = later(entry.UpdatedAt, note.UpdatedAt)
+			continue
+		}
+		plan.Entries = append(plan.Entries, ImportEntry{
+			Date:      note.Date,
+			Sources:   []string{note.Source},
+			Content:   note.Content,
+			CreatedAt: note.CreatedAt,
+			UpdatedAt: note.UpdatedAt,
+		})
+	}
+
+	// 与已有日报比较，确定每天的处理方式
+	for i := range plan.Entries {
+		entry := &plan.Entries[i]
+		task, err := s.taskService.GetTask(entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("获取 %s 的日报失败: %w", entry.Date.Format("2006-01-02"), err)
+		}
+		existing := ""
+		if task != nil {
+			existing = task.Content
+		}
+
+		switch {
+		case strings.TrimSpace(existing) == "":
+			entry.Action = ImportActionCreate
+		case strings.Contains(normalizeContent(existing), normalizeContent(entry.Content)):
+			// 重复导入同一来源时不会重复追加
+			entry.Action = ImportActionUnchanged
+			entry.Content = existing
+		case mode == ImportModeSkip:
+			entry.Action = ImportActionSkip
+			entry.Content = existing
+		default:
+			entry.Action = ImportActionMerge
+			entry.Content = mergeContent(existing, entry.Content)
+		}
+	}
+
+	util.Info("生成导入计划: %s, 新建 %d, 合并 %d, 跳过 %d, 无变化 %d, 忽略 %d",
+		path, plan.Count(ImportActionCreate), plan.Count(ImportActionMerge),
+		plan.Count(ImportActionSkip), plan.Count(ImportActionUnchanged), len(plan.Ignored))
+	return plan, nil
+}
+
+// Apply 执行导入计划中新建和合并的日报，返回写入的天数
+// 新建的日报使用来源中的创建和更新时间；合并的日报保留较早的创建时间，更新时间为导入时间
+func (s *ImportServiceImpl) Apply(plan *ImportPlan) (int, error) {
+	written := 0
+	for _, entry := range plan.Entries {
+		if entry.Action != ImportActionCreate && entry.Action != ImportActionMerge {
+			continue
+		}
+		dateKey := entry.Date.Format("2006-01-02")
+
+		if err := s.taskService.SaveTask(entry.Date, entry.Content); err != nil {
+			return written, fmt.Errorf("导入 %s 的日报失败: %w", dateKey, err)
+		}
+		written++
+
+		if err := s.restoreTimestamps(entry); err != nil {
+			return written, fmt.Errorf("记录 %s 的导入时间失败: %w", dateKey, err)
+		}
+	}
+
+	util.Info("导入完成: 写入 %d 天", written)
+	return written, nil
+}
+
+// restoreTimestamps 将来源中的时间戳写回日报
+func (s *ImportServiceImpl) restoreTimestamps(entry ImportEntry) error {
+	if entry.CreatedAt.IsZero() && entry.UpdatedAt.IsZero() {
+		return nil
+	}
+	task, err := s.taskRepo.GetByDate(entry.Date)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("日报不存在")
+	}
+
+	if entry.Action == ImportActionCreate {
+		task.CreatedAt = earlier(entry.CreatedAt, entry.UpdatedAt)
+		task.UpdatedAt = later(entry.UpdatedAt, task.CreatedAt)
+	} else if !entry.CreatedAt.IsZero() && entry.CreatedAt.Before(task.CreatedAt) {
+		task.CreatedAt = entry.CreatedAt
+	}
+	return s.taskRepo.Save(task)
+}
+
+// mergeContent 将导入内容追加到已有内容之后，中间空一行
+func mergeContent(existing, imported string) string {
+	existing = strings.TrimRight(existing, "\r\n ")
+	if existing == "" {
+		return imported
+	}
+	return existing + "\n\n" + strings.TrimSpace(imported)
+}
+
+// normalizeContent 统一换行符和首尾空白，用于判断内容是否已导入
+func normalizeContent(content string) string {
+	return strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+}
+
+// earlier 返回较早的非零时间
+func earlier(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// later 返回较晚的非零时间
+func later(a, b time.Time) time.Time {
+	if a.IsZero() || b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+)
+
+func TestImportService_PlanAndApply(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(filepath.Join(tempDir, "tasks"))
+	taskService := NewTaskService(taskRepo, filepath.Join(tempDir, "tasks"))
+	importService := NewImportService(taskService, taskRepo)
+
+	vault := filepath.Join(tempDir, "vault")
+	notes := map[string]string{
+		"2025-11-10.md":       "---\ncreated: 2025-11-10 09:00\nupdated: 2025-11-10 18:00\n---\n- [x] 导入的新日报\n",
+		"2025-11-11.md":       "- [ ] 导入的补充内容\n",
+		"daily/2025-11-11.md": "- [ ] 同一天的第二篇笔记\n",
+		"2025-11-12.md":       "- [x] 已经存在的内容\n",
+		"notes.md":            "没有日期\n",
+	}
+	for name, content := range notes {
+		path := filepath.Join(vault, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入笔记失败: %v", err)
+		}
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.Local) }
+	if err := taskService.SaveTask(day(11), "# 已有日报"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if err := taskService.SaveTask(day(12), "# 已有日报\n\n- [x] 已经存在的内容"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	// skip 模式：已有日报的日期跳过，生成计划不写入数据
+	plan, err := importService.Plan(vault, ImportModeSkip)
+	if err != nil {
+		t.Fatalf("生成导入计划失败: %v", err)
+	}
+	if plan.Count(ImportActionCreate) != 1 || plan.Count(ImportActionSkip) != 1 ||
+		plan.Count(ImportActionUnchanged) != 1 || len(plan.Ignored) != 1 {
+		t.Errorf("skip 模式的导入计划不正确:\n%s", plan.Report())
+	}
+	if task, _ := taskService.GetTask(day(10)); task != nil {
+		t.Error("生成导入计划时不应写入数据")
+	}
+
+	// merge 模式：同一天的多篇笔记依次追加到已有日报之后
+	plan, err = importService.Plan(vault, ImportModeMerge)
+	if err != nil {
+		t.Fatalf("生成导入计划失败: %v", err)
+	}
+	if !strings.Contains(plan.Report(), "共 3 天：新建 1，合并 1，跳过 0，无变化 1；忽略 1 个文件") {
+		t.Errorf("merge 模式的导入计划不正确:\n%s", plan.Report())
+	}
+	written, err := importService.Apply(plan)
+	if err != nil || written != 2 {
+		t.Fatalf("导入失败: 写入 %d 天, %v", written, err)
+	}
+
+	created, _ := taskService.GetTask(day(10))
+	if created == nil || created.Content != "- [x] 导入的新日报" {
+		t.Fatalf("新建的日报不正确: %+v", created)
+	}
+	if !created.CreatedAt.Equal(time.Date(2025, 11, 10, 9, 0, 0, 0, time.Local)) ||
+		!created.UpdatedAt.Equal(time.Date(2025, 11, 10, 18, 0, 0, 0, time.Local)) {
+		t.Errorf("应使用 front matter 中的时间: %v, %v", created.CreatedAt, created.UpdatedAt)
+	}
+
+	merged, _ := taskService.GetTask(day(11))
+	want := "# 已有日报\n\n- [ ] 导入的补充内容\n\n- [ ] 同一天的第二篇笔记"
+	if merged.Content != want {
+		t.Errorf("合并后的日报不正确:\n%q\n期望:\n%q", merged.Content, want)
+	}
+
+	// 再次导入时内容已存在，不会重复追加
+	plan, err = importService.Plan(vault, ImportModeMerge)
+	if err != nil {
+		t.Fatalf("生成导入计划失败: %v", err)
+	}
+	if plan.Count(ImportActionUnchanged) != 3 {
+		t.Errorf("重复导入应全部无变化:\n%s", plan.Report())
+	}
+}
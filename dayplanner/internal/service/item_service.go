@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// ErrInvalidItems 任务项无法写回日报，如行号不是任务项、状态无效或标题为空
+var ErrInvalidItems = errors.New("任务项无效")
+
+// DatedItem 带日期的任务项
+type DatedItem struct {
+	Date time.Time
+	Item model.Item
+}
+
+// ItemService 定义结构化任务项服务接口
+// 任务项由日报 Markdown 解析得到，修改后写回 Markdown 并通过 TaskService 保存，历史版本和同步照常工作
+type ItemService interface {
+	// GetItems 获取指定日期日报中的任务项，没有日报时返回空列表
+	GetItems(date time.Time) ([]model.Item, error)
+
+	// SaveItems 将任务项写回指定日期的日报，语义见 util.ApplyItems
+	SaveItems(date time.Time, items []model.Item) error
+
+	// ListItems 列出日期范围内（含首尾）的任务项，status 为空时不按状态过滤
+	ListItems(startDate, endDate time.Time, status model.ItemStatus) ([]DatedItem, error)
+}
+
+// ItemServiceImpl 任务项服务实现
+type ItemServiceImpl struct {
+	taskService TaskService
+}
+
+// NewItemService 创建新的任务项服务
+func NewItemService(taskService TaskService) *ItemServiceImpl {
+	return &ItemServiceImpl{
+		taskService: taskService,
+	}
+}
+
+// GetItems 获取指定日期日报中的任务项
+func (s *ItemServiceImpl) GetItems(date time.Time) ([]model.Item, error) {
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return []model.Item{}, nil
+	}
+	return util.ParseItems(task.Content), nil
+}
+
+// SaveItems 将任务项写回日报，内容没有变化时不保存
+// 读取和写回通过 TaskService.UpdateContent 在同一把锁内完成，不会覆盖期间自动保存的内容
+func (s *ItemServiceImpl) SaveItems(date time.Time, items []model.Item) error {
+	return s.taskService.UpdateContent(date, func(content string) (string, error) {
+		updated, err := util.ApplyItems(content, items)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidItems, err)
+		}
+		if updated != content {
+			util.Info("更新任务项: %s, %d 项", date.Format("2006-01-02"), len(items))
+		}
+		return updated, nil
+	})
+}
+
+// ListItems 列出日期范围内的任务项，按日期和行号排列
+func (s *ItemServiceImpl) ListItems(startDate, endDate time.Time, status model.ItemStatus) ([]DatedItem, error) {
+	if status != "" && !model.ValidItemStatus(status) {
+		return nil, fmt.Errorf("无效的任务项状态: %q", status)
+	}
+	tasks, err := s.taskService.GetTasksInRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []DatedItem
+	for _, task := range tasks {
+		for _, item := range util.ParseItems(task.Content) {
+			if status == "" || item.Status == status {
+				result = append(result, DatedItem{Date: task.Date, Item: item})
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestItemService_SaveAndList(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	taskService := NewTaskService(taskRepo, tempDir)
+	itemService := NewItemService(taskService)
+
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	if err := taskService.SaveTask(monday, "# 今日工作\n\n- [x] 完成登录接口 (2h)\n- [ ] 联调支付 !doing\n"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	// 没有日报的日期新增任务项
+	if err := itemService.SaveItems(tuesday, []model.Item{{Title: "编写测试", Status: model.ItemStatusTodo, Estimate: time.Hour, Line: -1}}); err != nil {
+		t.Fatalf("保存任务项失败: %v", err)
+	}
+	task, err := taskService.GetTask(tuesday)
+	if err != nil || task == nil {
+		t.Fatalf("获取任务失败: %v", err)
+	}
+	if task.Content != "- [ ] 编写测试 ~1h\n" {
+		t.Errorf("新增任务项内容不正确: %q", task.Content)
+	}
+
+	// 修改状态后写回，其余内容不变
+	items, err := itemService.GetItems(monday)
+	if err != nil {
+		t.Fatalf("获取任务项失败: %v", err)
+	}
+	items[1].Status = model.ItemStatusDone
+	if err := itemService.SaveItems(monday, items); err != nil {
+		t.Fatalf("保存任务项失败: %v", err)
+	}
+	task, _ = taskService.GetTask(monday)
+	if task.Content != "# 今日工作\n\n- [x] 完成登录接口 (2h)\n- [x] 联调支付\n" {
+		t.Errorf("修改后的内容不正确: %q", task.Content)
+	}
+
+	done, err := itemService.ListItems(monday, tuesday, model.ItemStatusDone)
+	if err != nil {
+		t.Fatalf("列出任务项失败: %v", err)
+	}
+	if len(done) != 2 || done[0].Item.Title != "完成登录接口" || done[1].Item.Title != "联调支付" {
+		t.Errorf("已完成的任务项不正确: %+v", done)
+	}
+
+	all, _ := itemService.ListItems(monday, tuesday, "")
+	if len(all) != 3 || !all[2].Date.Equal(tuesday) {
+		t.Errorf("全部任务项不正确: %+v", all)
+	}
+
+	if _, err := itemService.ListItems(monday, tuesday, "finished"); err == nil {
+		t.Error("无效状态应返回错误")
+	}
+}
+
+// recordingTaskRepository 按顺序记录每次保存的日报内容，读取时稍作等待以放大并发冲突
+type recordingTaskRepository struct {
+	repository.TaskRepository
+	mu    sync.Mutex
+	saved []string
+}
+
+func (r *recordingTaskRepository) GetByDate(date time.Time) (*model.Task, error) {
+	time.Sleep(time.Millisecond)
+	return r.TaskRepository.GetByDate(date)
+}
+
+func (r *recordingTaskRepository) Save(task *model.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved = append(r.saved, task.Content)
+	return r.TaskRepository.Save(task)
+}
+
+func TestItemService_SaveItemsConcurrentWithSaveTask(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := &recordingTaskRepository{TaskRepository: repository.NewFileTaskRepository(tempDir)}
+	taskService := NewTaskService(taskRepo, tempDir)
+	itemService := NewItemService(taskService)
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			item := model.Item{Title: fmt.Sprintf("任务 %d", i), Status: model.ItemStatusTodo, Line: -1}
+			if err := itemService.SaveItems(date, []model.Item{item}); err != nil {
+				t.Errorf("保存任务项失败: %v", err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if err := taskService.SaveTask(date, fmt.Sprintf("# 版本 %d\n", i)); err != nil {
+				t.Errorf("保存任务失败: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// 每次保存要么是 SaveTask 写入的完整内容，要么是在上一次保存的内容上替换任务项、保留其余内容；
+	// 基于过期内容写回的任务项会带回旧的标题，覆盖期间保存的内容
+	previous := ""
+	for _, content := range taskRepo.saved {
+		if !strings.Contains(content, "- [ ]") {
+			previous = content
+			continue
+		}
+		if nonItemText(content) != nonItemText(previous) {
+			t.Fatalf("任务项基于过期内容写回，覆盖了期间保存的内容:\n之前: %q\n之后: %q", previous, content)
+		}
+		previous = content
+	}
+}
+
+// nonItemText 返回日报中任务项以外的文字
+func nonItemText(content string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "- [") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// journalFlushDelay 记录修改后多久写入日志，连续输入时合并为一次写入
+const journalFlushDelay = 300 * time.Millisecond
+
+// JournalService 定义保存日志服务接口
+// 编辑器中的修改在自动保存之前先记录到日志，保存成功后删除；程序崩溃或断电后，下次启动时可以从日志恢复尚未保存的内容
+type JournalService interface {
+	// Record 记录指定日期尚未保存的内容
+	Record(date time.Time, content string)
+
+	// Commit 内容已保存，记录中没有更新的修改时删除该日期的记录
+	Commit(date time.Time, content string)
+
+	// Pending 返回上次运行时没有保存的记录，按日期排列；内容与已保存的日报相同的记录直接删除
+	Pending() ([]*model.JournalEntry, error)
+
+	// Recover 将记录的内容保存为日报，并从日志中删除该记录
+	Recover(entry *model.JournalEntry) error
+
+	// Discard 从日志中删除记录
+	Discard(entry *model.JournalEntry)
+
+	// Flush 立即写入尚未写入日志的修改
+	Flush() error
+}
+
+// JournalServiceImpl 保存日志服务实现
+type JournalServiceImpl struct {
+	journalRepo repository.JournalRepository
+	taskService TaskService
+	flushDelay  time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*model.JournalEntry // 按日期 (YYYY-MM-DD) 索引
+	timer   *time.Timer                    // 等待写入日志的定时器
+}
+
+// NewJournalService 创建新的保存日志服务
+func NewJournalService(journalRepo repository.JournalRepository, taskService TaskService) *JournalServiceImpl {
+	return &JournalServiceImpl{
+		journalRepo: journalRepo,
+		taskService: taskService,
+		flushDelay:  journalFlushDelay,
+		now:         time.Now,
+		entries:     make(map[string]*model.JournalEntry),
+	}
+}
+
+// NewJournalServiceFromConfig 按配置创建保存日志服务
+// 日志中是日报原文，启用加密时不记录日志，返回 nil
+func NewJournalServiceFromConfig(config *model.Config, dataPath string, taskService TaskService) *JournalServiceImpl {
+	if config.Encryption != "" {
+		return nil
+	}
+	return NewJournalService(repository.NewFileJournalRepository(repository.DefaultJournalPath(dataPath)), taskService)
+}
+
+// Record 记录指定日期尚未保存的内容
+func (s *JournalServiceImpl) Record(date time.Time, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := date.Format("2006-01-02")
+	s.entries[key] = &model.JournalEntry{Date: key, Content: content, UpdatedAt: s.now()}
+	s.scheduleFlushLocked()
+}
+
+// Commit 内容已保存，记录中的内容与保存的内容相同时删除该记录
+func (s *JournalServiceImpl) Commit(date time.Time, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := date.Format("2006-01-02")
+	if entry, ok := s.entries[key]; ok && entry.Content == content {
+		delete(s.entries, key)
+		s.scheduleFlushLocked()
+	}
+}
+
+// Pending 返回上次运行时没有保存的记录
+// 日志无法解析时记录警告并丢弃，不影响启动
+func (s *JournalServiceImpl) Pending() ([]*model.JournalEntry, error) {
+	entries, err := s.journalRepo.Load()
+	if err != nil {
+		util.Warn("读取保存日志失败，已忽略: %v", err)
+		entries = nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []*model.JournalEntry
+	for _, entry := range entries {
+		if _, ok := s.entries[entry.Date]; ok {
+			continue // 本次运行中已有更新的修改
+		}
+		date, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+		if err != nil {
+			util.Warn("保存日志中的日期无效，已忽略: %s", entry.Date)
+			continue
+		}
+		task, err := s.taskService.GetTask(date)
+		if err == nil && ((task == nil && entry.Content == "") || (task != nil && task.Content == entry.Content)) {
+			continue // 内容已经保存
+		}
+		s.entries[entry.Date] = entry
+		pending = append(pending, entry)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Date < pending[j].Date })
+
+	if len(pending) != len(entries) {
+		if err := s.flushLocked(); err != nil {
+			return nil, err
+		}
+	}
+	if len(pending) > 0 {
+		util.Info("保存日志中有 %d 篇未保存的日报", len(pending))
+	}
+	return pending, nil
+}
+
+// Recover 将记录的内容保存为日报，并从日志中删除该记录
+func (s *JournalServiceImpl) Recover(entry *model.JournalEntry) error {
+	date, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+	if err != nil {
+		return fmt.Errorf("无效的日期: %s", entry.Date)
+	}
+	if err := s.taskService.SaveTask(date, entry.Content); err != nil {
+		return err
+	}
+	util.Info("已从保存日志恢复日报: %s", entry.Date)
+	s.Discard(entry)
+	return nil
+}
+
+// Discard 从日志中删除记录，之后又有新的修改时保留新的修改
+func (s *JournalServiceImpl) Discard(entry *model.JournalEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.entries[entry.Date]; ok && current.Content == entry.Content {
+		delete(s.entries, entry.Date)
+		if err := s.flushLocked(); err != nil {
+			util.Error("写入保存日志失败: %v", err)
+		}
+	}
+}
+
+// Flush 立即写入尚未写入日志的修改
+func (s *JournalServiceImpl) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flushLocked()
+}
+
+// scheduleFlushLocked 在 flushDelay 后写入日志，已有等待中的写入时不重复安排
+func (s *JournalServiceImpl) scheduleFlushLocked() {
+	if s.flushDelay <= 0 {
+		if err := s.flushLocked(); err != nil {
+			util.Error("写入保存日志失败: %v", err)
+		}
+		return
+	}
+	if s.timer != nil {
+		return
+	}
+	s.timer = time.AfterFunc(s.flushDelay, func() {
+		if err := s.Flush(); err != nil {
+			util.Error("写入保存日志失败: %v", err)
+		}
+	})
+}
+
+// flushLocked 将当前的记录写入日志，调用方需持有锁
+func (s *JournalServiceImpl) flushLocked() error {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	entries := make([]*model.JournalEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Date < entries[j].Date })
+	return s.journalRepo.Save(entries)
+}
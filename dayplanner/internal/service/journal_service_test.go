@@ -0,0 +1,93 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestJournalService(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "data", "tasks")
+	taskService := NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	journalRepo := repository.NewFileJournalRepository(repository.DefaultJournalPath(dataPath))
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	if err := taskService.SaveTask(monday, "- 页面改版"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	journal := NewJournalService(journalRepo, taskService)
+	journal.Record(monday, "- 页面改版\n- 修复登录")
+	journal.Record(tuesday, "- 联调")
+	journal.Record(monday, "- 页面改版\n- 修复登录\n- 周会")
+	if err := journal.Flush(); err != nil {
+		t.Fatalf("写入日志失败: %v", err)
+	}
+
+	// 保存的内容与最新的修改不同时保留记录
+	journal.Commit(monday, "- 页面改版\n- 修复登录")
+	journal.Commit(tuesday, "- 联调")
+	journal.Flush()
+	entries, _ := journalRepo.Load()
+	if len(entries) != 1 || entries[0].Date != "2025-11-10" || entries[0].Content != "- 页面改版\n- 修复登录\n- 周会" {
+		t.Fatalf("日志内容错误: %+v", entries)
+	}
+
+	// 模拟崩溃后重新启动：日志中的修改尚未保存
+	restarted := NewJournalService(journalRepo, taskService)
+	pending, err := restarted.Pending()
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("应有一篇未保存的日报: %+v, %v", pending, err)
+	}
+	if err := restarted.Recover(pending[0]); err != nil {
+		t.Fatalf("恢复失败: %v", err)
+	}
+	if task, _ := taskService.GetTask(monday); task.Content != "- 页面改版\n- 修复登录\n- 周会" {
+		t.Errorf("恢复后的日报内容错误: %q", task.Content)
+	}
+	if entries, _ := journalRepo.Load(); len(entries) != 0 {
+		t.Errorf("恢复后应删除记录: %+v", entries)
+	}
+
+	// 内容已经保存的记录直接删除，放弃的记录不再出现
+	journalRepo.Save([]*model.JournalEntry{
+		{Date: "2025-11-10", Content: "- 页面改版\n- 修复登录\n- 周会"},
+		{Date: "2025-11-12", Content: "- 发布"},
+	})
+	restarted = NewJournalService(journalRepo, taskService)
+	pending, _ = restarted.Pending()
+	if len(pending) != 1 || pending[0].Date != "2025-11-12" {
+		t.Fatalf("只应返回未保存的记录: %+v", pending)
+	}
+	restarted.Discard(pending[0])
+	if pending, _ := NewJournalService(journalRepo, taskService).Pending(); len(pending) != 0 {
+		t.Errorf("放弃后不应再有未保存的记录: %+v", pending)
+	}
+}
+
+func TestJournalService_DelayedFlush(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "data", "tasks")
+	taskService := NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	journalRepo := repository.NewFileJournalRepository(repository.DefaultJournalPath(dataPath))
+	journal := NewJournalService(journalRepo, taskService)
+	journal.flushDelay = 20 * time.Millisecond
+
+	// 连续的修改合并为一次写入
+	journal.Record(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local), "- 页")
+	journal.Record(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local), "- 页面改版")
+	if entries, _ := journalRepo.Load(); len(entries) != 0 {
+		t.Errorf("延迟时间内不应写入日志: %+v", entries)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if entries, _ := journalRepo.Load(); len(entries) != 1 || entries[0].Content != "- 页面改版" {
+		t.Errorf("延迟后应写入最新的修改: %+v", entries)
+	}
+
+	if NewJournalServiceFromConfig(&model.Config{Encryption: model.EncryptionPassphrase}, dataPath, taskService) != nil {
+		t.Error("启用加密时不应记录保存日志")
+	}
+}
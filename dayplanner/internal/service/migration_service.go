@@ -0,0 +1,214 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
+)
+
+// 迁移时查询全部任务使用的日期范围
+var (
+	migrationRangeStart = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	migrationRangeEnd   = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// MigrationResult 迁移结果统计
+type MigrationResult struct {
+	Total   int // 源仓库中的任务总数
+	Copied  int // 本次复制的任务数
+	Skipped int // 之前的运行中已迁移且未变化、本次跳过的任务数
+}
+
+// migrationState 断点续传状态，记录已迁移任务的内容哈希
+type migrationState struct {
+	Source    string            `json:"source"`
+	Target    string            `json:"target"`
+	Completed map[string]string `json:"completed"` // 日期 -> 任务哈希
+}
+
+// MigrationService 通过 TaskRepository 接口在两个存储后端之间复制任务
+type MigrationService struct {
+	source     repository.TaskRepository
+	target     repository.TaskRepository
+	sourceName string
+	targetName string
+	statePath  string
+}
+
+// NewMigrationService 创建新的迁移服务
+// sourceName/targetName 用于校验状态文件是否属于同一次迁移，statePath 为断点续传状态文件路径
+func NewMigrationService(
+	source, target repository.TaskRepository,
+	sourceName, targetName, statePath string,
+) *MigrationService {
+	return &MigrationService{
+		source:     source,
+		target:     target,
+		sourceName: sourceName,
+		targetName: targetName,
+		statePath:  statePath,
+	}
+}
+
+// Migrate 将源仓库中的所有任务复制到目标仓库，保留 CreatedAt/UpdatedAt
+// 每复制一个任务都会更新状态文件，中断后再次执行会跳过已迁移且未变化的任务
+func (s *MigrationService) Migrate(onProgress func(done, total int)) (*MigrationResult, error) {
+	state, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	dates, err := s.source.GetTaskDates(migrationRangeStart, migrationRangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("读取源任务列表失败: %w", err)
+	}
+
+	result := &MigrationResult{Total: len(dates)}
+	util.Info("开始迁移任务: %s -> %s, 共 %d 个", s.sourceName, s.targetName, len(dates))
+
+	for i, date := range dates {
+		task, err := s.source.GetByDate(date)
+		if err != nil {
+			return result, fmt.Errorf("读取源任务失败 (%s): %w", date.Format("2006-01-02"), err)
+		}
+		if task == nil {
+			continue
+		}
+
+		key := date.Format("2006-01-02")
+		hash := TaskHash(task)
+		if state.Completed[key] == hash {
+			result.Skipped++
+		} else {
+			if err := s.target.Save(task); err != nil {
+				return result, fmt.Errorf("写入目标任务失败 (%s): %w", key, err)
+			}
+			state.Completed[key] = hash
+			if err := s.saveState(state); err != nil {
+				return result, err
+			}
+			result.Copied++
+		}
+
+		if onProgress != nil {
+			onProgress(i+1, len(dates))
+		}
+	}
+
+	util.Info("任务迁移完成: 复制 %d 个, 跳过 %d 个", result.Copied, result.Skipped)
+	return result, nil
+}
+
+// Verify 校验两个仓库的任务数量和每个任务的内容哈希是否一致
+func (s *MigrationService) Verify() error {
+	sourceDates, err := s.source.GetTaskDates(migrationRangeStart, migrationRangeEnd)
+	if err != nil {
+		return fmt.Errorf("读取源任务列表失败: %w", err)
+	}
+	targetDates, err := s.target.GetTaskDates(migrationRangeStart, migrationRangeEnd)
+	if err != nil {
+		return fmt.Errorf("读取目标任务列表失败: %w", err)
+	}
+
+	if len(sourceDates) != len(targetDates) {
+		return fmt.Errorf("任务数量不一致: 源 %d 个, 目标 %d 个", len(sourceDates), len(targetDates))
+	}
+
+	for _, date := range sourceDates {
+		key := date.Format("2006-01-02")
+		sourceTask, err := s.source.GetByDate(date)
+		if err != nil {
+			return fmt.Errorf("读取源任务失败 (%s): %w", key, err)
+		}
+		targetTask, err := s.target.GetByDate(date)
+		if err != nil {
+			return fmt.Errorf("读取目标任务失败 (%s): %w", key, err)
+		}
+		if targetTask == nil {
+			return fmt.Errorf("目标仓库缺少任务: %s", key)
+		}
+		if TaskHash(sourceTask) != TaskHash(targetTask) {
+			return fmt.Errorf("任务内容哈希不一致: %s", key)
+		}
+	}
+
+	util.Info("迁移校验通过: 共 %d 个任务", len(sourceDates))
+	return nil
+}
+
+// Finish 迁移并校验成功后删除断点续传状态文件
+func (s *MigrationService) Finish() error {
+	if err := os.Remove(s.statePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除迁移状态文件失败: %w", err)
+	}
+	return nil
+}
+
+// TaskHash 计算任务的内容哈希，覆盖日期、内容和时间戳
+func TaskHash(task *model.Task) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n",
+		task.Date.Format("2006-01-02"),
+		task.CreatedAt.UTC().Format(time.RFC3339Nano),
+		task.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	h.Write([]byte(task.Content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadState 加载断点续传状态，状态文件属于其他迁移时返回错误
+func (s *MigrationService) loadState() (*migrationState, error) {
+	state := &migrationState{
+		Source:    s.sourceName,
+		Target:    s.targetName,
+		Completed: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("读取迁移状态文件失败: %w", err)
+	}
+
+	var saved migrationState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("解析迁移状态文件失败: %w", err)
+	}
+	if saved.Source != s.sourceName || saved.Target != s.targetName {
+		return nil, fmt.Errorf("迁移状态文件 %s 属于另一次迁移 (%s -> %s)，请删除后重试",
+			s.statePath, saved.Source, saved.Target)
+	}
+	if saved.Completed != nil {
+		state.Completed = saved.Completed
+	}
+
+	util.Info("从断点继续迁移，已完成 %d 个任务", len(state.Completed))
+	return state, nil
+}
+
+// saveState 保存断点续传状态
+func (s *MigrationService) saveState(state *migrationState) error {
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0755); err != nil {
+		return fmt.Errorf("创建迁移状态目录失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化迁移状态失败: %w", err)
+	}
+
+	if err := util.WriteFileAtomic(s.statePath, data, 0644); err != nil {
+		return fmt.Errorf("写入迁移状态文件失败: %w", err)
+	}
+	return nil
+}
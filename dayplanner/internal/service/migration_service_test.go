@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+// failingTaskRepository 在保存指定次数后返回错误，用于模拟迁移中断
+type failingTaskRepository struct {
+	repository.TaskRepository
+	remaining int
+}
+
+func (r *failingTaskRepository) Save(task *model.Task) error {
+	if r.remaining <= 0 {
+		return errors.New("模拟写入中断")
+	}
+	r.remaining--
+	return r.TaskRepository.Save(task)
+}
+
+// seedFileRepository 向文件仓库写入测试任务
+func seedFileRepository(t *testing.T, repo repository.TaskRepository, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		createdAt := time.Date(2024, 1, 1+i, 9, 0, 0, 0, time.UTC)
+		task := &model.Task{
+			Date:      time.Date(2024, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			Content:   "# 工作记录\n\n- 事项 " + string(rune('A'+i)),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt.Add(8 * time.Hour),
+		}
+		if err := repo.Save(task); err != nil {
+			t.Fatalf("写入测试任务失败: %v", err)
+		}
+	}
+}
+
+func TestMigrationService_MigrateAndVerify(t *testing.T) {
+	tempDir := t.TempDir()
+	source := repository.NewFileTaskRepository(filepath.Join(tempDir, "tasks"))
+	seedFileRepository(t, source, 5)
+
+	target, err := repository.NewSQLiteTaskRepository(filepath.Join(tempDir, "tasks.db"))
+	if err != nil {
+		t.Fatalf("打开 SQLite 仓库失败: %v", err)
+	}
+	defer target.Close()
+
+	statePath := filepath.Join(tempDir, "migrate-state.json")
+	migration := NewMigrationService(source, target, "file:tasks", "sqlite:tasks.db", statePath)
+
+	result, err := migration.Migrate(nil)
+	if err != nil {
+		t.Fatalf("迁移失败: %v", err)
+	}
+	if result.Total != 5 || result.Copied != 5 || result.Skipped != 0 {
+		t.Errorf("迁移统计不正确: %+v", result)
+	}
+
+	if err := migration.Verify(); err != nil {
+		t.Fatalf("迁移校验失败: %v", err)
+	}
+
+	// 时间戳必须原样保留
+	task, err := target.GetByDate(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil || task == nil {
+		t.Fatalf("读取迁移后的任务失败: %v", err)
+	}
+	if !task.CreatedAt.Equal(time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedAt 未保留: %v", task.CreatedAt)
+	}
+	if !task.UpdatedAt.Equal(time.Date(2024, 1, 3, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt 未保留: %v", task.UpdatedAt)
+	}
+
+	if err := migration.Finish(); err != nil {
+		t.Fatalf("结束迁移失败: %v", err)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("迁移完成后状态文件应被删除")
+	}
+}
+
+func TestMigrationService_Resume(t *testing.T) {
+	tempDir := t.TempDir()
+	source := repository.NewFileTaskRepository(filepath.Join(tempDir, "source"))
+	seedFileRepository(t, source, 5)
+	target := repository.NewFileTaskRepository(filepath.Join(tempDir, "target"))
+	statePath := filepath.Join(tempDir, "migrate-state.json")
+
+	// 第一次迁移在写入 2 个任务后中断
+	interrupted := &failingTaskRepository{TaskRepository: target, remaining: 2}
+	_, err := NewMigrationService(source, interrupted, "file:source", "file:target", statePath).Migrate(nil)
+	if err == nil {
+		t.Fatal("期望迁移中断返回错误")
+	}
+
+	// 再次迁移应从断点继续
+	migration := NewMigrationService(source, target, "file:source", "file:target", statePath)
+	result, err := migration.Migrate(nil)
+	if err != nil {
+		t.Fatalf("继续迁移失败: %v", err)
+	}
+	if result.Copied != 3 || result.Skipped != 2 {
+		t.Errorf("断点续传统计不正确: %+v", result)
+	}
+	if err := migration.Verify(); err != nil {
+		t.Fatalf("迁移校验失败: %v", err)
+	}
+
+	// 状态文件属于其他迁移时应拒绝继续
+	other := NewMigrationService(source, target, "file:other", "file:target", statePath)
+	if _, err := other.Migrate(nil); err == nil {
+		t.Error("状态文件不匹配时应该返回错误")
+	}
+}
+
+func TestMigrationService_VerifyDetectsMismatch(t *testing.T) {
+	tempDir := t.TempDir()
+	source := repository.NewFileTaskRepository(filepath.Join(tempDir, "source"))
+	seedFileRepository(t, source, 3)
+	target := repository.NewFileTaskRepository(filepath.Join(tempDir, "target"))
+
+	migration := NewMigrationService(source, target, "file:source", "file:target",
+		filepath.Join(tempDir, "migrate-state.json"))
+	if _, err := migration.Migrate(nil); err != nil {
+		t.Fatalf("迁移失败: %v", err)
+	}
+
+	// 修改目标中的一个任务
+	task, _ := target.GetByDate(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	task.Content = "被篡改的内容"
+	if err := target.Save(task); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	if err := migration.Verify(); err == nil {
+		t.Error("内容不一致时校验应该失败")
+	}
+}
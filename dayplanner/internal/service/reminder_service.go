@@ -1,13 +1,16 @@
 package service
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/notifier"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/schedule"
 	"daily-report-tool/internal/util"
 )
 
@@ -21,15 +24,46 @@ type ReminderService interface {
 
 	// SendReminder 发送提醒消息
 	SendReminder(message string) error
+
+	// RemindOnce 立即检查今天是否已填写日报，未填写则发送提醒，返回是否发送了提醒
+	RemindOnce() (bool, error)
+
+	// RemindTeamOnce 立即检查团队今天的填报情况，@ 所有还没有填写日报的成员，返回提醒的成员名
+	RemindTeamOnce() ([]string, error)
 }
 
+// 未填写日报时发送的提醒标题和内容
+const (
+	reminderSubject = "日报提醒"
+	reminderMessage = "提醒：您今天还没有填写日报，请及时记录工作内容。"
+
+	// teamReminderMessage 团队提醒的默认内容，%s 为未填写的成员名
+	teamReminderMessage = "提醒：%s 今天还没有填写日报，请及时记录工作内容。"
+)
+
+// 发送重试与发件箱参数
+const (
+	sendAttempts      = 3                // 每次发送的立即尝试次数
+	outboxMaxAttempts = 10               // 发件箱中每条消息的最大重发次数
+	outboxMaxSize     = 50               // 发件箱最多保留的消息数，超出时丢弃最早的消息
+	outboxBaseDelay   = time.Minute      // 发件箱首次重发的等待时间
+	outboxMaxDelay    = 30 * time.Minute // 发件箱重发的最长等待时间
+)
+
+// sendRetryDelay 立即重试的初始等待时间，之后每次翻倍
+var sendRetryDelay = 2 * time.Second
+
 // ReminderServiceImpl 提醒服务实现
 type ReminderServiceImpl struct {
 	configService ConfigService
 	taskService   TaskService
+	teamService   TeamService                        // 可选，设置后支持团队提醒规则
+	stateRepo     repository.ReminderStateRepository // 可选，设置后状态在重启后保留
 	ticker        *time.Ticker
 	stopChan      chan bool
-	lastSentDate  string // 记录上次发送提醒的日期，防止重复发送
+	lastSent      map[string]string     // 各提醒规则上次处理的日期，防止重复发送
+	outbox        []model.OutboxMessage // 发送失败、等待重发的消息
+	stateLoaded   bool
 	mu            sync.Mutex
 	running       bool
 }
@@ -39,11 +73,25 @@ func NewReminderService(configService ConfigService, taskService TaskService) *R
 	return &ReminderServiceImpl{
 		configService: configService,
 		taskService:   taskService,
-		stopChan:      make(chan bool),
-		lastSentDate:  "",
+		lastSent:      make(map[string]string),
 	}
 }
 
+// SetStateRepository 设置状态仓库，用于持久化防重复记录和发件箱
+func (s *ReminderServiceImpl) SetStateRepository(stateRepo repository.ReminderStateRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stateRepo = stateRepo
+	s.stateLoaded = false
+}
+
+// SetTeamService 设置团队服务，用于团队提醒规则和 RemindTeamOnce
+func (s *ReminderServiceImpl) SetTeamService(teamService TeamService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teamService = teamService
+}
+
 // Start 启动提醒服务
 func (s *ReminderServiceImpl) Start() error {
 	s.mu.Lock()
@@ -71,10 +119,18 @@ func (s *ReminderServiceImpl) Start() error {
 	s.ticker = time.NewTicker(1 * time.Minute)
 	s.running = true
 
-	util.Info("提醒服务已启动，提醒时间: %s", config.ReminderTime)
+	if len(config.Reminders) > 0 {
+		util.Info("提醒服务已启动，提醒规则: %d 条", len(config.Reminders))
+	} else {
+		util.Info("提醒服务已启动，提醒时间: %s", config.ReminderTime)
+	}
 
-	// 启动后台 goroutine 进行定时检查
-	go s.checkReminder()
+	// 启动后台 goroutine 进行定时检查，启动时立即检查一次以补发错过的提醒
+	s.stopChan = make(chan bool)
+	go func(ticker *time.Ticker, stopChan chan bool) {
+		s.performReminderCheck()
+		s.checkReminder(ticker, stopChan)
+	}(s.ticker, s.stopChan)
 
 	return nil
 }
@@ -91,25 +147,27 @@ func (s *ReminderServiceImpl) Stop() {
 	if s.ticker != nil {
 		s.ticker.Stop()
 	}
-
-	s.stopChan <- true
+	// 关闭通道通知后台 goroutine 退出，不等待正在进行的发送完成
+	close(s.stopChan)
 	s.running = false
 	util.Info("提醒服务已停止")
 }
 
 // checkReminder 定时检查是否需要发送提醒
-func (s *ReminderServiceImpl) checkReminder() {
+func (s *ReminderServiceImpl) checkReminder(ticker *time.Ticker, stopChan chan bool) {
 	for {
 		select {
-		case <-s.ticker.C:
+		case <-ticker.C:
 			s.performReminderCheck()
-		case <-s.stopChan:
+		case <-stopChan:
 			return
 		}
 	}
 }
 
 // performReminderCheck 执行提醒检查逻辑
+// 每条规则在当天最近一次触发时间之后、补发截止时间之前都可以发送，
+// 因此休眠或程序未运行错过的提醒会在下一次检查时补发
 func (s *ReminderServiceImpl) performReminderCheck() {
 	// 获取配置
 	config, err := s.configService.GetConfig()
@@ -124,120 +182,383 @@ func (s *ReminderServiceImpl) performReminderCheck() {
 		return
 	}
 
-	// 检查当前时间是否匹配提醒时间
 	now := time.Now()
-	currentTime := now.Format("15:04")
+	s.flushOutbox(config, now)
 
-	if currentTime != config.ReminderTime {
-		return // 不是提醒时间
+	rules, err := schedule.RulesFromConfig(config)
+	if err != nil {
+		util.Error("提醒规则配置无效: %v", err)
+		return
 	}
 
-	util.Debug("到达提醒时间: %s", currentTime)
-
-	// 检查今天是否已经发送过提醒
 	today := now.Format("2006-01-02")
-	s.mu.Lock()
-	if s.lastSentDate == today {
-		s.mu.Unlock()
-		util.Debug("今天已发送过提醒，跳过")
-		return // 今天已经发送过提醒
+	calendar := schedule.NewCalendar()
+	for _, rule := range rules {
+		if rule.WorkdaysOnly {
+			// 仅在需要判断工作日时加载节假日日历
+			if calendar, err = schedule.LoadCalendar(config.HolidayFile); err != nil {
+				util.Error("加载节假日日历失败: %v", err)
+				calendar = schedule.NewCalendar()
+			}
+			break
+		}
+	}
+
+	for _, rule := range rules {
+		// 检查该规则今天是否已经处理过
+		if s.handledOn(rule.Name) == today {
+			continue
+		}
+
+		dueAt, ok := rule.LastDue(now, calendar)
+		if !ok {
+			continue // 今天还没到提醒时间，或今天不是工作日
+		}
+		if !now.Before(rule.CutoffTime(now)) {
+			util.Debug("提醒规则 %s 已过补发截止时间，跳过", rule.Name)
+			continue
+		}
+		if now.Sub(dueAt) >= time.Minute {
+			util.Info("补发错过的提醒: %s (应于 %s 发送)", rule.Name, dueAt.Format("15:04"))
+		} else {
+			util.Debug("到达提醒时间: %s (%s)", rule.Name, dueAt.Format("15:04"))
+		}
+
+		if rule.Team {
+			s.performTeamReminder(config, rule, now)
+			continue
+		}
+
+		// 检查今天是否有任务，检查失败时下一分钟重试
+		hasTask, err := s.taskService.HasTodayTask()
+		if err != nil {
+			util.Error("检查今天任务失败: %v", err)
+			continue
+		}
+
+		// 记录处理日期，防止重复发送；发送失败的渠道由发件箱负责重发
+		s.markHandled(rule.Name, today)
+		if hasTask {
+			util.Debug("今天已有任务，不需要提醒")
+			continue
+		}
+
+		message := rule.Message
+		if message == "" {
+			message = reminderMessage
+		}
+		if err := s.sendTo(config, notifier.Message{Subject: reminderSubject, Text: message}, rule.Channels, rule.CutoffTime(now)); err != nil {
+			util.Error("发送提醒失败 (%s): %v", rule.Name, err)
+			fmt.Printf("发送提醒失败: %v\n", err)
+			continue
+		}
+
+		util.Info("提醒已发送: %s (%s)", today, rule.Name)
+		fmt.Printf("提醒已发送: %s\n", today)
 	}
+}
+
+// performTeamReminder 执行一条团队提醒规则：只 @ 还没有填写日报的成员，所有人都已填写时不发送
+func (s *ReminderServiceImpl) performTeamReminder(config *model.Config, rule *schedule.Rule, now time.Time) {
+	s.mu.Lock()
+	teamService := s.teamService
 	s.mu.Unlock()
+	if teamService == nil {
+		util.Warn("提醒规则 %s 是团队提醒，但未连接团队服务器，跳过", rule.Name)
+		s.markHandled(rule.Name, now.Format("2006-01-02"))
+		return
+	}
 
-	// 检查今天是否有任务
-	hasTask, err := s.taskService.HasTodayTask()
+	// 获取填报情况失败时下一分钟重试
+	missing, err := s.missingMembers(teamService, now)
 	if err != nil {
-		util.Error("检查今天任务失败: %v", err)
-		fmt.Printf("检查今天任务失败: %v\n", err)
+		util.Error("获取团队填报情况失败: %v", err)
 		return
 	}
-
-	if hasTask {
-		util.Debug("今天已有任务，不需要提醒")
-		return // 今天已有任务，不需要提醒
+	s.markHandled(rule.Name, now.Format("2006-01-02"))
+	if len(missing) == 0 {
+		util.Debug("团队成员今天都已填写日报，不需要提醒")
+		return
 	}
 
-	// 发送提醒
-	message := "提醒：您今天还没有填写日报，请及时记录工作内容。"
-	if err := s.SendReminder(message); err != nil {
-		util.Error("发送提醒失败: %v", err)
-		fmt.Printf("发送提醒失败: %v\n", err)
+	if err := s.sendTo(config, teamReminder(missing, rule.Message), rule.Channels, rule.CutoffTime(now)); err != nil {
+		util.Error("发送团队提醒失败 (%s): %v", rule.Name, err)
 		return
 	}
+	util.Info("团队提醒已发送: %s (%s)，未填写 %d 人", now.Format("2006-01-02"), rule.Name, len(missing))
+}
 
-	// 记录发送日期，防止重复发送
+// RemindTeamOnce 立即检查团队今天的填报情况并 @ 还没有填写日报的成员，返回提醒的成员名
+// 不检查提醒时间和防重复记录；所有人都已填写时不发送，返回空列表
+func (s *ReminderServiceImpl) RemindTeamOnce() ([]string, error) {
 	s.mu.Lock()
-	s.lastSentDate = today
+	teamService := s.teamService
 	s.mu.Unlock()
+	if teamService == nil {
+		return nil, fmt.Errorf("未连接团队服务器")
+	}
+
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	now := time.Now()
+	s.flushOutbox(config, now)
+
+	missing, err := s.missingMembers(teamService, now)
+	if err != nil {
+		return nil, fmt.Errorf("获取团队填报情况失败: %w", err)
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	if err := s.sendTo(config, teamReminder(missing, ""), nil, endOfDay(now)); err != nil {
+		return nil, err
+	}
 
-	util.Info("提醒已发送: %s", today)
-	fmt.Printf("提醒已发送: %s\n", today)
+	names := make([]string, len(missing))
+	for i, member := range missing {
+		names[i] = member.Name
+	}
+	return names, nil
 }
 
-// SendReminder 发送提醒消息
-func (s *ReminderServiceImpl) SendReminder(message string) error {
-	util.Info("准备发送提醒消息: %s", message)
+// missingMembers 返回今天还没有填写日报的成员
+func (s *ReminderServiceImpl) missingMembers(teamService TeamService, now time.Time) ([]model.MemberStatus, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	status, err := teamService.Status(today)
+	if err != nil {
+		return nil, err
+	}
+	return status.Missing(), nil
+}
 
-	// 获取配置
+// teamReminder 生成 @ 未填写成员的提醒消息，message 为空时使用默认内容
+// 没有配置 mention 的成员只在正文中列出名字
+func teamReminder(missing []model.MemberStatus, message string) notifier.Message {
+	names := make([]string, 0, len(missing))
+	var mentions []string
+	for _, member := range missing {
+		names = append(names, member.Name)
+		if member.Mention != "" {
+			mentions = append(mentions, member.Mention)
+		}
+	}
+	text := fmt.Sprintf(teamReminderMessage, strings.Join(names, "、"))
+	if message != "" {
+		text = message + "\n未填写: " + strings.Join(names, "、")
+	}
+	return notifier.Message{Subject: reminderSubject, Text: text, Mentions: mentions}
+}
+
+// RemindOnce 立即检查今天是否已填写日报，未填写则发送提醒，返回是否发送了提醒
+// 不检查提醒时间和防重复记录，供定时检查和命令行单次提醒使用；发送前先重发发件箱中到期的消息
+func (s *ReminderServiceImpl) RemindOnce() (bool, error) {
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return false, fmt.Errorf("获取配置失败: %w", err)
+	}
+	s.flushOutbox(config, time.Now())
+
+	hasTask, err := s.taskService.HasTodayTask()
+	if err != nil {
+		return false, fmt.Errorf("检查今天任务失败: %w", err)
+	}
+
+	if hasTask {
+		util.Debug("今天已有任务，不需要提醒")
+		return false, nil // 今天已有任务，不需要提醒
+	}
+
+	// 发送提醒
+	if err := s.sendTo(config, notifier.Message{Subject: reminderSubject, Text: reminderMessage}, nil, endOfDay(time.Now())); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SendReminder 发送提醒消息到所有启用的通知渠道
+// 单个渠道失败不影响其他渠道，失败的渠道加入发件箱稍后重发，返回所有失败渠道的合并错误
+func (s *ReminderServiceImpl) SendReminder(message string) error {
 	config, err := s.configService.GetConfig()
 	if err != nil {
 		util.Error("获取配置失败: %v", err)
 		return fmt.Errorf("获取配置失败: %w", err)
 	}
+	return s.sendTo(config, notifier.Message{Subject: reminderSubject, Text: message}, nil, endOfDay(time.Now()))
+}
+
+// sendTo 发送提醒消息到指定名称的通知渠道，channels 为空表示所有启用的渠道
+// 每个渠道失败时按指数退避立即重试，仍失败则加入发件箱，在 expiresAt 之前继续重发
+func (s *ReminderServiceImpl) sendTo(config *model.Config, msg notifier.Message, channels []string, expiresAt time.Time) error {
+	util.Info("准备发送提醒消息: %s", msg.Text)
 
-	// 检查 Webhook URL
-	if config.WebhookURL == "" {
-		util.Warn("Webhook URL 未配置")
-		return fmt.Errorf("Webhook URL 未配置")
+	notifiers, err := notifier.FromConfig(config)
+	if err != nil {
+		util.Error("通知渠道配置无效: %v", err)
+		return fmt.Errorf("通知渠道配置无效: %w", err)
+	}
+	if notifiers, err = notifiers.Select(channels); err != nil {
+		util.Error("选择通知渠道失败: %v", err)
+		return err
+	}
+	if len(notifiers) == 0 {
+		util.Warn("未配置通知渠道")
+		return fmt.Errorf("未配置通知渠道")
 	}
 
-	// 构建企业微信消息体
-	payload := map[string]interface{}{
-		"msgtype": "text",
-		"text": map[string]string{
-			"content": message,
-		},
+	var errs []error
+	for _, n := range notifiers {
+		if err := notifier.WithRetry(n, sendAttempts, sendRetryDelay).Send(msg); err != nil {
+			util.Error("通知渠道 %s 发送失败，已加入发件箱: %v", n.Name(), err)
+			s.enqueue(n.Name(), msg, expiresAt, err)
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			continue
+		}
+		util.Info("通知渠道 %s 发送成功", n.Name())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("发送提醒失败: %w", errors.Join(errs...))
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		util.Error("序列化消息失败: %v", err)
-		return fmt.Errorf("序列化消息失败: %w", err)
+	util.Info("提醒消息发送成功")
+	return nil
+}
+
+// enqueue 将发送失败的消息加入发件箱
+func (s *ReminderServiceImpl) enqueue(channel string, message notifier.Message, expiresAt time.Time, sendErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadStateLocked()
+
+	s.outbox = append(s.outbox, model.OutboxMessage{
+		Channel:     channel,
+		Subject:     message.Subject,
+		Text:        message.Text,
+		Mentions:    message.Mentions,
+		Attempts:    1,
+		NextAttempt: time.Now().Add(outboxBaseDelay),
+		ExpiresAt:   expiresAt,
+		LastError:   sendErr.Error(),
+	})
+	if len(s.outbox) > outboxMaxSize {
+		util.Warn("发件箱已满，丢弃最早的 %d 条消息", len(s.outbox)-outboxMaxSize)
+		s.outbox = s.outbox[len(s.outbox)-outboxMaxSize:]
 	}
+	s.saveStateLocked()
+}
 
-	// 发送 HTTP POST 请求
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// flushOutbox 重发发件箱中到期的消息，成功、过期或超过重试次数的消息从发件箱移除
+func (s *ReminderServiceImpl) flushOutbox(config *model.Config, now time.Time) {
+	s.mu.Lock()
+	s.loadStateLocked()
+	pending := s.outbox
+	s.outbox = nil
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
 	}
 
-	util.Debug("发送 Webhook 请求到: %s", config.WebhookURL)
-	resp, err := client.Post(config.WebhookURL, "application/json", bytes.NewBuffer(jsonData))
+	notifiers, err := notifier.FromConfig(config)
 	if err != nil {
-		util.Error("发送 Webhook 请求失败: %v", err)
-		return fmt.Errorf("发送 Webhook 请求失败: %w", err)
+		util.Error("通知渠道配置无效，暂不重发: %v", err)
+		notifiers = nil
 	}
-	defer resp.Body.Close()
 
-	// 检查响应状态
-	if resp.StatusCode != http.StatusOK {
-		util.Error("Webhook 请求失败，状态码: %d", resp.StatusCode)
-		return fmt.Errorf("Webhook 请求失败，状态码: %d", resp.StatusCode)
+	var remaining []model.OutboxMessage
+	for _, message := range pending {
+		if !message.ExpiresAt.IsZero() && now.After(message.ExpiresAt) {
+			util.Warn("发件箱消息已过期，不再重发: %s", message.Channel)
+			continue
+		}
+		if now.Before(message.NextAttempt) || notifiers == nil {
+			remaining = append(remaining, message)
+			continue
+		}
+
+		selected, err := notifiers.Select([]string{message.Channel})
+		if err != nil {
+			util.Warn("发件箱消息的通知渠道已不存在，丢弃: %s", message.Channel)
+			continue
+		}
+
+		err = selected[0].Send(notifier.Message{Subject: message.Subject, Text: message.Text, Mentions: message.Mentions})
+		if err == nil {
+			util.Info("发件箱消息重发成功: %s (第 %d 次)", message.Channel, message.Attempts+1)
+			continue
+		}
+
+		message.Attempts++
+		message.LastError = err.Error()
+		if message.Attempts >= outboxMaxAttempts {
+			util.Error("发件箱消息重发 %d 次仍失败，放弃: %s, 错误: %v", message.Attempts, message.Channel, err)
+			continue
+		}
+		message.NextAttempt = now.Add(notifier.Backoff(message.Attempts, outboxBaseDelay, outboxMaxDelay))
+		util.Warn("发件箱消息重发失败，将于 %s 再次尝试: %s, 错误: %v",
+			message.NextAttempt.Format("15:04"), message.Channel, err)
+		remaining = append(remaining, message)
 	}
 
-	// 读取响应体
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		util.Error("解析响应失败: %v", err)
-		return fmt.Errorf("解析响应失败: %w", err)
+	s.mu.Lock()
+	// 重发期间新加入的消息排在后面
+	s.outbox = append(remaining, s.outbox...)
+	s.saveStateLocked()
+	s.mu.Unlock()
+}
+
+// handledOn 返回规则上次处理的日期
+func (s *ReminderServiceImpl) handledOn(rule string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadStateLocked()
+	return s.lastSent[rule]
+}
+
+// markHandled 记录规则的处理日期并保存状态
+func (s *ReminderServiceImpl) markHandled(rule, date string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadStateLocked()
+	s.lastSent[rule] = date
+	s.saveStateLocked()
+}
+
+// loadStateLocked 首次使用时从状态仓库加载状态，调用方需持有锁
+func (s *ReminderServiceImpl) loadStateLocked() {
+	if s.stateLoaded || s.stateRepo == nil {
+		return
 	}
+	s.stateLoaded = true
 
-	// 检查企业微信返回的错误码
-	if errcode, ok := result["errcode"].(float64); ok && errcode != 0 {
-		errmsg := result["errmsg"].(string)
-		util.Error("企业微信返回错误: %s (错误码: %.0f)", errmsg, errcode)
-		return fmt.Errorf("企业微信返回错误: %s (错误码: %.0f)", errmsg, errcode)
+	state, err := s.stateRepo.Load()
+	if err != nil {
+		util.Error("加载提醒状态失败: %v", err)
+		return
 	}
+	for rule, date := range state.LastSent {
+		s.lastSent[rule] = date
+	}
+	s.outbox = append(state.Outbox, s.outbox...)
+	util.Debug("已加载提醒状态: %d 条规则记录, 发件箱 %d 条", len(state.LastSent), len(state.Outbox))
+}
 
-	util.Info("提醒消息发送成功")
-	return nil
+// saveStateLocked 保存状态到状态仓库，调用方需持有锁
+func (s *ReminderServiceImpl) saveStateLocked() {
+	if s.stateRepo == nil {
+		return
+	}
+	state := &model.ReminderState{
+		LastSent: s.lastSent,
+		Outbox:   s.outbox,
+	}
+	if err := s.stateRepo.Save(state); err != nil {
+		util.Error("保存提醒状态失败: %v", err)
+	}
+}
+
+// endOfDay 返回日期当天的最后时刻
+func endOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
 }
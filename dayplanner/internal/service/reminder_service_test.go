@@ -1,6 +1,9 @@
 package service
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"testing"
 	"time"
@@ -9,6 +12,11 @@ import (
 	"daily-report-tool/internal/repository"
 )
 
+func init() {
+	// 测试中缩短立即重试的等待时间
+	sendRetryDelay = time.Millisecond
+}
+
 // mockConfigService 用于测试的配置服务 mock
 type mockConfigService struct {
 	config *model.Config
@@ -48,6 +56,22 @@ func (m *mockTaskService) HasTodayTask() (bool, error) {
 	return m.hasTask, nil
 }
 
+func (m *mockTaskService) Search(query string) ([]*model.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) GetTasksInRange(startDate, endDate time.Time) ([]*model.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) UpdateContent(date time.Time, update func(content string) (string, error)) error {
+	return nil
+}
+
+func (m *mockTaskService) MarkSubmitted(date time.Time, channels []string) error {
+	return nil
+}
+
 func TestReminderService_StartStop(t *testing.T) {
 	// 创建 mock 服务
 	configService := &mockConfigService{
@@ -146,13 +170,21 @@ func TestReminderService_SendReminder(t *testing.T) {
 }
 
 func TestReminderService_PreventDuplicateReminders(t *testing.T) {
-	// 创建 mock 服务
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	// 创建 mock 服务，规则每分钟都会触发
 	configService := &mockConfigService{
 		config: &model.Config{
-			WebhookURL:      "https://example.com/webhook",
+			WebhookURL:      server.URL,
 			ReminderTime:    "10:00",
 			ReminderEnabled: true,
 			DataPath:        "./data/tasks",
+			Reminders:       []model.ReminderRule{{Name: "always", Cron: "* * * * *"}},
 		},
 	}
 	taskService := &mockTaskService{hasTask: false}
@@ -162,10 +194,249 @@ func TestReminderService_PreventDuplicateReminders(t *testing.T) {
 
 	// 设置今天已发送
 	today := time.Now().Format("2006-01-02")
-	reminderService.lastSentDate = today
+	reminderService.lastSent["always"] = today
 
 	// 验证防重复机制
-	if reminderService.lastSentDate != today {
-		t.Errorf("lastSentDate 应该是今天的日期")
+	reminderService.performReminderCheck()
+	if requests != 0 {
+		t.Errorf("今天已发送过的规则不应再次发送，实际请求 %d 次", requests)
+	}
+
+	// 未发送过的规则应发送并记录日期
+	delete(reminderService.lastSent, "always")
+	reminderService.performReminderCheck()
+	reminderService.performReminderCheck()
+	if requests != 1 {
+		t.Errorf("同一规则每天只应发送一次，实际请求 %d 次", requests)
+	}
+	if reminderService.lastSent["always"] != today {
+		t.Errorf("lastSent 应该记录今天的日期")
+	}
+}
+
+func TestReminderService_Escalation(t *testing.T) {
+	received := make(map[string]string)
+	newServer := func(name string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			received[name] = body["text"]
+			w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	teamServer := newServer("team")
+	leaderServer := newServer("leader")
+
+	configService := &mockConfigService{
+		config: &model.Config{
+			ReminderTime:    "10:00",
+			ReminderEnabled: true,
+			Channels: []model.ChannelConfig{
+				{Name: "team", Type: model.ChannelTypeSlack, URL: teamServer.URL},
+				{Name: "leader", Type: model.ChannelTypeSlack, URL: leaderServer.URL},
+			},
+			Reminders: []model.ReminderRule{
+				{Name: "first", Cron: "* * * * *", Channels: []string{"team"}},
+				{Name: "urgent", Cron: "* * * * *", Message: "紧急：日报仍未填写", Channels: []string{"leader"}},
+				{Name: "later", Cron: "0 0 1 1 *", Message: "不应触发"},
+			},
+		},
+	}
+	reminderService := NewReminderService(configService, &mockTaskService{hasTask: false})
+	reminderService.performReminderCheck()
+
+	if received["team"] != reminderMessage {
+		t.Errorf("第一条规则应使用默认内容发送到 team: %q", received["team"])
+	}
+	if received["leader"] != "紧急：日报仍未填写" {
+		t.Errorf("升级规则应发送到 leader: %q", received["leader"])
+	}
+	if _, ok := reminderService.lastSent["later"]; ok && time.Now().Format("01-02") != "01-01" {
+		t.Error("未到时间的规则不应触发")
+	}
+
+	// 已填写日报时不再发送
+	received = make(map[string]string)
+	reminderService = NewReminderService(configService, &mockTaskService{hasTask: true})
+	reminderService.performReminderCheck()
+	if len(received) != 0 {
+		t.Errorf("已填写日报时不应发送提醒: %v", received)
+	}
+}
+
+func TestReminderService_CatchUp(t *testing.T) {
+	now := time.Now()
+	if now.Hour() == 0 && now.Minute() == 0 {
+		t.Skip("零点整无法区分补发与截止")
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	// 规则在零点触发，模拟休眠期间错过了提醒时间
+	config := &model.Config{
+		WebhookURL:      server.URL,
+		ReminderTime:    "00:00",
+		ReminderEnabled: true,
+	}
+	reminderService := NewReminderService(&mockConfigService{config: config}, &mockTaskService{hasTask: false})
+	reminderService.performReminderCheck()
+	if requests != 1 {
+		t.Errorf("错过的提醒应在唤醒后补发，实际请求 %d 次", requests)
+	}
+
+	// 超过截止时间后不再补发
+	config.ReminderCutoff = "00:00"
+	reminderService = NewReminderService(&mockConfigService{config: config}, &mockTaskService{hasTask: false})
+	reminderService.performReminderCheck()
+	if requests != 1 {
+		t.Errorf("超过截止时间不应补发，实际请求 %d 次", requests)
+	}
+}
+
+func TestReminderService_PersistState(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	configService := &mockConfigService{
+		config: &model.Config{
+			WebhookURL:      server.URL,
+			ReminderEnabled: true,
+			Reminders:       []model.ReminderRule{{Name: "always", Cron: "* * * * *"}},
+		},
+	}
+	stateRepo := repository.NewFileReminderStateRepository(filepath.Join(t.TempDir(), "reminder_state.json"))
+
+	reminderService := NewReminderService(configService, &mockTaskService{hasTask: false})
+	reminderService.SetStateRepository(stateRepo)
+	reminderService.performReminderCheck()
+
+	// 模拟程序重启：新的服务实例从状态文件恢复记录，不会重复发送
+	restarted := NewReminderService(configService, &mockTaskService{hasTask: false})
+	restarted.SetStateRepository(stateRepo)
+	restarted.performReminderCheck()
+
+	if requests != 1 {
+		t.Errorf("重启后不应重复发送提醒，实际请求 %d 次", requests)
+	}
+}
+
+func TestReminderService_Outbox(t *testing.T) {
+	failing := true
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	configService := &mockConfigService{
+		config: &model.Config{
+			ReminderTime: "10:00",
+			Channels:     []model.ChannelConfig{{Name: "team", Type: model.ChannelTypeSlack, URL: server.URL}},
+		},
+	}
+	stateRepo := repository.NewFileReminderStateRepository(filepath.Join(t.TempDir(), "reminder_state.json"))
+	reminderService := NewReminderService(configService, &mockTaskService{hasTask: false})
+	reminderService.SetStateRepository(stateRepo)
+
+	// 立即重试用尽后进入发件箱
+	if err := reminderService.SendReminder("测试消息"); err == nil {
+		t.Fatal("渠道失败时应返回错误")
+	}
+	if requests != sendAttempts {
+		t.Errorf("应立即重试 %d 次，实际请求 %d 次", sendAttempts, requests)
+	}
+	state, err := stateRepo.Load()
+	if err != nil || len(state.Outbox) != 1 || state.Outbox[0].Channel != "team" {
+		t.Fatalf("失败的消息应保存到发件箱: %+v, %v", state, err)
+	}
+
+	// 未到重发时间时不发送
+	reminderService.flushOutbox(configService.config, time.Now())
+	if requests != sendAttempts {
+		t.Errorf("未到重发时间不应发送，实际请求 %d 次", requests)
+	}
+
+	// 到达重发时间且渠道恢复后重发成功并移出发件箱
+	failing = false
+	reminderService.flushOutbox(configService.config, time.Now().Add(outboxBaseDelay))
+	if requests != sendAttempts+1 {
+		t.Errorf("到达重发时间应重发一次，实际请求 %d 次", requests)
+	}
+	if state, _ := stateRepo.Load(); len(state.Outbox) != 0 {
+		t.Errorf("重发成功后发件箱应为空: %+v", state.Outbox)
+	}
+}
+
+// stubTeamService 返回固定填报情况的团队服务
+type stubTeamService struct {
+	members []model.MemberStatus
+}
+
+func (s *stubTeamService) Status(date time.Time) (*model.TeamStatus, error) {
+	return &model.TeamStatus{Date: date, Members: s.members}, nil
+}
+
+func (s *stubTeamService) GetMemberTask(name string, date time.Time) (*model.Task, error) {
+	return nil, nil
+}
+
+func TestReminderService_TeamReminder(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	configService := &mockConfigService{
+		config: &model.Config{
+			ReminderTime:    "10:00",
+			ReminderEnabled: true,
+			Channels:        []model.ChannelConfig{{Name: "team", Type: model.ChannelTypeWeCom, URL: server.URL}},
+			Reminders:       []model.ReminderRule{{Name: "team", Cron: "* * * * *", Team: true}},
+		},
+	}
+	teamService := &stubTeamService{members: []model.MemberStatus{
+		{Name: "alice", Mention: "alice", Filed: true},
+		{Name: "bob", Mention: "bob"},
+		{Name: "carol"},
+	}}
+	// 本人已填写日报时团队提醒仍然检查其他成员
+	reminderService := NewReminderService(configService, &mockTaskService{hasTask: true})
+	reminderService.SetTeamService(teamService)
+	reminderService.performReminderCheck()
+
+	text, _ := received["text"].(map[string]any)
+	if text["content"] != "提醒：bob、carol 今天还没有填写日报，请及时记录工作内容。" {
+		t.Errorf("团队提醒内容不正确: %v", text["content"])
+	}
+	if mentions, _ := text["mentioned_list"].([]any); len(mentions) != 1 || mentions[0] != "bob" {
+		t.Errorf("只应 @ 未填写且配置了 mention 的成员: %v", text["mentioned_list"])
+	}
+
+	// 所有人都已填写时不发送
+	received = nil
+	for i := range teamService.members {
+		teamService.members[i].Filed = true
+	}
+	names, err := reminderService.RemindTeamOnce()
+	if err != nil || len(names) != 0 || received != nil {
+		t.Errorf("所有人都已填写时不应发送提醒: %v, %v, %v", names, err, received)
 	}
 }
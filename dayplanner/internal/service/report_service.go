@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// blockedKeywords 未勾选任务项中包含这些关键词时视为阻塞（不区分大小写）
+var blockedKeywords = []string{"阻塞", "受阻", "blocked"}
+
+// headingPattern 匹配 ATX 标题行
+var headingPattern = regexp.MustCompile(`^(#{1,6})(\s|$)`)
+
+// reportHeadingOffset 每日内容嵌入报告时标题下移的级数（报告本身占用 1~3 级标题）
+const reportHeadingOffset = 3
+
+// ItemStatus 汇总中任务项的状态
+type ItemStatus int
+
+const (
+	ItemStatusDone       ItemStatus = iota // 已完成
+	ItemStatusInProgress                   // 进行中
+	ItemStatusBlocked                      // 阻塞
+)
+
+// ReportItem 汇总中的一个去重后的任务项
+type ReportItem struct {
+	Text   string     // 任务项原始 Markdown 文本
+	Status ItemStatus // 最后一次出现时的状态
+}
+
+// ReportService 定义周报/月报生成服务接口
+type ReportService interface {
+	// GenerateReport 汇总日期范围内（含首尾）的日报，生成 Markdown 文档
+	GenerateReport(title string, startDate, endDate time.Time) (string, error)
+
+	// GenerateWeeklyReport 生成指定日期所在周（周一至周日）的周报
+	GenerateWeeklyReport(date time.Time) (string, error)
+
+	// GenerateMonthlyReport 生成指定月份的月报
+	GenerateMonthlyReport(year int, month time.Month) (string, error)
+}
+
+// ReportServiceImpl 报告生成服务实现
+type ReportServiceImpl struct {
+	taskService TaskService
+}
+
+// NewReportService 创建新的报告生成服务
+func NewReportService(taskService TaskService) *ReportServiceImpl {
+	return &ReportServiceImpl{
+		taskService: taskService,
+	}
+}
+
+// GenerateWeeklyReport 生成指定日期所在周（周一至周日）的周报
+func (s *ReportServiceImpl) GenerateWeeklyReport(date time.Time) (string, error) {
+	monday, sunday := util.WeekRange(date)
+	return s.GenerateReport("周报", monday, sunday)
+}
+
+// GenerateMonthlyReport 生成指定月份的月报
+func (s *ReportServiceImpl) GenerateMonthlyReport(year int, month time.Month) (string, error) {
+	first, last := util.MonthRange(year, month, time.Local)
+	return s.GenerateReport(fmt.Sprintf("%d年%d月 月报", year, month), first, last)
+}
+
+// GenerateReport 汇总日期范围内（含首尾）的日报，生成 Markdown 文档
+// 文档包含按状态分组的去重任务汇总，以及按天排列的日报原文
+func (s *ReportServiceImpl) GenerateReport(title string, startDate, endDate time.Time) (string, error) {
+	util.Info("生成报告: %s (%s ~ %s)", title, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	tasks, err := s.taskService.GetTasksInRange(startDate, endDate)
+	if err != nil {
+		util.Error("生成报告失败: %v", err)
+		return "", fmt.Errorf("获取日报失败: %w", err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s (%s ~ %s)\n\n", title, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	if len(tasks) == 0 {
+		sb.WriteString("该时间段内没有日报记录。\n")
+		return sb.String(), nil
+	}
+	fmt.Fprintf(&sb, "共 %d 天有日报记录。\n\n", len(tasks))
+
+	// 汇总部分
+	items := SummarizeChecklist(tasks)
+	sb.WriteString("## 工作汇总\n\n")
+	writeSummarySection(&sb, "已完成", items, ItemStatusDone)
+	writeSummarySection(&sb, "进行中", items, ItemStatusInProgress)
+	writeSummarySection(&sb, "阻塞", items, ItemStatusBlocked)
+
+	// 每日明细部分
+	sb.WriteString("## 每日明细\n\n")
+	for _, task := range tasks {
+		fmt.Fprintf(&sb, "### %s %s\n\n", task.Date.Format("2006-01-02"), util.ChineseWeekday(task.Date))
+		content := strings.TrimSpace(task.Content)
+		if content == "" {
+			sb.WriteString("（无内容）\n\n")
+			continue
+		}
+		sb.WriteString(demoteHeadings(content, reportHeadingOffset))
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String(), nil
+}
+
+// SummarizeChecklist 汇总多天日报中的任务列表项
+// 相同文本的任务项只保留一次，状态以最后一次出现为准，顺序按首次出现排列
+func SummarizeChecklist(tasks []*model.Task) []ReportItem {
+	var items []ReportItem
+	index := make(map[string]int)
+
+	for _, task := range tasks {
+		for _, checklistItem := range util.ParseChecklist(task.Content) {
+			key := normalizeItemText(checklistItem.Text)
+			if key == "" {
+				continue
+			}
+
+			status := ItemStatusInProgress
+			if checklistItem.Checked {
+				status = ItemStatusDone
+			} else if isBlockedItem(checklistItem.Text) {
+				status = ItemStatusBlocked
+			}
+
+			item := ReportItem{Text: checklistItem.Raw, Status: status}
+			if i, ok := index[key]; ok {
+				items[i] = item
+			} else {
+				index[key] = len(items)
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
+
+// writeSummarySection 输出指定状态的汇总小节
+func writeSummarySection(sb *strings.Builder, heading string, items []ReportItem, status ItemStatus) {
+	fmt.Fprintf(sb, "### %s\n\n", heading)
+	count := 0
+	for _, item := range items {
+		if item.Status == status {
+			fmt.Fprintf(sb, "- %s\n", item.Text)
+			count++
+		}
+	}
+	if count == 0 {
+		sb.WriteString("无\n")
+	}
+	sb.WriteString("\n")
+}
+
+// normalizeItemText 生成任务项去重用的键：合并空白并转为小写
+func normalizeItemText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// isBlockedItem 判断任务项是否标记为阻塞
+func isBlockedItem(text string) bool {
+	lower := strings.ToLower(text)
+	for _, keyword := range blockedKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// demoteHeadings 将 Markdown 中的标题下移指定级数（最多到 6 级），跳过代码块
+func demoteHeadings(content string, levels int) string {
+	lines := strings.Split(content, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		match := headingPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		level := len(match[1]) + levels
+		if level > 6 {
+			level = 6
+		}
+		lines[i] = strings.Repeat("#", level) + line[len(match[1]):]
+	}
+	return strings.Join(lines, "\n")
+}
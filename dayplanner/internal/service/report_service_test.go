@@ -0,0 +1,96 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+)
+
+func TestReportService_GenerateWeeklyReport(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	taskService := NewTaskService(taskRepo, tempDir)
+	reportService := NewReportService(taskService)
+
+	days := map[int]string{
+		10: "# 今日工作\n\n- [x] 完成需求评审\n- [ ] 开发登录接口\n- [ ] 等待运维开通权限（阻塞）",
+		11: "# 今日工作\n\n- [x] 开发登录接口\n- [ ] 编写单元测试",
+		14: "# 今日工作\n\n- [x] 编写单元测试\n\n```\n# 代码块中的标题不处理\n```",
+		17: "# 下周一的内容不应出现", // 不在 2025-11-10 所在周内
+	}
+	for day, content := range days {
+		if err := taskService.SaveTask(time.Date(2025, 11, day, 0, 0, 0, 0, time.Local), content); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	report, err := reportService.GenerateWeeklyReport(time.Date(2025, 11, 12, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("生成周报失败: %v", err)
+	}
+
+	mustContain := []string{
+		"# 周报 (2025-11-10 ~ 2025-11-16)",
+		"共 3 天有日报记录",
+		"### 2025-11-10 星期一",
+		"### 2025-11-14 星期五",
+		"#### 今日工作",
+		"# 代码块中的标题不处理",
+	}
+	for _, s := range mustContain {
+		if !strings.Contains(report, s) {
+			t.Errorf("周报缺少内容 %q:\n%s", s, report)
+		}
+	}
+	if strings.Contains(report, "下周一的内容") {
+		t.Errorf("周报不应包含范围外的日报")
+	}
+
+	// 检查汇总：状态以最后一次出现为准，且每项只出现一次
+	summary := report[strings.Index(report, "## 工作汇总"):strings.Index(report, "## 每日明细")]
+	done := summary[strings.Index(summary, "### 已完成"):strings.Index(summary, "### 进行中")]
+	inProgress := summary[strings.Index(summary, "### 进行中"):strings.Index(summary, "### 阻塞")]
+	blocked := summary[strings.Index(summary, "### 阻塞"):]
+
+	for _, item := range []string{"完成需求评审", "开发登录接口", "编写单元测试"} {
+		if !strings.Contains(done, item) {
+			t.Errorf("已完成中缺少 %s:\n%s", item, done)
+		}
+		if strings.Count(summary, item) != 1 {
+			t.Errorf("汇总中 %s 应只出现一次:\n%s", item, summary)
+		}
+	}
+	if !strings.Contains(inProgress, "无") {
+		t.Errorf("进行中应为空:\n%s", inProgress)
+	}
+	if !strings.Contains(blocked, "等待运维开通权限") {
+		t.Errorf("阻塞中缺少任务项:\n%s", blocked)
+	}
+}
+
+func TestReportService_GenerateMonthlyReport_Empty(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(tempDir)
+	reportService := NewReportService(NewTaskService(taskRepo, tempDir))
+
+	report, err := reportService.GenerateMonthlyReport(2025, time.February)
+	if err != nil {
+		t.Fatalf("生成月报失败: %v", err)
+	}
+	if !strings.Contains(report, "2025-02-01 ~ 2025-02-28") {
+		t.Errorf("月报日期范围不正确:\n%s", report)
+	}
+	if !strings.Contains(report, "没有日报记录") {
+		t.Errorf("空月报应提示没有记录:\n%s", report)
+	}
+}
+
+func TestDemoteHeadings(t *testing.T) {
+	content := "# 一级\n## 二级\n#### 四级\n#不是标题\n~~~\n# 代码\n~~~"
+	want := "#### 一级\n##### 二级\n###### 四级\n#不是标题\n~~~\n# 代码\n~~~"
+	if got := demoteHeadings(content, 3); got != want {
+		t.Errorf("标题下移结果不正确:\n期望:\n%s\n实际:\n%s", want, got)
+	}
+}
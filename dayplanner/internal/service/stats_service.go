@@ -0,0 +1,413 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"daily-report-tool/internal/schedule"
+	"daily-report-tool/internal/util"
+)
+
+// 没有标签或项目的耗时归入的分组名称
+const (
+	UntaggedName  = "（无标签）"
+	NoProjectName = "（无项目）"
+)
+
+// streakLookbackDays 计算当前连续填报天数时最多向前查找的天数
+const streakLookbackDays = 3660
+
+// StatsItem 按标签或项目汇总的耗时
+type StatsItem struct {
+	Name     string        // 标签或项目名称
+	Duration time.Duration // 记录的耗时之和
+	Entries  int           // 带有该标签或项目的记录数（含未记录耗时的）
+}
+
+// WeekStats 一周（周一至周日）的汇总
+type WeekStats struct {
+	Start        time.Time     // 周一
+	Duration     time.Duration // 记录的耗时之和
+	ReportedDays int           // 写了日报的天数
+}
+
+// Stats 日期范围内的工时和填报统计
+type Stats struct {
+	Start         time.Time
+	End           time.Time
+	TotalDuration time.Duration // 所有记录的耗时之和
+	Entries       int           // 工作记录（列表项和段落）数
+	ReportedDays  int           // 写了日报的天数，包括周末和节假日
+	Workdays      int           // 截至今天的工作日数
+	MissedDays    []time.Time   // 截至昨天没有写日报的工作日
+	CurrentStreak int           // 截至范围结束（或今天）连续填报的工作日数，今天尚未填写不算中断
+	LongestStreak int           // 范围内最长连续填报的工作日数
+	Tags          []StatsItem   // 按耗时从多到少排列
+	Projects      []StatsItem   // 按耗时从多到少排列
+	Weeks         []WeekStats   // 按日期排列，包括没有日报的周
+}
+
+// DayActivity 一天的日报活跃度，用于年度热力图
+type DayActivity struct {
+	Date     time.Time
+	Chars    int           // 日报字数（去除首尾空白后的字符数），没有日报时为 0
+	Duration time.Duration // 日报中记录的耗时之和
+	Workday  bool          // 按节假日日历判断是否为工作日
+	Holiday  bool          // 是否为日历中登记的节假日
+}
+
+// StatsService 定义工时和填报统计服务接口
+type StatsService interface {
+	// Compute 统计日期范围内（含首尾）的日报
+	Compute(startDate, endDate time.Time) (*Stats, error)
+
+	// Activity 返回日期范围内（含首尾）每天的活跃度，按日期排列，包括没有日报的日期
+	Activity(startDate, endDate time.Time) ([]DayActivity, error)
+}
+
+// StatsServiceImpl 统计服务实现
+type StatsServiceImpl struct {
+	taskService   TaskService
+	configService ConfigService
+	now           func() time.Time
+}
+
+// NewStatsService 创建新的统计服务
+func NewStatsService(taskService TaskService, configService ConfigService) *StatsServiceImpl {
+	return &StatsServiceImpl{
+		taskService:   taskService,
+		configService: configService,
+		now:           time.Now,
+	}
+}
+
+// Compute 使用 goldmark 解析范围内每篇日报中的工作记录，按标签、项目和周汇总耗时，并统计填报情况
+// 工作日按配置的节假日日历判断
+func (s *StatsServiceImpl) Compute(startDate, endDate time.Time) (*Stats, error) {
+	start := util.StartOfDay(startDate)
+	end := util.StartOfDay(endDate)
+	if end.Before(start) {
+		return nil, fmt.Errorf("结束日期不能早于开始日期")
+	}
+
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	calendar, err := schedule.LoadCalendar(config.HolidayFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载节假日日历失败: %w", err)
+	}
+
+	tasks, err := s.taskService.GetTasksInRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{Start: start, End: end}
+	tags := newStatsCounter()
+	projects := newStatsCounter()
+	weekIndex := make(map[string]int)
+	for monday, _ := util.WeekRange(start); !monday.After(end); monday = monday.AddDate(0, 0, 7) {
+		weekIndex[monday.Format("2006-01-02")] = len(stats.Weeks)
+		stats.Weeks = append(stats.Weeks, WeekStats{Start: monday})
+	}
+
+	reported := make(map[string]bool)
+	for _, task := range tasks {
+		if strings.TrimSpace(task.Content) == "" {
+			continue
+		}
+		reported[task.Date.Format("2006-01-02")] = true
+		monday, _ := util.WeekRange(time.Date(task.Date.Year(), task.Date.Month(), task.Date.Day(), 0, 0, 0, 0, start.Location()))
+		week := &stats.Weeks[weekIndex[monday.Format("2006-01-02")]]
+		week.ReportedDays++
+
+		for _, entry := range util.ParseWorkEntries(task.Content) {
+			stats.Entries++
+			stats.TotalDuration += entry.Duration
+			week.Duration += entry.Duration
+			tags.add(entry.Tags, UntaggedName, entry.Duration)
+			projects.add(entry.Projects, NoProjectName, entry.Duration)
+		}
+	}
+	stats.ReportedDays = len(reported)
+	stats.Tags = tags.items()
+	stats.Projects = projects.items()
+
+	// 填报情况只统计到今天，今天还没写不算缺报
+	today := util.StartOfDay(s.now())
+	last := end
+	if last.After(today) {
+		last = today
+	}
+	streak := 0
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !calendar.IsWorkday(day) {
+			continue
+		}
+		stats.Workdays++
+		key := day.Format("2006-01-02")
+		switch {
+		case reported[key]:
+			streak++
+			stats.LongestStreak = max(stats.LongestStreak, streak)
+		case day.Equal(today):
+		default:
+			streak = 0
+			stats.MissedDays = append(stats.MissedDays, day)
+		}
+	}
+
+	stats.CurrentStreak, err = s.currentStreak(calendar, last, today)
+	if err != nil {
+		return nil, err
+	}
+
+	util.Debug("统计完成: %s ~ %s, %d 天有日报, 共 %v", start.Format("2006-01-02"), end.Format("2006-01-02"), stats.ReportedDays, stats.TotalDuration)
+	return stats, nil
+}
+
+// Activity 按天统计日报字数和记录的耗时，并按配置的节假日日历标记工作日和节假日
+func (s *StatsServiceImpl) Activity(startDate, endDate time.Time) ([]DayActivity, error) {
+	start := util.StartOfDay(startDate)
+	end := util.StartOfDay(endDate)
+	if end.Before(start) {
+		return nil, fmt.Errorf("结束日期不能早于开始日期")
+	}
+
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	calendar, err := schedule.LoadCalendar(config.HolidayFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载节假日日历失败: %w", err)
+	}
+
+	tasks, err := s.taskService.GetTasksInRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []DayActivity
+	index := make(map[string]int)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		index[day.Format("2006-01-02")] = len(days)
+		days = append(days, DayActivity{
+			Date:    day,
+			Workday: calendar.IsWorkday(day),
+			Holiday: calendar.IsHoliday(day),
+		})
+	}
+	for _, task := range tasks {
+		i, ok := index[task.Date.Format("2006-01-02")]
+		content := strings.TrimSpace(task.Content)
+		if !ok || content == "" {
+			continue
+		}
+		days[i].Chars = utf8.RuneCountInString(content)
+		for _, entry := range util.ParseWorkEntries(content) {
+			days[i].Duration += entry.Duration
+		}
+	}
+	return days, nil
+}
+
+// currentStreak 从 last 向前计算连续填报的工作日数，不受统计范围限制，按月读取日报日期
+func (s *StatsServiceImpl) currentStreak(calendar *schedule.Calendar, last, today time.Time) (int, error) {
+	reported := make(map[string]bool)
+	loadedMonth := ""
+	streak := 0
+	for i := 0; i < streakLookbackDays; i++ {
+		day := last.AddDate(0, 0, -i)
+		if !calendar.IsWorkday(day) {
+			continue
+		}
+		if month := day.Format("2006-01"); month != loadedMonth {
+			dates, err := s.taskService.GetMonthTaskDates(day.Year(), day.Month())
+			if err != nil {
+				return 0, fmt.Errorf("获取日报日期失败: %w", err)
+			}
+			for _, date := range dates {
+				reported[date.Format("2006-01-02")] = true
+			}
+			loadedMonth = month
+		}
+		if reported[day.Format("2006-01-02")] {
+			streak++
+			continue
+		}
+		if day.Equal(today) {
+			continue
+		}
+		break
+	}
+	return streak, nil
+}
+
+// statsCounter 按名称累计耗时和记录数
+type statsCounter struct {
+	index map[string]int
+	list  []StatsItem
+}
+
+// newStatsCounter 创建空的计数器
+func newStatsCounter() *statsCounter {
+	return &statsCounter{index: make(map[string]int)}
+}
+
+// add 将一条记录计入所有名称，没有名称且记录了耗时时计入 fallback 分组
+func (c *statsCounter) add(names []string, fallback string, duration time.Duration) {
+	if len(names) == 0 {
+		if duration == 0 {
+			return
+		}
+		names = []string{fallback}
+	}
+	for _, name := range names {
+		i, ok := c.index[name]
+		if !ok {
+			i = len(c.list)
+			c.index[name] = i
+			c.list = append(c.list, StatsItem{Name: name})
+		}
+		c.list[i].Duration += duration
+		c.list[i].Entries++
+	}
+}
+
+// items 返回按耗时从多到少排列的结果，耗时相同时按记录数和名称排列
+func (c *statsCounter) items() []StatsItem {
+	items := append([]StatsItem(nil), c.list...)
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Duration != items[j].Duration {
+			return items[i].Duration > items[j].Duration
+		}
+		if items[i].Entries != items[j].Entries {
+			return items[i].Entries > items[j].Entries
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+// roundHours 将耗时换算为保留两位小数的小时数
+func roundHours(duration time.Duration) float64 {
+	return math.Round(duration.Hours()*100) / 100
+}
+
+// WriteStatsCSV 以 CSV 格式输出统计结果，列为 类别,名称,小时,记录数,天数
+// 类别为 汇总、标签、项目、周 或 缺报，不适用的列留空
+func WriteStatsCSV(w io.Writer, stats *Stats) error {
+	hours := func(d time.Duration) string { return strconv.FormatFloat(roundHours(d), 'f', -1, 64) }
+	count := strconv.Itoa
+
+	rows := [][]string{
+		{"类别", "名称", "小时", "记录数", "天数"},
+		{"汇总", "总耗时", hours(stats.TotalDuration), count(stats.Entries), ""},
+		{"汇总", "填报天数", "", "", count(stats.ReportedDays)},
+		{"汇总", "工作日", "", "", count(stats.Workdays)},
+		{"汇总", "缺报天数", "", "", count(len(stats.MissedDays))},
+		{"汇总", "当前连续填报", "", "", count(stats.CurrentStreak)},
+		{"汇总", "最长连续填报", "", "", count(stats.LongestStreak)},
+	}
+	for _, item := range stats.Tags {
+		rows = append(rows, []string{"标签", item.Name, hours(item.Duration), count(item.Entries), ""})
+	}
+	for _, item := range stats.Projects {
+		rows = append(rows, []string{"项目", item.Name, hours(item.Duration), count(item.Entries), ""})
+	}
+	for _, week := range stats.Weeks {
+		rows = append(rows, []string{"周", week.Start.Format("2006-01-02"), hours(week.Duration), "", count(week.ReportedDays)})
+	}
+	for _, day := range stats.MissedDays {
+		rows = append(rows, []string{"缺报", day.Format("2006-01-02"), "", "", ""})
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("写入 CSV 失败: %w", err)
+	}
+	return nil
+}
+
+// statsJSON 统计结果的 JSON 格式，耗时以小时表示，日期格式为 YYYY-MM-DD
+type statsJSON struct {
+	Start         string          `json:"start"`
+	End           string          `json:"end"`
+	TotalHours    float64         `json:"total_hours"`
+	Entries       int             `json:"entries"`
+	ReportedDays  int             `json:"reported_days"`
+	Workdays      int             `json:"workdays"`
+	MissedDays    []string        `json:"missed_days"`
+	CurrentStreak int             `json:"current_streak"`
+	LongestStreak int             `json:"longest_streak"`
+	Tags          []statsItemJSON `json:"tags"`
+	Projects      []statsItemJSON `json:"projects"`
+	Weeks         []weekJSON      `json:"weeks"`
+}
+
+type statsItemJSON struct {
+	Name    string  `json:"name"`
+	Hours   float64 `json:"hours"`
+	Entries int     `json:"entries"`
+}
+
+type weekJSON struct {
+	Start        string  `json:"start"`
+	Hours        float64 `json:"hours"`
+	ReportedDays int     `json:"reported_days"`
+}
+
+// WriteStatsJSON 以 JSON 格式输出统计结果
+func WriteStatsJSON(w io.Writer, stats *Stats) error {
+	view := statsJSON{
+		Start:         stats.Start.Format("2006-01-02"),
+		End:           stats.End.Format("2006-01-02"),
+		TotalHours:    roundHours(stats.TotalDuration),
+		Entries:       stats.Entries,
+		ReportedDays:  stats.ReportedDays,
+		Workdays:      stats.Workdays,
+		MissedDays:    []string{},
+		CurrentStreak: stats.CurrentStreak,
+		LongestStreak: stats.LongestStreak,
+		Tags:          statsItemsJSON(stats.Tags),
+		Projects:      statsItemsJSON(stats.Projects),
+		Weeks:         []weekJSON{},
+	}
+	for _, day := range stats.MissedDays {
+		view.MissedDays = append(view.MissedDays, day.Format("2006-01-02"))
+	}
+	for _, week := range stats.Weeks {
+		view.Weeks = append(view.Weeks, weekJSON{
+			Start:        week.Start.Format("2006-01-02"),
+			Hours:        roundHours(week.Duration),
+			ReportedDays: week.ReportedDays,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(view); err != nil {
+		return fmt.Errorf("写入 JSON 失败: %w", err)
+	}
+	return nil
+}
+
+// statsItemsJSON 转换标签或项目汇总，空列表输出为 []
+func statsItemsJSON(items []StatsItem) []statsItemJSON {
+	result := make([]statsItemJSON, 0, len(items))
+	for _, item := range items {
+		result = append(result, statsItemJSON{Name: item.Name, Hours: roundHours(item.Duration), Entries: item.Entries})
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestStatsService_Compute(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(filepath.Join(tempDir, "tasks"))
+	taskService := NewTaskService(taskRepo, filepath.Join(tempDir, "tasks"))
+
+	// 2025-11-07（周五）调休放假，2025-11-09（周日）补班
+	holidayFile := filepath.Join(tempDir, "holidays.json")
+	if err := os.WriteFile(holidayFile, []byte(`{"holidays":["2025-11-07"],"workdays":["2025-11-09"]}`), 0644); err != nil {
+		t.Fatalf("写入节假日日历失败: %v", err)
+	}
+	configService := &mockConfigService{config: &model.Config{HolidayFile: holidayFile}}
+
+	days := map[int]string{
+		5:  "- [x] 接口开发 #后端 +支付 (3h)",
+		6:  "- [x] 接口开发 #后端 +支付 (2h)\n- 周会 (1h)",
+		9:  "- [x] 补班修复线上问题 #运维 (4h)",
+		10: "- [x] 联调 #后端 #联调 +支付（1小时30分钟）",
+		12: "- [ ] 整理文档 #文档",
+	}
+	for day, content := range days {
+		if err := taskService.SaveTask(time.Date(2025, 11, day, 0, 0, 0, 0, time.Local), content); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	statsService := NewStatsService(taskService, configService)
+	statsService.now = func() time.Time { return time.Date(2025, 11, 13, 9, 0, 0, 0, time.Local) }
+
+	stats, err := statsService.Compute(time.Date(2025, 11, 3, 0, 0, 0, 0, time.Local), time.Date(2025, 11, 16, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("统计失败: %v", err)
+	}
+
+	if stats.TotalDuration != 11*time.Hour+30*time.Minute {
+		t.Errorf("总耗时不匹配: %v", stats.TotalDuration)
+	}
+	if stats.ReportedDays != 5 {
+		t.Errorf("填报天数不匹配: %d", stats.ReportedDays)
+	}
+	// 11-03 ~ 11-13 的工作日：3,4,5,6,9,10,11,12,13（11-07 放假，11-09 补班）
+	if stats.Workdays != 9 {
+		t.Errorf("工作日数不匹配: %d", stats.Workdays)
+	}
+	var missed []string
+	for _, day := range stats.MissedDays {
+		missed = append(missed, day.Format("2006-01-02"))
+	}
+	if strings.Join(missed, ",") != "2025-11-03,2025-11-04,2025-11-11" {
+		t.Errorf("缺报日期不匹配: %v", missed)
+	}
+	// 11-12 已填报，今天（11-13）尚未填写不算中断，11-11 缺报
+	if stats.CurrentStreak != 1 {
+		t.Errorf("当前连续填报天数不匹配: %d", stats.CurrentStreak)
+	}
+	if stats.LongestStreak != 4 {
+		t.Errorf("最长连续填报天数不匹配: %d", stats.LongestStreak)
+	}
+
+	if len(stats.Tags) == 0 || stats.Tags[0].Name != "后端" || stats.Tags[0].Duration != 6*time.Hour+30*time.Minute || stats.Tags[0].Entries != 3 {
+		t.Errorf("标签汇总不匹配: %+v", stats.Tags)
+	}
+	var untagged *StatsItem
+	for i := range stats.Tags {
+		if stats.Tags[i].Name == UntaggedName {
+			untagged = &stats.Tags[i]
+		}
+	}
+	if untagged == nil || untagged.Duration != time.Hour {
+		t.Errorf("无标签的耗时应单独汇总: %+v", stats.Tags)
+	}
+	if len(stats.Projects) == 0 || stats.Projects[0].Name != "支付" || stats.Projects[0].Duration != 6*time.Hour+30*time.Minute {
+		t.Errorf("项目汇总不匹配: %+v", stats.Projects)
+	}
+
+	if len(stats.Weeks) != 2 {
+		t.Fatalf("期望 2 周，实际 %d", len(stats.Weeks))
+	}
+	if stats.Weeks[0].Duration != 10*time.Hour || stats.Weeks[0].ReportedDays != 3 {
+		t.Errorf("第一周汇总不匹配: %+v", stats.Weeks[0])
+	}
+	if stats.Weeks[1].Duration != 90*time.Minute || stats.Weeks[1].ReportedDays != 2 {
+		t.Errorf("第二周汇总不匹配: %+v", stats.Weeks[1])
+	}
+
+	var csvOut bytes.Buffer
+	if err := WriteStatsCSV(&csvOut, stats); err != nil {
+		t.Fatalf("输出 CSV 失败: %v", err)
+	}
+	for _, line := range []string{"类别,名称,小时,记录数,天数", "标签,后端,6.5,3,", "周,2025-11-10,1.5,,2", "缺报,2025-11-11,,,"} {
+		if !strings.Contains(csvOut.String(), line+"\n") {
+			t.Errorf("CSV 缺少行 %q:\n%s", line, csvOut.String())
+		}
+	}
+
+	var jsonOut bytes.Buffer
+	if err := WriteStatsJSON(&jsonOut, stats); err != nil {
+		t.Fatalf("输出 JSON 失败: %v", err)
+	}
+	var decoded struct {
+		TotalHours float64  `json:"total_hours"`
+		MissedDays []string `json:"missed_days"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("解析 JSON 失败: %v", err)
+	}
+	if decoded.TotalHours != 11.5 || len(decoded.MissedDays) != 3 {
+		t.Errorf("JSON 内容不匹配: %s", jsonOut.String())
+	}
+}
+
+func TestStatsService_Activity(t *testing.T) {
+	tempDir := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(filepath.Join(tempDir, "tasks"))
+	taskService := NewTaskService(taskRepo, filepath.Join(tempDir, "tasks"))
+
+	holidayFile := filepath.Join(tempDir, "holidays.json")
+	if err := os.WriteFile(holidayFile, []byte(`{"holidays":["2025-11-07"],"workdays":["2025-11-09"]}`), 0644); err != nil {
+		t.Fatalf("写入节假日日历失败: %v", err)
+	}
+	configService := &mockConfigService{config: &model.Config{HolidayFile: holidayFile}}
+
+	if err := taskService.SaveTask(time.Date(2025, 11, 6, 0, 0, 0, 0, time.Local), "- 接口开发 (2h)\n- 周会 (30m)\n"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	days, err := NewStatsService(taskService, configService).Activity(time.Date(2025, 11, 6, 0, 0, 0, 0, time.Local), time.Date(2025, 11, 9, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("统计活跃度失败: %v", err)
+	}
+	if len(days) != 4 {
+		t.Fatalf("应返回 4 天，实际: %d", len(days))
+	}
+	if days[0].Chars != 22 || days[0].Duration != 2*time.Hour+30*time.Minute || !days[0].Workday {
+		t.Errorf("11-06 的活跃度不正确: %+v", days[0])
+	}
+	if days[1].Chars != 0 || days[1].Workday || !days[1].Holiday {
+		t.Errorf("11-07 应为没有日报的节假日: %+v", days[1])
+	}
+	if days[2].Workday || days[2].Holiday {
+		t.Errorf("11-08 应为普通周末: %+v", days[2])
+	}
+	if !days[3].Workday {
+		t.Errorf("11-09 应为调休补班的工作日: %+v", days[3])
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/notifier"
+	"daily-report-tool/internal/util"
+)
+
+var (
+	// ErrEmptyReport 日报不存在或内容为空，没有可提交的内容
+	ErrEmptyReport = errors.New("日报为空，没有可提交的内容")
+
+	// ErrAlreadySubmitted 日报已提交到所有通知渠道
+	ErrAlreadySubmitted = errors.New("日报已提交过")
+)
+
+// SubmitResult 一次提交的结果
+type SubmitResult struct {
+	Sent    []string // 本次提交成功的渠道
+	Skipped []string // 之前已提交过而跳过的渠道
+}
+
+// SubmitService 定义日报提交服务接口
+type SubmitService interface {
+	// Submit 将指定日期的日报以 Markdown 消息提交到所有启用的通知渠道
+	// 已提交过的渠道会跳过，force 为 true 时重新提交到所有渠道；部分渠道失败时返回错误，成功的渠道仍会记录
+	Submit(date time.Time, force bool) (*SubmitResult, error)
+}
+
+// SubmitServiceImpl 日报提交服务实现
+type SubmitServiceImpl struct {
+	taskService   TaskService
+	configService ConfigService
+}
+
+// NewSubmitService 创建新的日报提交服务
+func NewSubmitService(taskService TaskService, configService ConfigService) *SubmitServiceImpl {
+	return &SubmitServiceImpl{
+		taskService:   taskService,
+		configService: configService,
+	}
+}
+
+// Submit 提交日报并在日报中记录提交时间和渠道
+// 长消息会拆分为多条发送，因此不做自动重试，避免部分内容重复出现在群里
+func (s *SubmitServiceImpl) Submit(date time.Time, force bool) (*SubmitResult, error) {
+	dateKey := date.Format("2006-01-02")
+	task, err := s.taskService.GetTask(date)
+	if err != nil {
+		return nil, fmt.Errorf("获取日报失败: %w", err)
+	}
+	if task == nil || strings.TrimSpace(task.Content) == "" {
+		return nil, ErrEmptyReport
+	}
+
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	notifiers, err := notifier.FromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("通知渠道配置无效: %w", err)
+	}
+	if len(notifiers) == 0 {
+		return nil, fmt.Errorf("未配置通知渠道")
+	}
+
+	util.Info("提交日报: %s", dateKey)
+	message := notifier.Message{
+		Subject:  submitSubject(date),
+		Text:     task.Content,
+		Markdown: task.Content,
+	}
+	result := &SubmitResult{}
+	var errs []error
+	for _, n := range notifiers {
+		if !force && task.IsSubmittedTo(n.Name()) {
+			result.Skipped = append(result.Skipped, n.Name())
+			continue
+		}
+		if err := n.Send(message); err != nil {
+			util.Error("日报提交到 %s 失败: %v", n.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			continue
+		}
+		util.Info("日报已提交到 %s", n.Name())
+		result.Sent = append(result.Sent, n.Name())
+	}
+
+	// 发送期间日报可能被自动保存，只更新提交状态，不写回发送前读取的内容
+	if len(result.Sent) > 0 {
+		if err := s.taskService.MarkSubmitted(date, result.Sent); err != nil {
+			return result, fmt.Errorf("日报已提交，但记录提交状态失败: %w", err)
+		}
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("提交日报失败: %w", errors.Join(errs...))
+	}
+	if len(result.Sent) == 0 {
+		return result, ErrAlreadySubmitted
+	}
+	return result, nil
+}
+
+// submitSubject 返回提交消息的标题，用于钉钉、飞书和邮件
+func submitSubject(date time.Time) string {
+	return fmt.Sprintf("%s %s 日报", date.Format("2006-01-02"), util.ChineseWeekday(date))
+}
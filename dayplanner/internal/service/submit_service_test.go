@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestSubmitService_Submit(t *testing.T) {
+	var messages []string
+	var taskService *TaskServiceImpl
+	failSlack := true
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	wecom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			MsgType  string            `json:"msgtype"`
+			Markdown map[string]string `json:"markdown"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.MsgType != "markdown" {
+			t.Errorf("应发送 markdown 消息，实际: %s", body.MsgType)
+		}
+		messages = append(messages, body.Markdown["content"])
+		// 模拟发送期间编辑器自动保存了新内容
+		if len(messages) == 1 {
+			if err := taskService.SaveTask(date, "- [x] 完成接口联调\n- [ ] 补充文档"); err != nil {
+				t.Errorf("自动保存失败: %v", err)
+			}
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer wecom.Close()
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failSlack {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer slack.Close()
+
+	configService := &mockConfigService{config: &model.Config{
+		ReminderTime: "10:00",
+		Channels: []model.ChannelConfig{
+			{Name: "team", Type: model.ChannelTypeWeCom, URL: wecom.URL},
+			{Name: "slack", Type: model.ChannelTypeSlack, URL: slack.URL},
+		},
+	}}
+	dataPath := t.TempDir()
+	taskRepo := repository.NewFileTaskRepository(dataPath)
+	taskService = NewTaskService(taskRepo, dataPath)
+	submitService := NewSubmitService(taskService, configService)
+
+	if _, err := submitService.Submit(date, false); !errors.Is(err, ErrEmptyReport) {
+		t.Errorf("没有日报时应返回 ErrEmptyReport，实际: %v", err)
+	}
+
+	if err := taskRepo.Save(&model.Task{Date: date, Content: "- [x] 完成接口联调", CreatedAt: date, UpdatedAt: date}); err != nil {
+		t.Fatalf("保存日报失败: %v", err)
+	}
+
+	// Slack 失败时企业微信仍记录为已提交
+	result, err := submitService.Submit(date, false)
+	if err == nil || len(result.Sent) != 1 || result.Sent[0] != "team" {
+		t.Fatalf("部分渠道失败时应返回错误并记录成功的渠道: %+v %v", result, err)
+	}
+	if len(messages) != 1 || messages[0] != "- ✅ 完成接口联调" {
+		t.Errorf("企业微信消息内容不正确: %q", messages)
+	}
+	task, _ := taskRepo.GetByDate(date)
+	if task.SubmittedAt.IsZero() || !task.IsSubmittedTo("team") || task.IsSubmittedTo("slack") {
+		t.Errorf("应记录提交时间和成功的渠道: %v %v", task.SubmittedAt, task.SubmittedTo)
+	}
+	if task.Content != "- [x] 完成接口联调\n- [ ] 补充文档" {
+		t.Errorf("记录提交状态不应覆盖发送期间保存的内容: %q", task.Content)
+	}
+
+	// 再次提交只发送到之前失败的渠道
+	failSlack = false
+	result, err = submitService.Submit(date, false)
+	if err != nil || len(result.Sent) != 1 || result.Sent[0] != "slack" || len(result.Skipped) != 1 || len(messages) != 1 {
+		t.Errorf("再次提交应跳过已提交的渠道: %+v %v", result, err)
+	}
+
+	if _, err := submitService.Submit(date, false); !errors.Is(err, ErrAlreadySubmitted) {
+		t.Errorf("已提交到所有渠道时应返回 ErrAlreadySubmitted，实际: %v", err)
+	}
+	if result, err := submitService.Submit(date, true); err != nil || len(result.Sent) != 2 || len(messages) != 2 {
+		t.Errorf("强制提交应发送到所有渠道: %+v %v", result, err)
+	}
+}
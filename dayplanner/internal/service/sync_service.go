@@ -0,0 +1,469 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"daily-report-tool/internal/gitsync"
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/util"
+)
+
+// defaultSyncInterval 未配置同步间隔时的定时同步间隔
+const defaultSyncInterval = 15 * time.Minute
+
+// ErrSyncConflict 同一天的日报在本机和远端都被修改，需要用户合并
+var ErrSyncConflict = errors.New("同步冲突：同一天的日报在本机和远端都被修改")
+
+// SyncConflict 需要用户合并的一天
+type SyncConflict struct {
+	Date   time.Time
+	Path   string // 冲突文件在数据目录中的路径
+	Base   string // 共同祖先的日报内容，没有时为空
+	Local  string // 本机的日报内容
+	Remote string // 远端的日报内容
+
+	localTask  *model.Task
+	remoteTask *model.Task
+}
+
+// SyncService 定义任务数据同步服务接口
+type SyncService interface {
+	// Start 启动定时同步，启动时立即同步一次
+	Start() error
+
+	// Stop 停止定时同步
+	Stop()
+
+	// Sync 立即同步：提交本机修改、拉取合并远端修改并推送；有未解决的冲突时返回 ErrSyncConflict
+	Sync() error
+
+	// NotifySaved 日报保存后调用，提交该日报的修改
+	NotifySaved(date time.Time)
+
+	// Conflicts 返回尚未解决的冲突，按日期升序
+	Conflicts() []SyncConflict
+
+	// Resolve 使用合并后的内容解决某一天的冲突，所有冲突解决后完成合并并推送
+	Resolve(date time.Time, content string) error
+
+	// SetOnConflict 设置出现新冲突时的回调（在后台 goroutine 中调用）
+	SetOnConflict(callback func(conflicts []SyncConflict))
+
+	// SetOnUpdated 设置拉取到远端修改后的回调（在后台 goroutine 中调用），用于刷新界面
+	SetOnUpdated(callback func())
+}
+
+// TaskCodec 日报文件内容的编解码器，由加密任务仓库实现
+type TaskCodec interface {
+	// EncryptTask 返回内容加密后的任务副本
+	EncryptTask(task *model.Task) (*model.Task, error)
+
+	// DecryptTask 解密任务内容
+	DecryptTask(task *model.Task) (*model.Task, error)
+}
+
+// NewSyncServiceFromConfig 按配置创建同步服务，未启用同步时返回 nil
+// configPath 为配置文件路径，WebDAV 同步会同时同步配置文件
+func NewSyncServiceFromConfig(config *model.Config, dataPath, configPath string) (SyncService, error) {
+	interval := time.Duration(config.SyncInterval) * time.Minute
+	switch config.SyncBackend {
+	case "":
+		return nil, nil
+	case model.SyncBackendGit:
+		return NewGitSyncService(dataPath, config.SyncRemote, config.SyncBranch, interval)
+	case model.SyncBackendWebDAV:
+		return NewWebDAVSyncService(dataPath, configPath, config.SyncRemote, config.SyncUsername, config.SyncPassword, interval)
+	default:
+		return nil, fmt.Errorf("不支持的同步方式: %s", config.SyncBackend)
+	}
+}
+
+// GitSyncServiceImpl 基于 git 的同步服务实现
+// 每次保存后提交，定时拉取、合并并推送；冲突时放弃合并，保留本机内容直到用户解决
+type GitSyncServiceImpl struct {
+	repo     *gitsync.Repo
+	interval time.Duration
+
+	mu         sync.Mutex // 串行执行 git 操作
+	conflicts  []SyncConflict
+	resolved   map[string][]byte // 已解决的冲突文件内容，全部解决后一起提交
+	onConflict func(conflicts []SyncConflict)
+	onUpdated  func()
+	codec      TaskCodec // 可选，数据目录中的日报加密时用于解密冲突内容
+
+	ticker   *time.Ticker
+	stopChan chan bool
+	running  bool
+}
+
+// NewGitSyncService 创建 git 同步服务，数据目录还不是 git 仓库时自动初始化
+func NewGitSyncService(dataPath, remote, branch string, interval time.Duration) (*GitSyncServiceImpl, error) {
+	repo, err := gitsync.Open(dataPath, remote, branch)
+	if err != nil {
+		return nil, fmt.Errorf("打开同步仓库失败: %w", err)
+	}
+	if interval <= 0 {
+		interval = defaultSyncInterval
+	}
+	return &GitSyncServiceImpl{
+		repo:     repo,
+		interval: interval,
+		resolved: make(map[string][]byte),
+	}, nil
+}
+
+// SetTaskCodec 设置日报文件内容的编解码器，数据目录中的日报加密时使用
+func (s *GitSyncServiceImpl) SetTaskCodec(codec TaskCodec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codec = codec
+}
+
+// SetOnConflict 设置出现新冲突时的回调
+func (s *GitSyncServiceImpl) SetOnConflict(callback func(conflicts []SyncConflict)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onConflict = callback
+}
+
+// SetOnUpdated 设置拉取到远端修改后的回调
+func (s *GitSyncServiceImpl) SetOnUpdated(callback func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onUpdated = callback
+}
+
+// Start 启动定时同步，启动时立即同步一次
+func (s *GitSyncServiceImpl) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return fmt.Errorf("同步服务已经在运行")
+	}
+	s.ticker = time.NewTicker(s.interval)
+	s.stopChan = make(chan bool)
+	s.running = true
+	util.Info("同步服务已启动，间隔: %v", s.interval)
+
+	go runSyncLoop(s.Sync, s.ticker, s.stopChan, nil)
+	return nil
+}
+
+// runSyncLoop 立即同步一次，之后每次定时器触发或收到 kick 时再次同步，直到 stopChan 关闭
+func runSyncLoop(syncFunc func() error, ticker *time.Ticker, stopChan chan bool, kick <-chan struct{}) {
+	for {
+		if err := syncFunc(); err != nil && !errors.Is(err, ErrSyncConflict) {
+			util.Error("定时同步失败: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-kick:
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// Stop 停止定时同步
+func (s *GitSyncServiceImpl) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+	util.Info("同步服务已停止")
+}
+
+// NotifySaved 日报保存后提交该日报的修改，失败只记录日志，下次同步时会再次提交
+func (s *GitSyncServiceImpl) NotifySaved(date time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.repo.CommitAll("更新 " + date.Format("2006-01-02")); err != nil {
+		util.Error("提交日报修改失败: %v", err)
+	}
+}
+
+// Conflicts 返回尚未解决的冲突
+func (s *GitSyncServiceImpl) Conflicts() []SyncConflict {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SyncConflict(nil), s.conflicts...)
+}
+
+// Sync 立即同步：提交本机修改、拉取合并远端修改并推送
+func (s *GitSyncServiceImpl) Sync() error {
+	s.mu.Lock()
+	notify, err := s.syncLocked()
+	s.mu.Unlock()
+
+	notify()
+	return err
+}
+
+// syncLocked 在持有锁时执行同步，返回需要在释放锁后调用的通知
+func (s *GitSyncServiceImpl) syncLocked() (func(), error) {
+	noop := func() {}
+	if _, err := s.repo.CommitAll("同步本机修改"); err != nil {
+		return noop, err
+	}
+
+	fetched, err := s.repo.Fetch()
+	if err != nil {
+		return noop, err
+	}
+	if fetched {
+		changed, conflicts, err := s.repo.Merge()
+		if err != nil {
+			return noop, err
+		}
+		if len(conflicts) > 0 {
+			// 已有解决方案时（如远端在解决期间没有新修改）直接使用
+			return s.mergeLocked(conflicts)
+		}
+		if err := s.repo.Push(); err != nil {
+			return noop, err
+		}
+		s.conflicts = nil
+		s.resolved = make(map[string][]byte)
+		if changed {
+			util.Info("同步完成，已合并远端修改")
+			return s.updatedNotifier(), nil
+		}
+		util.Debug("同步完成，远端没有新修改")
+		return noop, nil
+	}
+
+	// 远端还没有分支，推送本机内容创建
+	if err := s.repo.Push(); err != nil {
+		return noop, err
+	}
+	util.Info("同步完成，已推送到空的远程仓库")
+	return noop, nil
+}
+
+// mergeLocked 自动解决可以解决的冲突，其余冲突记录下来等待用户合并
+// 所有冲突都有解决方案时完成合并并推送
+func (s *GitSyncServiceImpl) mergeLocked(conflicts []gitsync.Conflict) (func(), error) {
+	noop := func() {}
+	known := make(map[string]bool)
+	for _, conflict := range s.conflicts {
+		known[conflict.Path] = true
+	}
+
+	var pending []SyncConflict
+	for _, conflict := range conflicts {
+		if _, ok := s.resolved[conflict.Path]; ok {
+			continue
+		}
+		if content, ok := s.autoResolve(conflict); ok {
+			s.resolved[conflict.Path] = content
+			continue
+		}
+		syncConflict, err := s.newSyncConflict(conflict)
+		if err != nil {
+			return noop, err
+		}
+		pending = append(pending, syncConflict)
+	}
+
+	if len(pending) > 0 {
+		sort.Slice(pending, func(i, j int) bool { return pending[i].Path < pending[j].Path })
+		s.conflicts = pending
+
+		newConflict := false
+		for _, conflict := range pending {
+			if !known[conflict.Path] {
+				newConflict = true
+			}
+		}
+		util.Warn("同步冲突: %d 天需要合并", len(pending))
+		if newConflict && s.onConflict != nil {
+			callback, snapshot := s.onConflict, append([]SyncConflict(nil), pending...)
+			return func() { callback(snapshot) }, ErrSyncConflict
+		}
+		return noop, ErrSyncConflict
+	}
+
+	// 所有冲突都已解决，重新合并并写入解决方案；期间远端有新冲突时再次处理
+	remaining, err := s.repo.MergeResolved(s.resolved)
+	if err != nil {
+		return noop, err
+	}
+	if len(remaining) > 0 {
+		return s.mergeLocked(remaining)
+	}
+	if err := s.repo.Push(); err != nil {
+		return noop, err
+	}
+	s.conflicts = nil
+	s.resolved = make(map[string][]byte)
+	util.Info("同步完成，冲突已合并")
+	return s.updatedNotifier(), nil
+}
+
+// Resolve 使用合并后的内容解决某一天的冲突，所有冲突解决后完成合并并推送
+func (s *GitSyncServiceImpl) Resolve(date time.Time, content string) error {
+	s.mu.Lock()
+	notify, err := s.resolveLocked(date, content)
+	s.mu.Unlock()
+
+	notify()
+	return err
+}
+
+// resolveLocked 在持有锁时记录解决方案
+func (s *GitSyncServiceImpl) resolveLocked(date time.Time, content string) (func(), error) {
+	noop := func() {}
+	index := -1
+	for i, conflict := range s.conflicts {
+		if conflict.Date.Format("2006-01-02") == date.Format("2006-01-02") {
+			index = i
+		}
+	}
+	if index < 0 {
+		return noop, fmt.Errorf("%s 没有需要合并的冲突", date.Format("2006-01-02"))
+	}
+
+	conflict := s.conflicts[index]
+	merged := mergedTask(conflict, content)
+	if s.codec != nil {
+		var err error
+		if merged, err = s.codec.EncryptTask(merged); err != nil {
+			return noop, err
+		}
+	}
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return noop, fmt.Errorf("序列化合并结果失败: %w", err)
+	}
+	s.resolved[conflict.Path] = data
+	s.conflicts = append(s.conflicts[:index], s.conflicts[index+1:]...)
+	util.Info("已合并 %s 的冲突，剩余 %d 天", date.Format("2006-01-02"), len(s.conflicts))
+
+	if len(s.conflicts) > 0 {
+		return noop, nil
+	}
+
+	// 最后一个冲突解决后重新获取远端，完成合并
+	if _, err := s.repo.Fetch(); err != nil {
+		return noop, err
+	}
+	return s.mergeLocked(nil)
+}
+
+// updatedNotifier 返回调用远端更新回调的函数
+func (s *GitSyncServiceImpl) updatedNotifier() func() {
+	callback := s.onUpdated
+	if callback == nil {
+		return func() {}
+	}
+	return callback
+}
+
+// autoResolve 自动解决不需要用户参与的冲突：
+// 非日报文件以及一方删除的文件保留现有内容；两边内容相同只是元数据不同时使用较新的一方
+func (s *GitSyncServiceImpl) autoResolve(conflict gitsync.Conflict) ([]byte, bool) {
+	if _, ok := conflictDate(conflict.Path); !ok || conflict.Ours == nil || conflict.Theirs == nil {
+		if conflict.Ours != nil {
+			return conflict.Ours, true
+		}
+		return conflict.Theirs, true
+	}
+
+	local, localErr := s.parseTask(conflict.Ours)
+	remote, remoteErr := s.parseTask(conflict.Theirs)
+	if localErr != nil || remoteErr != nil {
+		return nil, false
+	}
+	if normalizeContent(local.Content) != normalizeContent(remote.Content) {
+		return nil, false
+	}
+	if remote.UpdatedAt.After(local.UpdatedAt) {
+		return conflict.Theirs, true
+	}
+	return conflict.Ours, true
+}
+
+// newSyncConflict 将 git 冲突转换为需要用户合并的日报冲突
+func (s *GitSyncServiceImpl) newSyncConflict(conflict gitsync.Conflict) (SyncConflict, error) {
+	date, _ := conflictDate(conflict.Path)
+	result := SyncConflict{Date: date, Path: conflict.Path}
+
+	var err error
+	if result.localTask, err = s.parseTask(conflict.Ours); err != nil {
+		return result, fmt.Errorf("解析本机日报 %s 失败: %w", conflict.Path, err)
+	}
+	if result.remoteTask, err = s.parseTask(conflict.Theirs); err != nil {
+		return result, fmt.Errorf("解析远端日报 %s 失败: %w", conflict.Path, err)
+	}
+	if base, err := s.parseTask(conflict.Base); err == nil && base != nil {
+		result.Base = base.Content
+	}
+	result.Local = result.localTask.Content
+	result.Remote = result.remoteTask.Content
+	return result, nil
+}
+
+// parseTask 解析冲突文件中的日报，设置了编解码器时解密内容
+func (s *GitSyncServiceImpl) parseTask(data []byte) (*model.Task, error) {
+	task, err := parseConflictTask(data)
+	if err != nil || task == nil || s.codec == nil {
+		return task, err
+	}
+	return s.codec.DecryptTask(task)
+}
+
+// mergedTask 以本机日报为基础生成合并后的日报，创建时间取两者中较早的
+func mergedTask(conflict SyncConflict, content string) *model.Task {
+	task := *conflict.localTask
+	task.Content = content
+	task.CreatedAt = earlier(task.CreatedAt, conflict.remoteTask.CreatedAt)
+	task.UpdatedAt = time.Now()
+
+	// 保留两边的提交记录，避免合并后重复提交到已提交过的渠道
+	task.SubmittedAt = later(task.SubmittedAt, conflict.remoteTask.SubmittedAt)
+	task.SubmittedTo = append([]string(nil), task.SubmittedTo...)
+	for _, channel := range conflict.remoteTask.SubmittedTo {
+		if !task.IsSubmittedTo(channel) {
+			task.SubmittedTo = append(task.SubmittedTo, channel)
+		}
+	}
+	return &task
+}
+
+// parseConflictTask 解析冲突文件中某一方的日报，内容为 nil 时返回 nil
+func parseConflictTask(data []byte) (*model.Task, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var task model.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// conflictDate 从数据目录中的路径识别日报日期，只有根目录下的 YYYY-MM-DD.json 是日报文件
+func conflictDate(filePath string) (time.Time, bool) {
+	if strings.Contains(filePath, "/") || path.Ext(filePath) != ".json" {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(filePath, ".json"), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
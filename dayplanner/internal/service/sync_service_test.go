@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/gitsync"
+	"daily-report-tool/internal/repository"
+)
+
+// newSyncedTaskService 创建使用 git 同步的任务服务
+func newSyncedTaskService(t *testing.T, remote string) (*TaskServiceImpl, *GitSyncServiceImpl) {
+	t.Helper()
+	dataPath := filepath.Join(t.TempDir(), "tasks")
+	syncService, err := NewGitSyncService(dataPath, remote, "main", time.Hour)
+	if err != nil {
+		t.Fatalf("创建同步服务失败: %v", err)
+	}
+	taskService := NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	taskService.SetSyncService(syncService)
+	return taskService, syncService
+}
+
+func TestGitSyncService_SyncAndResolve(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("未安装 git")
+	}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("创建裸仓库失败: %v: %s", err, output)
+	}
+
+	taskA, syncA := newSyncedTaskService(t, remote)
+	taskB, syncB := newSyncedTaskService(t, remote)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	updated := 0
+	syncB.SetOnUpdated(func() { updated++ })
+	var notified []SyncConflict
+	syncB.SetOnConflict(func(conflicts []SyncConflict) { notified = conflicts })
+
+	// A 保存后同步，B 拉取到 A 的日报
+	if err := taskA.SaveTask(monday, "- [x] 机器 A 的工作"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	if err := syncA.Sync(); err != nil {
+		t.Fatalf("A 同步失败: %v", err)
+	}
+	if err := syncB.Sync(); err != nil {
+		t.Fatalf("B 同步失败: %v", err)
+	}
+	if task, _ := taskB.GetTask(monday); task == nil || task.Content != "- [x] 机器 A 的工作" || updated != 1 {
+		t.Fatalf("B 应拉取到 A 的日报: %+v, 更新回调 %d 次", task, updated)
+	}
+
+	// 两边修改同一天：B 同步时出现冲突，本机内容保持不变
+	if err := taskA.SaveTask(monday, "- [x] 机器 A 的工作\n- [ ] A 追加"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	if err := syncA.Sync(); err != nil {
+		t.Fatalf("A 同步失败: %v", err)
+	}
+	if err := taskB.SaveTask(monday, "- [x] 机器 A 的工作\n- [ ] B 追加"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	if err := syncB.Sync(); !errors.Is(err, ErrSyncConflict) {
+		t.Fatalf("期望同步冲突，实际 %v", err)
+	}
+	if len(notified) != 1 || notified[0].Local != "- [x] 机器 A 的工作\n- [ ] B 追加" ||
+		notified[0].Remote != "- [x] 机器 A 的工作\n- [ ] A 追加" || notified[0].Base != "- [x] 机器 A 的工作" {
+		t.Fatalf("冲突回调不正确: %+v", notified)
+	}
+	if task, _ := taskB.GetTask(monday); task.Content != "- [x] 机器 A 的工作\n- [ ] B 追加" {
+		t.Errorf("冲突时应保留本机内容: %q", task.Content)
+	}
+
+	// 再次同步不会重复通知同一冲突
+	notified = nil
+	if err := syncB.Sync(); !errors.Is(err, ErrSyncConflict) || notified != nil {
+		t.Errorf("已知冲突不应重复通知: %v, %+v", err, notified)
+	}
+
+	merged := "- [x] 机器 A 的工作\n- [ ] A 追加\n- [ ] B 追加"
+	if err := syncB.Resolve(monday, merged); err != nil {
+		t.Fatalf("解决冲突失败: %v", err)
+	}
+	if len(syncB.Conflicts()) != 0 {
+		t.Error("解决后不应还有冲突")
+	}
+	if err := syncA.Sync(); err != nil {
+		t.Fatalf("A 同步失败: %v", err)
+	}
+	task, _ := taskA.GetTask(monday)
+	if task.Content != merged {
+		t.Errorf("A 应拉取到合并结果: %q", task.Content)
+	}
+}
+
+func TestAutoResolve(t *testing.T) {
+	older := []byte(`{"date":"2025-11-10T00:00:00+08:00","content":"相同内容","created_at":"2025-11-10T09:00:00+08:00","updated_at":"2025-11-10T10:00:00+08:00"}`)
+	newer := []byte(`{"date":"2025-11-10T00:00:00+08:00","content":"相同内容\n","created_at":"2025-11-10T09:00:00+08:00","updated_at":"2025-11-10T11:00:00+08:00"}`)
+	other := []byte(`{"date":"2025-11-10T00:00:00+08:00","content":"不同内容"}`)
+	syncService := &GitSyncServiceImpl{}
+
+	if content, ok := syncService.autoResolve(gitConflict("2025-11-10.json", older, newer)); !ok || string(content) != string(newer) {
+		t.Error("内容相同时应使用较新的一方")
+	}
+	if _, ok := syncService.autoResolve(gitConflict("2025-11-10.json", older, other)); ok {
+		t.Error("内容不同时需要用户合并")
+	}
+	if content, ok := syncService.autoResolve(gitConflict(".gitignore", []byte("a"), []byte("b"))); !ok || string(content) != "a" {
+		t.Error("非日报文件应保留本机内容")
+	}
+	if content, ok := syncService.autoResolve(gitConflict("2025-11-10.json", nil, other)); !ok || string(content) != string(other) {
+		t.Error("一方删除时应保留现有内容")
+	}
+	if _, ok := conflictDate("history/2025-11-10.json"); ok {
+		t.Error("子目录中的文件不是日报")
+	}
+}
+
+// gitConflict 创建测试用的 git 冲突
+func gitConflict(path string, ours, theirs []byte) gitsync.Conflict {
+	return gitsync.Conflict{Path: path, Ours: ours, Theirs: theirs}
+}
@@ -3,10 +3,13 @@ package service
 import (
 	"fmt"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"daily-report-tool/internal/model"
 	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/util"
 )
 
 // TaskService 定义任务管理服务接口
@@ -22,12 +25,28 @@ type TaskService interface {
 
 	// HasTodayTask 检查今天是否有任务
 	HasTodayTask() (bool, error)
+
+	// Search 全文搜索任务内容，按日期倒序返回
+	Search(query string) ([]*model.Task, error)
+
+	// GetTasksInRange 获取日期范围内（含首尾）的所有任务，按日期升序返回
+	GetTasksInRange(startDate, endDate time.Time) ([]*model.Task, error)
+
+	// UpdateContent 读取日报内容交给 update 修改后写回，读取和写回之间不会被其他保存打断
+	// update 的参数在日报不存在时为空字符串；返回的内容与原内容相同时不保存，返回错误时不保存并原样返回该错误
+	UpdateContent(date time.Time, update func(content string) (string, error)) error
+
+	// MarkSubmitted 记录日报已提交到 channels，只修改提交时间和渠道，不改动日报内容
+	MarkSubmitted(date time.Time, channels []string) error
 }
 
 // TaskServiceImpl 任务管理服务实现
 type TaskServiceImpl struct {
-	taskRepo repository.TaskRepository
-	dataPath string
+	taskRepo     repository.TaskRepository
+	revisionRepo repository.RevisionRepository // 可选，设置后每次保存都会记录历史版本
+	syncService  SyncService                   // 可选，设置后每次保存都会提交到同步仓库
+	dataPath     string
+	mu           sync.Mutex // 串行化任务的读取和写回，避免并发保存互相覆盖
 }
 
 // NewTaskService 创建新的任务管理服务
@@ -38,6 +57,16 @@ func NewTaskService(taskRepo repository.TaskRepository, dataPath string) *TaskSe
 	}
 }
 
+// SetRevisionRepository 设置历史版本仓库，启用保存时的版本记录
+func (s *TaskServiceImpl) SetRevisionRepository(revisionRepo repository.RevisionRepository) {
+	s.revisionRepo = revisionRepo
+}
+
+// SetSyncService 设置同步服务，保存后提交修改
+func (s *TaskServiceImpl) SetSyncService(syncService SyncService) {
+	s.syncService = syncService
+}
+
 // GetTask 获取指定日期的任务
 func (s *TaskServiceImpl) GetTask(date time.Time) (*model.Task, error) {
 	// 确保数据目录存在
@@ -60,11 +89,52 @@ func (s *TaskServiceImpl) SaveTask(date time.Time, content string) error {
 		return err
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// 获取现有任务
 	existingTask, err := s.taskRepo.GetByDate(date)
 	if err != nil {
 		return fmt.Errorf("获取现有任务失败: %w", err)
 	}
+	return s.saveLocked(date, existingTask, content)
+}
+
+// UpdateContent 在锁内读取、修改并写回日报内容，避免与编辑器自动保存或 API 写入互相覆盖
+func (s *TaskServiceImpl) UpdateContent(date time.Time, update func(content string) (string, error)) error {
+	// 确保数据目录存在
+	if err := s.ensureDataDirectory(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingTask, err := s.taskRepo.GetByDate(date)
+	if err != nil {
+		return fmt.Errorf("获取现有任务失败: %w", err)
+	}
+	content := ""
+	if existingTask != nil {
+		content = existingTask.Content
+	}
+
+	updated, err := update(content)
+	if err != nil {
+		return err
+	}
+	if updated == content {
+		return nil
+	}
+	return s.saveLocked(date, existingTask, updated)
+}
+
+// saveLocked 在持有锁时保存任务内容，existingTask 为保存前读取的任务，不存在时为 nil
+func (s *TaskServiceImpl) saveLocked(date time.Time, existingTask *model.Task, content string) error {
+	// 启用历史版本前已存在的内容先记为基线版本，保证可以恢复
+	if existingTask != nil {
+		s.recordBaseline(existingTask)
+	}
 
 	now := time.Now()
 	var task *model.Task
@@ -88,9 +158,86 @@ func (s *TaskServiceImpl) SaveTask(date time.Time, content string) error {
 		return fmt.Errorf("保存任务失败: %w", err)
 	}
 
+	s.recordRevision(task.Date, task.Content, now)
+	if s.syncService != nil {
+		s.syncService.NotifySaved(task.Date)
+	}
+	return nil
+}
+
+// MarkSubmitted 重新读取日报并只更新提交时间和渠道，提交期间自动保存的内容不会被覆盖
+func (s *TaskServiceImpl) MarkSubmitted(date time.Time, channels []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, err := s.taskRepo.GetByDate(date)
+	if err != nil {
+		return fmt.Errorf("获取现有任务失败: %w", err)
+	}
+	if task == nil {
+		return fmt.Errorf("日报不存在: %s", date.Format("2006-01-02"))
+	}
+
+	for _, channel := range channels {
+		if !task.IsSubmittedTo(channel) {
+			task.SubmittedTo = append(task.SubmittedTo, channel)
+		}
+	}
+	now := time.Now()
+	task.SubmittedAt = now
+	task.UpdatedAt = now
+	if err := s.taskRepo.Save(task); err != nil {
+		return fmt.Errorf("保存任务失败: %w", err)
+	}
+
+	if s.syncService != nil {
+		s.syncService.NotifySaved(task.Date)
+	}
 	return nil
 }
 
+// recordBaseline 当某天还没有任何历史版本时，将保存前的内容记为第一个版本
+func (s *TaskServiceImpl) recordBaseline(existingTask *model.Task) {
+	if s.revisionRepo == nil {
+		return
+	}
+
+	revisions, err := s.revisionRepo.List(existingTask.Date)
+	if err != nil {
+		util.Warn("读取历史版本失败: %v", err)
+		return
+	}
+	if len(revisions) == 0 {
+		s.recordRevision(existingTask.Date, existingTask.Content, existingTask.UpdatedAt)
+	}
+}
+
+// recordRevision 记录一个历史版本，内容与最新版本相同时跳过
+// 历史版本写入失败只记录日志，不影响任务本身的保存
+func (s *TaskServiceImpl) recordRevision(date time.Time, content string, createdAt time.Time) {
+	if s.revisionRepo == nil {
+		return
+	}
+
+	revisions, err := s.revisionRepo.List(date)
+	if err != nil {
+		util.Warn("读取历史版本失败: %v", err)
+		return
+	}
+	if len(revisions) > 0 && revisions[len(revisions)-1].Content == content {
+		return
+	}
+
+	revision := &model.Revision{
+		Date:      date,
+		Content:   content,
+		CreatedAt: createdAt,
+	}
+	if err := s.revisionRepo.Add(revision); err != nil {
+		util.Warn("记录历史版本失败: %v", err)
+	}
+}
+
 // GetMonthTaskDates 获取月份内有任务的日期
 func (s *TaskServiceImpl) GetMonthTaskDates(year int, month time.Month) ([]time.Time, error) {
 	// 确保数据目录存在
@@ -126,6 +273,49 @@ func (s *TaskServiceImpl) HasTodayTask() (bool, error) {
 	return hasTask, nil
 }
 
+// Search 全文搜索任务内容，按日期倒序返回
+func (s *TaskServiceImpl) Search(query string) ([]*model.Task, error) {
+	tasks, err := s.taskRepo.Search(query)
+	if err != nil {
+		return nil, fmt.Errorf("搜索任务失败: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetTasksInRange 获取日期范围内（含首尾）的所有任务，按日期升序返回
+func (s *TaskServiceImpl) GetTasksInRange(startDate, endDate time.Time) ([]*model.Task, error) {
+	// 确保数据目录存在
+	if err := s.ensureDataDirectory(); err != nil {
+		return nil, err
+	}
+
+	// 按日历日期比较，避免时区差异导致首尾日期被遗漏
+	rangeStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	rangeEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 23, 59, 59, 0, time.UTC)
+
+	dates, err := s.taskRepo.GetTaskDates(rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("获取任务日期失败: %w", err)
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	tasks := make([]*model.Task, 0, len(dates))
+	for _, date := range dates {
+		task, err := s.taskRepo.GetByDate(date)
+		if err != nil {
+			return nil, fmt.Errorf("获取任务失败 (%s): %w", date.Format("2006-01-02"), err)
+		}
+		if task != nil {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
 // ensureDataDirectory 确保数据目录存在
 func (s *TaskServiceImpl) ensureDataDirectory() error {
 	if err := os.MkdirAll(s.dataPath, 0755); err != nil {
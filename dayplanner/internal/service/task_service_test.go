@@ -194,3 +194,32 @@ func TestTaskService_EnsureDataDirectory(t *testing.T) {
 		t.Errorf("数据目录未创建: %s", dataPath)
 	}
 }
+
+func TestTaskService_Search(t *testing.T) {
+	tempDir := t.TempDir()
+
+	taskRepo, err := repository.NewSQLiteTaskRepository(filepath.Join(tempDir, "tasks.db"))
+	if err != nil {
+		t.Fatalf("打开 SQLite 仓库失败: %v", err)
+	}
+	defer taskRepo.Close()
+	taskService := NewTaskService(taskRepo, tempDir)
+
+	if err := taskService.SaveTask(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local), "- 发布新版本"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+	if err := taskService.SaveTask(time.Date(2025, 11, 11, 0, 0, 0, 0, time.Local), "- 整理会议纪要"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	results, err := taskService.Search("发布新版本")
+	if err != nil {
+		t.Fatalf("搜索任务失败: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("期望 1 个搜索结果，实际得到: %d", len(results))
+	}
+	if results[0].Date.Format("2006-01-02") != "2025-11-10" {
+		t.Errorf("搜索结果日期不正确: %s", results[0].Date.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/teamclient"
+	"daily-report-tool/internal/util"
+)
+
+// TeamService 定义团队填报情况服务接口，供组长查看谁已填写、谁还没有填写日报
+type TeamService interface {
+	// Status 获取团队在指定日期的填报情况
+	Status(date time.Time) (*model.TeamStatus, error)
+
+	// GetMemberTask 获取成员指定日期的日报，没有日报时返回 nil
+	GetMemberTask(name string, date time.Time) (*model.Task, error)
+}
+
+// TeamServiceImpl 团队服务器上的团队服务实现，直接读取各成员的任务仓库
+type TeamServiceImpl struct {
+	teamRepo      repository.TeamTaskRepository
+	configService ConfigService
+}
+
+// NewTeamService 创建团队服务器上的团队服务，成员列表取自配置中的 team_members
+func NewTeamService(teamRepo repository.TeamTaskRepository, configService ConfigService) *TeamServiceImpl {
+	return &TeamServiceImpl{
+		teamRepo:      teamRepo,
+		configService: configService,
+	}
+}
+
+// Status 获取团队在指定日期的填报情况，内容为空的日报视为未填写
+func (s *TeamServiceImpl) Status(date time.Time) (*model.TeamStatus, error) {
+	config, err := s.configService.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	status := &model.TeamStatus{Date: date}
+	for _, member := range config.TeamMembers {
+		task, err := s.GetMemberTask(member.Name, date)
+		if err != nil {
+			return nil, err
+		}
+		memberStatus := model.MemberStatus{
+			Name:    member.Name,
+			Mention: member.Mention,
+			Lead:    member.Lead,
+		}
+		if task != nil && strings.TrimSpace(task.Content) != "" {
+			memberStatus.Filed = true
+			memberStatus.UpdatedAt = task.UpdatedAt
+		}
+		status.Members = append(status.Members, memberStatus)
+	}
+	util.Debug("团队填报情况: %s, 已填写 %d/%d", date.Format("2006-01-02"), len(status.Filed()), len(status.Members))
+	return status, nil
+}
+
+// GetMemberTask 获取成员指定日期的日报
+func (s *TeamServiceImpl) GetMemberTask(name string, date time.Time) (*model.Task, error) {
+	repo, err := s.teamRepo.ForUser(name)
+	if err != nil {
+		return nil, err
+	}
+	task, err := repo.GetByDate(date)
+	if err != nil {
+		return nil, fmt.Errorf("读取成员 %s 的日报失败: %w", name, err)
+	}
+	return task, nil
+}
+
+// RemoteTeamService 通过团队服务器获取填报情况，供组长的客户端使用，非组长调用时返回 teamclient.ErrForbidden
+type RemoteTeamService struct {
+	client *teamclient.Client
+}
+
+// NewRemoteTeamService 创建通过团队服务器获取填报情况的团队服务
+func NewRemoteTeamService(client *teamclient.Client) *RemoteTeamService {
+	return &RemoteTeamService{client: client}
+}
+
+// NewTeamServiceFromConfig 按配置创建团队服务：使用团队服务器存储时返回 RemoteTeamService，否则返回 nil
+func NewTeamServiceFromConfig(config *model.Config) (TeamService, error) {
+	if config.StorageBackend != model.StorageBackendTeam {
+		return nil, nil
+	}
+	client, err := teamclient.NewClient(config.TeamServer, config.TeamToken)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteTeamService(client), nil
+}
+
+// Status 获取团队在指定日期的填报情况
+func (s *RemoteTeamService) Status(date time.Time) (*model.TeamStatus, error) {
+	return s.client.Status(date)
+}
+
+// GetMemberTask 获取成员指定日期的日报
+func (s *RemoteTeamService) GetMemberTask(name string, date time.Time) (*model.Task, error) {
+	return s.client.MemberTask(name, date)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+)
+
+func TestTeamService_Status(t *testing.T) {
+	teamRepo := repository.NewFileTeamTaskRepository(t.TempDir())
+	configService := &mockConfigService{config: &model.Config{
+		TeamMembers: []model.TeamMember{
+			{Name: "alice", Token: "a", Lead: true},
+			{Name: "bob", Token: "b", Mention: "13800000000"},
+			{Name: "carol", Token: "c"},
+		},
+	}}
+	teamService := NewTeamService(teamRepo, configService)
+
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	updatedAt := date.Add(18 * time.Hour)
+	for name, content := range map[string]string{"alice": "- [x] 评审方案", "carol": "  \n"} {
+		repo, err := teamRepo.ForUser(name)
+		if err != nil {
+			t.Fatalf("获取成员仓库失败: %v", err)
+		}
+		if err := repo.Save(&model.Task{Date: date, Content: content, UpdatedAt: updatedAt}); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+	}
+
+	status, err := teamService.Status(date)
+	if err != nil {
+		t.Fatalf("获取填报情况失败: %v", err)
+	}
+	filed, missing := status.Filed(), status.Missing()
+	if len(filed) != 1 || filed[0].Name != "alice" || !filed[0].UpdatedAt.Equal(updatedAt) {
+		t.Errorf("已填写成员不正确: %+v", filed)
+	}
+	// 内容为空的日报视为未填写
+	if len(missing) != 2 || missing[0].Name != "bob" || missing[0].Mention != "13800000000" || missing[1].Name != "carol" {
+		t.Errorf("未填写成员不正确: %+v", missing)
+	}
+
+	task, err := teamService.GetMemberTask("alice", date)
+	if err != nil || task == nil || task.Content != "- [x] 评审方案" {
+		t.Errorf("读取成员日报不正确: %+v, %v", task, err)
+	}
+	if _, err := teamService.GetMemberTask("../alice", date); err == nil {
+		t.Error("无效的成员名应返回错误")
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"daily-report-tool/internal/util"
+)
+
+// DefaultTemplateName 没有对应星期的模板时使用的模板名称
+const DefaultTemplateName = "default"
+
+// templateExt 模板文件扩展名
+const templateExt = ".md"
+
+// templateLookbackDays 查找上一篇日报时最多向前查找的天数（跨过周末和小长假）
+const templateLookbackDays = 7
+
+// weekdayTemplateNames 各星期对应的模板名称，按 time.Weekday 索引
+var weekdayTemplateNames = [...]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// TemplateData 渲染模板时可用的占位符
+type TemplateData struct {
+	Date       string   // 日报日期，如 2025-11-10
+	Weekday    string   // 中文星期，如 星期一
+	Yesterday  string   // 上一篇日报的日期，没有时为空
+	Unfinished []string // 上一篇日报中未勾选的任务项（复选框之后的原始 Markdown）
+}
+
+// UnfinishedList 将未完成任务项格式化为 Markdown 任务列表，可在模板中用 {{.UnfinishedList}} 引用
+func (d TemplateData) UnfinishedList() string {
+	lines := make([]string, len(d.Unfinished))
+	for i, item := range d.Unfinished {
+		lines[i] = "- [ ] " + item
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TemplateService 定义日报模板服务接口
+type TemplateService interface {
+	// ListTemplates 列出模板目录中的所有模板名称（不含扩展名），按名称排序
+	ListTemplates() ([]string, error)
+
+	// Render 使用指定名称的模板渲染指定日期的日报内容
+	Render(name string, date time.Time) (string, error)
+
+	// RenderForDate 按星期选择模板（如 friday.md），没有时使用 default.md，都不存在时返回空字符串
+	RenderForDate(date time.Time) (string, error)
+}
+
+// TemplateServiceImpl 基于 Markdown 文件的模板服务实现
+type TemplateServiceImpl struct {
+	templateDir string
+	taskService TaskService
+}
+
+// NewTemplateService 创建新的模板服务
+func NewTemplateService(templateDir string, taskService TaskService) *TemplateServiceImpl {
+	return &TemplateServiceImpl{
+		templateDir: templateDir,
+		taskService: taskService,
+	}
+}
+
+// DefaultTemplateDir 返回默认的模板目录，位于配置文件所在目录下
+func DefaultTemplateDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "templates")
+}
+
+// ListTemplates 列出模板目录中的所有模板名称（不含扩展名），按名称排序
+func (s *TemplateServiceImpl) ListTemplates() ([]string, error) {
+	entries, err := os.ReadDir(s.templateDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取模板目录失败: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), templateExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Render 使用指定名称的模板渲染指定日期的日报内容
+func (s *TemplateServiceImpl) Render(name string, date time.Time) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("无效的模板名称: %q", name)
+	}
+
+	path := filepath.Join(s.templateDir, name+templateExt)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("模板不存在: %s", name)
+		}
+		return "", fmt.Errorf("读取模板失败: %w", err)
+	}
+
+	tmpl, err := template.New(name).Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("解析模板 %s 失败: %w", name, err)
+	}
+
+	templateData, err := s.templateData(date)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", fmt.Errorf("渲染模板 %s 失败: %w", name, err)
+	}
+
+	util.Debug("已渲染模板: %s (%s)", name, templateData.Date)
+	return buf.String(), nil
+}
+
+// RenderForDate 按星期选择模板（如 friday.md），没有时使用 default.md，都不存在时返回空字符串
+func (s *TemplateServiceImpl) RenderForDate(date time.Time) (string, error) {
+	for _, name := range []string{weekdayTemplateNames[date.Weekday()], DefaultTemplateName} {
+		if _, err := os.Stat(filepath.Join(s.templateDir, name+templateExt)); err != nil {
+			continue
+		}
+		return s.Render(name, date)
+	}
+	return "", nil
+}
+
+// templateData 生成渲染模板用的占位符数据
+// 未完成任务项取自最近一篇日报（最多向前查找 7 天），以便周一能带上周五未完成的事项
+func (s *TemplateServiceImpl) templateData(date time.Time) (TemplateData, error) {
+	data := TemplateData{
+		Date:    date.Format("2006-01-02"),
+		Weekday: util.ChineseWeekday(date),
+	}
+
+	day := util.StartOfDay(date)
+	for i := 1; i <= templateLookbackDays; i++ {
+		previous := day.AddDate(0, 0, -i)
+		task, err := s.taskService.GetTask(previous)
+		if err != nil {
+			return data, fmt.Errorf("获取上一篇日报失败: %w", err)
+		}
+		if task == nil || strings.TrimSpace(task.Content) == "" {
+			continue
+		}
+
+		data.Yesterday = previous.Format("2006-01-02")
+		for _, item := range util.ParseChecklist(task.Content) {
+			if !item.Checked && strings.TrimSpace(item.Raw) != "" {
+				data.Unfinished = append(data.Unfinished, item.Raw)
+			}
+		}
+		break
+	}
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+)
+
+func TestTemplateService_Render(t *testing.T) {
+	tempDir := t.TempDir()
+	taskService := NewTaskService(repository.NewFileTaskRepository(tempDir), tempDir)
+	templateDir := filepath.Join(tempDir, "templates")
+	templateService := NewTemplateService(templateDir, taskService)
+
+	// 模板目录不存在时没有模板，也不预填内容
+	if names, err := templateService.ListTemplates(); err != nil || len(names) != 0 {
+		t.Errorf("模板目录不存在时应返回空列表: %v, %v", names, err)
+	}
+	if content, err := templateService.RenderForDate(time.Now()); err != nil || content != "" {
+		t.Errorf("没有模板时应返回空内容: %q, %v", content, err)
+	}
+
+	templates := map[string]string{
+		"default.md": "# {{.Date}} {{.Weekday}}\n\n## 今日完成\n\n## 明日计划\n{{.UnfinishedList}}\n\n## 风险\n",
+		"monday.md":  "# 周一 {{.Date}}，上次日报 {{.Yesterday}}\n{{range .Unfinished}}* {{.}}\n{{end}}",
+		"notes.txt":  "不是模板",
+	}
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("创建模板目录失败: %v", err)
+	}
+	for name, content := range templates {
+		if err := os.WriteFile(filepath.Join(templateDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("写入模板失败: %v", err)
+		}
+	}
+
+	// 周五的日报中有未完成任务项
+	friday := time.Date(2025, 11, 14, 0, 0, 0, 0, time.Local)
+	if err := taskService.SaveTask(friday, "- [x] 完成评审\n- [ ] 修复 **登录** 问题\n- [ ] 编写文档"); err != nil {
+		t.Fatalf("保存任务失败: %v", err)
+	}
+
+	names, err := templateService.ListTemplates()
+	if err != nil || !reflect.DeepEqual(names, []string{"default", "monday"}) {
+		t.Errorf("模板列表不正确: %v, %v", names, err)
+	}
+
+	// 周一使用 monday.md，并带上周五未完成的任务项
+	monday := time.Date(2025, 11, 17, 0, 0, 0, 0, time.Local)
+	content, err := templateService.RenderForDate(monday)
+	if err != nil {
+		t.Fatalf("渲染模板失败: %v", err)
+	}
+	if want := "# 周一 2025-11-17，上次日报 2025-11-14\n* 修复 **登录** 问题\n* 编写文档\n"; content != want {
+		t.Errorf("周一模板渲染结果不正确:\n%q\n期望:\n%q", content, want)
+	}
+
+	// 其他日期使用 default.md，7 天内没有日报时不带未完成任务项
+	content, err = templateService.RenderForDate(time.Date(2025, 11, 25, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("渲染模板失败: %v", err)
+	}
+	if want := "# 2025-11-25 星期二\n\n## 今日完成\n\n## 明日计划\n\n\n## 风险\n"; content != want {
+		t.Errorf("默认模板渲染结果不正确:\n%q", content)
+	}
+
+	// 按名称选择模板
+	content, err = templateService.Render("default", monday)
+	if err != nil || content != "# 2025-11-17 星期一\n\n## 今日完成\n\n## 明日计划\n- [ ] 修复 **登录** 问题\n- [ ] 编写文档\n\n## 风险\n" {
+		t.Errorf("按名称渲染结果不正确: %q, %v", content, err)
+	}
+	for _, name := range []string{"missing", "../default", ""} {
+		if _, err := templateService.Render(name, monday); err == nil {
+			t.Errorf("模板 %q 应该报错", name)
+		}
+	}
+}
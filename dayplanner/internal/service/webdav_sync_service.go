@@ -0,0 +1,587 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"daily-report-tool/internal/davclient"
+	"daily-report-tool/internal/util"
+)
+
+const (
+	// webdavStateFile 数据目录中记录上次同步结果的状态文件
+	webdavStateFile = ".webdav-sync.json"
+
+	// webdavConflictDir 数据目录中保存冲突时落选版本的目录
+	webdavConflictDir = ".conflicts"
+
+	// webdavTaskDir 远端存放日报文件的目录
+	webdavTaskDir = "tasks"
+
+	// webdavConfigFile 远端的配置文件名
+	webdavConfigFile = "config.json"
+)
+
+// webdavFileState 上次同步时某个文件的状态，作为三方比较的共同祖先
+type webdavFileState struct {
+	ETag      string    `json:"etag"`       // 远端 ETag
+	Hash      string    `json:"hash"`       // 内容的 SHA-256
+	UpdatedAt time.Time `json:"updated_at"` // 日报的更新时间，配置文件为修改时间
+}
+
+// webdavSyncState 同步状态文件的内容，键为远端路径（如 tasks/2025-11-10.json）
+type webdavSyncState struct {
+	Files map[string]webdavFileState `json:"files"`
+}
+
+// webdavFile 本机或远端的一个文件
+type webdavFile struct {
+	data      []byte
+	hash      string
+	updatedAt time.Time
+}
+
+// WebDAVSyncServiceImpl 基于 WebDAV 的同步服务实现
+// 将数据目录中的日报文件、附件和配置文件镜像到 WebDAV 服务器，通过 ETag 检测远端修改，
+// 两边都修改时以上次同步的状态为共同祖先比较 UpdatedAt，较新的一方胜出，落选的版本保存到 .conflicts 目录
+type WebDAVSyncServiceImpl struct {
+	client     *davclient.Client
+	dataPath   string
+	configPath string
+	interval   time.Duration
+
+	syncMu    sync.Mutex // 串行执行同步，同步期间可能长时间访问网络
+	mu        sync.Mutex // 保护回调和定时器状态，只在读写这些字段时短暂持有
+	onUpdated func()
+
+	ticker   *time.Ticker
+	stopChan chan bool
+	kick     chan struct{} // 保存日报后触发一次同步
+	running  bool
+}
+
+// NewWebDAVSyncService 创建 WebDAV 同步服务，remote 为远端目录地址
+func NewWebDAVSyncService(dataPath, configPath, remote, username, password string, interval time.Duration) (*WebDAVSyncServiceImpl, error) {
+	client, err := davclient.NewClient(remote, username, password)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dataPath, 0755); err != nil {
+		return nil, fmt.Errorf("创建数据目录失败: %w", err)
+	}
+	if interval <= 0 {
+		interval = defaultSyncInterval
+	}
+	return &WebDAVSyncServiceImpl{
+		client:     client,
+		dataPath:   dataPath,
+		configPath: configPath,
+		interval:   interval,
+		kick:       make(chan struct{}, 1),
+	}, nil
+}
+
+// SetOnConflict WebDAV 同步按更新时间自动解决冲突，不会调用该回调
+func (s *WebDAVSyncServiceImpl) SetOnConflict(callback func(conflicts []SyncConflict)) {}
+
+// SetOnUpdated 设置拉取到远端修改后的回调
+func (s *WebDAVSyncServiceImpl) SetOnUpdated(callback func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onUpdated = callback
+}
+
+// Start 启动定时同步，启动时立即同步一次
+func (s *WebDAVSyncServiceImpl) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return fmt.Errorf("同步服务已经在运行")
+	}
+	s.ticker = time.NewTicker(s.interval)
+	s.stopChan = make(chan bool)
+	s.running = true
+	util.Info("WebDAV 同步服务已启动，间隔: %v", s.interval)
+
+	go runSyncLoop(s.Sync, s.ticker, s.stopChan, s.kick)
+	return nil
+}
+
+// Stop 停止定时同步
+func (s *WebDAVSyncServiceImpl) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+	util.Info("WebDAV 同步服务已停止")
+}
+
+// NotifySaved 日报保存后在后台触发一次同步，未启动定时同步时（如命令行）不做任何事
+func (s *WebDAVSyncServiceImpl) NotifySaved(date time.Time) {
+	select {
+	case s.kick <- struct{}{}:
+	default:
+		// 已有待执行的同步
+	}
+}
+
+// Conflicts WebDAV 同步自动解决冲突，始终返回空
+func (s *WebDAVSyncServiceImpl) Conflicts() []SyncConflict {
+	return nil
+}
+
+// Resolve WebDAV 同步没有需要用户合并的冲突
+func (s *WebDAVSyncServiceImpl) Resolve(date time.Time, content string) error {
+	return fmt.Errorf("%s 没有需要合并的冲突，WebDAV 同步按更新时间自动解决冲突", date.Format("2006-01-02"))
+}
+
+// Sync 立即同步：比较本机、远端和上次同步的状态，上传或下载有变化的文件
+func (s *WebDAVSyncServiceImpl) Sync() error {
+	s.syncMu.Lock()
+	updated, err := s.syncLocked()
+	s.syncMu.Unlock()
+
+	s.mu.Lock()
+	callback := s.onUpdated
+	s.mu.Unlock()
+
+	if updated && callback != nil {
+		callback()
+	}
+	return err
+}
+
+// syncLocked 在持有 syncMu 时执行同步，返回本机文件是否被远端修改
+func (s *WebDAVSyncServiceImpl) syncLocked() (bool, error) {
+	state, err := s.loadState()
+	if err != nil {
+		return false, err
+	}
+	local, err := s.scanLocal()
+	if err != nil {
+		return false, err
+	}
+	remote, err := s.scanRemote()
+	if err != nil {
+		return false, err
+	}
+
+	keys := make(map[string]bool)
+	for key := range state.Files {
+		keys[key] = true
+	}
+	for key := range local {
+		keys[key] = true
+	}
+	for key := range remote {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	// 单个文件失败不影响其他文件，状态文件记录已完成的部分
+	updated := false
+	var errs []error
+	for _, key := range sorted {
+		changed, err := s.syncFile(state, key, local, remote)
+		if err != nil {
+			util.Error("同步 %s 失败: %v", key, err)
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+		updated = updated || changed
+	}
+	if err := s.saveState(state); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return updated, errors.Join(errs...)
+	}
+
+	if updated {
+		util.Info("WebDAV 同步完成，已拉取远端修改")
+	} else {
+		util.Debug("WebDAV 同步完成")
+	}
+	return updated, nil
+}
+
+// syncFile 同步一个文件，返回本机文件是否被修改
+func (s *WebDAVSyncServiceImpl) syncFile(state *webdavSyncState, key string, local map[string]*webdavFile, remote map[string]davclient.Resource) (bool, error) {
+	base, hasBase := state.Files[key]
+	localFile, hasLocal := local[key]
+	remoteRes, hasRemote := remote[key]
+
+	localChanged := hasLocal != hasBase || (hasLocal && localFile.hash != base.Hash)
+	remoteChanged := hasRemote != hasBase || (hasRemote && remoteRes.ETag != base.ETag)
+
+	switch {
+	case !localChanged && !remoteChanged:
+		return false, nil
+	case !remoteChanged:
+		return false, s.push(state, key, localFile, remoteRes.ETag)
+	case !localChanged:
+		return true, s.pull(state, key, hasRemote)
+	}
+
+	// 两边都有变化：先读取远端内容，内容相同时只更新状态
+	if !hasRemote {
+		// 远端删除、本机修改：保留本机内容
+		return false, s.push(state, key, localFile, "")
+	}
+	data, etag, err := s.client.Get(key)
+	if err != nil {
+		return false, err
+	}
+	remoteFile := s.parseFile(key, data, remoteRes.ModTime)
+	if !hasLocal {
+		// 本机删除、远端修改：保留远端内容
+		return true, s.writeLocal(state, key, remoteFile, etag)
+	}
+	if remoteFile.hash == localFile.hash {
+		state.Files[key] = webdavFileState{ETag: etag, Hash: localFile.hash, UpdatedAt: localFile.updatedAt}
+		return false, nil
+	}
+
+	// 三方比较 UpdatedAt：只有一方在上次同步后更新时使用该方，都更新时使用较新的一方，相同时保留本机
+	localNewer := localFile.updatedAt.After(base.UpdatedAt)
+	remoteNewer := remoteFile.updatedAt.After(base.UpdatedAt)
+	useRemote := remoteNewer && (!localNewer || remoteFile.updatedAt.After(localFile.updatedAt))
+
+	if useRemote {
+		util.Warn("同步冲突: %s 使用远端版本，本机版本保存到 %s", key, webdavConflictDir)
+		if err := s.saveConflictCopy(key, "local", localFile.data); err != nil {
+			return false, err
+		}
+		return true, s.writeLocal(state, key, remoteFile, etag)
+	}
+	util.Warn("同步冲突: %s 使用本机版本，远端版本保存到 %s", key, webdavConflictDir)
+	if err := s.saveConflictCopy(key, "remote", remoteFile.data); err != nil {
+		return false, err
+	}
+	return false, s.push(state, key, localFile, etag)
+}
+
+// push 将本机文件上传到远端，本机已删除时删除远端文件；etag 为远端当前的 ETag，远端没有该文件时为空
+// 远端在此期间被其他机器修改时跳过，下次同步再处理
+func (s *WebDAVSyncServiceImpl) push(state *webdavSyncState, key string, file *webdavFile, etag string) error {
+	if file == nil {
+		if err := s.client.Delete(key, etag); err != nil {
+			return s.skipIfModified(key, err)
+		}
+		delete(state.Files, key)
+		util.Debug("已删除远端文件: %s", key)
+		return nil
+	}
+
+	if s.isAttachment(key) && etag == "" {
+		// 附件按日期分目录保存，上传新附件前确保远端目录存在
+		for _, dir := range []string{util.AttachmentDir, path.Dir(key)} {
+			if err := s.client.Mkdir(dir); err != nil {
+				return fmt.Errorf("创建远端目录失败: %w", err)
+			}
+		}
+	}
+	newETag, err := s.client.Put(key, file.data, etag)
+	if err != nil {
+		return s.skipIfModified(key, err)
+	}
+	state.Files[key] = webdavFileState{ETag: newETag, Hash: file.hash, UpdatedAt: file.updatedAt}
+	util.Debug("已上传: %s", key)
+	return nil
+}
+
+// pull 下载远端文件覆盖本机文件，远端已删除时删除本机文件
+func (s *WebDAVSyncServiceImpl) pull(state *webdavSyncState, key string, exists bool) error {
+	if !exists {
+		if err := os.Remove(s.localPath(key)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("删除本机文件失败: %w", err)
+		}
+		if s.isAttachment(key) {
+			// 当天的附件目录为空时一并删除，不为空时删除失败，忽略即可
+			os.Remove(filepath.Dir(s.localPath(key)))
+		}
+		delete(state.Files, key)
+		util.Debug("远端已删除，删除本机文件: %s", key)
+		return nil
+	}
+
+	data, etag, err := s.client.Get(key)
+	if err != nil {
+		return err
+	}
+	return s.writeLocal(state, key, s.parseFile(key, data, time.Now()), etag)
+}
+
+// writeLocal 将远端内容写入本机文件并记录状态
+func (s *WebDAVSyncServiceImpl) writeLocal(state *webdavSyncState, key string, file *webdavFile, etag string) error {
+	filePath := s.localPath(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	// 先写临时文件再重命名，避免读取到写了一半的文件；日报和配置文件可能包含敏感内容，只允许当前用户读写
+	if err := util.WriteFileAtomic(filePath, file.data, 0600); err != nil {
+		return fmt.Errorf("写入本机文件失败: %w", err)
+	}
+	state.Files[key] = webdavFileState{ETag: etag, Hash: file.hash, UpdatedAt: file.updatedAt}
+	util.Debug("已下载: %s", key)
+	return nil
+}
+
+// skipIfModified 远端 ETag 不匹配时只记录日志，其他错误原样返回
+func (s *WebDAVSyncServiceImpl) skipIfModified(key string, err error) error {
+	if errors.Is(err, davclient.ErrPreconditionFailed) {
+		util.Warn("%s 在同步期间被其他机器修改，下次同步时处理", key)
+		return nil
+	}
+	return err
+}
+
+// saveConflictCopy 将冲突中落选的版本保存到数据目录的 .conflicts 目录
+func (s *WebDAVSyncServiceImpl) saveConflictCopy(key, side string, data []byte) error {
+	dir := filepath.Join(s.dataPath, webdavConflictDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建冲突目录失败: %w", err)
+	}
+	name := path.Base(key)
+	ext := path.Ext(name)
+	copyName := fmt.Sprintf("%s.%s-%s%s", strings.TrimSuffix(name, ext), side, time.Now().Format("20060102-150405"), ext)
+	if err := os.WriteFile(filepath.Join(dir, copyName), data, 0600); err != nil {
+		return fmt.Errorf("保存冲突版本失败: %w", err)
+	}
+	return nil
+}
+
+// scanLocal 读取本机的日报文件、附件和配置文件
+func (s *WebDAVSyncServiceImpl) scanLocal() (map[string]*webdavFile, error) {
+	files := make(map[string]*webdavFile)
+
+	entries, err := os.ReadDir(s.dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据目录失败: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, ok := conflictDate(entry.Name()); !ok {
+			continue
+		}
+		key := webdavTaskDir + "/" + entry.Name()
+		file, err := s.readLocal(key)
+		if err != nil {
+			return nil, err
+		}
+		files[key] = file
+	}
+
+	if err := s.scanLocalAttachments(files); err != nil {
+		return nil, err
+	}
+
+	if s.configPath != "" {
+		if _, err := os.Stat(s.configPath); err == nil {
+			file, err := s.readLocal(webdavConfigFile)
+			if err != nil {
+				return nil, err
+			}
+			files[webdavConfigFile] = file
+		}
+	}
+	return files, nil
+}
+
+// scanLocalAttachments 读取数据目录 attachments/YYYY-MM-DD/ 中的附件
+func (s *WebDAVSyncServiceImpl) scanLocalAttachments(files map[string]*webdavFile) error {
+	days, err := os.ReadDir(filepath.Join(s.dataPath, util.AttachmentDir))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取附件目录失败: %w", err)
+	}
+	for _, day := range days {
+		if !day.IsDir() || !isAttachmentDay(day.Name()) {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(s.dataPath, util.AttachmentDir, day.Name()))
+		if err != nil {
+			return fmt.Errorf("读取附件目录失败: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !isAttachmentName(entry.Name()) {
+				continue
+			}
+			key := path.Join(util.AttachmentDir, day.Name(), entry.Name())
+			file, err := s.readLocal(key)
+			if err != nil {
+				return err
+			}
+			files[key] = file
+		}
+	}
+	return nil
+}
+
+// readLocal 读取本机文件，配置文件的更新时间取文件修改时间
+func (s *WebDAVSyncServiceImpl) readLocal(key string) (*webdavFile, error) {
+	filePath := s.localPath(key)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取本机文件失败: %w", err)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取本机文件失败: %w", err)
+	}
+	return s.parseFile(key, data, info.ModTime()), nil
+}
+
+// scanRemote 列出远端的日报文件、附件和配置文件，远端目录不存在时创建
+func (s *WebDAVSyncServiceImpl) scanRemote() (map[string]davclient.Resource, error) {
+	files := make(map[string]davclient.Resource)
+
+	root, err := s.client.List("")
+	if errors.Is(err, davclient.ErrNotFound) {
+		if err := s.client.Mkdir(""); err != nil {
+			return nil, fmt.Errorf("创建远端目录失败: %w", err)
+		}
+		root, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range root {
+		if res.Name == webdavConfigFile && s.configPath != "" {
+			files[webdavConfigFile] = res
+		}
+	}
+
+	tasks, err := s.client.List(webdavTaskDir)
+	if errors.Is(err, davclient.ErrNotFound) {
+		if err := s.client.Mkdir(webdavTaskDir); err != nil {
+			return nil, fmt.Errorf("创建远端目录失败: %w", err)
+		}
+		tasks, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range tasks {
+		if _, ok := conflictDate(res.Name); ok {
+			files[webdavTaskDir+"/"+res.Name] = res
+		}
+	}
+
+	// 附件目录在上传第一个附件时才创建
+	days, err := s.client.ListDirs(util.AttachmentDir)
+	if errors.Is(err, davclient.ErrNotFound) {
+		days, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		if !isAttachmentDay(day) {
+			continue
+		}
+		attachments, err := s.client.List(util.AttachmentDir + "/" + day)
+		if err != nil && !errors.Is(err, davclient.ErrNotFound) {
+			return nil, err
+		}
+		for _, res := range attachments {
+			if isAttachmentName(res.Name) {
+				files[path.Join(util.AttachmentDir, day, res.Name)] = res
+			}
+		}
+	}
+	return files, nil
+}
+
+// parseFile 计算文件内容的哈希并确定更新时间：日报取 UpdatedAt，无法解析时以及配置文件、附件使用 modTime
+func (s *WebDAVSyncServiceImpl) parseFile(key string, data []byte, modTime time.Time) *webdavFile {
+	sum := sha256.Sum256(data)
+	file := &webdavFile{data: data, hash: hex.EncodeToString(sum[:]), updatedAt: modTime}
+	if strings.HasPrefix(key, webdavTaskDir+"/") {
+		if task, err := parseConflictTask(data); err == nil && task != nil && !task.UpdatedAt.IsZero() {
+			file.updatedAt = task.UpdatedAt
+		}
+	}
+	return file
+}
+
+// localPath 返回远端路径对应的本机文件路径
+func (s *WebDAVSyncServiceImpl) localPath(key string) string {
+	if key == webdavConfigFile {
+		return s.configPath
+	}
+	if s.isAttachment(key) {
+		return filepath.Join(s.dataPath, filepath.FromSlash(key))
+	}
+	return filepath.Join(s.dataPath, path.Base(key))
+}
+
+// isAttachment 判断远端路径是否为附件
+func (s *WebDAVSyncServiceImpl) isAttachment(key string) bool {
+	return strings.HasPrefix(key, util.AttachmentDir+"/")
+}
+
+// isAttachmentDay 判断目录名是否为附件的日期目录
+func isAttachmentDay(name string) bool {
+	_, err := time.Parse("2006-01-02", name)
+	return err == nil
+}
+
+// isAttachmentName 判断文件是否为需要同步的附件，跳过临时文件和隐藏文件
+func isAttachmentName(name string) bool {
+	return !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, ".tmp")
+}
+
+// loadState 读取同步状态文件，不存在时返回空状态
+func (s *WebDAVSyncServiceImpl) loadState() (*webdavSyncState, error) {
+	state := &webdavSyncState{Files: make(map[string]webdavFileState)}
+	data, err := os.ReadFile(filepath.Join(s.dataPath, webdavStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取同步状态失败: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("解析同步状态失败: %w", err)
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]webdavFileState)
+	}
+	return state, nil
+}
+
+// saveState 保存同步状态文件
+func (s *WebDAVSyncServiceImpl) saveState(state *webdavSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化同步状态失败: %w", err)
+	}
+	if err := util.WriteFileAtomic(filepath.Join(s.dataPath, webdavStateFile), data, 0644); err != nil {
+		return fmt.Errorf("保存同步状态失败: %w", err)
+	}
+	return nil
+}
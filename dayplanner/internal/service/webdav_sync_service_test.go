@@ -0,0 +1,253 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/repository"
+
+	"golang.org/x/net/webdav"
+)
+
+// webdavMachine 一台使用 WebDAV 同步的机器
+type webdavMachine struct {
+	dataPath    string
+	configPath  string
+	taskService *TaskServiceImpl
+	syncService *WebDAVSyncServiceImpl
+	updated     int
+}
+
+// newWebDAVMachine 创建使用 WebDAV 同步的任务服务
+func newWebDAVMachine(t *testing.T, remote string) *webdavMachine {
+	t.Helper()
+	root := t.TempDir()
+	m := &webdavMachine{
+		dataPath:   filepath.Join(root, "data", "tasks"),
+		configPath: filepath.Join(root, "config", "config.json"),
+	}
+	syncService, err := NewWebDAVSyncService(m.dataPath, m.configPath, remote, "", "", time.Hour)
+	if err != nil {
+		t.Fatalf("创建同步服务失败: %v", err)
+	}
+	syncService.SetOnUpdated(func() { m.updated++ })
+	m.syncService = syncService
+	m.taskService = NewTaskService(repository.NewFileTaskRepository(m.dataPath), m.dataPath)
+	m.taskService.SetSyncService(syncService)
+	return m
+}
+
+// sync 同步并在失败时终止测试
+func (m *webdavMachine) sync(t *testing.T) {
+	t.Helper()
+	if err := m.syncService.Sync(); err != nil {
+		t.Fatalf("同步失败: %v", err)
+	}
+}
+
+// content 返回某天的日报内容，不存在时返回空字符串
+func (m *webdavMachine) content(t *testing.T, date time.Time) string {
+	t.Helper()
+	task, err := m.taskService.GetTask(date)
+	if err != nil {
+		t.Fatalf("读取日报失败: %v", err)
+	}
+	if task == nil {
+		return ""
+	}
+	return task.Content
+}
+
+// conflictCopies 返回 .conflicts 目录中的文件名
+func (m *webdavMachine) conflictCopies() []string {
+	entries, _ := os.ReadDir(filepath.Join(m.dataPath, webdavConflictDir))
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestWebDAVSyncService(t *testing.T) {
+	server := httptest.NewServer(&webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()})
+	defer server.Close()
+	remote := server.URL + "/daily-report/"
+
+	a := newWebDAVMachine(t, remote)
+	b := newWebDAVMachine(t, remote)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	// A 的日报和配置文件同步到 B
+	os.MkdirAll(filepath.Dir(a.configPath), 0755)
+	if err := os.WriteFile(a.configPath, []byte(`{"reminder_time":"09:30"}`), 0644); err != nil {
+		t.Fatalf("写入配置失败: %v", err)
+	}
+	if err := a.taskService.SaveTask(monday, "- [x] 机器 A 的周一"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	a.sync(t)
+	b.sync(t)
+	if b.content(t, monday) != "- [x] 机器 A 的周一" || b.updated != 1 {
+		t.Fatalf("B 应拉取到 A 的日报: %q, 更新回调 %d 次", b.content(t, monday), b.updated)
+	}
+	if data, _ := os.ReadFile(b.configPath); string(data) != `{"reminder_time":"09:30"}` {
+		t.Errorf("B 应拉取到 A 的配置文件: %q", data)
+	}
+
+	// 没有变化时不上传也不下载
+	a.sync(t)
+	if a.updated != 0 {
+		t.Error("没有远端修改时不应调用更新回调")
+	}
+
+	// 两边都修改：B 较新，B 同步时保留本机版本，远端版本存入 .conflicts；A 随后拉取 B 的版本
+	if err := a.taskService.SaveTask(monday, "A 修改"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	a.sync(t)
+	if err := b.taskService.SaveTask(monday, "B 修改"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	b.sync(t)
+	if b.content(t, monday) != "B 修改" || len(b.conflictCopies()) != 1 {
+		t.Errorf("B 的版本较新，应保留: %q, 冲突副本 %v", b.content(t, monday), b.conflictCopies())
+	}
+	a.sync(t)
+	if a.content(t, monday) != "B 修改" {
+		t.Errorf("A 应拉取到 B 的版本: %q", a.content(t, monday))
+	}
+
+	// 两边都修改：远端较新时使用远端版本，本机版本存入 .conflicts
+	if err := b.taskService.SaveTask(monday, "B 先修改"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	if err := a.taskService.SaveTask(monday, "A 后修改"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	a.sync(t)
+	b.sync(t)
+	if b.content(t, monday) != "A 后修改" {
+		t.Errorf("远端版本较新，应使用远端版本: %q", b.content(t, monday))
+	}
+	copies := b.conflictCopies()
+	if len(copies) != 2 || !strings.Contains(strings.Join(copies, " "), "2025-11-10.local-") {
+		t.Errorf("本机落选版本应保存到 .conflicts: %v", copies)
+	}
+
+	// 删除同步到另一台机器
+	if err := b.taskService.SaveTask(tuesday, "周二"); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	b.sync(t)
+	a.sync(t)
+	if a.content(t, tuesday) != "周二" {
+		t.Fatalf("A 应拉取到周二的日报")
+	}
+	if err := os.Remove(filepath.Join(a.dataPath, "2025-11-11.json")); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	a.sync(t)
+	b.sync(t)
+	if b.content(t, tuesday) != "" {
+		t.Error("A 删除的日报应在 B 上删除")
+	}
+
+	// 同步状态记录在数据目录中，不会被当作日报
+	if _, err := os.Stat(filepath.Join(a.dataPath, webdavStateFile)); err != nil {
+		t.Errorf("应保存同步状态文件: %v", err)
+	}
+	dates, err := a.taskService.GetMonthTaskDates(2025, time.November)
+	if err != nil || len(dates) != 1 {
+		t.Errorf("状态文件和冲突目录不应被当作日报: %v, %v", dates, err)
+	}
+}
+
+func TestWebDAVSyncService_Attachments(t *testing.T) {
+	server := httptest.NewServer(&webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()})
+	defer server.Close()
+	remote := server.URL + "/daily-report/"
+
+	a := newWebDAVMachine(t, remote)
+	b := newWebDAVMachine(t, remote)
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+
+	// A 添加的附件和引用它的日报同步到 B
+	attachments := NewAttachmentService(repository.NewFileAttachmentRepository(a.dataPath), a.taskService)
+	attachment, markdown, err := attachments.Add(monday, "截图.png", []byte("png"))
+	if err != nil {
+		t.Fatalf("添加附件失败: %v", err)
+	}
+	if err := a.taskService.SaveTask(monday, "- 页面改版\n  "+markdown); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	a.sync(t)
+	b.sync(t)
+	localPath := filepath.Join(b.dataPath, filepath.FromSlash(attachment.Link))
+	if data, err := os.ReadFile(localPath); err != nil || string(data) != "png" {
+		t.Fatalf("B 应拉取到 A 的附件: %q, %v", data, err)
+	}
+	dates, err := b.taskService.GetMonthTaskDates(2025, time.November)
+	if err != nil || len(dates) != 1 {
+		t.Errorf("附件目录不应被当作日报: %v, %v", dates, err)
+	}
+
+	// 删除附件同步到 A，空的日期目录一并删除
+	if err := os.Remove(localPath); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	b.sync(t)
+	a.sync(t)
+	if _, err := os.Stat(filepath.Join(a.dataPath, filepath.FromSlash(attachment.Link))); !os.IsNotExist(err) {
+		t.Errorf("B 删除的附件应在 A 上删除: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(a.dataPath, "attachments", "2025-11-10")); !os.IsNotExist(err) {
+		t.Errorf("空的附件目录应删除: %v", err)
+	}
+}
+
+func TestWebDAVSyncService_StopDuringSync(t *testing.T) {
+	handler := &webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()}
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 第一个请求阻塞，模拟网络缓慢时的同步
+		once.Do(func() {
+			close(entered)
+			<-release
+		})
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	m := newWebDAVMachine(t, server.URL)
+	if err := m.syncService.Start(); err != nil {
+		t.Fatalf("启动同步服务失败: %v", err)
+	}
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("启动后应立即同步")
+	}
+
+	// 同步进行中时设置回调和停止服务不应等待网络请求
+	done := make(chan struct{})
+	go func() {
+		m.syncService.SetOnUpdated(func() {})
+		m.syncService.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("同步进行中时 Stop 和 SetOnUpdated 不应阻塞")
+	}
+}
@@ -0,0 +1,237 @@
+// Package teamclient 实现团队服务器的客户端：读写本人的日报，组长查看成员的填报情况和日报
+// 服务器由 daily-report team serve 提供，使用成员的访问令牌认证
+package teamclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+var (
+	// ErrUnauthorized 访问令牌缺失或错误
+	ErrUnauthorized = errors.New("团队服务器访问令牌无效")
+
+	// ErrForbidden 非组长访问组长才能使用的接口
+	ErrForbidden = errors.New("只有组长可以查看团队成员的填报情况和日报")
+)
+
+// Member 令牌对应的成员
+type Member struct {
+	Name string `json:"name"`
+	Lead bool   `json:"lead"`
+}
+
+// Client 团队服务器客户端
+type Client struct {
+	base  *url.URL
+	token string
+	http  *http.Client
+}
+
+// NewClient 创建团队服务器客户端，baseURL 为服务器地址，如 http://team.example.com:17900
+func NewClient(baseURL, token string) (*Client, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("无效的团队服务器地址: %s", baseURL)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("未配置团队服务器访问令牌 (team_token)")
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return &Client{
+		base:  base,
+		token: token,
+		http:  &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// Me 返回令牌对应的成员，可用于检查服务器地址和令牌是否正确
+func (c *Client) Me() (*Member, error) {
+	var member Member
+	if err := c.getJSON("team/v1/me", nil, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetTask 获取本人指定日期的日报，不存在时返回 nil
+func (c *Client) GetTask(date time.Time) (*model.Task, error) {
+	return c.getTask("team/v1/tasks/"+date.Format("2006-01-02"), date)
+}
+
+// SaveTask 保存本人的日报
+func (c *Client) SaveTask(task *model.Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("序列化任务数据失败: %w", err)
+	}
+	resp, err := c.do(http.MethodPut, "team/v1/tasks/"+task.Date.Format("2006-01-02"), nil, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// TaskDates 获取本人在日期范围内（含首尾）有日报的日期
+func (c *Client) TaskDates(startDate, endDate time.Time) ([]time.Time, error) {
+	var result struct {
+		Dates []string `json:"dates"`
+	}
+	query := url.Values{"from": {startDate.Format("2006-01-02")}, "to": {endDate.Format("2006-01-02")}}
+	if err := c.getJSON("team/v1/tasks", query, &result); err != nil {
+		return nil, err
+	}
+
+	dates := make([]time.Time, 0, len(result.Dates))
+	for _, value := range result.Dates {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("团队服务器返回了无效的日期: %q", value)
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
+
+// Search 搜索本人内容包含所有关键词的日报，按日期倒序返回
+func (c *Client) Search(query string) ([]*model.Task, error) {
+	var result struct {
+		Results []*model.Task `json:"results"`
+	}
+	if err := c.getJSON("team/v1/search", url.Values{"q": {query}}, &result); err != nil {
+		return nil, err
+	}
+	for _, task := range result.Results {
+		task.Date = localDay(task.Date)
+	}
+	return result.Results, nil
+}
+
+// Status 获取团队在指定日期的填报情况，仅组长可用
+func (c *Client) Status(date time.Time) (*model.TeamStatus, error) {
+	var result struct {
+		Members []struct {
+			Name      string    `json:"name"`
+			Mention   string    `json:"mention"`
+			Lead      bool      `json:"lead"`
+			Filed     bool      `json:"filed"`
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"members"`
+	}
+	if err := c.getJSON("team/v1/status/"+date.Format("2006-01-02"), nil, &result); err != nil {
+		return nil, err
+	}
+
+	status := &model.TeamStatus{Date: date}
+	for _, member := range result.Members {
+		status.Members = append(status.Members, model.MemberStatus{
+			Name:      member.Name,
+			Mention:   member.Mention,
+			Lead:      member.Lead,
+			Filed:     member.Filed,
+			UpdatedAt: member.UpdatedAt,
+		})
+	}
+	return status, nil
+}
+
+// MemberTask 获取成员指定日期的日报，不存在时返回 nil，仅组长可用
+func (c *Client) MemberTask(name string, date time.Time) (*model.Task, error) {
+	return c.getTask("team/v1/members/"+url.PathEscape(name)+"/tasks/"+date.Format("2006-01-02"), date)
+}
+
+// getTask 读取一篇日报，404 时返回 nil
+func (c *Client) getTask(path string, date time.Time) (*model.Task, error) {
+	resp, err := c.do(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var task model.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("解析团队服务器响应失败: %w", err)
+	}
+	// 服务器与本机时区可能不同，以请求的日期为准
+	task.Date = date
+	return &task, nil
+}
+
+// getJSON 发送 GET 请求并解析 JSON 响应
+func (c *Client) getJSON(path string, query url.Values, value any) error {
+	resp, err := c.do(http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		return fmt.Errorf("解析团队服务器响应失败: %w", err)
+	}
+	return nil
+}
+
+// do 发送带访问令牌的请求
+func (c *Client) do(method, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	target := c.base.ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()})
+	req, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("创建团队服务器请求失败: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("连接团队服务器失败: %w", err)
+	}
+	return resp, nil
+}
+
+// checkStatus 检查响应状态码，失败时返回服务器给出的错误信息
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	var result struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &result) == nil && result.Error != "" {
+		return fmt.Errorf("团队服务器返回 %s: %s", resp.Status, result.Error)
+	}
+	return fmt.Errorf("团队服务器 %s %s 返回 %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// localDay 返回时间所在日期在本地时区的零点，服务器按自己的时区保存日期
+func localDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -278,6 +278,14 @@ func (cv *CalendarView) selectDate(date time.Time) {
 	}
 }
 
+// GoToDate 跳转到指定日期所在月份并选中该日期
+func (cv *CalendarView) GoToDate(date time.Time) {
+	cv.currentYear = date.Year()
+	cv.currentMonth = date.Month()
+	cv.refresh()
+	cv.selectDate(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local))
+}
+
 // Refresh 刷新日历（公开方法，供外部调用）
 func (cv *CalendarView) Refresh() {
 	cv.refresh()
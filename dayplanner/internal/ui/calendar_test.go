@@ -32,6 +32,22 @@ func (m *mockTaskService) HasTodayTask() (bool, error) {
 	return false, nil
 }
 
+func (m *mockTaskService) Search(query string) ([]*model.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) GetTasksInRange(startDate, endDate time.Time) ([]*model.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) UpdateContent(date time.Time, update func(content string) (string, error)) error {
+	return nil
+}
+
+func (m *mockTaskService) MarkSubmitted(date time.Time, channels []string) error {
+	return nil
+}
+
 func TestNewCalendarView(t *testing.T) {
 	// 初始化测试应用
 	test.NewApp()
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CarryOverView 顺延未完成任务项的对话框
+type CarryOverView struct {
+	window           fyne.Window
+	carryOverService service.CarryOverService
+	beforeCarryOver  func(date time.Time) // 顺延前回调，用于保存编辑器中尚未保存的内容
+	onCarriedOver    func(date time.Time) // 顺延完成后回调，用于重新载入编辑器
+	dismissed        map[string]bool      // 本次运行中用户已忽略提示的日期
+}
+
+// NewCarryOverView 创建新的顺延对话框
+func NewCarryOverView(parent fyne.Window, carryOverService service.CarryOverService) *CarryOverView {
+	return &CarryOverView{
+		window:           parent,
+		carryOverService: carryOverService,
+		dismissed:        make(map[string]bool),
+	}
+}
+
+// SetBeforeCarryOver 设置顺延前回调
+func (cv *CarryOverView) SetBeforeCarryOver(callback func(date time.Time)) {
+	cv.beforeCarryOver = callback
+}
+
+// SetOnCarriedOver 设置顺延完成回调
+func (cv *CarryOverView) SetOnCarriedOver(callback func(date time.Time)) {
+	cv.onCarriedOver = callback
+}
+
+// Prompt 有可顺延的任务项时提示用户，没有或已忽略过时不打扰
+func (cv *CarryOverView) Prompt(date time.Time) {
+	if cv.dismissed[date.Format("2006-01-02")] {
+		return
+	}
+	cv.show(date, false)
+}
+
+// Show 显示可顺延的任务项，没有时提示用户
+func (cv *CarryOverView) Show(date time.Time) {
+	cv.show(date, true)
+}
+
+// show 查找可顺延的任务项并显示选择对话框，默认全部勾选
+func (cv *CarryOverView) show(date time.Time, manual bool) {
+	candidate, err := cv.carryOverService.FindUnfinished(date)
+	if err != nil {
+		util.Error("查找未完成任务项失败: %v", err)
+		if manual {
+			util.ShowErrorDialogWithMessage("顺延失败", "无法读取上一个工作日的日报", err, cv.window)
+		}
+		return
+	}
+	if candidate == nil {
+		if manual {
+			util.ShowInfoDialog("没有可顺延的事项", "上一个工作日的任务项均已完成或已顺延", cv.window)
+		}
+		return
+	}
+
+	checks := make([]*widget.Check, len(candidate.Items))
+	list := container.NewVBox()
+	for i, item := range candidate.Items {
+		checks[i] = widget.NewCheck(item, nil)
+		checks[i].SetChecked(true)
+		list.Add(checks[i])
+	}
+
+	message := widget.NewLabel(fmt.Sprintf("%s %s 有 %d 项未完成，选择要顺延到 %s 的事项：",
+		candidate.From.Format("2006-01-02"), util.ChineseWeekday(candidate.From),
+		len(candidate.Items), date.Format("2006-01-02")))
+	message.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(message, nil, nil, nil, container.NewVScroll(list))
+
+	carryDialog := dialog.NewCustomConfirm("顺延未完成事项", "顺延", "忽略", content, func(confirmed bool) {
+		if !confirmed {
+			cv.dismissed[date.Format("2006-01-02")] = true
+			return
+		}
+
+		var items []string
+		for i, check := range checks {
+			if check.Checked {
+				items = append(items, candidate.Items[i])
+			}
+		}
+		cv.carryOver(date, candidate.From, items)
+	}, cv.window)
+	carryDialog.Resize(fyne.NewSize(520, 360))
+	carryDialog.Show()
+}
+
+// carryOver 执行顺延并通知主窗口重新载入
+func (cv *CarryOverView) carryOver(date, from time.Time, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	if cv.beforeCarryOver != nil {
+		cv.beforeCarryOver(date)
+	}
+
+	if err := cv.carryOverService.CarryOver(date, from, items); err != nil {
+		util.ShowErrorDialogWithMessage("顺延失败", "无法顺延未完成事项", err, cv.window)
+		return
+	}
+	// 只顺延了部分事项时，剩余事项不再自动提示
+	cv.dismissed[date.Format("2006-01-02")] = true
+
+	if cv.onCarriedOver != nil {
+		cv.onCarriedOver(date)
+	}
+	util.ShowSuccessNotification(fmt.Sprintf("已顺延 %d 项未完成事项", len(items)), cv.window)
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/storage"
+)
+
+// pngSignature PNG 文件头
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// clipboardImageTimeout 调用系统命令读取剪贴板图片的超时时间
+const clipboardImageTimeout = 3 * time.Second
+
+// windowsClipboardScript 在 Windows 上将剪贴板中的图片以 PNG 格式写到标准输出
+const windowsClipboardScript = `Add-Type -AssemblyName System.Windows.Forms,System.Drawing;` +
+	`$img = [System.Windows.Forms.Clipboard]::GetImage();` +
+	`if ($img) { $ms = New-Object System.IO.MemoryStream; $img.Save($ms, [System.Drawing.Imaging.ImageFormat]::Png);` +
+	`$out = [Console]::OpenStandardOutput(); $out.Write($ms.ToArray(), 0, $ms.Length) }`
+
+// textClipboard 内存中的剪贴板，用于通过编辑框的粘贴逻辑插入文字
+type textClipboard struct {
+	content string
+}
+
+// Content 返回剪贴板内容
+func (c *textClipboard) Content() string {
+	return c.content
+}
+
+// SetContent 设置剪贴板内容
+func (c *textClipboard) SetContent(content string) {
+	c.content = content
+}
+
+// clipboardFiles 解析剪贴板文字中的本机文件：每行一个绝对路径或 file:// URI，全部是已存在的文件时返回路径
+func clipboardFiles(text string) []string {
+	var paths []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "file://") {
+			uri, err := storage.ParseURI(line)
+			if err != nil {
+				return nil
+			}
+			line = uri.Path()
+		}
+		if !filepath.IsAbs(line) {
+			return nil
+		}
+		if info, err := os.Stat(line); err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		paths = append(paths, line)
+	}
+	return paths
+}
+
+// readClipboardImage 通过系统命令读取剪贴板中的 PNG 图片：Linux 使用 wl-paste 或 xclip，
+// macOS 使用 pngpaste，Windows 使用 PowerShell；命令不存在或剪贴板中没有图片时返回 nil
+func readClipboardImage() []byte {
+	var commands [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		commands = [][]string{{"pngpaste", "-"}}
+	case "windows":
+		commands = [][]string{{"powershell", "-NoProfile", "-STA", "-Command", windowsClipboardScript}}
+	default:
+		commands = [][]string{
+			{"wl-paste", "--no-newline", "--type", "image/png"},
+			{"xclip", "-selection", "clipboard", "-t", "image/png", "-o"},
+		}
+	}
+
+	for _, command := range commands {
+		if _, err := exec.LookPath(command[0]); err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), clipboardImageTimeout)
+		data, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+		cancel()
+		if err == nil && bytes.HasPrefix(data, pngSignature) {
+			return data
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/png" // 解码渲染结果的尺寸
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// diagramRenderTimeout 调用外部命令渲染一个图表的超时时间
+const diagramRenderTimeout = 15 * time.Second
+
+// diagramRenderDelay 图表代码停止修改后多久开始渲染，避免每次输入都启动外部命令
+const diagramRenderDelay = 600 * time.Millisecond
+
+// diagramMaxWidth 预览中图表的最大显示宽度
+const diagramMaxWidth = 640
+
+// diagramTool 渲染一种图表语言的外部命令
+type diagramTool struct {
+	name    string                              // 图表类型的显示名称
+	command string                              // 命令名称
+	args    func(input, output string) []string // 读取 input 文件，将 PNG 写入 output 文件
+}
+
+// diagramTools 支持的图表语言，按围栏代码块的语言名称（小写）索引
+var diagramTools = map[string]diagramTool{
+	"mermaid": {
+		name:    "Mermaid",
+		command: "mmdc",
+		args: func(input, output string) []string {
+			return []string{"-i", input, "-o", output, "-b", "transparent", "-q"}
+		},
+	},
+	"dot": {
+		name:    "Graphviz",
+		command: "dot",
+		args: func(input, output string) []string {
+			return []string{"-Tpng", "-o", output, input}
+		},
+	},
+}
+
+func init() {
+	diagramTools["graphviz"] = diagramTools["dot"]
+}
+
+// isDiagramLanguage 判断代码块语言是否为图表
+func isDiagramLanguage(language string) bool {
+	_, ok := diagramTools[strings.ToLower(language)]
+	return ok
+}
+
+// diagramResult 一个图表的渲染结果
+type diagramResult struct {
+	png []byte
+	err error
+}
+
+// diagramRenderer 调用外部命令将图表代码渲染为 PNG，结果按语言和代码缓存
+// Mermaid 需要安装 mermaid-cli（mmdc），Graphviz 需要安装 dot；命令不存在时预览显示占位内容
+type diagramRenderer struct {
+	lookPath   func(file string) (string, error)                            // 查找命令，测试时替换
+	runCommand func(ctx context.Context, name string, args ...string) error // 运行命令，测试时替换
+	delay      time.Duration                                                // 为 0 时在创建图表块时同步渲染
+
+	mu    sync.Mutex
+	cache map[string]diagramResult
+}
+
+// newDiagramRenderer 创建图表渲染器
+func newDiagramRenderer() *diagramRenderer {
+	return &diagramRenderer{
+		lookPath: exec.LookPath,
+		runCommand: func(ctx context.Context, name string, args ...string) error {
+			output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+			if err != nil && len(output) > 0 {
+				return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+			}
+			return err
+		},
+		delay: diagramRenderDelay,
+		cache: make(map[string]diagramResult),
+	}
+}
+
+// available 判断是否安装了渲染该语言的命令
+func (r *diagramRenderer) available(language string) bool {
+	tool, ok := diagramTools[strings.ToLower(language)]
+	if !ok {
+		return false
+	}
+	_, err := r.lookPath(tool.command)
+	return err == nil
+}
+
+// cached 返回已缓存的渲染结果
+func (r *diagramRenderer) cached(language, code string) (diagramResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.cache[diagramKey(language, code)]
+	return result, ok
+}
+
+// render 渲染图表并缓存结果
+func (r *diagramRenderer) render(language, code string) ([]byte, error) {
+	if result, ok := r.cached(language, code); ok {
+		return result.png, result.err
+	}
+
+	png, err := r.run(language, code)
+	if err != nil {
+		util.Warn("渲染图表失败: %v", err)
+	}
+	r.mu.Lock()
+	r.cache[diagramKey(language, code)] = diagramResult{png: png, err: err}
+	r.mu.Unlock()
+	return png, err
+}
+
+// run 在临时目录中调用外部命令渲染图表
+func (r *diagramRenderer) run(language, code string) ([]byte, error) {
+	tool, ok := diagramTools[strings.ToLower(language)]
+	if !ok {
+		return nil, fmt.Errorf("不支持的图表类型: %s", language)
+	}
+	command, err := r.lookPath(tool.command)
+	if err != nil {
+		return nil, fmt.Errorf("未找到 %s 命令: %w", tool.command, err)
+	}
+
+	dir, err := os.MkdirTemp("", "daily-report-diagram-")
+	if err != nil {
+		return nil, fmt.Errorf("创建临时目录失败: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "diagram."+strings.ToLower(language))
+	output := filepath.Join(dir, "diagram.png")
+	if err := os.WriteFile(input, []byte(code), 0600); err != nil {
+		return nil, fmt.Errorf("写入图表代码失败: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagramRenderTimeout)
+	defer cancel()
+	if err := r.runCommand(ctx, command, tool.args(input, output)...); err != nil {
+		return nil, fmt.Errorf("%s 渲染失败: %w", tool.name, err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("读取渲染结果失败: %w", err)
+	}
+	util.Debug("已渲染 %s 图表 (%d 字节)", tool.name, len(data))
+	return data, nil
+}
+
+// diagramKey 图表缓存的键
+func diagramKey(language, code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(language) + "\x00" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// diagramView 预览中的一个图表块：渲染完成前和无法渲染时显示占位内容
+type diagramView struct {
+	container *fyne.Container
+	language  string
+	code      string
+	disposed  atomic.Bool // 块已从预览中移除，渲染结果不再显示
+}
+
+// newDiagramView 创建图表块，已安装渲染命令时在后台渲染
+func newDiagramView(renderer *diagramRenderer, language, code string) *diagramView {
+	dv := &diagramView{
+		container: container.NewStack(),
+		language:  language,
+		code:      code,
+	}
+	name := diagramTools[strings.ToLower(language)].name
+
+	if result, ok := renderer.cached(language, code); ok {
+		dv.showResult(name, result.png, result.err)
+		return dv
+	}
+	if !renderer.available(language) {
+		dv.showPlaceholder(fmt.Sprintf("%s 图表（安装 %s 后显示为图片）", name, diagramTools[strings.ToLower(language)].command))
+		return dv
+	}
+
+	if renderer.delay == 0 {
+		png, err := renderer.render(language, code)
+		dv.showResult(name, png, err)
+		return dv
+	}
+
+	dv.showPlaceholder(fmt.Sprintf("正在渲染 %s 图表...", name))
+	time.AfterFunc(renderer.delay, func() {
+		if dv.disposed.Load() {
+			return
+		}
+		png, err := renderer.render(language, code)
+		fyne.Do(func() {
+			if !dv.disposed.Load() {
+				dv.showResult(name, png, err)
+			}
+		})
+	})
+	return dv
+}
+
+// dispose 标记图表块已移除
+func (dv *diagramView) dispose() {
+	dv.disposed.Store(true)
+}
+
+// showResult 显示渲染结果，失败时显示错误和图表代码
+func (dv *diagramView) showResult(name string, png []byte, err error) {
+	if err != nil {
+		dv.showPlaceholder(fmt.Sprintf("%s 图表渲染失败: %v", name, err))
+		return
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(png))
+	if err != nil {
+		dv.showPlaceholder(fmt.Sprintf("%s 图表渲染失败: %v", name, err))
+		return
+	}
+
+	img := canvas.NewImageFromResource(fyne.NewStaticResource("diagram.png", png))
+	img.FillMode = canvas.ImageFillContain
+	width, height := float32(config.Width), float32(config.Height)
+	if width > diagramMaxWidth {
+		width, height = diagramMaxWidth, height*diagramMaxWidth/width
+	}
+	img.SetMinSize(fyne.NewSize(width, height))
+	dv.container.Objects = []fyne.CanvasObject{container.NewHBox(img)}
+	dv.container.Refresh()
+}
+
+// showPlaceholder 显示说明文字和图表代码
+func (dv *diagramView) showPlaceholder(message string) {
+	label := widget.NewLabelWithStyle(message, fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	label.Importance = widget.LowImportance
+	code := widget.NewRichText(&widget.TextSegment{
+		Text:  strings.TrimSuffix(dv.code, "\n"),
+		Style: widget.RichTextStyle{Inline: true, TextStyle: fyne.TextStyle{Monospace: true}, ColorName: theme.ColorNamePlaceHolder},
+	})
+	code.Wrapping = fyne.TextWrapBreak
+	dv.container.Objects = []fyne.CanvasObject{container.NewVBox(label, code)}
+	dv.container.Refresh()
+}
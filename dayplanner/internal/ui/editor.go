@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"daily-report-tool/internal/service"
@@ -13,15 +15,27 @@ import (
 
 // EditorView 编辑器视图组件
 type EditorView struct {
-	container       *fyne.Container
-	titleLabel      *widget.Label
-	editor          *widget.Entry
-	currentDate     time.Time
-	onContentChange func(content string)
-	saveTimer       *time.Timer
-	taskService     service.TaskService
-	onSaveComplete  func() // 保存完成后的回调，用于刷新日历
-	parentWindow    fyne.Window // 用于显示错误对话框
+	container         *fyne.Container
+	titleLabel        *widget.Label
+	editor            *editorEntry
+	findBar           *findBar
+	outlineButton     *widget.Button // 工具栏中的大纲按钮，设置切换回调后显示
+	onToggleOutline   func()
+	cursorLine        int            // 光标所在的行，从 0 开始
+	onCursorLine      func(line int) // 光标移到另一行时的回调，用于同步预览滚动
+	currentDate       time.Time
+	onContentChange   func(content string)
+	saveTimer         *time.Timer
+	pendingDate       time.Time // 待自动保存内容所属的日期
+	pendingContent    string    // 待自动保存的内容
+	pendingSaved      bool      // 定时器触发的自动保存是否已完成
+	suppressSave      bool      // 程序填入内容（如模板）时不触发自动保存
+	taskService       service.TaskService
+	templateService   service.TemplateService   // 可选，设置后空白日期自动填入模板
+	attachmentService service.AttachmentService // 可选，设置后可以粘贴和拖入附件
+	journalService    service.JournalService    // 可选，设置后自动保存前的修改先记录到保存日志
+	onSaveComplete    func()                    // 保存完成后的回调，用于刷新日历
+	parentWindow      fyne.Window               // 用于显示错误对话框
 }
 
 // NewEditorView 创建新的编辑器视图
@@ -35,15 +49,26 @@ func NewEditorView(taskService service.TaskService) *EditorView {
 	ev.titleLabel = widget.NewLabel("选择日期以开始编辑")
 	ev.titleLabel.TextStyle = fyne.TextStyle{Bold: true}
 
-	// 创建多行文本编辑器
-	ev.editor = widget.NewMultiLineEntry()
+	// 创建多行文本编辑器，粘贴文件或截图时作为附件插入
+	ev.editor = newEditorEntry()
 	ev.editor.SetPlaceHolder("在此输入您的日报内容，支持 Markdown 格式...")
 	ev.editor.Wrapping = fyne.TextWrapWord
+	ev.editor.onPasteFiles = ev.AttachFiles
+	ev.editor.onPasteImage = func(data []byte) bool {
+		return ev.AttachImage("paste-"+time.Now().Format("150405")+".png", data)
+	}
+
+	// 格式快捷键和工具栏
+	ev.registerShortcuts()
+	toolbar := ev.createToolbar()
+	ev.findBar = newFindBar(ev.editor)
 
 	// 监听内容变更事件
 	ev.editor.OnChanged = func(content string) {
-		// 触发自动保存
-		ev.triggerAutoSave(content)
+		// 触发自动保存（程序填入的模板内容除外）
+		if !ev.suppressSave {
+			ev.triggerAutoSave(content)
+		}
 		
 		// 调用外部回调（用于更新预览）
 		if ev.onContentChange != nil {
@@ -51,13 +76,26 @@ func NewEditorView(taskService service.TaskService) *EditorView {
 		}
 	}
 
-	// 创建容器布局
+	// 光标移到另一行时通知外部
+	ev.editor.OnCursorChanged = func() {
+		line := ev.editor.cursorLine()
+		if line == ev.cursorLine {
+			return
+		}
+		ev.cursorLine = line
+		if ev.onCursorLine != nil {
+			ev.onCursorLine(line)
+		}
+	}
+
+	// 创建容器布局：标题、工具栏和查找栏位于编辑器上方
+	top := container.NewVBox(ev.titleLabel, toolbar, ev.findBar.container)
 	ev.container = container.NewBorder(
-		ev.titleLabel, // top
-		nil,           // bottom
-		nil,           // left
-		nil,           // right
-		ev.editor,     // center
+		top,       // top
+		nil,       // bottom
+		nil,       // left
+		nil,       // right
+		ev.editor, // center
 	)
 
 	return ev
@@ -79,9 +117,148 @@ func (ev *EditorView) GetContent() string {
 }
 
 // SetDate 设置当前编辑的日期
+// 切换日期前先保存上一个日期尚未保存的修改；新日期没有日报时填入模板（不会自动保存），
+// 没有模板时清空编辑器
 func (ev *EditorView) SetDate(date time.Time) {
+	ev.FlushAutoSave()
 	ev.currentDate = date
 	ev.titleLabel.SetText(date.Format("2006年01月02日 星期一"))
+	ev.prefillTemplate(date)
+}
+
+// SetTemplateService 设置模板服务
+func (ev *EditorView) SetTemplateService(templateService service.TemplateService) {
+	ev.templateService = templateService
+}
+
+// ApplyTemplate 使用指定名称的模板替换编辑器内容，替换后正常触发自动保存
+func (ev *EditorView) ApplyTemplate(name string) error {
+	if ev.templateService == nil {
+		return fmt.Errorf("模板服务未初始化")
+	}
+	content, err := ev.templateService.Render(name, ev.currentDate)
+	if err != nil {
+		return err
+	}
+	ev.editor.SetText(content)
+	return nil
+}
+
+// SetAttachmentService 设置附件服务
+func (ev *EditorView) SetAttachmentService(attachmentService service.AttachmentService) {
+	ev.attachmentService = attachmentService
+}
+
+// SetJournalService 设置保存日志服务，修改在自动保存之前先记录到日志，崩溃后下次启动可以恢复
+func (ev *EditorView) SetJournalService(journalService service.JournalService) {
+	ev.journalService = journalService
+}
+
+// AttachFiles 将本机文件保存为当前日期的附件，并在光标处插入引用它们的链接
+// 没有设置附件服务时返回 false，由调用方按普通文字处理
+func (ev *EditorView) AttachFiles(paths []string) bool {
+	if ev.attachmentService == nil {
+		return false
+	}
+	var links []string
+	for _, filePath := range paths {
+		_, markdown, err := ev.attachmentService.AddFile(ev.currentDate, filePath)
+		if err != nil {
+			ev.showAttachError(err)
+			break
+		}
+		links = append(links, markdown)
+	}
+	if len(links) > 0 {
+		ev.InsertAtCursor(strings.Join(links, "\n"))
+	}
+	return true
+}
+
+// AttachImage 将粘贴的截图保存为当前日期的附件，并在光标处插入图片链接
+func (ev *EditorView) AttachImage(name string, data []byte) bool {
+	if ev.attachmentService == nil {
+		return false
+	}
+	_, markdown, err := ev.attachmentService.Add(ev.currentDate, name, data)
+	if err != nil {
+		ev.showAttachError(err)
+		return true
+	}
+	ev.InsertAtCursor(markdown)
+	return true
+}
+
+// showAttachError 显示添加附件失败的错误
+func (ev *EditorView) showAttachError(err error) {
+	util.Error("添加附件失败: %v", err)
+	if ev.parentWindow != nil {
+		util.ShowErrorDialogWithMessage("添加附件失败", "无法保存附件", err, ev.parentWindow)
+	}
+}
+
+// InsertAtCursor 在光标处插入文字（替换选中的文字），插入后光标位于文字之后，可以撤销
+func (ev *EditorView) InsertAtCursor(text string) {
+	ev.editor.insertText(text)
+}
+
+// ShowFind 显示查找栏，replace 为 true 时同时显示替换输入框
+func (ev *EditorView) ShowFind(replace bool) {
+	ev.findBar.show(replace)
+}
+
+// HideFind 隐藏查找栏
+func (ev *EditorView) HideFind() {
+	ev.findBar.hide()
+}
+
+// SetOnCursorLineChanged 设置光标移到另一行时的回调，参数为光标所在的行（从 0 开始）
+func (ev *EditorView) SetOnCursorLineChanged(callback func(line int)) {
+	ev.onCursorLine = callback
+}
+
+// ScrollToLine 将光标移到第 line 行（从 0 开始）的开头，使编辑器滚动到该行
+// 不获取键盘焦点；有选中的文字时不移动，避免取消用户的选区
+func (ev *EditorView) ScrollToLine(line int) {
+	if ev.editor.SelectedText() != "" || line == ev.cursorLine {
+		return
+	}
+	ev.editor.selectRange(lineOffset(ev.editor.Text, line), lineOffset(ev.editor.Text, line))
+}
+
+// SetOnToggleOutline 设置工具栏中大纲按钮的回调，设置后显示该按钮
+func (ev *EditorView) SetOnToggleOutline(callback func()) {
+	ev.onToggleOutline = callback
+	ev.outlineButton.Show()
+}
+
+// prefillTemplate 日期没有日报时填入该日期的模板
+func (ev *EditorView) prefillTemplate(date time.Time) {
+	if ev.taskService == nil {
+		return
+	}
+	task, err := ev.taskService.GetTask(date)
+	if err != nil {
+		util.Error("加载任务失败: %v", err)
+		return
+	}
+	if task != nil && task.Content != "" {
+		return // 已有日报，由调用方设置内容
+	}
+
+	content := ""
+	if ev.templateService != nil {
+		if content, err = ev.templateService.RenderForDate(date); err != nil {
+			util.Warn("渲染日报模板失败: %v", err)
+			content = ""
+		} else if content != "" {
+			util.Debug("空白日期已填入模板: %s", date.Format("2006-01-02"))
+		}
+	}
+
+	ev.suppressSave = true
+	ev.editor.SetText(content)
+	ev.suppressSave = false
 }
 
 // GetDate 获取当前编辑的日期
@@ -112,24 +289,45 @@ func (ev *EditorView) triggerAutoSave(content string) {
 		ev.saveTimer.Stop()
 	}
 
-	// 创建新的定时器，2 秒后保存
+	// 创建新的定时器，2 秒后保存；记录日期，避免切换日期后保存到新日期
+	date := ev.currentDate
+	ev.pendingDate = date
+	ev.pendingContent = content
+	ev.pendingSaved = false
+	if ev.journalService != nil {
+		ev.journalService.Record(date, content)
+	}
 	ev.saveTimer = time.AfterFunc(2*time.Second, func() {
-		ev.saveContent(content)
+		ev.saveContent(date, content)
+		ev.pendingSaved = true
 	})
 }
 
+// HasPendingSave 返回是否有尚未自动保存的内容
+func (ev *EditorView) HasPendingSave() bool {
+	return ev.saveTimer != nil && !ev.pendingSaved
+}
+
+// FlushAutoSave 立即保存尚未到时间的自动保存内容
+func (ev *EditorView) FlushAutoSave() {
+	if ev.saveTimer != nil && ev.saveTimer.Stop() {
+		ev.saveContent(ev.pendingDate, ev.pendingContent)
+	}
+	ev.saveTimer = nil
+}
+
 // saveContent 保存内容到任务服务
-func (ev *EditorView) saveContent(content string) {
+func (ev *EditorView) saveContent(date time.Time, content string) {
 	if ev.taskService == nil {
 		util.Warn("任务服务未初始化")
 		return
 	}
 
 	util.Debug("自动保存任务: %s, 内容长度: %d", 
-		ev.currentDate.Format("2006-01-02"), len(content))
+		date.Format("2006-01-02"), len(content))
 
 	// 调用任务服务保存内容
-	err := ev.taskService.SaveTask(ev.currentDate, content)
+	err := ev.taskService.SaveTask(date, content)
 	if err != nil {
 		util.Error("保存任务失败: %v", err)
 		// 如果有父窗口，显示错误对话框
@@ -140,7 +338,10 @@ func (ev *EditorView) saveContent(content string) {
 		return
 	}
 
-	util.Info("任务保存成功: %s", ev.currentDate.Format("2006-01-02"))
+	util.Info("任务保存成功: %s", date.Format("2006-01-02"))
+	if ev.journalService != nil {
+		ev.journalService.Commit(date, content)
+	}
 
 	// 保存成功后调用回调，刷新日历视图
 	if ev.onSaveComplete != nil {
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// editorEntry 日报编辑器使用的多行编辑框
+// 在 Entry 的基础上支持粘贴附件和格式化快捷键，并提供按字符偏移移动光标、选中和替换文字的方法，
+// 这些修改都通过 Entry 自身的按键和粘贴逻辑完成，因此可以撤销
+type editorEntry struct {
+	widget.Entry
+	onPasteFiles func(paths []string) bool // 粘贴文件，返回是否已处理
+	onPasteImage func(data []byte) bool    // 粘贴截图，返回是否已处理
+	readImage    func() []byte             // 读取剪贴板中的图片，测试时替换
+	shortcuts    map[string]func()         // 格式化快捷键，按快捷键名称索引
+}
+
+// newEditorEntry 创建日报编辑框
+func newEditorEntry() *editorEntry {
+	e := &editorEntry{
+		readImage: readClipboardImage,
+		shortcuts: make(map[string]func()),
+	}
+	e.MultiLine = true
+	e.Wrapping = fyne.TextWrapWord
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// addShortcut 注册快捷键
+func (e *editorEntry) addShortcut(shortcut fyne.Shortcut, action func()) {
+	e.shortcuts[shortcut.ShortcutName()] = action
+}
+
+// TypedShortcut 处理快捷键
+// Fyne 的剪贴板只支持文字：剪贴板中是本机文件（在文件管理器中复制的文件）时作为附件插入，
+// 剪贴板中没有文字时尝试通过系统命令读取截图
+func (e *editorEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if e.Disabled() {
+		e.Entry.TypedShortcut(shortcut)
+		return
+	}
+	if action, ok := e.shortcuts[shortcut.ShortcutName()]; ok {
+		action()
+		return
+	}
+	if paste, ok := shortcut.(*fyne.ShortcutPaste); ok && paste.Clipboard != nil {
+		text := paste.Clipboard.Content()
+		if paths := clipboardFiles(text); len(paths) > 0 && e.onPasteFiles != nil && e.onPasteFiles(paths) {
+			return
+		}
+		if text == "" && e.onPasteImage != nil {
+			if data := e.readImage(); len(data) > 0 && e.onPasteImage(data) {
+				return
+			}
+		}
+	}
+	e.Entry.TypedShortcut(shortcut)
+}
+
+// insertText 在光标处插入文字（替换选中的文字），插入后光标位于文字之后
+func (e *editorEntry) insertText(text string) {
+	e.Entry.TypedShortcut(&fyne.ShortcutPaste{Clipboard: &textClipboard{content: text}})
+}
+
+// selection 返回选中文字的字符偏移范围，没有选中时起止都是光标位置
+// 调用后选区收起到起点，需要保留选区时由调用方重新选中
+func (e *editorEntry) selection() (start, end int) {
+	selected := len([]rune(e.SelectedText()))
+	if selected == 0 {
+		offset := e.CursorTextOffset()
+		return offset, offset
+	}
+	e.releaseShift()
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	start = e.CursorTextOffset()
+	return start, start + selected
+}
+
+// cursorLine 返回光标所在的行（按换行符计算，不受自动换行影响），从 0 开始
+func (e *editorEntry) cursorLine() int {
+	runes := []rune(e.Text)
+	return strings.Count(string(runes[:clampOffset(e.CursorTextOffset(), len(runes))]), "\n")
+}
+
+// selectRange 选中 [start, end) 范围的文字，start 等于 end 时只移动光标
+func (e *editorEntry) selectRange(start, end int) {
+	length := len([]rune(e.Text))
+	start = clampOffset(start, length)
+	end = clampOffset(end, length)
+	if end < start {
+		start, end = end, start
+	}
+
+	// 先取消已有选区并回到开头，再用方向键移动到目标位置，使 Entry 内部的选区状态保持同步
+	e.releaseShift()
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageUp})
+	e.moveRightTo(start)
+	if end > start {
+		e.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+		e.moveRightTo(end)
+		e.releaseShift()
+	}
+}
+
+// replaceRange 将 [start, end) 范围的文字替换为 text，完成后光标移到 cursor
+// 只替换新旧文字中不同的部分：纯插入或纯删除（如添加标题标记）只产生一个撤销步骤，
+// 其他修改与在选区上粘贴相同，先撤销插入再撤销删除
+func (e *editorEntry) replaceRange(start, end int, text string, cursor int) {
+	length := len([]rune(e.Text))
+	start = clampOffset(start, length)
+	end = clampOffset(end, length)
+	old := []rune(e.Text)[start:end]
+	replacement := []rune(text)
+	for len(old) > 0 && len(replacement) > 0 && old[0] == replacement[0] {
+		old, replacement = old[1:], replacement[1:]
+		start++
+	}
+	for len(old) > 0 && len(replacement) > 0 && old[len(old)-1] == replacement[len(replacement)-1] {
+		old, replacement = old[:len(old)-1], replacement[:len(replacement)-1]
+		end--
+	}
+
+	e.selectRange(start, end)
+	if len(replacement) > 0 {
+		e.insertText(string(replacement))
+	} else if end > start {
+		e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDelete})
+	}
+	e.selectRange(cursor, cursor)
+}
+
+// moveRightTo 将光标从当前位置之前移动到 offset
+// 先把光标放到目标前一个字符，再按一次右方向键，由 Entry 更新光标和选区
+func (e *editorEntry) moveRightTo(offset int) {
+	if offset <= 0 || offset <= e.CursorTextOffset() {
+		return
+	}
+	e.placeCursor(offset - 1)
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+}
+
+// placeCursor 将光标行列设置为字符偏移 offset 对应的位置
+// CursorRow 是自动换行后的显示行，逐行查找包含 offset 的行；不存在的行起点为 0
+func (e *editorEntry) placeCursor(offset int) {
+	row := 0
+	for {
+		e.CursorRow, e.CursorColumn = row+1, 0
+		if begin := e.CursorTextOffset(); begin == 0 || begin > offset {
+			break
+		}
+		row++
+	}
+	e.CursorRow, e.CursorColumn = row, 0
+	e.CursorColumn = offset - e.CursorTextOffset()
+}
+
+// releaseShift 释放 Shift 键，避免方向键扩展选区
+func (e *editorEntry) releaseShift() {
+	e.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	e.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftRight})
+}
+
+// focus 将键盘焦点移到编辑框
+func (e *editorEntry) focus() {
+	if fyne.CurrentApp() == nil {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(e); c != nil {
+		c.Focus(e)
+	}
+}
+
+// clampOffset 将字符偏移限制在 [0, length] 范围内
+func clampOffset(offset, length int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > length {
+		return length
+	}
+	return offset
+}
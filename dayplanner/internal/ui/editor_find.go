@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// findEntry 查找栏的输入框，按 Esc 关闭查找栏
+type findEntry struct {
+	widget.Entry
+	onEscape func()
+}
+
+// newFindEntry 创建查找栏的输入框
+func newFindEntry(placeHolder string) *findEntry {
+	e := &findEntry{}
+	e.SetPlaceHolder(placeHolder)
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// TypedKey 处理按键
+func (e *findEntry) TypedKey(key *fyne.KeyEvent) {
+	if key.Name == fyne.KeyEscape && e.onEscape != nil {
+		e.onEscape()
+		return
+	}
+	e.Entry.TypedKey(key)
+}
+
+// findBar 编辑器上方的查找替换栏，在当前日报中查找，默认隐藏
+type findBar struct {
+	editor        *editorEntry
+	container     *fyne.Container
+	replaceRow    *fyne.Container
+	findEntry     *findEntry
+	replaceEntry  *findEntry
+	caseSensitive *widget.Check
+	countLabel    *widget.Label
+}
+
+// newFindBar 创建查找替换栏
+func newFindBar(editor *editorEntry) *findBar {
+	fb := &findBar{editor: editor}
+
+	fb.findEntry = newFindEntry("查找")
+	fb.findEntry.onEscape = fb.hide
+	fb.findEntry.OnChanged = fb.onQueryChanged
+	fb.findEntry.OnSubmitted = func(string) { fb.next() }
+
+	fb.replaceEntry = newFindEntry("替换为")
+	fb.replaceEntry.onEscape = fb.hide
+	fb.replaceEntry.OnSubmitted = func(string) { fb.replace() }
+
+	fb.caseSensitive = widget.NewCheck("区分大小写", func(bool) { fb.updateCount(-1) })
+	fb.countLabel = widget.NewLabel("")
+
+	prevButton := widget.NewButtonWithIcon("", theme.MoveUpIcon(), fb.previous)
+	nextButton := widget.NewButtonWithIcon("", theme.MoveDownIcon(), fb.next)
+	closeButton := widget.NewButtonWithIcon("", theme.CancelIcon(), fb.hide)
+	closeButton.Importance = widget.LowImportance
+
+	replaceButton := widget.NewButton("替换", fb.replace)
+	replaceAllButton := widget.NewButton("全部替换", fb.replaceAll)
+
+	findRow := container.NewBorder(nil, nil, nil,
+		container.NewHBox(fb.countLabel, fb.caseSensitive, prevButton, nextButton, closeButton),
+		fb.findEntry)
+	fb.replaceRow = container.NewBorder(nil, nil, nil,
+		container.NewHBox(replaceButton, replaceAllButton),
+		fb.replaceEntry)
+
+	fb.container = container.NewVBox(findRow, fb.replaceRow)
+	fb.container.Hide()
+	return fb
+}
+
+// show 显示查找栏，replace 为 true 时同时显示替换输入框；选中的单行文字作为查找内容
+func (fb *findBar) show(replace bool) {
+	if selected := fb.editor.SelectedText(); selected != "" && !strings.Contains(selected, "\n") {
+		// 填入查找内容时不重新查找，保持编辑器中的选区
+		fb.findEntry.OnChanged = nil
+		fb.findEntry.SetText(selected)
+		fb.findEntry.OnChanged = fb.onQueryChanged
+	}
+	if replace {
+		fb.replaceRow.Show()
+	} else {
+		fb.replaceRow.Hide()
+	}
+	fb.container.Show()
+	fb.updateCount(-1)
+
+	if fyne.CurrentApp() != nil {
+		if c := fyne.CurrentApp().Driver().CanvasForObject(fb.findEntry); c != nil {
+			c.Focus(fb.findEntry)
+		}
+	}
+}
+
+// hide 隐藏查找栏，焦点回到编辑器
+func (fb *findBar) hide() {
+	fb.container.Hide()
+	fb.editor.focus()
+}
+
+// onQueryChanged 输入查找内容时从当前位置开始查找，选中第一个匹配
+func (fb *findBar) onQueryChanged(string) {
+	start, _ := fb.editor.selection()
+	fb.selectMatch(start, true)
+}
+
+// matches 返回当前日报中所有匹配的位置
+func (fb *findBar) matches() []util.TextRange {
+	return util.FindMatches(fb.editor.Text, fb.findEntry.Text, fb.caseSensitive.Checked)
+}
+
+// next 选中当前位置之后的下一个匹配，到末尾后从头开始
+func (fb *findBar) next() {
+	_, end := fb.editor.selection()
+	fb.selectMatch(end, true)
+}
+
+// previous 选中当前位置之前的上一个匹配，到开头后从末尾开始
+func (fb *findBar) previous() {
+	start, _ := fb.editor.selection()
+	fb.selectMatch(start, false)
+}
+
+// selectMatch 从 offset 开始向后（forward）或向前查找并选中匹配
+func (fb *findBar) selectMatch(offset int, forward bool) {
+	matches := fb.matches()
+	if len(matches) == 0 {
+		fb.updateCount(-1)
+		return
+	}
+
+	index := -1
+	if forward {
+		for i, match := range matches {
+			if match.Start >= offset {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			index = 0
+		}
+	} else {
+		for i := len(matches) - 1; i >= 0; i-- {
+			if matches[i].End <= offset {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			index = len(matches) - 1
+		}
+	}
+
+	fb.editor.selectRange(matches[index].Start, matches[index].End)
+	fb.updateCount(index)
+}
+
+// replace 替换当前选中的匹配并选中下一个；选中的不是匹配时只查找下一个
+func (fb *findBar) replace() {
+	start, end := fb.editor.selection()
+	for _, match := range fb.matches() {
+		if match.Start == start && match.End == end {
+			replacement := fb.replaceEntry.Text
+			fb.editor.replaceRange(start, end, replacement, start+len([]rune(replacement)))
+			fb.selectMatch(start+len([]rune(replacement)), true)
+			return
+		}
+	}
+	fb.selectMatch(start, true)
+}
+
+// replaceAll 替换所有匹配，整篇日报作为一次修改替换，可以撤销
+func (fb *findBar) replaceAll() {
+	matches := fb.matches()
+	if len(matches) == 0 {
+		fb.updateCount(-1)
+		return
+	}
+	cursor, _ := fb.editor.selection()
+	text := util.ReplaceMatches(fb.editor.Text, matches, fb.replaceEntry.Text)
+	fb.editor.replaceRange(0, len([]rune(fb.editor.Text)), text, cursor)
+	fb.countLabel.SetText(fmt.Sprintf("已替换 %d 处", len(matches)))
+	util.Debug("查找替换: 已替换 %d 处", len(matches))
+}
+
+// updateCount 更新匹配数量，current 为当前选中的匹配序号，-1 表示没有选中
+func (fb *findBar) updateCount(current int) {
+	if fb.findEntry.Text == "" {
+		fb.countLabel.SetText("")
+		return
+	}
+	count := len(fb.matches())
+	switch {
+	case count == 0:
+		fb.countLabel.SetText("没有匹配")
+	case current < 0:
+		fb.countLabel.SetText(fmt.Sprintf("%d 个匹配", count))
+	default:
+		fb.countLabel.SetText(fmt.Sprintf("%d/%d", current+1, count))
+	}
+}
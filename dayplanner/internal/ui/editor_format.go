@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"strings"
+
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 格式化命令插入的占位文字，插入后选中，直接输入即可替换
+const (
+	boldPlaceholder = "粗体文字"
+	linkPlaceholder = "链接文字"
+	linkURLDefault  = "https://"
+)
+
+// editorCommand 编辑器命令，快捷键和"编辑"菜单共用
+type editorCommand struct {
+	label    string
+	shortcut *desktop.CustomShortcut
+	action   func()
+}
+
+// editorShortcut 返回 Ctrl（macOS 上为 Cmd）加指定按键的快捷键
+func editorShortcut(key fyne.KeyName) *desktop.CustomShortcut {
+	return &desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault}
+}
+
+// commands 返回编辑器的格式化和查找命令，nil 表示菜单中的分隔线
+func (ev *EditorView) commands() []*editorCommand {
+	return []*editorCommand{
+		{label: "查找...", shortcut: editorShortcut(fyne.KeyF), action: func() { ev.ShowFind(false) }},
+		{label: "替换...", shortcut: editorShortcut(fyne.KeyH), action: func() { ev.ShowFind(true) }},
+		nil,
+		{label: "一级标题", shortcut: editorShortcut(fyne.Key1), action: func() { ev.ToggleHeading(1) }},
+		{label: "二级标题", shortcut: editorShortcut(fyne.Key2), action: func() { ev.ToggleHeading(2) }},
+		{label: "三级标题", shortcut: editorShortcut(fyne.Key3), action: func() { ev.ToggleHeading(3) }},
+		{label: "粗体", shortcut: editorShortcut(fyne.KeyB), action: ev.ToggleBold},
+		{label: "列表", shortcut: editorShortcut(fyne.KeyL), action: ev.ToggleList},
+		{label: "切换复选框", shortcut: editorShortcut(fyne.KeyReturn), action: ev.ToggleCheckbox},
+		{label: "链接", shortcut: editorShortcut(fyne.KeyK), action: ev.InsertLink},
+		{label: "代码块", shortcut: editorShortcut(fyne.KeyE), action: ev.InsertCodeBlock},
+	}
+}
+
+// registerShortcuts 在编辑框中注册命令的快捷键
+func (ev *EditorView) registerShortcuts() {
+	for _, command := range ev.commands() {
+		if command != nil && command.shortcut != nil {
+			ev.editor.addShortcut(command.shortcut, command.action)
+		}
+	}
+	// 小键盘的回车键同样可以切换复选框
+	ev.editor.addShortcut(editorShortcut(fyne.KeyEnter), ev.ToggleCheckbox)
+}
+
+// createToolbar 创建编辑器上方的格式工具栏
+func (ev *EditorView) createToolbar() *fyne.Container {
+	button := func(label string, icon fyne.Resource, action func()) *widget.Button {
+		b := widget.NewButtonWithIcon(label, icon, action)
+		b.Importance = widget.LowImportance
+		return b
+	}
+	ev.outlineButton = button("大纲", theme.MenuIcon(), func() {
+		if ev.onToggleOutline != nil {
+			ev.onToggleOutline()
+		}
+	})
+	ev.outlineButton.Hide()
+
+	return container.NewHBox(
+		button("H1", nil, func() { ev.ToggleHeading(1) }),
+		button("H2", nil, func() { ev.ToggleHeading(2) }),
+		button("H3", nil, func() { ev.ToggleHeading(3) }),
+		button("B", nil, ev.ToggleBold),
+		button("", theme.ListIcon(), ev.ToggleList),
+		button("", theme.CheckButtonCheckedIcon(), ev.ToggleCheckbox),
+		button("链接", nil, ev.InsertLink),
+		button("代码", nil, ev.InsertCodeBlock),
+		widget.NewSeparator(),
+		button("", theme.SearchReplaceIcon(), func() { ev.ShowFind(true) }),
+		ev.outlineButton,
+	)
+}
+
+// ToggleHeading 将光标所在行（或选中的各行）设置为指定级别的标题，已是该级别时恢复为普通文字
+func (ev *EditorView) ToggleHeading(level int) {
+	ev.formatLines(func(lines []string) []string {
+		for i, line := range lines {
+			if len(lines) == 1 || strings.TrimSpace(line) != "" {
+				lines[i] = util.ToggleHeading(line, level)
+			}
+		}
+		return lines
+	})
+}
+
+// ToggleList 将光标所在行（或选中的各行）设置为无序列表，已是列表时去掉列表标记
+func (ev *EditorView) ToggleList() {
+	ev.formatLines(util.ToggleList)
+}
+
+// ToggleCheckbox 切换光标所在行（或选中的各行）的复选框，普通文字变为未勾选的任务项
+func (ev *EditorView) ToggleCheckbox() {
+	ev.formatLines(func(lines []string) []string {
+		for i, line := range lines {
+			if len(lines) == 1 || strings.TrimSpace(line) != "" {
+				lines[i] = util.ToggleCheckbox(line)
+			}
+		}
+		return lines
+	})
+}
+
+// ToggleBold 将选中的文字设为粗体，已是粗体时取消；没有选中时插入选中的占位文字
+func (ev *EditorView) ToggleBold() {
+	e := ev.editor
+	start, end := e.selection()
+	text := []rune(e.Text)
+	selected := string(text[start:end])
+
+	switch {
+	case end-start >= 4 && strings.HasPrefix(selected, "**") && strings.HasSuffix(selected, "**"):
+		inner := string(text[start+2 : end-2])
+		e.replaceRange(start, end, inner, start)
+		e.selectRange(start, end-4)
+	case start >= 2 && end+2 <= len(text) && string(text[start-2:start]) == "**" && string(text[end:end+2]) == "**":
+		e.replaceRange(start-2, end+2, selected, start-2)
+		e.selectRange(start-2, end-2)
+	default:
+		ev.wrapRange(start, end, "**", "**", boldPlaceholder)
+	}
+	e.focus()
+}
+
+// InsertLink 插入链接：选中网址时作为链接地址，选中其他文字时作为链接文字，然后选中需要填写的部分
+func (ev *EditorView) InsertLink() {
+	e := ev.editor
+	start, end := e.selection()
+	selected := string([]rune(e.Text)[start:end])
+
+	label, url := linkPlaceholder, linkURLDefault
+	if isURL(selected) {
+		url = selected
+	} else if selected != "" {
+		label = selected
+	}
+	link := "[" + label + "](" + url + ")"
+	e.replaceRange(start, end, link, start)
+
+	// 选中还需要填写的部分：没有链接文字时选中文字，否则选中地址
+	if label == linkPlaceholder {
+		e.selectRange(start+1, start+1+len([]rune(label)))
+	} else {
+		urlStart := start + len([]rune(label)) + 3
+		e.selectRange(urlStart, urlStart+len([]rune(url)))
+	}
+	e.focus()
+}
+
+// InsertCodeBlock 将选中的文字放入代码块，没有选中时插入空代码块，光标位于代码块内
+func (ev *EditorView) InsertCodeBlock() {
+	e := ev.editor
+	start, end := e.selection()
+	text := []rune(e.Text)
+	code := strings.TrimSuffix(string(text[start:end]), "\n")
+
+	// 代码块的围栏需要单独成行
+	prefix := "```\n"
+	if start > 0 && text[start-1] != '\n' {
+		prefix = "\n" + prefix
+	}
+	suffix := "\n```"
+	if end < len(text) && text[end] != '\n' || end > start && text[end-1] == '\n' {
+		suffix += "\n"
+	}
+
+	cursor := start + len([]rune(prefix)) + len([]rune(code))
+	e.replaceRange(start, end, prefix+code+suffix, cursor)
+	e.focus()
+}
+
+// GoToLine 将光标移到指定行（从 0 开始）的开头并聚焦编辑器
+func (ev *EditorView) GoToLine(line int) {
+	offset := lineOffset(ev.editor.Text, line)
+	ev.editor.selectRange(offset, offset)
+	ev.editor.focus()
+}
+
+// lineOffset 返回第 line 行（从 0 开始）开头的字符偏移，行不存在时返回文本末尾
+func lineOffset(text string, line int) int {
+	offset := 0
+	runes := []rune(text)
+	for i := 0; i < line && offset < len(runes); offset++ {
+		if runes[offset] == '\n' {
+			i++
+		}
+	}
+	return offset
+}
+
+// formatLines 对选区覆盖的各行（没有选区时为光标所在行）应用 transform
+// 没有选区时光标保持在原来的文字之后，有选区时替换后选中修改的各行，便于连续应用多个格式
+func (ev *EditorView) formatLines(transform func(lines []string) []string) {
+	e := ev.editor
+	start, end := e.selection()
+	text := []rune(e.Text)
+	lineStart, lineEnd := lineBounds(text, start, end)
+
+	original := string(text[lineStart:lineEnd])
+	replaced := strings.Join(transform(strings.Split(original, "\n")), "\n")
+	switch {
+	case replaced == original:
+		e.selectRange(start, end)
+	case start == end:
+		cursor := lineStart + len([]rune(replaced)) - (lineEnd - start)
+		if cursor < lineStart {
+			cursor = lineStart
+		}
+		e.replaceRange(lineStart, lineEnd, replaced, cursor)
+	default:
+		e.replaceRange(lineStart, lineEnd, replaced, lineStart)
+		e.selectRange(lineStart, lineStart+len([]rune(replaced)))
+	}
+	e.focus()
+}
+
+// wrapRange 用 prefix 和 suffix 包围 [start, end) 范围的文字并选中原文字，范围为空时插入占位文字
+func (ev *EditorView) wrapRange(start, end int, prefix, suffix, placeholder string) {
+	e := ev.editor
+	inner := string([]rune(e.Text)[start:end])
+	if inner == "" {
+		inner = placeholder
+	}
+	e.replaceRange(start, end, prefix+inner+suffix, start)
+	innerStart := start + len([]rune(prefix))
+	e.selectRange(innerStart, innerStart+len([]rune(inner)))
+}
+
+// lineBounds 返回选区 [start, end) 覆盖的完整行的范围；选区结束于下一行开头时不包含该行
+func lineBounds(text []rune, start, end int) (lineStart, lineEnd int) {
+	lineStart = start
+	for lineStart > 0 && text[lineStart-1] != '\n' {
+		lineStart--
+	}
+	if end > start && text[end-1] == '\n' {
+		end--
+	}
+	lineEnd = end
+	for lineEnd < len(text) && text[lineEnd] != '\n' {
+		lineEnd++
+	}
+	return lineStart, lineEnd
+}
+
+// isURL 判断文字是否为网址
+func isURL(text string) bool {
+	return (strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://")) && !strings.ContainsAny(text, " \t\n")
+}
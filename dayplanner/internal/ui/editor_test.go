@@ -0,0 +1,401 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+)
+
+func TestEditorView_PrefillTemplate(t *testing.T) {
+	test.NewApp()
+
+	tempDir := t.TempDir()
+	taskService := service.NewTaskService(repository.NewFileTaskRepository(tempDir), tempDir)
+	templateDir := filepath.Join(tempDir, "templates")
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("创建模板目录失败: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "default.md"), []byte("# {{.Date}}\n\n## 今日完成\n"), 0644); err != nil {
+		t.Fatalf("写入模板失败: %v", err)
+	}
+
+	ev := NewEditorView(taskService)
+	ev.SetTemplateService(service.NewTemplateService(templateDir, taskService))
+
+	// 空白日期填入模板，但不自动保存
+	empty := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local)
+	ev.SetDate(empty)
+	if got := ev.GetContent(); got != "# 2025-11-10\n\n## 今日完成\n" {
+		t.Errorf("空白日期应填入模板，实际: %q", got)
+	}
+	if ev.saveTimer != nil {
+		t.Error("填入模板不应触发自动保存")
+	}
+
+	// 用户修改后切换日期，未到时间的修改立即保存到原日期
+	ev.SetContent("- [x] 完成评审")
+	ev.SetDate(time.Date(2025, 11, 11, 0, 0, 0, 0, time.Local))
+	task, err := taskService.GetTask(empty)
+	if err != nil || task == nil || task.Content != "- [x] 完成评审" {
+		t.Errorf("切换日期前应保存原日期的修改: %+v, %v", task, err)
+	}
+
+	// 已有日报的日期不填入模板
+	ev.SetContent("")
+	ev.SetDate(empty)
+	if got := ev.GetContent(); got != "" {
+		t.Errorf("已有日报的日期不应填入模板，实际: %q", got)
+	}
+}
+
+func TestEditorView_PasteAttachments(t *testing.T) {
+	test.NewApp()
+
+	tempDir := t.TempDir()
+	taskService := service.NewTaskService(repository.NewFileTaskRepository(tempDir), tempDir)
+	attachmentService := service.NewAttachmentService(repository.NewFileAttachmentRepository(tempDir), taskService)
+	ev := NewEditorView(taskService)
+	ev.SetDate(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local))
+	ev.SetContent("- 页面改版\n- 周会")
+	ev.editor.CursorRow, ev.editor.CursorColumn = 0, len([]rune("- 页面改版"))
+
+	// 没有附件服务时按普通文字粘贴
+	screenshot := filepath.Join(t.TempDir(), "截图.png")
+	os.WriteFile(screenshot, []byte("png"), 0644)
+	clipboard := test.NewClipboard()
+	clipboard.SetContent(screenshot)
+	ev.editor.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	if got := ev.GetContent(); got != "- 页面改版"+screenshot+"\n- 周会" {
+		t.Fatalf("未启用附件时应粘贴文字，实际: %q", got)
+	}
+
+	// 粘贴文件时保存为附件并在光标处插入链接
+	ev.SetAttachmentService(attachmentService)
+	ev.SetContent("- 页面改版\n- 周会")
+	ev.editor.CursorRow, ev.editor.CursorColumn = 0, len([]rune("- 页面改版"))
+	offset := ev.editor.CursorTextOffset()
+	ev.editor.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	list, _ := attachmentService.List(ev.GetDate())
+	if len(list) != 1 {
+		t.Fatalf("应保存一个附件，实际: %d", len(list))
+	}
+	want := "- 页面改版![" + list[0].Name + "](" + list[0].Link + ")\n- 周会"
+	if got := ev.GetContent(); got != want {
+		t.Errorf("应在光标处插入图片链接，实际: %q", got)
+	}
+	if got := ev.editor.CursorTextOffset() - offset; got != len([]rune(list[0].Link))+len([]rune(list[0].Name))+5 {
+		t.Errorf("光标应位于插入内容之后，实际移动了 %d 个字符", got)
+	}
+
+	// 剪贴板中只有截图时读取图片
+	ev.editor.readImage = func() []byte { return []byte("\x89PNG\r\n\x1a\nshot") }
+	clipboard.SetContent("")
+	ev.editor.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	if list, _ := attachmentService.List(ev.GetDate()); len(list) != 2 {
+		t.Errorf("应保存粘贴的截图，实际附件数: %d", len(list))
+	}
+
+	// 普通文字照常粘贴
+	clipboard.SetContent("相对路径.png")
+	ev.editor.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	if !strings.Contains(ev.GetContent(), "相对路径.png") {
+		t.Errorf("普通文字应照常粘贴，实际: %q", ev.GetContent())
+	}
+}
+
+func TestEditorView_Formatting(t *testing.T) {
+	test.NewApp()
+
+	ev := NewEditorView(nil)
+	w := test.NewWindow(ev.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 400))
+
+	// 光标所在行设置为标题，光标保持在原来的文字之后
+	ev.SetContent("今日完成\n写文档")
+	ev.editor.selectRange(2, 2)
+	ev.ToggleHeading(2)
+	if got := ev.GetContent(); got != "## 今日完成\n写文档" {
+		t.Fatalf("应设置为二级标题，实际: %q", got)
+	}
+	if got := ev.editor.CursorTextOffset(); got != 5 {
+		t.Errorf("光标应保持在原来的文字之后，实际位置: %d", got)
+	}
+	ev.ToggleHeading(2)
+	if got := ev.GetContent(); got != "今日完成\n写文档" {
+		t.Errorf("再次设置同级标题应恢复为普通文字，实际: %q", got)
+	}
+
+	// 快捷键切换复选框：普通文字变为任务项，再次切换为已勾选
+	ev.editor.selectRange(7, 7)
+	ev.editor.TypedShortcut(editorShortcut(fyne.KeyReturn))
+	ev.editor.TypedShortcut(editorShortcut(fyne.KeyReturn))
+	if got := ev.GetContent(); got != "今日完成\n- [x] 写文档" {
+		t.Errorf("应切换光标所在行的复选框，实际: %q", got)
+	}
+
+	// 修改可以撤销：添加任务项标记只插入文字，撤销一次即可
+	ev.editor.Undo()
+	ev.editor.Undo()
+	if got := ev.GetContent(); got != "今日完成\n- [ ] 写文档" {
+		t.Errorf("撤销勾选后应恢复为未勾选，实际: %q", got)
+	}
+	ev.editor.Undo()
+	if got := ev.GetContent(); got != "今日完成\n写文档" {
+		t.Errorf("撤销后应恢复为普通文字，实际: %q", got)
+	}
+
+	// 选中多行设置为列表
+	ev.SetContent("写文档\n评审\n发布")
+	ev.editor.selectRange(1, 6)
+	ev.ToggleList()
+	if got := ev.GetContent(); got != "- 写文档\n- 评审\n发布" {
+		t.Errorf("应将选中的各行设置为列表，实际: %q", got)
+	}
+	if got := ev.editor.SelectedText(); got != "- 写文档\n- 评审" {
+		t.Errorf("设置后应选中修改的各行，实际: %q", got)
+	}
+
+	// 粗体：选中文字时包围并保持选中，再次设置时取消
+	ev.SetContent("修复登录问题")
+	ev.editor.selectRange(2, 4)
+	ev.editor.TypedShortcut(editorShortcut(fyne.KeyB))
+	if got := ev.GetContent(); got != "修复**登录**问题" {
+		t.Fatalf("应将选中的文字设为粗体，实际: %q", got)
+	}
+	if got := ev.editor.SelectedText(); got != "登录" {
+		t.Errorf("设为粗体后应选中原文字，实际: %q", got)
+	}
+	ev.ToggleBold()
+	if got := ev.GetContent(); got != "修复登录问题" {
+		t.Errorf("再次设置应取消粗体，实际: %q", got)
+	}
+
+	// 链接：选中的网址作为地址，并选中链接文字
+	ev.SetContent("见 https://example.com/pr/1")
+	ev.editor.selectRange(2, len([]rune(ev.GetContent())))
+	ev.InsertLink()
+	if got := ev.GetContent(); got != "见 [链接文字](https://example.com/pr/1)" {
+		t.Errorf("应插入链接，实际: %q", got)
+	}
+	if got := ev.editor.SelectedText(); got != linkPlaceholder {
+		t.Errorf("应选中链接文字，实际: %q", got)
+	}
+
+	// 代码块：围栏单独成行，光标位于代码块内
+	ev.SetContent("执行 go test")
+	ev.editor.selectRange(3, 10)
+	ev.InsertCodeBlock()
+	if got := ev.GetContent(); got != "执行 \n```\ngo test\n```" {
+		t.Errorf("应将选中的文字放入代码块，实际: %q", got)
+	}
+	if got := ev.editor.CursorTextOffset(); got != 15 {
+		t.Errorf("光标应位于代码块内，实际位置: %d", got)
+	}
+}
+
+func TestEditorView_FindReplace(t *testing.T) {
+	test.NewApp()
+
+	ev := NewEditorView(nil)
+	w := test.NewWindow(ev.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 400))
+
+	original := "修复 Bug\n复现 bug\n关闭 BUG"
+	ev.SetContent(original)
+	ev.editor.TypedShortcut(editorShortcut(fyne.KeyH))
+	if !ev.findBar.container.Visible() || !ev.findBar.replaceRow.Visible() {
+		t.Fatal("Ctrl+H 应显示查找替换栏")
+	}
+
+	// 输入查找内容时选中第一个匹配，下一个和上一个循环查找
+	ev.findBar.findEntry.SetText("bug")
+	if got := ev.editor.SelectedText(); got != "Bug" {
+		t.Errorf("应选中第一个匹配，实际: %q", got)
+	}
+	if got := ev.findBar.countLabel.Text; got != "1/3" {
+		t.Errorf("匹配计数不正确: %q", got)
+	}
+	ev.findBar.next()
+	ev.findBar.next()
+	ev.findBar.next()
+	if got := ev.findBar.countLabel.Text; got != "1/3" {
+		t.Errorf("查找到末尾后应从头开始，实际: %q", got)
+	}
+	ev.findBar.previous()
+	if got := ev.findBar.countLabel.Text; got != "3/3" {
+		t.Errorf("向前查找到开头后应从末尾开始，实际: %q", got)
+	}
+
+	// 区分大小写
+	ev.findBar.caseSensitive.SetChecked(true)
+	if got := ev.findBar.countLabel.Text; got != "1 个匹配" {
+		t.Errorf("区分大小写时应只有一个匹配，实际: %q", got)
+	}
+	ev.findBar.caseSensitive.SetChecked(false)
+
+	// 替换当前匹配并选中下一个
+	ev.findBar.replaceEntry.SetText("问题")
+	ev.editor.selectRange(0, 0)
+	ev.findBar.next()
+	ev.findBar.replace()
+	if got := ev.GetContent(); got != "修复 问题\n复现 bug\n关闭 BUG" {
+		t.Errorf("应替换当前匹配，实际: %q", got)
+	}
+	if got := ev.editor.SelectedText(); got != "bug" {
+		t.Errorf("替换后应选中下一个匹配，实际: %q", got)
+	}
+
+	// 全部替换可以撤销
+	ev.SetContent(original)
+	ev.findBar.replaceAll()
+	if got := ev.GetContent(); got != "修复 问题\n复现 问题\n关闭 问题" {
+		t.Errorf("应替换所有匹配，实际: %q", got)
+	}
+	if got := ev.findBar.countLabel.Text; got != "已替换 3 处" {
+		t.Errorf("应显示替换数量，实际: %q", got)
+	}
+	ev.editor.Undo() // 撤销插入
+	ev.editor.Undo() // 撤销删除
+	if got := ev.GetContent(); got != original {
+		t.Errorf("撤销后应恢复全部替换前的内容，实际: %q", got)
+	}
+
+	// Esc 关闭查找栏
+	ev.findBar.findEntry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if ev.findBar.container.Visible() {
+		t.Error("Esc 应关闭查找栏")
+	}
+}
+
+func TestEditorView_GoToLine(t *testing.T) {
+	test.NewApp()
+
+	ev := NewEditorView(nil)
+	w := test.NewWindow(ev.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 400))
+
+	content := "# 今日工作\n\n" + strings.Repeat("一段很长的工作记录，会在编辑器中自动换行显示。", 10) + "\n\n## 明日计划\n- 发布"
+	ev.SetContent(content)
+
+	outline := NewOutlineView()
+	outline.SetOnHeadingSelected(func(heading util.Heading) {
+		ev.GoToLine(heading.Line)
+	})
+	outline.Update(content)
+	if len(outline.headings) != 2 {
+		t.Fatalf("大纲应有两个标题，实际: %+v", outline.headings)
+	}
+
+	// 点击大纲中的标题，光标跳到标题所在行的开头（长段落会自动换行成多个显示行）
+	outline.headingList.Select(1)
+	want := strings.Index(content, "## 明日计划")
+	if got := ev.editor.CursorTextOffset(); got != len([]rune(content[:want])) {
+		t.Errorf("光标应位于标题开头，期望 %d, 实际 %d", len([]rune(content[:want])), got)
+	}
+	if ev.editor.CursorRow <= 4 {
+		t.Errorf("长段落应自动换行，标题所在的显示行应大于 4，实际: %d", ev.editor.CursorRow)
+	}
+	if w.Canvas().Focused() != ev.editor {
+		t.Error("跳转后应聚焦编辑器")
+	}
+}
+
+func TestClipboardFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a b.png")
+	b := filepath.Join(dir, "b.pdf")
+	os.WriteFile(a, []byte("a"), 0644)
+	os.WriteFile(b, []byte("b"), 0644)
+
+	if got := clipboardFiles(a + "\n" + storage.NewFileURI(b).String() + "\n"); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("应解析出两个文件，实际: %v", got)
+	}
+	for _, text := range []string{"", "普通文字", a + "\n普通文字", dir, filepath.Join(dir, "missing.png")} {
+		if got := clipboardFiles(text); got != nil {
+			t.Errorf("%q 不应被当作文件，实际: %v", text, got)
+		}
+	}
+}
+
+func TestEditorView_CursorLineSync(t *testing.T) {
+	test.NewApp()
+
+	ev := NewEditorView(nil)
+	w := test.NewWindow(ev.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(600, 400))
+	ev.SetContent("# 今日工作\n- 页面改版\n- 修复登录\n\n## 明日计划\n- 联调")
+
+	var lines []int
+	ev.SetOnCursorLineChanged(func(line int) {
+		lines = append(lines, line)
+	})
+
+	// 在同一行内移动光标不触发回调
+	ev.editor.selectRange(2, 2)
+	lines = nil
+	ev.editor.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	if len(lines) != 0 {
+		t.Errorf("同一行内移动不应触发回调: %v", lines)
+	}
+	ev.editor.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if len(lines) != 1 || lines[0] != 1 {
+		t.Errorf("移到下一行应通知行号 1，实际: %v", lines)
+	}
+
+	// 按预览的位置滚动：光标移到行首，不获取焦点
+	ev.ScrollToLine(4)
+	if got, want := ev.editor.CursorTextOffset(), len([]rune("# 今日工作\n- 页面改版\n- 修复登录\n\n")); got != want {
+		t.Errorf("光标应位于第 4 行开头，期望 %d, 实际 %d", want, got)
+	}
+	if w.Canvas().Focused() == ev.editor {
+		t.Error("同步滚动不应聚焦编辑器")
+	}
+
+	// 有选中的文字时不移动光标
+	ev.editor.selectRange(0, 3)
+	ev.ScrollToLine(5)
+	if ev.editor.SelectedText() != "# 今" {
+		t.Errorf("同步滚动不应取消选区，实际选中: %q", ev.editor.SelectedText())
+	}
+}
+
+func TestEditorView_Journal(t *testing.T) {
+	test.NewApp()
+
+	dataPath := filepath.Join(t.TempDir(), "data", "tasks")
+	taskService := service.NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	journalRepo := repository.NewFileJournalRepository(repository.DefaultJournalPath(dataPath))
+	journal := service.NewJournalService(journalRepo, taskService)
+
+	ev := NewEditorView(taskService)
+	ev.SetJournalService(journal)
+	ev.SetDate(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local))
+
+	// 修改后先记录到保存日志
+	ev.editor.SetText("- 页面改版")
+	journal.Flush()
+	if entries, _ := journalRepo.Load(); len(entries) != 1 || entries[0].Content != "- 页面改版" {
+		t.Fatalf("自动保存前应记录到保存日志: %+v", entries)
+	}
+
+	// 自动保存后删除记录
+	ev.FlushAutoSave()
+	journal.Flush()
+	if entries, _ := journalRepo.Load(); len(entries) != 0 {
+		t.Errorf("保存后应删除记录: %+v", entries)
+	}
+}
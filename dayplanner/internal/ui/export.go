@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"daily-report-tool/internal/export"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// exportFormatLabels 导出格式在界面上的名称
+var exportFormatLabels = map[export.Format]string{
+	export.FormatHTML: "HTML 文件",
+	export.FormatSite: "静态网站（目录）",
+	export.FormatPDF:  "PDF 文档",
+	export.FormatDOCX: "Word 文档 (DOCX)",
+}
+
+// 导出范围选项
+const (
+	exportRangeDay   = "当天"
+	exportRangeWeek  = "本周"
+	exportRangeMonth = "本月"
+)
+
+// ExportView 导出日报对话框
+type ExportView struct {
+	window        fyne.Window
+	exportService service.ExportService
+}
+
+// NewExportView 创建新的导出对话框
+func NewExportView(parent fyne.Window, exportService service.ExportService) *ExportView {
+	return &ExportView{
+		window:        parent,
+		exportService: exportService,
+	}
+}
+
+// Show 显示导出选项，日期范围以 date 为基准
+func (ev *ExportView) Show(date time.Time) {
+	labels := make([]string, len(export.Formats))
+	for i, format := range export.Formats {
+		labels[i] = exportFormatLabels[format]
+	}
+	formatSelect := widget.NewSelect(labels, nil)
+	formatSelect.SetSelectedIndex(0)
+
+	rangeSelect := widget.NewSelect([]string{exportRangeDay, exportRangeWeek, exportRangeMonth}, nil)
+	rangeSelect.SetSelected(exportRangeDay)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("格式", formatSelect),
+		widget.NewFormItem("范围", rangeSelect),
+	}
+	exportDialog := dialog.NewForm("导出日报", "导出...", "取消", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		format := export.Formats[formatSelect.SelectedIndex()]
+		startDate, endDate := exportDateRange(rangeSelect.Selected, date)
+		if format == export.FormatSite {
+			ev.exportSite(startDate, endDate)
+		} else {
+			ev.exportFile(format, startDate, endDate)
+		}
+	}, ev.window)
+	exportDialog.Resize(fyne.NewSize(400, 220))
+	exportDialog.Show()
+}
+
+// exportFile 渲染单文件格式，成功后弹出保存对话框
+func (ev *ExportView) exportFile(format export.Format, startDate, endDate time.Time) {
+	// 先渲染再选择保存位置，没有日报时不弹出保存对话框
+	var buf bytes.Buffer
+	if err := ev.exportService.Render(&buf, format, startDate, endDate); err != nil {
+		util.ShowErrorDialogWithMessage("导出失败", "无法导出日报", err, ev.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			util.ShowErrorDialogWithMessage("导出失败", "无法保存导出文件", err, ev.window)
+			return
+		}
+		if writer == nil {
+			return // 用户取消
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(buf.Bytes()); err != nil {
+			util.ShowErrorDialogWithMessage("导出失败", "无法写入导出文件", err, ev.window)
+			return
+		}
+		util.ShowSuccessNotification(fmt.Sprintf("已导出到 %s", writer.URI().Path()), ev.window)
+	}, ev.window)
+
+	saveDialog.SetFileName(service.ExportFileName(format, startDate, endDate))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+	saveDialog.Show()
+}
+
+// exportSite 选择目录后在其中创建以日期范围命名的网站目录
+func (ev *ExportView) exportSite(startDate, endDate time.Time) {
+	folderDialog := dialog.NewFolderOpen(func(folder fyne.ListableURI, err error) {
+		if err != nil {
+			util.ShowErrorDialogWithMessage("导出失败", "无法打开目录", err, ev.window)
+			return
+		}
+		if folder == nil {
+			return // 用户取消
+		}
+
+		dir := filepath.Join(folder.Path(), service.ExportFileName(export.FormatSite, startDate, endDate))
+		if err := ev.exportService.Export(export.FormatSite, startDate, endDate, dir); err != nil {
+			util.ShowErrorDialogWithMessage("导出失败", "无法导出静态网站", err, ev.window)
+			return
+		}
+		util.ShowSuccessNotification(fmt.Sprintf("已导出到 %s", dir), ev.window)
+	}, ev.window)
+	folderDialog.Show()
+}
+
+// exportDateRange 返回导出范围选项对应的起止日期
+func exportDateRange(option string, date time.Time) (time.Time, time.Time) {
+	switch option {
+	case exportRangeWeek:
+		return util.WeekRange(date)
+	case exportRangeMonth:
+		return util.MonthRange(date.Year(), date.Month(), date.Location())
+	}
+	day := util.StartOfDay(date)
+	return day, day
+}
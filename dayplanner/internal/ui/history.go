@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// historyPanelWidth 历史版本面板的最小宽度
+const historyPanelWidth = 320
+
+// 差异对比方式
+const (
+	compareWithPrevious = "与上一版本对比"
+	compareWithCurrent  = "与当前内容对比"
+)
+
+// HistoryView 历史版本面板，显示在编辑器旁边
+type HistoryView struct {
+	container      *fyne.Container
+	revisionList   *widget.List
+	compareSelect  *widget.Select
+	diffGrid       *widget.TextGrid
+	restoreButton  *widget.Button
+	statusLabel    *widget.Label
+	historyService service.HistoryService
+	parentWindow   fyne.Window
+
+	currentDate time.Time
+	revisions   []*model.Revision // 按版本号倒序，最新的在前
+	selected    *model.Revision
+
+	// 回调函数
+	onRestored func(content string)
+}
+
+// NewHistoryView 创建新的历史版本面板
+func NewHistoryView(parent fyne.Window, historyService service.HistoryService) *HistoryView {
+	hv := &HistoryView{
+		historyService: historyService,
+		parentWindow:   parent,
+	}
+
+	titleLabel := widget.NewLabel("历史版本")
+	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+	hv.statusLabel = widget.NewLabel("")
+
+	// 创建版本列表
+	hv.revisionList = widget.NewList(
+		func() int {
+			return len(hv.revisions)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			revision := hv.revisions[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("#%d  %s  %d 字",
+				revision.ID, revision.CreatedAt.Format("15:04:05"), utf8.RuneCountInString(revision.Content)))
+		},
+	)
+	hv.revisionList.OnSelected = func(id widget.ListItemID) {
+		if id < len(hv.revisions) {
+			hv.selected = hv.revisions[id]
+			hv.restoreButton.Enable()
+			hv.showDiff()
+		}
+	}
+
+	hv.compareSelect = widget.NewSelect([]string{compareWithPrevious, compareWithCurrent}, func(string) {
+		hv.showDiff()
+	})
+	hv.compareSelect.SetSelected(compareWithPrevious)
+
+	hv.diffGrid = widget.NewTextGrid()
+
+	hv.restoreButton = widget.NewButton("恢复此版本", func() {
+		hv.confirmRestore()
+	})
+	hv.restoreButton.Disable()
+
+	// 用透明矩形撑开面板宽度
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(historyPanelWidth, 0))
+
+	content := container.NewVSplit(hv.revisionList, container.NewScroll(hv.diffGrid))
+	content.SetOffset(0.4)
+
+	hv.container = container.NewStack(
+		spacer,
+		container.NewBorder(
+			container.NewVBox(titleLabel, hv.compareSelect, hv.statusLabel), // top
+			hv.restoreButton, // bottom
+			nil,              // left
+			nil,              // right
+			content,          // center
+		),
+	)
+
+	return hv
+}
+
+// GetContainer 获取容器
+func (hv *HistoryView) GetContainer() *fyne.Container {
+	return hv.container
+}
+
+// SetOnRestored 设置恢复完成回调，参数为恢复后的内容
+func (hv *HistoryView) SetOnRestored(callback func(content string)) {
+	hv.onRestored = callback
+}
+
+// SetDate 切换到指定日期并加载其历史版本
+func (hv *HistoryView) SetDate(date time.Time) {
+	hv.currentDate = date
+	hv.Refresh()
+}
+
+// Refresh 重新加载当前日期的历史版本
+func (hv *HistoryView) Refresh() {
+	hv.selected = nil
+	hv.revisionList.UnselectAll()
+	hv.restoreButton.Disable()
+	hv.diffGrid.SetText("")
+
+	revisions, err := hv.historyService.ListRevisions(hv.currentDate)
+	if err != nil {
+		util.Error("加载历史版本失败: %v", err)
+		hv.revisions = nil
+		hv.statusLabel.SetText("加载失败，请查看日志")
+		hv.revisionList.Refresh()
+		return
+	}
+
+	// 最新的版本排在最前
+	hv.revisions = make([]*model.Revision, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		hv.revisions = append(hv.revisions, revisions[i])
+	}
+
+	if len(hv.revisions) == 0 {
+		hv.statusLabel.SetText("暂无历史版本")
+	} else {
+		hv.statusLabel.SetText(fmt.Sprintf("共 %d 个版本", len(hv.revisions)))
+	}
+	hv.revisionList.Refresh()
+}
+
+// showDiff 显示选中版本的差异
+func (hv *HistoryView) showDiff() {
+	if hv.selected == nil {
+		return
+	}
+
+	var lines []util.DiffLine
+	var err error
+	if hv.compareSelect.Selected == compareWithCurrent {
+		lines, err = hv.historyService.Diff(hv.currentDate, hv.selected.ID, 0)
+	} else {
+		previous := hv.previousRevision(hv.selected)
+		if previous == nil {
+			// 第一个版本：整体视为新增
+			lines = util.DiffLines("", hv.selected.Content)
+		} else {
+			lines, err = hv.historyService.Diff(hv.currentDate, previous.ID, hv.selected.ID)
+		}
+	}
+	if err != nil {
+		util.Error("计算版本差异失败: %v", err)
+		hv.diffGrid.SetText("计算差异失败，请查看日志")
+		return
+	}
+
+	hv.diffGrid.SetText(strings.TrimSuffix(util.FormatDiff(lines), "\n"))
+	insertStyle := &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameSuccess)}
+	deleteStyle := &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameError)}
+	for row, line := range lines {
+		switch line.Op {
+		case util.DiffInsert:
+			hv.diffGrid.SetRowStyle(row, insertStyle)
+		case util.DiffDelete:
+			hv.diffGrid.SetRowStyle(row, deleteStyle)
+		}
+	}
+	hv.diffGrid.Refresh()
+}
+
+// previousRevision 返回指定版本的上一个版本，没有时返回 nil
+func (hv *HistoryView) previousRevision(revision *model.Revision) *model.Revision {
+	for i, r := range hv.revisions {
+		if r.ID == revision.ID && i+1 < len(hv.revisions) {
+			return hv.revisions[i+1]
+		}
+	}
+	return nil
+}
+
+// confirmRestore 确认后恢复选中的版本
+func (hv *HistoryView) confirmRestore() {
+	if hv.selected == nil {
+		return
+	}
+	revision := hv.selected
+
+	message := fmt.Sprintf("确定将 %s 的日报恢复到版本 #%d (%s) 吗？\n当前内容会保留在历史版本中。",
+		hv.currentDate.Format("2006-01-02"), revision.ID, revision.CreatedAt.Format("15:04:05"))
+	dialog.ShowConfirm("恢复历史版本", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if err := hv.historyService.Restore(hv.currentDate, revision.ID); err != nil {
+			util.ShowErrorDialogWithMessage("恢复失败", "无法恢复历史版本", err, hv.parentWindow)
+			return
+		}
+		if hv.onRestored != nil {
+			hv.onRestored(revision.Content)
+		}
+		hv.Refresh()
+		util.ShowSuccessNotification(fmt.Sprintf("已恢复到版本 #%d", revision.ID), hv.parentWindow)
+	}, hv.parentWindow)
+}
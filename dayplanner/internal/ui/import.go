@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 导入模式选项
+const (
+	importModeMergeLabel = "合并到已有日报之后"
+	importModeSkipLabel  = "跳过已有日报的日期"
+)
+
+// ImportView 导入日报对话框：选择来源后先显示导入计划，确认后才写入
+type ImportView struct {
+	window        fyne.Window
+	importService service.ImportService
+	beforeImport  func() // 导入前回调，用于保存编辑器中尚未保存的内容
+	onImported    func() // 导入完成后回调，用于刷新日历和编辑器
+}
+
+// NewImportView 创建新的导入对话框
+func NewImportView(parent fyne.Window, importService service.ImportService) *ImportView {
+	return &ImportView{
+		window:        parent,
+		importService: importService,
+	}
+}
+
+// SetBeforeImport 设置导入前回调
+func (iv *ImportView) SetBeforeImport(callback func()) {
+	iv.beforeImport = callback
+}
+
+// SetOnImported 设置导入完成回调
+func (iv *ImportView) SetOnImported(callback func()) {
+	iv.onImported = callback
+}
+
+// Show 显示导入来源和模式选择
+func (iv *ImportView) Show() {
+	pathEntry := widget.NewEntry()
+	pathEntry.SetPlaceHolder("Markdown / Obsidian / Joplin 导出目录，或 .jex 文件")
+
+	folderButton := widget.NewButton("选择目录...", func() {
+		dialog.ShowFolderOpen(func(folder fyne.ListableURI, err error) {
+			if err == nil && folder != nil {
+				pathEntry.SetText(folder.Path())
+			}
+		}, iv.window)
+	})
+	fileButton := widget.NewButton("选择 .jex 文件...", func() {
+		openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err == nil && reader != nil {
+				pathEntry.SetText(reader.URI().Path())
+				reader.Close()
+			}
+		}, iv.window)
+		openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".jex"}))
+		openDialog.Show()
+	})
+
+	modeSelect := widget.NewSelect([]string{importModeMergeLabel, importModeSkipLabel}, nil)
+	modeSelect.SetSelected(importModeMergeLabel)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("来源", container.NewBorder(nil, container.NewHBox(folderButton, fileButton), nil, nil, pathEntry)),
+		widget.NewFormItem("已有日报", modeSelect),
+	}
+	importDialog := dialog.NewForm("导入日报", "预览...", "取消", items, func(confirmed bool) {
+		if !confirmed || pathEntry.Text == "" {
+			return
+		}
+		mode := service.ImportModeMerge
+		if modeSelect.Selected == importModeSkipLabel {
+			mode = service.ImportModeSkip
+		}
+		iv.preview(pathEntry.Text, mode)
+	}, iv.window)
+	importDialog.Resize(fyne.NewSize(560, 260))
+	importDialog.Show()
+}
+
+// preview 生成导入计划并显示试运行报告，确认后执行导入
+func (iv *ImportView) preview(path string, mode service.ImportMode) {
+	if iv.beforeImport != nil {
+		iv.beforeImport()
+	}
+
+	plan, err := iv.importService.Plan(path, mode)
+	if err != nil {
+		util.ShowErrorDialogWithMessage("导入失败", "无法读取导入来源", err, iv.window)
+		return
+	}
+	pending := plan.Count(service.ImportActionCreate) + plan.Count(service.ImportActionMerge)
+
+	report := widget.NewMultiLineEntry()
+	report.SetText(plan.Report())
+	report.Wrapping = fyne.TextWrapOff
+	report.Disable()
+
+	if pending == 0 {
+		infoDialog := dialog.NewCustom("没有需要导入的日报", "关闭", report, iv.window)
+		infoDialog.Resize(fyne.NewSize(720, 480))
+		infoDialog.Show()
+		return
+	}
+
+	confirmDialog := dialog.NewCustomConfirm("导入计划", fmt.Sprintf("导入 %d 天", pending), "取消", report, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		written, err := iv.importService.Apply(plan)
+		if written > 0 && iv.onImported != nil {
+			iv.onImported()
+		}
+		if err != nil {
+			util.ShowErrorDialogWithMessage("导入失败", fmt.Sprintf("已导入 %d 天，其余未导入", written), err, iv.window)
+			return
+		}
+		util.ShowSuccessNotification(fmt.Sprintf("已导入 %d 天的日报", written), iv.window)
+	}, iv.window)
+	confirmDialog.Resize(fyne.NewSize(720, 480))
+	confirmDialog.Show()
+}
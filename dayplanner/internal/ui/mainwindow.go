@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"daily-report-tool/internal/service"
@@ -8,35 +11,89 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
 )
 
 // MainWindow 主窗口
 type MainWindow struct {
-	app             fyne.App
-	window          fyne.Window
-	taskService     service.TaskService
-	configService   service.ConfigService
-	reminderService service.ReminderService
+	app               fyne.App
+	window            fyne.Window
+	taskService       service.TaskService
+	configService     service.ConfigService
+	reminderService   service.ReminderService
+	reportService     service.ReportService
+	historyService    service.HistoryService
+	templateService   service.TemplateService
+	carryOverService  service.CarryOverService
+	exportService     service.ExportService
+	importService     service.ImportService
+	submitService     service.SubmitService
+	statsService      service.StatsService
+	syncService       service.SyncService       // 未启用同步时为 nil
+	teamService       service.TeamService       // 未使用团队服务器存储时为 nil
+	attachmentService service.AttachmentService // 启用加密或使用团队服务器存储时为 nil
+	journalService    service.JournalService    // 启用加密时为 nil，通过 SetJournalService 设置
 
 	// UI 组件
-	calendarView *CalendarView
-	editorView   *EditorView
-	previewView  *PreviewView
-	settingsView *SettingsView
+	calendarView  *CalendarView
+	editorView    *EditorView
+	previewView   *PreviewView
+	settingsView  *SettingsView
+	searchView    *SearchView
+	reportView    *ReportView
+	historyView   *HistoryView
+	outlineView   *OutlineView
+	carryOverView *CarryOverView
+	exportView    *ExportView
+	importView    *ImportView
+	mergeView     *MergeView
+	statsView     *StatsView
+	yearView      *YearView
+	teamView      *TeamView       // 未使用团队服务器存储时为 nil
+	editorArea    *fyne.Container // 编辑器、大纲和历史版本面板
+	outlineItem   *fyne.MenuItem  // "查看 → 大纲"菜单项
+	syncScroll    bool            // 编辑器和预览同步滚动
+	scrollSyncing bool            // 正在按另一侧的位置滚动，避免两侧互相触发
+	rightTabs     *container.AppTabs
+}
+
+// Services 主窗口依赖的服务，按名称传入，可选的服务未启用时留空
+type Services struct {
+	Task       service.TaskService
+	Config     service.ConfigService
+	Reminder   service.ReminderService
+	Report     service.ReportService
+	History    service.HistoryService
+	Template   service.TemplateService
+	CarryOver  service.CarryOverService
+	Export     service.ExportService
+	Import     service.ImportService
+	Submit     service.SubmitService
+	Stats      service.StatsService
+	Sync       service.SyncService       // 可选，未启用同步时为 nil
+	Team       service.TeamService       // 可选，未使用团队服务器存储时为 nil
+	Attachment service.AttachmentService // 可选，启用加密或使用团队服务器存储时为 nil
 }
 
 // NewMainWindow 创建新的主窗口
-func NewMainWindow(
-	app fyne.App,
-	taskService service.TaskService,
-	configService service.ConfigService,
-	reminderService service.ReminderService,
-) *MainWindow {
+func NewMainWindow(app fyne.App, services Services) *MainWindow {
 	mw := &MainWindow{
-		app:             app,
-		taskService:     taskService,
-		configService:   configService,
-		reminderService: reminderService,
+		app:               app,
+		taskService:       services.Task,
+		configService:     services.Config,
+		reminderService:   services.Reminder,
+		reportService:     services.Report,
+		historyService:    services.History,
+		templateService:   services.Template,
+		carryOverService:  services.CarryOver,
+		exportService:     services.Export,
+		importService:     services.Import,
+		submitService:     services.Submit,
+		statsService:      services.Stats,
+		syncService:       services.Sync,
+		teamService:       services.Team,
+		attachmentService: services.Attachment,
 	}
 
 	// 创建窗口
@@ -62,13 +119,60 @@ func (mw *MainWindow) initializeComponents() {
 	// 创建编辑器视图
 	mw.editorView = NewEditorView(mw.taskService)
 	mw.editorView.SetParentWindow(mw.window)
+	mw.editorView.SetTemplateService(mw.templateService)
 
 	// 创建预览视图
 	mw.previewView = NewPreviewView()
+	mw.applyPreviewTheme()
+
+	// 启用附件时编辑器可以粘贴附件，预览内嵌显示图片
+	if mw.attachmentService != nil {
+		mw.editorView.SetAttachmentService(mw.attachmentService)
+		mw.previewView.SetAttachmentService(mw.attachmentService)
+	}
 
 	// 创建设置视图
 	mw.settingsView = NewSettingsView(mw.window, mw.configService)
 
+	// 创建搜索视图
+	mw.searchView = NewSearchView(mw.taskService)
+
+	// 创建报告视图
+	mw.reportView = NewReportView(mw.window, mw.reportService)
+
+	// 创建历史版本面板（默认隐藏）
+	mw.historyView = NewHistoryView(mw.window, mw.historyService)
+	mw.historyView.GetContainer().Hide()
+
+	// 创建大纲面板（默认隐藏）
+	mw.outlineView = NewOutlineView()
+	mw.outlineView.GetContainer().Hide()
+
+	// 创建顺延对话框
+	mw.carryOverView = NewCarryOverView(mw.window, mw.carryOverService)
+
+	// 创建导出对话框
+	mw.exportView = NewExportView(mw.window, mw.exportService)
+
+	// 创建导入对话框
+	mw.importView = NewImportView(mw.window, mw.importService)
+
+	// 创建统计视图
+	mw.statsView = NewStatsView(mw.statsService)
+
+	// 创建年度视图
+	mw.yearView = NewYearView(mw.statsService)
+
+	// 创建团队视图
+	if mw.teamService != nil {
+		mw.teamView = NewTeamView(mw.window, mw.teamService, mw.reminderService)
+	}
+
+	// 创建同步冲突合并对话框
+	if mw.syncService != nil {
+		mw.mergeView = NewMergeView(mw.window, mw.syncService)
+	}
+
 	// 设置组件间交互
 	mw.setupInteractions()
 }
@@ -83,17 +187,161 @@ func (mw *MainWindow) setupInteractions() {
 	// 2. 编辑器内容变更事件 - 更新预览
 	mw.editorView.SetOnContentChange(func(content string) {
 		mw.previewView.UpdatePreview(content)
+		if mw.outlineView.GetContainer().Visible() {
+			mw.outlineView.Update(content)
+		}
 	})
 
-	// 3. 编辑器保存完成事件 - 刷新日历标记
+	// 3. 编辑器保存完成事件 - 刷新日历标记和历史版本
 	mw.editorView.SetOnSaveComplete(func() {
 		mw.calendarView.Refresh()
+		if mw.historyView.GetContainer().Visible() {
+			fyne.Do(mw.historyView.Refresh)
+		}
 	})
 
 	// 4. 设置更新事件 - 重启提醒服务
 	mw.settingsView.SetOnConfigUpdated(func() {
 		mw.onConfigUpdated()
 	})
+
+	// 5. 搜索结果选择事件 - 跳转到对应日期
+	mw.searchView.SetOnResultSelected(func(date time.Time) {
+		mw.calendarView.GoToDate(date)
+	})
+
+	// 6. 历史版本恢复事件 - 重新载入编辑器内容
+	mw.historyView.SetOnRestored(func(content string) {
+		mw.editorView.CancelAutoSave()
+		mw.editorView.SetContent(content)
+		mw.previewView.UpdatePreview(content)
+		mw.calendarView.Refresh()
+	})
+
+	// 7. 顺延前保存编辑器内容（包括尚未保存的模板），顺延后重新载入
+	mw.carryOverView.SetBeforeCarryOver(func(date time.Time) {
+		mw.editorView.FlushAutoSave()
+		if !mw.editorView.GetDate().Equal(date) || mw.editorView.GetContent() == "" {
+			return
+		}
+		if task, err := mw.taskService.GetTask(date); err == nil && (task == nil || task.Content == "") {
+			if err := mw.taskService.SaveTask(date, mw.editorView.GetContent()); err != nil {
+				util.Error("保存模板内容失败: %v", err)
+			}
+		}
+	})
+	mw.carryOverView.SetOnCarriedOver(func(date time.Time) {
+		mw.calendarView.Refresh()
+		if mw.editorView.GetDate().Equal(date) {
+			mw.onDateSelected(date)
+		}
+	})
+
+	// 8. 导入前保存编辑器内容，导入后刷新日历并重新载入当前日期
+	mw.importView.SetBeforeImport(mw.editorView.FlushAutoSave)
+	mw.importView.SetOnImported(func() {
+		mw.calendarView.Refresh()
+		mw.onDateSelected(mw.editorView.GetDate())
+	})
+
+	// 9. 同步拉取到远端修改后刷新界面，出现冲突时打开合并对话框
+	if mw.syncService != nil {
+		mw.syncService.SetOnUpdated(func() {
+			fyne.Do(mw.onSyncUpdated)
+		})
+		mw.syncService.SetOnConflict(func(conflicts []service.SyncConflict) {
+			fyne.Do(mw.mergeView.Show)
+		})
+		mw.mergeView.SetOnResolved(mw.onSyncUpdated)
+	}
+
+	// 10. 大纲中点击标题 - 编辑器跳转到标题所在行
+	mw.outlineView.SetOnHeadingSelected(func(heading util.Heading) {
+		mw.editorView.GoToLine(heading.Line)
+	})
+	mw.editorView.SetOnToggleOutline(mw.toggleOutline)
+
+	// 11. 编辑器和预览同步滚动：光标移到另一行时预览滚动到该行，滚动预览时编辑器跟随
+	mw.syncScroll = true
+	mw.editorView.SetOnCursorLineChanged(func(line int) {
+		mw.syncScrollTo(func() { mw.previewView.ScrollToLine(line) })
+	})
+	mw.previewView.SetOnScrolled(func(line int) {
+		mw.syncScrollTo(func() { mw.editorView.ScrollToLine(line) })
+	})
+}
+
+// syncScrollTo 启用同步滚动时按另一侧的位置滚动，滚动引起的光标和位置变化不再同步回去
+func (mw *MainWindow) syncScrollTo(scroll func()) {
+	if !mw.syncScroll || mw.scrollSyncing {
+		return
+	}
+	mw.scrollSyncing = true
+	defer func() { mw.scrollSyncing = false }()
+	scroll()
+}
+
+// applyPreviewTheme 按配置设置预览主题
+func (mw *MainWindow) applyPreviewTheme() {
+	config, err := mw.configService.GetConfig()
+	if err != nil {
+		util.Warn("读取预览主题失败: %v", err)
+		return
+	}
+	mw.previewView.SetTheme(config.PreviewTheme)
+}
+
+// SetJournalService 设置保存日志服务，并提示恢复上次退出前没有保存的日报
+func (mw *MainWindow) SetJournalService(journalService service.JournalService) {
+	mw.journalService = journalService
+	mw.editorView.SetJournalService(journalService)
+	mw.promptJournalRecovery()
+}
+
+// promptJournalRecovery 上次运行时有修改没有保存（如程序崩溃或断电）时询问是否恢复
+// 恢复的内容覆盖已保存的日报，覆盖前的内容仍可在历史版本中找回
+func (mw *MainWindow) promptJournalRecovery() {
+	pending, err := mw.journalService.Pending()
+	if err != nil {
+		util.Error("读取保存日志失败: %v", err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	lines := make([]string, len(pending))
+	for i, entry := range pending {
+		lines[i] = fmt.Sprintf("%s（%s 修改）", entry.Date, entry.UpdatedAt.Local().Format("01-02 15:04"))
+	}
+	message := fmt.Sprintf("上次退出前以下 %d 篇日报的修改没有保存：\n%s\n\n是否恢复这些修改？选择\"否\"将丢弃这些修改。",
+		len(pending), strings.Join(lines, "\n"))
+	util.ShowConfirmDialog("恢复未保存的日报", message, func(recover bool) {
+		for _, entry := range pending {
+			if !recover {
+				mw.journalService.Discard(entry)
+				continue
+			}
+			if err := mw.journalService.Recover(entry); err != nil {
+				util.Error("恢复日报失败: %v", err)
+				util.ShowErrorDialog("恢复失败", err, mw.window)
+				return
+			}
+		}
+		if recover {
+			mw.calendarView.Refresh()
+			mw.onDateSelected(mw.editorView.GetDate())
+		}
+	}, mw.window)
+}
+
+// onSyncUpdated 同步修改了数据目录后刷新日历，编辑器没有未保存的内容时重新载入当前日期
+func (mw *MainWindow) onSyncUpdated() {
+	mw.calendarView.Refresh()
+	if mw.editorView.HasPendingSave() {
+		return
+	}
+	mw.onDateSelected(mw.editorView.GetDate())
 }
 
 // onDateSelected 处理日期选择事件
@@ -109,8 +357,11 @@ func (mw *MainWindow) onDateSelected(date time.Time) {
 		return
 	}
 
-	// 设置编辑器日期
+	// 设置编辑器日期，没有日报时编辑器会填入模板
 	mw.editorView.SetDate(date)
+	if mw.historyView.GetContainer().Visible() {
+		mw.historyView.SetDate(date)
+	}
 
 	// 设置编辑器内容
 	if task != nil && task.Content != "" {
@@ -118,18 +369,29 @@ func (mw *MainWindow) onDateSelected(date time.Time) {
 		// 同时更新预览
 		mw.previewView.UpdatePreview(task.Content)
 		util.Debug("加载任务内容成功，长度: %d", len(task.Content))
+	} else if content := mw.editorView.GetContent(); content != "" {
+		// 没有任务内容，预览模板
+		mw.previewView.UpdatePreview(content)
+		util.Debug("该日期无任务内容，已填入模板")
 	} else {
-		// 没有任务内容，清空编辑器和预览
-		mw.editorView.SetContent("")
+		// 没有任务内容，清空预览
 		mw.previewView.Clear()
 		util.Debug("该日期无任务内容")
 	}
+
+	// 打开今天的日报时提示顺延上一个工作日的未完成事项
+	if util.StartOfDay(date).Equal(util.StartOfDay(time.Now())) {
+		mw.carryOverView.Prompt(date)
+	}
 }
 
 // onConfigUpdated 处理配置更新事件
 func (mw *MainWindow) onConfigUpdated() {
 	util.Info("配置已更新，重启提醒服务")
 
+	// 预览主题立即生效
+	mw.applyPreviewTheme()
+
 	// 停止现有的提醒服务
 	if mw.reminderService != nil {
 		mw.reminderService.Stop()
@@ -147,17 +409,70 @@ func (mw *MainWindow) onConfigUpdated() {
 
 // setupLayout 设置窗口布局
 func (mw *MainWindow) setupLayout() {
+	// 编辑器左侧为大纲面板，右侧为历史版本面板，隐藏时不占空间
+	mw.editorArea = container.NewBorder(
+		nil,                           // top
+		nil,                           // bottom
+		mw.outlineView.GetContainer(), // left
+		mw.historyView.GetContainer(), // right
+		mw.editorView.GetContainer(),  // center
+	)
+
 	// 创建右侧分栏：编辑器和预览
 	rightSplit := container.NewVSplit(
-		mw.editorView.GetContainer(),
+		mw.editorArea,
 		mw.previewView.GetContainer(),
 	)
 	rightSplit.SetOffset(0.5) // 设置分割比例为 50:50
 
-	// 创建主分栏：日历和右侧内容
+	// 创建左侧栏：日历在上，搜索在下
+	leftPanel := container.NewBorder(
+		mw.calendarView,              // top
+		nil,                          // bottom
+		nil,                          // left
+		nil,                          // right
+		mw.searchView.GetContainer(), // center
+	)
+
+	// 右侧内容分为日报和统计两个标签页，切换到统计时先保存编辑器内容再重新统计
+	// 年度标签页以热力图显示全年的日报，打开某一天时回到日报标签页
+	// 使用团队服务器存储时增加团队标签页，切换时重新获取成员的填报情况
+	reportTab := container.NewTabItem("日报", rightSplit)
+	statsTab := container.NewTabItem("统计", mw.statsView.GetContainer())
+	yearTab := container.NewTabItem("年度", mw.yearView.GetContainer())
+	rightTabs := container.NewAppTabs(
+		reportTab,
+		statsTab,
+		yearTab,
+	)
+	mw.rightTabs = rightTabs
+	mw.yearView.SetOnDateOpened(func(date time.Time) {
+		mw.calendarView.GoToDate(date)
+		rightTabs.Select(reportTab)
+	})
+	var teamTab *container.TabItem
+	if mw.teamView != nil {
+		teamTab = container.NewTabItem("团队", mw.teamView.GetContainer())
+		rightTabs.Append(teamTab)
+	}
+	rightTabs.OnSelected = func(tab *container.TabItem) {
+		switch tab {
+		case statsTab:
+			mw.editorView.FlushAutoSave()
+			mw.statsView.Refresh()
+		case yearTab:
+			mw.editorView.FlushAutoSave()
+			mw.yearView.Refresh()
+		case teamTab:
+			mw.editorView.FlushAutoSave()
+			mw.teamView.Refresh()
+		}
+	}
+
+	// 创建主分栏：左侧栏和右侧内容
 	mainSplit := container.NewHSplit(
-		mw.calendarView,
-		rightSplit,
+		leftPanel,
+		rightTabs,
 	)
 	mainSplit.SetOffset(0.25) // 设置分割比例为 25:75
 
@@ -167,6 +482,21 @@ func (mw *MainWindow) setupLayout() {
 	// 设置窗口内容
 	mw.window.SetContent(mainSplit)
 	mw.window.SetMainMenu(mainMenu)
+
+	// 拖入窗口的文件作为附件插入当前日报
+	if mw.attachmentService != nil {
+		mw.window.SetOnDropped(func(_ fyne.Position, uris []fyne.URI) {
+			var paths []string
+			for _, uri := range uris {
+				if uri.Scheme() == "file" {
+					paths = append(paths, uri.Path())
+				}
+			}
+			if len(paths) > 0 {
+				mw.editorView.AttachFiles(paths)
+			}
+		})
+	}
 }
 
 // createMenu 创建菜单栏
@@ -176,15 +506,217 @@ func (mw *MainWindow) createMenu() *fyne.MainMenu {
 		mw.settingsView.Show()
 	})
 
-	// 创建文件菜单
-	fileMenu := fyne.NewMenu("文件", settingsItem)
+	// 创建插入模板菜单项
+	templateItem := fyne.NewMenuItem("插入模板...", func() {
+		mw.showTemplatePicker()
+	})
+
+	// 创建顺延菜单项，以编辑器当前日期为目标
+	carryOverItem := fyne.NewMenuItem("顺延未完成事项...", func() {
+		mw.carryOverView.Show(mw.editorView.GetDate())
+	})
+
+	// 创建导入菜单项
+	importItem := fyne.NewMenuItem("导入...", func() {
+		mw.importView.Show()
+	})
+
+	// 创建文件菜单，启用附件时加入插入附件菜单项，启用同步时加入同步菜单项
+	fileItems := []*fyne.MenuItem{templateItem, carryOverItem}
+	if mw.attachmentService != nil {
+		fileItems = append(fileItems, fyne.NewMenuItem("插入附件...", mw.showAttachmentPicker))
+	}
+	fileItems = append(fileItems, importItem, fyne.NewMenuItemSeparator())
+	if mw.syncService != nil {
+		syncItem := fyne.NewMenuItem("立即同步", mw.syncNow)
+		mergeItem := fyne.NewMenuItem("解决同步冲突...", func() {
+			mw.mergeView.Show()
+		})
+		fileItems = append(fileItems, syncItem, mergeItem, fyne.NewMenuItemSeparator())
+	}
+	fileMenu := fyne.NewMenu("文件", append(fileItems, settingsItem)...)
+
+	// 创建报告菜单，以编辑器当前日期为基准
+	weeklyReportItem := fyne.NewMenuItem("生成周报", func() {
+		mw.reportView.ShowWeekly(mw.editorView.GetDate())
+	})
+	monthlyReportItem := fyne.NewMenuItem("生成月报", func() {
+		date := mw.editorView.GetDate()
+		mw.reportView.ShowMonthly(date.Year(), date.Month())
+	})
+	exportItem := fyne.NewMenuItem("导出...", func() {
+		// 先保存编辑器中尚未自动保存的内容，确保导出的是最新内容
+		mw.editorView.FlushAutoSave()
+		mw.exportView.Show(mw.editorView.GetDate())
+	})
+	submitItem := fyne.NewMenuItem("提交日报...", func() {
+		mw.submitReport(false)
+	})
+	reportMenu := fyne.NewMenu("报告", weeklyReportItem, monthlyReportItem, fyne.NewMenuItemSeparator(), exportItem, submitItem)
+
+	// 创建查看菜单
+	historyItem := fyne.NewMenuItem("历史版本", nil)
+	historyItem.Action = func() {
+		mw.toggleHistory()
+		historyItem.Checked = mw.historyView.GetContainer().Visible()
+		mw.window.MainMenu().Refresh()
+	}
+	mw.outlineItem = fyne.NewMenuItem("大纲", mw.toggleOutline)
+	syncScrollItem := fyne.NewMenuItem("同步滚动", nil)
+	syncScrollItem.Checked = mw.syncScroll
+	syncScrollItem.Action = func() {
+		mw.syncScroll = !mw.syncScroll
+		syncScrollItem.Checked = mw.syncScroll
+		mw.window.MainMenu().Refresh()
+	}
+	viewMenu := fyne.NewMenu("查看", mw.outlineItem, historyItem, syncScrollItem)
+
+	// 创建编辑菜单，与编辑器的快捷键相同
+	var editItems []*fyne.MenuItem
+	for _, command := range mw.editorView.commands() {
+		if command == nil {
+			editItems = append(editItems, fyne.NewMenuItemSeparator())
+			continue
+		}
+		item := fyne.NewMenuItem(command.label, command.action)
+		item.Shortcut = command.shortcut
+		editItems = append(editItems, item)
+	}
+	editMenu := fyne.NewMenu("编辑", editItems...)
 
 	// 创建主菜单
-	mainMenu := fyne.NewMainMenu(fileMenu)
+	mainMenu := fyne.NewMainMenu(fileMenu, editMenu, viewMenu, reportMenu)
 
 	return mainMenu
 }
 
+// showTemplatePicker 选择模板并替换编辑器内容，编辑器已有内容时先确认
+func (mw *MainWindow) showTemplatePicker() {
+	names, err := mw.templateService.ListTemplates()
+	if err != nil {
+		util.ShowErrorDialogWithMessage("加载失败", "无法读取模板目录", err, mw.window)
+		return
+	}
+	if len(names) == 0 {
+		util.ShowInfoDialog("没有模板", "请在 config/templates 目录中添加 Markdown 模板文件", mw.window)
+		return
+	}
+
+	templateSelect := widget.NewSelect(names, nil)
+	templateSelect.SetSelectedIndex(0)
+
+	dialog.ShowForm("插入模板", "插入", "取消",
+		[]*widget.FormItem{widget.NewFormItem("模板", templateSelect)},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			apply := func() {
+				if err := mw.editorView.ApplyTemplate(templateSelect.Selected); err != nil {
+					util.ShowErrorDialogWithMessage("插入失败", "无法渲染模板", err, mw.window)
+				}
+			}
+			if mw.editorView.GetContent() == "" {
+				apply()
+				return
+			}
+			dialog.ShowConfirm("替换内容", "插入模板将替换编辑器中的当前内容，是否继续？", func(ok bool) {
+				if ok {
+					apply()
+				}
+			}, mw.window)
+		}, mw.window)
+}
+
+// showAttachmentPicker 选择本机文件作为附件插入当前日报
+func (mw *MainWindow) showAttachmentPicker() {
+	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		reader.Close()
+		mw.editorView.AttachFiles([]string{reader.URI().Path()})
+	}, mw.window)
+}
+
+// syncNow 保存编辑器内容后在后台立即同步
+func (mw *MainWindow) syncNow() {
+	mw.editorView.FlushAutoSave()
+	go func() {
+		err := mw.syncService.Sync()
+		fyne.Do(func() {
+			switch {
+			case errors.Is(err, service.ErrSyncConflict):
+				// 新冲突由回调打开合并对话框，已知冲突在这里重新打开
+				mw.mergeView.Show()
+			case err != nil:
+				util.ShowErrorDialogWithMessage("同步失败", "无法同步到远程仓库", err, mw.window)
+			default:
+				util.ShowSuccessNotification("同步完成", mw.window)
+			}
+		})
+	}()
+}
+
+// submitReport 保存编辑器内容后在后台将当前日期的日报提交到通知渠道
+// 已提交过时询问是否重新提交
+func (mw *MainWindow) submitReport(force bool) {
+	mw.editorView.FlushAutoSave()
+	date := mw.editorView.GetDate()
+	go func() {
+		result, err := mw.submitService.Submit(date, force)
+		fyne.Do(func() {
+			switch {
+			case errors.Is(err, service.ErrEmptyReport):
+				util.ShowInfoDialog("无法提交", "当天日报为空，请先填写内容", mw.window)
+			case errors.Is(err, service.ErrAlreadySubmitted):
+				message := "日报已提交过，是否重新提交到所有通知渠道？"
+				if task, taskErr := mw.taskService.GetTask(date); taskErr == nil && task != nil && !task.SubmittedAt.IsZero() {
+					message = fmt.Sprintf("日报已于 %s 提交，是否重新提交到所有通知渠道？", task.SubmittedAt.Format("2006-01-02 15:04"))
+				}
+				dialog.ShowConfirm("重新提交", message, func(ok bool) {
+					if ok {
+						mw.submitReport(true)
+					}
+				}, mw.window)
+			case err != nil:
+				util.ShowErrorDialogWithMessage("提交失败", "日报未能提交到全部通知渠道", err, mw.window)
+			default:
+				util.ShowSuccessNotification("日报已提交到: "+strings.Join(result.Sent, "、"), mw.window)
+			}
+		})
+	}()
+}
+
+// toggleHistory 显示或隐藏历史版本面板
+func (mw *MainWindow) toggleHistory() {
+	historyContainer := mw.historyView.GetContainer()
+	if historyContainer.Visible() {
+		historyContainer.Hide()
+	} else {
+		mw.historyView.SetDate(mw.editorView.GetDate())
+		historyContainer.Show()
+	}
+	mw.editorArea.Refresh()
+}
+
+// toggleOutline 显示或隐藏大纲面板
+func (mw *MainWindow) toggleOutline() {
+	outlineContainer := mw.outlineView.GetContainer()
+	if outlineContainer.Visible() {
+		outlineContainer.Hide()
+	} else {
+		mw.outlineView.Update(mw.editorView.GetContent())
+		outlineContainer.Show()
+	}
+	mw.editorArea.Refresh()
+
+	if mw.outlineItem != nil {
+		mw.outlineItem.Checked = outlineContainer.Visible()
+		mw.window.MainMenu().Refresh()
+	}
+}
+
 // Show 显示主窗口
 func (mw *MainWindow) Show() {
 	mw.window.ShowAndRun()
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MergeView 同步冲突合并对话框：并排显示本机和远端内容，编辑合并结果后逐天解决
+type MergeView struct {
+	window      fyne.Window
+	syncService service.SyncService
+	showing     bool   // 对话框是否正在显示，避免重复弹出
+	onResolved  func() // 冲突解决后回调，用于刷新日历和编辑器
+}
+
+// NewMergeView 创建新的合并对话框
+func NewMergeView(parent fyne.Window, syncService service.SyncService) *MergeView {
+	return &MergeView{
+		window:      parent,
+		syncService: syncService,
+	}
+}
+
+// SetOnResolved 设置冲突解决回调
+func (mv *MergeView) SetOnResolved(callback func()) {
+	mv.onResolved = callback
+}
+
+// Show 显示第一个尚未解决的冲突
+func (mv *MergeView) Show() {
+	if mv.showing {
+		return
+	}
+	conflicts := mv.syncService.Conflicts()
+	if len(conflicts) == 0 {
+		util.ShowInfoDialog("没有冲突", "没有需要合并的日报", mv.window)
+		return
+	}
+	mv.showConflict(conflicts[0], len(conflicts))
+}
+
+// showConflict 显示某一天的冲突
+func (mv *MergeView) showConflict(conflict service.SyncConflict, total int) {
+	local := readOnlyEntry(conflict.Local)
+	remote := readOnlyEntry(conflict.Remote)
+
+	result := widget.NewMultiLineEntry()
+	result.Wrapping = fyne.TextWrapWord
+	result.SetText(unionLines(conflict.Local, conflict.Remote))
+
+	buttons := container.NewHBox(
+		widget.NewButton("使用本机", func() { result.SetText(conflict.Local) }),
+		widget.NewButton("使用远端", func() { result.SetText(conflict.Remote) }),
+		widget.NewButton("合并两者", func() { result.SetText(unionLines(conflict.Local, conflict.Remote)) }),
+	)
+
+	sides := container.NewGridWithColumns(2,
+		container.NewBorder(widget.NewLabel("本机"), nil, nil, nil, local),
+		container.NewBorder(widget.NewLabel("远端"), nil, nil, nil, remote),
+	)
+	merged := container.NewBorder(container.NewBorder(nil, nil, widget.NewLabel("合并结果"), buttons), nil, nil, nil, result)
+	content := container.NewVSplit(sides, merged)
+
+	title := fmt.Sprintf("合并 %s 的日报（剩余 %d 天）", conflict.Date.Format("2006-01-02"), total)
+	mv.showing = true
+	mergeDialog := dialog.NewCustomConfirm(title, "保存合并结果", "稍后处理", content, func(confirmed bool) {
+		mv.showing = false
+		if !confirmed {
+			return
+		}
+		if err := mv.syncService.Resolve(conflict.Date, result.Text); err != nil {
+			util.ShowErrorDialogWithMessage("合并失败", "无法完成同步合并", err, mv.window)
+			return
+		}
+		if mv.onResolved != nil {
+			mv.onResolved()
+		}
+		// 继续处理下一个冲突
+		if len(mv.syncService.Conflicts()) > 0 {
+			mv.Show()
+			return
+		}
+		util.ShowSuccessNotification("同步冲突已全部合并", mv.window)
+	}, mv.window)
+	mergeDialog.Resize(fyne.NewSize(960, 640))
+	mergeDialog.Show()
+}
+
+// readOnlyEntry 创建只读的多行文本框
+func readOnlyEntry(text string) *widget.Entry {
+	entry := widget.NewMultiLineEntry()
+	entry.Wrapping = fyne.TextWrapWord
+	entry.SetText(text)
+	entry.Disable()
+	return entry
+}
+
+// unionLines 合并两段文本：公共行保留一次，只在一侧出现的行按位置依次保留
+func unionLines(local, remote string) string {
+	var lines []string
+	for _, line := range util.DiffLines(local, remote) {
+		lines = append(lines, line.Text)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// outlinePanelWidth 大纲面板的最小宽度
+const outlinePanelWidth = 200
+
+// OutlineView 大纲面板，显示在编辑器左侧，列出当前日报的标题，点击跳转到对应位置
+type OutlineView struct {
+	container   *fyne.Container
+	headingList *widget.List
+	emptyLabel  *widget.Label
+	headings    []util.Heading
+
+	// 回调函数
+	onHeadingSelected func(heading util.Heading)
+}
+
+// NewOutlineView 创建新的大纲面板
+func NewOutlineView() *OutlineView {
+	ov := &OutlineView{}
+
+	titleLabel := widget.NewLabel("大纲")
+	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+	ov.emptyLabel = widget.NewLabel("没有标题")
+
+	ov.headingList = widget.NewList(
+		func() int {
+			return len(ov.headings)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			heading := ov.headings[id]
+			// 按标题级别缩进
+			obj.(*widget.Label).SetText(strings.Repeat("    ", heading.Level-1) + heading.Text)
+		},
+	)
+	ov.headingList.OnSelected = func(id widget.ListItemID) {
+		// 取消选中，再次点击同一个标题时仍然可以跳转
+		ov.headingList.Unselect(id)
+		if id < len(ov.headings) && ov.onHeadingSelected != nil {
+			ov.onHeadingSelected(ov.headings[id])
+		}
+	}
+
+	// 用透明矩形撑开面板宽度
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(outlinePanelWidth, 0))
+
+	ov.container = container.NewStack(
+		spacer,
+		container.NewBorder(
+			container.NewVBox(titleLabel, ov.emptyLabel), // top
+			nil,            // bottom
+			nil,            // left
+			nil,            // right
+			ov.headingList, // center
+		),
+	)
+
+	return ov
+}
+
+// GetContainer 获取容器
+func (ov *OutlineView) GetContainer() *fyne.Container {
+	return ov.container
+}
+
+// SetOnHeadingSelected 设置点击标题的回调
+func (ov *OutlineView) SetOnHeadingSelected(callback func(heading util.Heading)) {
+	ov.onHeadingSelected = callback
+}
+
+// Update 根据日报内容重新生成大纲
+func (ov *OutlineView) Update(markdown string) {
+	ov.headings = util.MarkdownOutline(markdown)
+	if len(ov.headings) == 0 {
+		ov.emptyLabel.Show()
+	} else {
+		ov.emptyLabel.Hide()
+	}
+	ov.headingList.Refresh()
+}
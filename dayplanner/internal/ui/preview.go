@@ -1,43 +1,99 @@
 package ui
 
 import (
+	"image/color"
+	"strings"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
 	"daily-report-tool/internal/util"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// codeTokenColors 预览中代码高亮使用的主题颜色，随浅色和深色主题变化
+var codeTokenColors = map[util.CodeTokenKind]fyne.ThemeColorName{
+	util.CodeTokenPlain:   theme.ColorNameForeground,
+	util.CodeTokenKeyword: theme.ColorNamePrimary,
+	util.CodeTokenString:  theme.ColorNameSuccess,
+	util.CodeTokenComment: theme.ColorNamePlaceHolder,
+	util.CodeTokenNumber:  theme.ColorNameWarning,
+}
+
+// previewBlock 预览中的一个顶层块及其渲染结果
+type previewBlock struct {
+	util.MarkdownBlock
+	object   fyne.CanvasObject
+	richText *widget.RichText // 图表块为 nil
+	diagram  *diagramView     // 不是图表块时为 nil
+}
+
+// lineCount 块占用的行数
+func (b *previewBlock) lineCount() int {
+	if n := strings.Count(b.Source, "\n"); n > 0 {
+		return n
+	}
+	return 1
+}
+
 // PreviewView Markdown 预览视图组件
+// 预览按顶层块增量渲染：每个块是一个独立的 RichText（或图表），内容变化时只重新渲染修改过的块，
+// 块的位置同时用于与编辑器同步滚动
 type PreviewView struct {
-	container   *fyne.Container
-	titleLabel  *widget.Label
-	richText    *widget.RichText
+	container       *fyne.Container
+	titleLabel      *widget.Label
+	blockBox        *fyne.Container
 	scrollContainer *container.Scroll
+	themeOverride   *container.ThemeOverride
+	background      *canvas.Rectangle
+	blocks          []*previewBlock
+	diagrams        *diagramRenderer
+	themeName       string // 预览主题，为空时跟随界面主题
+
+	attachmentService service.AttachmentService // 可选，设置后内嵌显示日报中的图片附件
+
+	// 回调函数
+	onScrolled func(line int)
 }
 
 // NewPreviewView 创建新的预览视图
 func NewPreviewView() *PreviewView {
-	pv := &PreviewView{}
+	pv := &PreviewView{
+		diagrams: newDiagramRenderer(),
+	}
 
 	// 创建标题标签
 	pv.titleLabel = widget.NewLabel("预览")
 	pv.titleLabel.TextStyle = fyne.TextStyle{Bold: true}
 
-	// 创建 RichText 组件用于显示渲染后的内容
-	pv.richText = widget.NewRichText()
-	pv.richText.Wrapping = fyne.TextWrapWord
+	// 每个顶层块一个组件，放在滚动容器中
+	pv.blockBox = container.NewVBox()
+	pv.scrollContainer = container.NewScroll(pv.blockBox)
+	pv.scrollContainer.OnScrolled = func(offset fyne.Position) {
+		if pv.onScrolled != nil && len(pv.blocks) > 0 {
+			pv.onScrolled(pv.lineAtOffset(offset.Y))
+		}
+	}
 
-	// 创建滚动容器
-	pv.scrollContainer = container.NewScroll(pv.richText)
+	// 固定浅色或深色主题时使用主题的背景色
+	pv.background = canvas.NewRectangle(color.Transparent)
+	pv.themeOverride = container.NewThemeOverride(
+		container.NewStack(pv.background, pv.scrollContainer),
+		&previewTheme{follow: true},
+	)
 
 	// 创建容器布局
 	pv.container = container.NewBorder(
-		pv.titleLabel,      // top
-		nil,                // bottom
-		nil,                // left
-		nil,                // right
-		pv.scrollContainer, // center
+		pv.titleLabel,    // top
+		nil,              // bottom
+		nil,              // left
+		nil,              // right
+		pv.themeOverride, // center
 	)
 
 	return pv
@@ -48,28 +104,211 @@ func (pv *PreviewView) GetContainer() *fyne.Container {
 	return pv.container
 }
 
+// SetAttachmentService 设置附件服务，预览时将附件的相对链接解析为本机文件
+func (pv *PreviewView) SetAttachmentService(attachmentService service.AttachmentService) {
+	pv.attachmentService = attachmentService
+}
+
+// SetOnScrolled 设置用户滚动预览时的回调，参数为预览顶部对应的日报行号（从 0 开始）
+func (pv *PreviewView) SetOnScrolled(callback func(line int)) {
+	pv.onScrolled = callback
+}
+
+// SetTheme 设置预览主题：model.PreviewThemeLight 和 model.PreviewThemeDark 固定为浅色或深色，为空时跟随界面主题
+func (pv *PreviewView) SetTheme(name string) {
+	pv.themeName = name
+	th := &previewTheme{follow: true}
+	switch name {
+	case model.PreviewThemeLight:
+		th = &previewTheme{variant: theme.VariantLight}
+	case model.PreviewThemeDark:
+		th = &previewTheme{variant: theme.VariantDark}
+	}
+
+	if th.follow {
+		pv.background.FillColor = color.Transparent
+	} else {
+		pv.background.FillColor = th.Color(theme.ColorNameBackground, th.variant)
+	}
+	pv.background.Refresh()
+	pv.themeOverride.Theme = th
+	pv.themeOverride.Refresh()
+}
+
 // UpdatePreview 更新预览内容
+// 与上次的内容按顶层块比较，开头和结尾未修改的块直接复用，只渲染中间修改过的块
 func (pv *PreviewView) UpdatePreview(markdown string) {
-	if markdown == "" {
-		pv.richText.ParseMarkdown("")
-		return
+	parts := util.SplitMarkdownBlocks(pv.resolveAttachments(markdown))
+
+	prefix := 0
+	for prefix < len(parts) && prefix < len(pv.blocks) && pv.blocks[prefix].Source == parts[prefix].Source {
+		prefix++
 	}
+	suffix := 0
+	for suffix < len(parts)-prefix && suffix < len(pv.blocks)-prefix &&
+		pv.blocks[len(pv.blocks)-1-suffix].Source == parts[len(parts)-1-suffix].Source {
+		suffix++
+	}
+
+	blocks := make([]*previewBlock, 0, len(parts))
+	blocks = append(blocks, pv.blocks[:prefix]...)
+	for _, part := range parts[prefix : len(parts)-suffix] {
+		blocks = append(blocks, pv.renderBlock(part))
+	}
+	blocks = append(blocks, pv.blocks[len(pv.blocks)-suffix:]...)
+	for _, removed := range pv.blocks[prefix : len(pv.blocks)-suffix] {
+		if removed.diagram != nil {
+			removed.diagram.dispose()
+		}
+	}
+
+	// 复用的块前面插入或删除了行时，行号需要更新
+	objects := make([]fyne.CanvasObject, len(blocks))
+	for i, block := range blocks {
+		block.Line = parts[i].Line
+		objects[i] = block.object
+	}
+	pv.blocks = blocks
+	pv.blockBox.Objects = objects
+	pv.blockBox.Refresh()
+	pv.scrollContainer.Refresh()
+	if pv.themeName != "" {
+		// 新的块需要重新应用固定的主题
+		pv.themeOverride.Refresh()
+	}
+}
 
-	// 使用 goldmark 将 Markdown 转换为 HTML
-	_, err := util.MarkdownToHTML(markdown)
-	if err != nil {
-		// 如果转换失败，显示原始文本
-		pv.richText.ParseMarkdown(markdown)
+// renderBlock 渲染一个顶层块：图表代码块显示为图片或占位内容，支持的语言的代码块高亮显示
+func (pv *PreviewView) renderBlock(part util.MarkdownBlock) *previewBlock {
+	block := &previewBlock{MarkdownBlock: part}
+	if part.Fenced && isDiagramLanguage(part.Language) {
+		block.diagram = newDiagramView(pv.diagrams, part.Language, part.Code)
+		block.object = block.diagram.container
+		return block
+	}
+
+	if tokens, ok := util.HighlightCode(strings.TrimSuffix(part.Code, "\n"), part.Language); part.Fenced && ok {
+		segments := make([]widget.RichTextSegment, 0, len(tokens))
+		for _, token := range tokens {
+			segments = append(segments, &widget.TextSegment{
+				Text: token.Text,
+				Style: widget.RichTextStyle{
+					Inline:    true,
+					ColorName: codeTokenColors[token.Kind],
+					SizeName:  theme.SizeNameText,
+					TextStyle: fyne.TextStyle{Monospace: true},
+				},
+			})
+		}
+		block.richText = widget.NewRichText(segments...)
+	} else {
+		block.richText = widget.NewRichTextFromMarkdown(part.Source)
+	}
+	block.richText.Wrapping = fyne.TextWrapWord
+	block.object = block.richText
+	return block
+}
+
+// ScrollToLine 滚动预览，使日报第 line 行（从 0 开始）对应的内容位于顶部
+// 行位于块内时按行在块中的比例估算位置
+func (pv *PreviewView) ScrollToLine(line int) {
+	if len(pv.blocks) == 0 {
 		return
 	}
+	index := 0
+	for i, block := range pv.blocks {
+		if block.Line <= line {
+			index = i
+		}
+	}
+	block := pv.blocks[index]
+	fraction := float32(line-block.Line) / float32(block.lineCount())
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	y := block.object.Position().Y + fraction*block.object.Size().Height
+	if max := pv.blockBox.Size().Height - pv.scrollContainer.Size().Height; y > max {
+		y = max
+	}
+	if y < 0 {
+		y = 0
+	}
+	pv.scrollContainer.ScrollToOffset(fyne.NewPos(pv.scrollContainer.Offset.X, y))
+}
 
-	// Fyne 的 RichText 支持 Markdown，但不直接支持 HTML
-	// 我们使用 ParseMarkdown 来显示内容
-	// 注意：这里直接使用 Markdown 而不是 HTML，因为 Fyne 的 RichText 更好地支持 Markdown
-	pv.richText.ParseMarkdown(markdown)
+// lineAtOffset 返回预览中纵向位置 y 对应的日报行号
+func (pv *PreviewView) lineAtOffset(y float32) int {
+	block := pv.blocks[0]
+	for _, b := range pv.blocks {
+		if b.object.Position().Y <= y {
+			block = b
+		}
+	}
+	height := block.object.Size().Height
+	if height <= 0 {
+		return block.Line
+	}
+	fraction := (y - block.object.Position().Y) / height
+	if fraction > 1 {
+		fraction = 1
+	}
+	return block.Line + int(fraction*float32(block.lineCount()))
+}
+
+// resolveAttachments 将附件的相对链接替换为本机文件 URI，图片内嵌显示，其他附件点击后用系统程序打开
+// 数据目录可能包含空格，链接地址用尖括号包围
+func (pv *PreviewView) resolveAttachments(markdown string) string {
+	if pv.attachmentService == nil {
+		return markdown
+	}
+	return util.ReplaceAttachmentLinks(markdown, func(link string) string {
+		filePath, err := pv.attachmentService.Path(link)
+		if err != nil {
+			return link
+		}
+		return "<" + storage.NewFileURI(filePath).String() + ">"
+	})
 }
 
 // Clear 清空预览
 func (pv *PreviewView) Clear() {
-	pv.richText.ParseMarkdown("")
+	pv.UpdatePreview("")
+}
+
+// previewTheme 预览使用的主题：字体、图标和尺寸与界面主题相同，颜色可以固定为浅色或深色
+type previewTheme struct {
+	follow  bool              // 跟随界面主题
+	variant fyne.ThemeVariant // 固定的浅色或深色
+}
+
+// base 返回界面当前使用的主题
+func (t *previewTheme) base() fyne.Theme {
+	if app := fyne.CurrentApp(); app != nil && app.Settings().Theme() != nil {
+		return app.Settings().Theme()
+	}
+	return theme.DefaultTheme()
+}
+
+// Color 返回主题颜色
+func (t *previewTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if !t.follow {
+		variant = t.variant
+	}
+	return t.base().Color(name, variant)
+}
+
+// Font 返回主题字体
+func (t *previewTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return t.base().Font(style)
+}
+
+// Icon 返回主题图标
+func (t *previewTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return t.base().Icon(name)
+}
+
+// Size 返回主题尺寸
+func (t *previewTheme) Size(name fyne.ThemeSizeName) float32 {
+	return t.base().Size(name)
 }
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/repository"
+	"daily-report-tool/internal/service"
+)
+
+// previewSegments 返回预览中所有文本块的片段
+func previewSegments(pv *PreviewView) []widget.RichTextSegment {
+	var segments []widget.RichTextSegment
+	for _, block := range pv.blocks {
+		if block.richText != nil {
+			segments = append(segments, block.richText.Segments...)
+		}
+	}
+	return segments
+}
+
+func TestPreviewView_Attachments(t *testing.T) {
+	test.NewApp()
+
+	dataPath := t.TempDir() + "/数据 目录"
+	taskService := service.NewTaskService(repository.NewFileTaskRepository(dataPath), dataPath)
+	attachmentService := service.NewAttachmentService(repository.NewFileAttachmentRepository(dataPath), taskService)
+	image, markdown, err := attachmentService.Add(time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local), "截图.png", []byte("png"))
+	if err != nil {
+		t.Fatalf("添加附件失败: %v", err)
+	}
+
+	pv := NewPreviewView()
+	pv.SetAttachmentService(attachmentService)
+	pv.UpdatePreview("- 页面改版\n\n" + markdown)
+
+	imagePath, _ := attachmentService.Path(image.Link)
+	for _, segment := range previewSegments(pv) {
+		if img, ok := segment.(*widget.ImageSegment); ok {
+			if img.Source.String() != storage.NewFileURI(imagePath).String() {
+				t.Errorf("图片应指向本机文件，实际: %s", img.Source)
+			}
+			return
+		}
+	}
+	t.Errorf("预览中应内嵌显示图片附件: %#v", previewSegments(pv))
+}
+
+func TestPreviewView_IncrementalUpdate(t *testing.T) {
+	test.NewApp()
+
+	pv := NewPreviewView()
+	pv.UpdatePreview("# 今日工作\n\n- 页面改版\n\n## 明日计划\n\n- 联调\n")
+	if len(pv.blocks) != 4 {
+		t.Fatalf("应有 4 个块，实际 %d", len(pv.blocks))
+	}
+	before := make([]fyne.CanvasObject, len(pv.blocks))
+	for i, block := range pv.blocks {
+		before[i] = block.object
+	}
+
+	// 在第二个块中插入一行，只有该块重新渲染，后面的块行号后移
+	pv.UpdatePreview("# 今日工作\n\n- 页面改版\n- 修复登录\n\n## 明日计划\n\n- 联调\n")
+	if len(pv.blocks) != 4 {
+		t.Fatalf("应有 4 个块，实际 %d", len(pv.blocks))
+	}
+	for i, block := range pv.blocks {
+		if reused := block.object == before[i]; reused == (i == 1) {
+			t.Errorf("块 %d 复用情况错误: %v", i, reused)
+		}
+	}
+	if pv.blocks[2].Line != 5 || pv.blocks[3].Line != 7 {
+		t.Errorf("复用块的行号应更新，实际 %d、%d", pv.blocks[2].Line, pv.blocks[3].Line)
+	}
+	if len(pv.blockBox.Objects) != 4 || pv.blockBox.Objects[3] != before[3] {
+		t.Error("预览容器应显示更新后的块")
+	}
+
+	pv.Clear()
+	if len(pv.blocks) != 0 || len(pv.blockBox.Objects) != 0 {
+		t.Errorf("清空后不应有块: %d", len(pv.blocks))
+	}
+}
+
+func TestPreviewView_CodeHighlighting(t *testing.T) {
+	test.NewApp()
+
+	pv := NewPreviewView()
+	pv.UpdatePreview("```go\nreturn \"ok\" // 完成\n```\n\n```text\nreturn\n```\n")
+
+	colors := make(map[string]fyne.ThemeColorName)
+	for _, segment := range pv.blocks[0].richText.Segments {
+		text := segment.(*widget.TextSegment)
+		if !text.Style.TextStyle.Monospace {
+			t.Errorf("代码应使用等宽字体: %q", text.Text)
+		}
+		colors[text.Text] = text.Style.ColorName
+	}
+	if colors["return"] != theme.ColorNamePrimary || colors[`"ok"`] != theme.ColorNameSuccess || colors["// 完成"] != theme.ColorNamePlaceHolder {
+		t.Errorf("高亮颜色错误: %v", colors)
+	}
+
+	// 不支持的语言按普通代码块显示
+	if _, ok := pv.blocks[1].richText.Segments[0].(*widget.ParagraphSegment); ok {
+		t.Error("不支持的语言不应作为段落显示")
+	}
+	if len(pv.blocks[1].richText.Segments) != 1 {
+		t.Errorf("不支持的语言应显示为一个代码块: %#v", pv.blocks[1].richText.Segments)
+	}
+}
+
+func TestPreviewView_Diagrams(t *testing.T) {
+	test.NewApp()
+
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1280, 200)))
+	pv := NewPreviewView()
+	pv.diagrams = &diagramRenderer{
+		lookPath: func(file string) (string, error) {
+			if file == "mmdc" {
+				return "/usr/bin/mmdc", nil
+			}
+			return "", errors.New("not found")
+		},
+		runCommand: func(ctx context.Context, name string, args ...string) error {
+			for i, arg := range args {
+				if arg == "-o" {
+					return os.WriteFile(args[i+1], buf.Bytes(), 0600)
+				}
+			}
+			return errors.New("缺少输出文件")
+		},
+		delay: 0, // 同步渲染，结果在 UpdatePreview 返回前显示
+		cache: make(map[string]diagramResult),
+	}
+
+	pv.UpdatePreview("```mermaid\ngraph TD; A-->B\n```\n\n```dot\ndigraph { a -> b }\n```\n")
+
+	// 未安装 dot 时显示占位内容
+	placeholder := pv.blocks[1].diagram.container.Objects[0].(*fyne.Container).Objects[0].(*widget.Label)
+	if placeholder.Text != "Graphviz 图表（安装 dot 后显示为图片）" {
+		t.Errorf("占位文字错误: %q", placeholder.Text)
+	}
+
+	// 渲染完成后显示图片，宽度不超过上限
+	box, ok := pv.blocks[0].diagram.container.Objects[0].(*fyne.Container)
+	if !ok || len(box.Objects) != 1 {
+		t.Fatal("图表没有渲染为图片")
+	}
+	img, ok := box.Objects[0].(*canvas.Image)
+	if !ok {
+		t.Fatal("图表没有渲染为图片")
+	}
+	if size := img.MinSize(); size.Width != diagramMaxWidth || size.Height != 100 {
+		t.Errorf("图表尺寸错误: %v", size)
+	}
+
+	// 相同的图表使用缓存，立即显示
+	pv.UpdatePreview("# 架构\n\n```mermaid\ngraph TD; A-->B\n```\n")
+	box = pv.blocks[1].diagram.container.Objects[0].(*fyne.Container)
+	if _, ok := box.Objects[0].(*canvas.Image); !ok {
+		t.Error("缓存的图表应立即显示")
+	}
+}
+
+func TestPreviewView_ScrollAndTheme(t *testing.T) {
+	test.NewApp()
+
+	pv := NewPreviewView()
+	w := test.NewWindow(pv.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 200))
+
+	markdown := ""
+	for i := 0; i < 30; i++ {
+		markdown += "## 小节\n\n正文内容\n\n"
+	}
+	pv.UpdatePreview(markdown)
+
+	var scrolledTo int
+	pv.SetOnScrolled(func(line int) {
+		scrolledTo = line
+	})
+	pv.ScrollToLine(20)
+	offset := pv.scrollContainer.Offset.Y
+	if offset <= 0 || offset != pv.blocks[10].object.Position().Y {
+		t.Errorf("第 20 行应滚动到第 11 个块，偏移 %v", offset)
+	}
+	if line := pv.lineAtOffset(offset); line != 20 {
+		t.Errorf("偏移位置应对应第 20 行，实际 %d", line)
+	}
+
+	// 用户滚动时通知对应的行号
+	pv.scrollContainer.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.NewDelta(0, -offset)})
+	if scrolledTo <= 20 {
+		t.Errorf("向下滚动后行号应增加，实际 %d", scrolledTo)
+	}
+
+	base := fyne.CurrentApp().Settings().Theme()
+	pv.SetTheme(model.PreviewThemeDark)
+	dark := base.Color(theme.ColorNameBackground, theme.VariantDark)
+	if pv.background.FillColor != dark {
+		t.Errorf("深色主题背景色错误: %v", pv.background.FillColor)
+	}
+	if th := pv.themeOverride.Theme; th.Color(theme.ColorNameForeground, theme.VariantLight) != base.Color(theme.ColorNameForeground, theme.VariantDark) {
+		t.Error("深色主题应固定使用深色的颜色")
+	}
+	pv.SetTheme("")
+	if th := pv.themeOverride.Theme; th.Color(theme.ColorNameForeground, theme.VariantLight) != base.Color(theme.ColorNameForeground, theme.VariantLight) {
+		t.Error("为空时应跟随界面主题")
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ReportView 周报/月报预览对话框
+type ReportView struct {
+	window        fyne.Window
+	reportService service.ReportService
+}
+
+// NewReportView 创建新的报告预览对话框
+func NewReportView(parent fyne.Window, reportService service.ReportService) *ReportView {
+	return &ReportView{
+		window:        parent,
+		reportService: reportService,
+	}
+}
+
+// ShowWeekly 生成并显示指定日期所在周的周报
+func (rv *ReportView) ShowWeekly(date time.Time) {
+	report, err := rv.reportService.GenerateWeeklyReport(date)
+	if err != nil {
+		util.ShowErrorDialogWithMessage("生成失败", "无法生成周报", err, rv.window)
+		return
+	}
+
+	monday, _ := util.WeekRange(date)
+	rv.show("周报", report, fmt.Sprintf("周报-%s.md", monday.Format("2006-01-02")))
+}
+
+// ShowMonthly 生成并显示指定月份的月报
+func (rv *ReportView) ShowMonthly(year int, month time.Month) {
+	report, err := rv.reportService.GenerateMonthlyReport(year, month)
+	if err != nil {
+		util.ShowErrorDialogWithMessage("生成失败", "无法生成月报", err, rv.window)
+		return
+	}
+
+	rv.show("月报", report, fmt.Sprintf("月报-%d-%02d.md", year, month))
+}
+
+// show 显示报告内容，支持复制和另存为文件
+func (rv *ReportView) show(title, report, fileName string) {
+	// 报告内容可在对话框中修改后再复制或保存
+	reportEntry := widget.NewMultiLineEntry()
+	reportEntry.SetText(report)
+	reportEntry.Wrapping = fyne.TextWrapWord
+
+	copyButton := widget.NewButton("复制到剪贴板", func() {
+		fyne.CurrentApp().Clipboard().SetContent(reportEntry.Text)
+		util.ShowSuccessNotification("已复制到剪贴板", rv.window)
+	})
+
+	saveButton := widget.NewButton("另存为...", func() {
+		rv.saveToFile(reportEntry.Text, fileName)
+	})
+
+	content := container.NewBorder(
+		nil, // top
+		container.NewHBox(copyButton, saveButton), // bottom
+		nil,         // left
+		nil,         // right
+		reportEntry, // center
+	)
+
+	reportDialog := dialog.NewCustom(title, "关闭", content, rv.window)
+	reportDialog.Resize(fyne.NewSize(800, 600))
+	reportDialog.Show()
+}
+
+// saveToFile 弹出保存对话框，将报告写入 Markdown 文件
+func (rv *ReportView) saveToFile(report, fileName string) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			util.ShowErrorDialogWithMessage("保存失败", "无法保存报告", err, rv.window)
+			return
+		}
+		if writer == nil {
+			return // 用户取消
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write([]byte(report)); err != nil {
+			util.ShowErrorDialogWithMessage("保存失败", "无法写入报告文件", err, rv.window)
+			return
+		}
+		util.ShowSuccessNotification(fmt.Sprintf("报告已保存到 %s", writer.URI().Path()), rv.window)
+	}, rv.window)
+
+	saveDialog.SetFileName(fileName)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".md"}))
+	saveDialog.Show()
+}
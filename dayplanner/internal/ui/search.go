@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// searchSnippetLength 搜索结果摘要的最大字符数
+const searchSnippetLength = 40
+
+// SearchView 任务搜索视图组件
+type SearchView struct {
+	container   *fyne.Container
+	searchEntry *widget.Entry
+	statusLabel *widget.Label
+	resultList  *widget.List
+	results     []*model.Task
+	taskService service.TaskService
+
+	// 回调函数
+	onResultSelected func(date time.Time)
+}
+
+// NewSearchView 创建新的搜索视图
+func NewSearchView(taskService service.TaskService) *SearchView {
+	sv := &SearchView{
+		taskService: taskService,
+	}
+
+	// 创建搜索输入框，回车触发搜索
+	sv.searchEntry = widget.NewEntry()
+	sv.searchEntry.SetPlaceHolder("搜索日报内容，多个关键词用空格分隔")
+	sv.searchEntry.OnSubmitted = func(query string) {
+		sv.Search(query)
+	}
+
+	searchButton := widget.NewButton("搜索", func() {
+		sv.Search(sv.searchEntry.Text)
+	})
+
+	sv.statusLabel = widget.NewLabel("")
+
+	// 创建结果列表
+	sv.resultList = widget.NewList(
+		func() int {
+			return len(sv.results)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			task := sv.results[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s  %s",
+				task.Date.Format("2006-01-02"), buildSnippet(task.Content)))
+		},
+	)
+	sv.resultList.OnSelected = func(id widget.ListItemID) {
+		if id < len(sv.results) && sv.onResultSelected != nil {
+			sv.onResultSelected(sv.results[id].Date)
+		}
+	}
+
+	// 创建容器布局
+	sv.container = container.NewBorder(
+		container.NewVBox(
+			container.NewBorder(nil, nil, nil, searchButton, sv.searchEntry),
+			sv.statusLabel,
+		), // top
+		nil,           // bottom
+		nil,           // left
+		nil,           // right
+		sv.resultList, // center
+	)
+
+	return sv
+}
+
+// GetContainer 获取容器
+func (sv *SearchView) GetContainer() *fyne.Container {
+	return sv.container
+}
+
+// SetOnResultSelected 设置搜索结果选择回调
+func (sv *SearchView) SetOnResultSelected(callback func(date time.Time)) {
+	sv.onResultSelected = callback
+}
+
+// Search 执行搜索并刷新结果列表
+func (sv *SearchView) Search(query string) {
+	query = strings.TrimSpace(query)
+	sv.resultList.UnselectAll()
+
+	if query == "" {
+		sv.results = nil
+		sv.statusLabel.SetText("")
+		sv.resultList.Refresh()
+		return
+	}
+
+	results, err := sv.taskService.Search(query)
+	if err != nil {
+		util.Error("搜索任务失败: %v", err)
+		sv.results = nil
+		sv.statusLabel.SetText("搜索失败，请查看日志")
+		sv.resultList.Refresh()
+		return
+	}
+
+	sv.results = results
+	sv.statusLabel.SetText(fmt.Sprintf("找到 %d 条结果", len(results)))
+	sv.resultList.Refresh()
+}
+
+// buildSnippet 生成单行的内容摘要
+func buildSnippet(content string) string {
+	snippet := strings.Join(strings.Fields(content), " ")
+	if utf8.RuneCountInString(snippet) > searchSnippetLength {
+		runes := []rune(snippet)
+		snippet = string(runes[:searchSnippetLength]) + "..."
+	}
+	return snippet
+}
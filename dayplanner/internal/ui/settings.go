@@ -22,11 +22,38 @@ type SettingsView struct {
 	hourSelect      *widget.Select
 	minuteSelect    *widget.Select
 	reminderCheck   *widget.Check
+	workdaysCheck   *widget.Check
+	remindersLabel  *widget.Label
+	storageSelect   *widget.Select
+	teamServerEntry *widget.Entry
+	teamTokenEntry  *widget.Entry
+	previewSelect   *widget.Select
+	channelsLabel   *widget.Label
 	saveButton      *widget.Button
 	cancelButton    *widget.Button
 	onConfigUpdated func() // 配置更新后的回调
 }
 
+// 存储后端选项与配置值的对应关系
+var storageBackendOptions = []struct {
+	label   string
+	backend string
+}{
+	{label: "文件 (每天一个 JSON 文件)", backend: model.StorageBackendFile},
+	{label: "SQLite (支持全文搜索)", backend: model.StorageBackendSQLite},
+	{label: "团队服务器", backend: model.StorageBackendTeam},
+}
+
+// 预览主题选项与配置值的对应关系
+var previewThemeOptions = []struct {
+	label string
+	theme string
+}{
+	{label: "跟随界面主题", theme: ""},
+	{label: "浅色", theme: model.PreviewThemeLight},
+	{label: "深色", theme: model.PreviewThemeDark},
+}
+
 // NewSettingsView 创建新的设置界面
 func NewSettingsView(parent fyne.Window, configService service.ConfigService) *SettingsView {
 	sv := &SettingsView{
@@ -63,6 +90,40 @@ func (sv *SettingsView) initializeComponents() {
 	// 创建提醒开关
 	sv.reminderCheck = widget.NewCheck("启用每日提醒", nil)
 
+	// 创建工作日开关
+	sv.workdaysCheck = widget.NewCheck("仅在工作日提醒（参考节假日日历）", nil)
+
+	// 创建提醒规则说明
+	sv.remindersLabel = widget.NewLabel("")
+	sv.remindersLabel.Wrapping = fyne.TextWrapWord
+
+	// 创建存储后端选择器
+	storageLabels := make([]string, len(storageBackendOptions))
+	for i, option := range storageBackendOptions {
+		storageLabels[i] = option.label
+	}
+	sv.storageSelect = widget.NewSelect(storageLabels, sv.onStorageChanged)
+	sv.storageSelect.SetSelected(storageBackendOptions[0].label)
+
+	// 创建团队服务器地址和访问令牌输入框，仅在选择团队服务器时显示
+	sv.teamServerEntry = widget.NewEntry()
+	sv.teamServerEntry.SetPlaceHolder("http://team.example.com:17900")
+	sv.teamTokenEntry = widget.NewPasswordEntry()
+	sv.teamTokenEntry.SetPlaceHolder("组长通过 team add-member 生成的访问令牌")
+	sv.onStorageChanged(sv.storageSelect.Selected)
+
+	// 创建预览主题选择器
+	previewLabels := make([]string, len(previewThemeOptions))
+	for i, option := range previewThemeOptions {
+		previewLabels[i] = option.label
+	}
+	sv.previewSelect = widget.NewSelect(previewLabels, nil)
+	sv.previewSelect.SetSelected(previewThemeOptions[0].label)
+
+	// 创建通知渠道说明
+	sv.channelsLabel = widget.NewLabel("")
+	sv.channelsLabel.Wrapping = fyne.TextWrapWord
+
 	// 创建保存按钮
 	sv.saveButton = widget.NewButton("保存", sv.onSave)
 
@@ -95,7 +156,7 @@ func (sv *SettingsView) Show() {
 
 	// 创建并显示对话框
 	settingsDialog := dialog.NewCustom("设置", "关闭", content, sv.window)
-	settingsDialog.Resize(fyne.NewSize(500, 300))
+	settingsDialog.Resize(fyne.NewSize(500, 600))
 	settingsDialog.Show()
 }
 
@@ -106,6 +167,7 @@ func (sv *SettingsView) createForm() *fyne.Container {
 	webhookForm := container.NewVBox(
 		webhookLabel,
 		sv.webhookEntry,
+		sv.channelsLabel,
 	)
 
 	// 提醒时间表单项
@@ -123,6 +185,22 @@ func (sv *SettingsView) createForm() *fyne.Container {
 	// 提醒开关表单项
 	reminderForm := container.NewVBox(
 		sv.reminderCheck,
+		sv.workdaysCheck,
+		sv.remindersLabel,
+	)
+
+	// 存储后端表单项
+	storageForm := container.NewVBox(
+		widget.NewLabel("存储方式 (重启后生效):"),
+		sv.storageSelect,
+		sv.teamServerEntry,
+		sv.teamTokenEntry,
+	)
+
+	// 预览主题表单项
+	previewForm := container.NewVBox(
+		widget.NewLabel("预览主题:"),
+		sv.previewSelect,
 	)
 
 	// 组合所有表单项
@@ -130,6 +208,8 @@ func (sv *SettingsView) createForm() *fyne.Container {
 		webhookForm,
 		timeForm,
 		reminderForm,
+		storageForm,
+		previewForm,
 	)
 
 	return form
@@ -146,6 +226,7 @@ func (sv *SettingsView) loadConfig() {
 
 	// 设置 Webhook URL
 	sv.webhookEntry.SetText(config.WebhookURL)
+	sv.channelsLabel.SetText(describeChannels(config.Channels))
 
 	// 解析并设置提醒时间
 	if config.ReminderTime != "" {
@@ -158,6 +239,48 @@ func (sv *SettingsView) loadConfig() {
 
 	// 设置提醒开关
 	sv.reminderCheck.SetChecked(config.ReminderEnabled)
+	sv.workdaysCheck.SetChecked(config.ReminderWorkdaysOnly)
+	sv.remindersLabel.SetText(describeReminders(config.Reminders))
+	sv.remindersLabel.Hidden = len(config.Reminders) == 0
+
+	// 设置存储后端
+	for _, option := range storageBackendOptions {
+		if option.backend == config.StorageBackend ||
+			(config.StorageBackend == "" && option.backend == model.StorageBackendFile) {
+			sv.storageSelect.SetSelected(option.label)
+		}
+	}
+	sv.teamServerEntry.SetText(config.TeamServer)
+	sv.teamTokenEntry.SetText(config.TeamToken)
+
+	// 设置预览主题
+	for _, option := range previewThemeOptions {
+		if option.theme == config.PreviewTheme {
+			sv.previewSelect.SetSelected(option.label)
+		}
+	}
+}
+
+// onStorageChanged 切换存储方式时显示或隐藏团队服务器设置
+func (sv *SettingsView) onStorageChanged(selected string) {
+	if sv.teamServerEntry == nil {
+		return
+	}
+	team := selected == storageBackendLabel(model.StorageBackendTeam)
+	sv.teamServerEntry.Hidden = !team
+	sv.teamTokenEntry.Hidden = !team
+	sv.teamServerEntry.Refresh()
+	sv.teamTokenEntry.Refresh()
+}
+
+// storageBackendLabel 返回存储后端在选择器中的显示名称
+func storageBackendLabel(backend string) string {
+	for _, option := range storageBackendOptions {
+		if option.backend == backend {
+			return option.label
+		}
+	}
+	return ""
 }
 
 // SetOnConfigUpdated 设置配置更新回调
@@ -177,26 +300,43 @@ func (sv *SettingsView) onSave() {
 		return
 	}
 
-	// 如果启用提醒，验证 Webhook URL
-	if sv.reminderCheck.Checked && webhookURL == "" {
-		util.Warn("启用提醒但未配置 Webhook URL")
-		util.ShowWarningDialog("输入错误", "启用提醒功能需要配置企业微信 Webhook URL", sv.window)
-		return
+	// 在现有配置基础上修改，保留界面上未展示的配置项
+	config, err := sv.configService.GetConfig()
+	if err != nil {
+		util.Warn("读取现有配置失败，将使用默认值: %v", err)
+		config = &model.Config{
+			DataPath: "./data/tasks", // 保持默认数据路径
+		}
 	}
 
-	// 构建配置对象
-	config := &model.Config{
-		WebhookURL:      webhookURL,
-		ReminderTime:    fmt.Sprintf("%s:%s", sv.hourSelect.Selected, sv.minuteSelect.Selected),
-		ReminderEnabled: sv.reminderCheck.Checked,
-		DataPath:        "./data/tasks", // 保持默认数据路径
+	// 如果启用提醒，验证是否有可用的通知渠道
+	if sv.reminderCheck.Checked && webhookURL == "" && len(config.Channels) == 0 {
+		util.Warn("启用提醒但未配置通知渠道")
+		util.ShowWarningDialog("输入错误", "启用提醒功能需要配置企业微信 Webhook URL 或其他通知渠道", sv.window)
+		return
+	}
+	config.WebhookURL = webhookURL
+	config.ReminderTime = fmt.Sprintf("%s:%s", sv.hourSelect.Selected, sv.minuteSelect.Selected)
+	config.ReminderEnabled = sv.reminderCheck.Checked
+	config.ReminderWorkdaysOnly = sv.workdaysCheck.Checked
+	for _, option := range storageBackendOptions {
+		if option.label == sv.storageSelect.Selected {
+			config.StorageBackend = option.backend
+		}
+	}
+	config.TeamServer = strings.TrimSpace(sv.teamServerEntry.Text)
+	config.TeamToken = strings.TrimSpace(sv.teamTokenEntry.Text)
+	for _, option := range previewThemeOptions {
+		if option.label == sv.previewSelect.Selected {
+			config.PreviewTheme = option.theme
+		}
 	}
 
 	util.Info("保存配置: Webhook=%s, 提醒时间=%s, 启用=%v", 
 		config.WebhookURL, config.ReminderTime, config.ReminderEnabled)
 
 	// 调用配置服务验证和保存
-	err := sv.configService.UpdateConfig(config)
+	err = sv.configService.UpdateConfig(config)
 	if err != nil {
 		// 显示错误提示
 		util.ShowErrorDialogWithMessage("保存失败", "无法保存配置", err, sv.window)
@@ -227,3 +367,38 @@ func (sv *SettingsView) showError(message string) {
 func (sv *SettingsView) showSuccess(message string) {
 	dialog.ShowInformation("成功", message, sv.window)
 }
+
+// describeChannels 生成配置文件中通知渠道的说明文字
+func describeChannels(channels []model.ChannelConfig) string {
+	if len(channels) == 0 {
+		return "提醒将发送到上面的企业微信地址。如需钉钉、飞书、Slack、邮件等渠道，请在 config.json 的 channels 中配置。"
+	}
+
+	names := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		name := channel.Name
+		if name == "" {
+			name = channel.Type
+		}
+		if channel.Disabled {
+			name += "（已停用）"
+		}
+		names = append(names, name)
+	}
+	return fmt.Sprintf("已在 config.json 中配置 %d 个通知渠道: %s。提醒将发送到这些渠道，上面的企业微信地址不再使用。",
+		len(channels), strings.Join(names, "、"))
+}
+
+// describeReminders 生成配置文件中提醒规则的说明文字
+func describeReminders(reminders []model.ReminderRule) string {
+	if len(reminders) == 0 {
+		return ""
+	}
+
+	rules := make([]string, 0, len(reminders))
+	for _, reminder := range reminders {
+		rules = append(rules, reminder.Cron)
+	}
+	return fmt.Sprintf("已在 config.json 中配置 %d 条提醒规则 (%s)，上面的提醒时间和工作日开关不再使用。",
+		len(reminders), strings.Join(rules, "；"))
+}
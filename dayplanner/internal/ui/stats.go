@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// statsMaxBars 每个图表最多显示的条目数，其余合并为"其他"
+const statsMaxBars = 12
+
+// 统计范围选项
+const (
+	statsRangeWeek  = "本周"
+	statsRangeMonth = "本月"
+	statsRangeYear  = "今年"
+)
+
+// StatsView 工时和填报统计视图，显示在主窗口的"统计"标签页中
+type StatsView struct {
+	container    *fyne.Container
+	statsService service.StatsService
+	rangeSelect  *widget.Select
+	summaryLabel *widget.Label
+	missedLabel  *widget.Label
+	tagChart     *fyne.Container
+	projectChart *fyne.Container
+	weekChart    *fyne.Container
+}
+
+// NewStatsView 创建新的统计视图
+func NewStatsView(statsService service.StatsService) *StatsView {
+	sv := &StatsView{
+		statsService: statsService,
+		summaryLabel: widget.NewLabel(""),
+		missedLabel:  widget.NewLabel(""),
+		tagChart:     container.NewVBox(),
+		projectChart: container.NewVBox(),
+		weekChart:    container.NewVBox(),
+	}
+	sv.missedLabel.Wrapping = fyne.TextWrapWord
+
+	sv.rangeSelect = widget.NewSelect([]string{statsRangeWeek, statsRangeMonth, statsRangeYear}, func(string) {
+		sv.Refresh()
+	})
+	sv.rangeSelect.Selected = statsRangeMonth
+	refreshButton := widget.NewButton("刷新", sv.Refresh)
+
+	charts := container.NewVBox(
+		widget.NewCard("按标签", "", sv.tagChart),
+		widget.NewCard("按项目", "", sv.projectChart),
+		widget.NewCard("按周", "", sv.weekChart),
+	)
+	sv.container = container.NewBorder(
+		container.NewVBox(
+			container.NewHBox(widget.NewLabel("统计范围"), sv.rangeSelect, refreshButton),
+			sv.summaryLabel,
+			sv.missedLabel,
+		), // top
+		nil,                          // bottom
+		nil,                          // left
+		nil,                          // right
+		container.NewVScroll(charts), // center
+	)
+	return sv
+}
+
+// GetContainer 获取视图容器
+func (sv *StatsView) GetContainer() *fyne.Container {
+	return sv.container
+}
+
+// Refresh 按选择的范围重新统计并更新图表
+func (sv *StatsView) Refresh() {
+	start, end := statsRange(sv.rangeSelect.Selected, time.Now())
+	stats, err := sv.statsService.Compute(start, end)
+	if err != nil {
+		util.Error("统计失败: %v", err)
+		sv.summaryLabel.SetText(fmt.Sprintf("统计失败: %v", err))
+		return
+	}
+
+	sv.summaryLabel.SetText(fmt.Sprintf("%s ~ %s    总耗时 %s（%d 条记录）\n填报 %d 天 / 工作日 %d 天，缺报 %d 天    连续填报 %d 个工作日，最长 %d 个工作日",
+		stats.Start.Format("2006-01-02"), stats.End.Format("2006-01-02"),
+		formatStatsHours(stats.TotalDuration), stats.Entries,
+		stats.ReportedDays, stats.Workdays, len(stats.MissedDays),
+		stats.CurrentStreak, stats.LongestStreak))
+
+	if len(stats.MissedDays) == 0 {
+		sv.missedLabel.SetText("")
+	} else {
+		days := make([]string, 0, len(stats.MissedDays))
+		for _, day := range stats.MissedDays {
+			days = append(days, day.Format("01-02"))
+		}
+		sv.missedLabel.SetText("缺报日期: " + strings.Join(days, "、"))
+	}
+
+	setBars(sv.tagChart, itemBars(stats.Tags))
+	setBars(sv.projectChart, itemBars(stats.Projects))
+	weekBars := make([]chartBar, 0, len(stats.Weeks))
+	for _, week := range stats.Weeks {
+		weekBars = append(weekBars, chartBar{
+			label:    week.Start.Format("01-02") + " 起",
+			value:    week.Duration,
+			subtitle: fmt.Sprintf("%d 天", week.ReportedDays),
+		})
+	}
+	setBars(sv.weekChart, weekBars)
+}
+
+// statsRange 返回统计范围选项对应的日期范围
+func statsRange(option string, now time.Time) (time.Time, time.Time) {
+	switch option {
+	case statsRangeWeek:
+		return util.WeekRange(now)
+	case statsRangeYear:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location()),
+			time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, now.Location())
+	default:
+		return util.MonthRange(now.Year(), now.Month(), now.Location())
+	}
+}
+
+// chartBar 条形图中的一项
+type chartBar struct {
+	label    string
+	value    time.Duration
+	subtitle string // 显示在耗时之后的补充信息
+}
+
+// itemBars 将标签或项目汇总转换为条形图数据，超出 statsMaxBars 的部分合并为"其他"
+func itemBars(items []service.StatsItem) []chartBar {
+	bars := make([]chartBar, 0, min(len(items), statsMaxBars))
+	var other chartBar
+	for i, item := range items {
+		if i >= statsMaxBars-1 && len(items) > statsMaxBars {
+			other.value += item.Duration
+			continue
+		}
+		bars = append(bars, chartBar{label: item.Name, value: item.Duration, subtitle: fmt.Sprintf("%d 条", item.Entries)})
+	}
+	if other.value > 0 {
+		other.label = "其他"
+		bars = append(bars, other)
+	}
+	return bars
+}
+
+// setBars 用条形图替换容器内容，条形长度按最大值等比例缩放
+func setBars(chart *fyne.Container, bars []chartBar) {
+	if len(bars) == 0 {
+		chart.Objects = []fyne.CanvasObject{widget.NewLabel("没有记录耗时，可在任务项后写上 (2h)、(30m) 这样的耗时")}
+		chart.Refresh()
+		return
+	}
+
+	var maxValue time.Duration
+	for _, bar := range bars {
+		maxValue = max(maxValue, bar.value)
+	}
+
+	rows := make([]fyne.CanvasObject, 0, len(bars)*2)
+	for _, bar := range bars {
+		ratio := float32(0)
+		if maxValue > 0 {
+			ratio = float32(bar.value) / float32(maxValue)
+		}
+		rect := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		value := formatStatsHours(bar.value)
+		if bar.subtitle != "" {
+			value += "  " + bar.subtitle
+		}
+		rows = append(rows,
+			widget.NewLabel(bar.label),
+			container.NewBorder(nil, nil, nil, widget.NewLabel(value), container.New(&barLayout{ratio: ratio}, rect)),
+		)
+	}
+	chart.Objects = []fyne.CanvasObject{container.New(layout.NewFormLayout(), rows...)}
+	chart.Refresh()
+}
+
+// barLayout 按比例设置条形宽度的布局，条形在垂直方向居中
+type barLayout struct {
+	ratio float32
+}
+
+// Layout 排列条形
+func (l *barLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	height := size.Height / 2
+	for _, object := range objects {
+		object.Move(fyne.NewPos(0, (size.Height-height)/2))
+		object.Resize(fyne.NewSize(size.Width*l.ratio, height))
+	}
+}
+
+// MinSize 返回条形的最小尺寸
+func (l *barLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(120, theme.TextSize())
+}
+
+// formatStatsHours 将耗时格式化为小时数，如 "6.5h"
+func formatStatsHours(duration time.Duration) string {
+	return strconv.FormatFloat(math.Round(duration.Hours()*100)/100, 'f', -1, 64) + "h"
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TeamView 团队填报情况视图，显示在主窗口的"团队"标签页中，仅组长可以查看
+type TeamView struct {
+	container       *fyne.Container
+	parentWindow    fyne.Window
+	teamService     service.TeamService
+	reminderService service.ReminderService
+	dateEntry       *widget.Entry
+	summaryLabel    *widget.Label
+	memberList      *widget.List
+	reportTitle     *widget.Label
+	reportText      *widget.RichText
+	remindButton    *widget.Button
+
+	date    time.Time
+	members []model.MemberStatus // 未填写的在前
+}
+
+// NewTeamView 创建新的团队视图
+func NewTeamView(parent fyne.Window, teamService service.TeamService, reminderService service.ReminderService) *TeamView {
+	tv := &TeamView{
+		parentWindow:    parent,
+		teamService:     teamService,
+		reminderService: reminderService,
+		summaryLabel:    widget.NewLabel(""),
+		reportTitle:     widget.NewLabel(""),
+		reportText:      widget.NewRichText(),
+	}
+	tv.summaryLabel.Wrapping = fyne.TextWrapWord
+	tv.reportTitle.TextStyle = fyne.TextStyle{Bold: true}
+	tv.reportText.Wrapping = fyne.TextWrapWord
+
+	tv.dateEntry = widget.NewEntry()
+	tv.dateEntry.SetPlaceHolder("YYYY-MM-DD")
+	tv.dateEntry.SetText(time.Now().Format("2006-01-02"))
+	tv.dateEntry.OnSubmitted = func(string) {
+		tv.Refresh()
+	}
+	refreshButton := widget.NewButton("刷新", tv.Refresh)
+	tv.remindButton = widget.NewButton("提醒未填写的成员", tv.onRemind)
+
+	// 创建成员列表
+	tv.memberList = widget.NewList(
+		func() int {
+			return len(tv.members)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(describeMemberStatus(tv.members[id]))
+		},
+	)
+	tv.memberList.OnSelected = func(id widget.ListItemID) {
+		if id < len(tv.members) {
+			tv.showReport(tv.members[id])
+		}
+	}
+
+	report := container.NewBorder(tv.reportTitle, nil, nil, nil, container.NewScroll(tv.reportText))
+	split := container.NewHSplit(tv.memberList, report)
+	split.SetOffset(0.3)
+
+	tv.container = container.NewBorder(
+		container.NewVBox(
+			container.NewHBox(widget.NewLabel("日期"), tv.dateEntry, refreshButton, tv.remindButton),
+			tv.summaryLabel,
+		), // top
+		nil,   // bottom
+		nil,   // left
+		nil,   // right
+		split, // center
+	)
+	return tv
+}
+
+// GetContainer 获取视图容器
+func (tv *TeamView) GetContainer() *fyne.Container {
+	return tv.container
+}
+
+// Refresh 重新获取所选日期的团队填报情况
+func (tv *TeamView) Refresh() {
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(tv.dateEntry.Text), time.Local)
+	if err != nil {
+		tv.summaryLabel.SetText("日期格式应为 YYYY-MM-DD")
+		return
+	}
+	tv.date = date
+
+	status, err := tv.teamService.Status(date)
+	if err != nil {
+		util.Warn("获取团队填报情况失败: %v", err)
+		tv.summaryLabel.SetText(fmt.Sprintf("获取团队填报情况失败: %v", err))
+		tv.setMembers(nil)
+		return
+	}
+
+	filed, missing := status.Filed(), status.Missing()
+	summary := fmt.Sprintf("%s 已填写 %d/%d", date.Format("2006-01-02"), len(filed), len(status.Members))
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, member := range missing {
+			names[i] = member.Name
+		}
+		summary += "，未填写: " + strings.Join(names, "、")
+	}
+	tv.summaryLabel.SetText(summary)
+	tv.setMembers(append(missing, filed...))
+}
+
+// setMembers 替换成员列表并清空右侧的日报内容
+func (tv *TeamView) setMembers(members []model.MemberStatus) {
+	tv.members = members
+	tv.memberList.UnselectAll()
+	tv.memberList.Refresh()
+	tv.reportTitle.SetText("")
+	tv.reportText.ParseMarkdown("")
+}
+
+// showReport 显示成员在所选日期的日报
+func (tv *TeamView) showReport(member model.MemberStatus) {
+	tv.reportTitle.SetText(fmt.Sprintf("%s 的日报", member.Name))
+	if !member.Filed {
+		tv.reportText.ParseMarkdown("*还没有填写日报*")
+		return
+	}
+
+	task, err := tv.teamService.GetMemberTask(member.Name, tv.date)
+	if err != nil {
+		util.Warn("获取 %s 的日报失败: %v", member.Name, err)
+		tv.reportText.ParseMarkdown(fmt.Sprintf("获取日报失败: %v", err))
+		return
+	}
+	if task == nil {
+		tv.reportText.ParseMarkdown("*还没有填写日报*")
+		return
+	}
+	tv.reportText.ParseMarkdown(task.Content)
+}
+
+// onRemind 通过通知渠道提醒今天还没有填写日报的成员
+func (tv *TeamView) onRemind() {
+	if tv.reminderService == nil {
+		return
+	}
+	tv.remindButton.Disable()
+	go func() {
+		reminded, err := tv.reminderService.RemindTeamOnce()
+		fyne.Do(func() {
+			tv.remindButton.Enable()
+			if err != nil {
+				util.ShowErrorDialogWithMessage("提醒失败", "无法提醒未填写日报的成员", err, tv.parentWindow)
+				return
+			}
+			if len(reminded) == 0 {
+				util.ShowInfoDialog("无需提醒", "团队成员今天都已填写日报", tv.parentWindow)
+				return
+			}
+			util.ShowSuccessNotification("已提醒: "+strings.Join(reminded, "、"), tv.parentWindow)
+		})
+	}()
+}
+
+// describeMemberStatus 生成成员列表中一行的文字
+func describeMemberStatus(member model.MemberStatus) string {
+	name := member.Name
+	if member.Lead {
+		name += " (组长)"
+	}
+	if !member.Filed {
+		return "✗ " + name + "  未填写"
+	}
+	return fmt.Sprintf("✓ %s  %s 更新", name, member.UpdatedAt.Local().Format("15:04"))
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"errors"
+
+	"daily-report-tool/internal/keystore"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// UnlockWindow 启动时输入加密口令的窗口
+type UnlockWindow struct {
+	window     fyne.Window
+	passphrase *widget.Entry
+	confirm    *widget.Entry // 首次设置口令时的确认输入，解锁已有数据时为 nil
+	message    *widget.Label
+	unlock     func(passphrase string) error
+	onUnlocked func()
+}
+
+// NewUnlockWindow 创建口令输入窗口，creating 为 true 表示首次启用加密，需要输入两次口令
+// unlock 使用口令解开密钥，返回 keystore.ErrWrongKey 时提示重新输入；成功后关闭窗口并调用 onUnlocked
+func NewUnlockWindow(app fyne.App, creating bool, unlock func(passphrase string) error, onUnlocked func()) *UnlockWindow {
+	uw := &UnlockWindow{
+		window:     app.NewWindow("日报工具 - 解锁"),
+		passphrase: widget.NewPasswordEntry(),
+		message:    widget.NewLabel(""),
+		unlock:     unlock,
+		onUnlocked: onUnlocked,
+	}
+	uw.passphrase.SetPlaceHolder("加密口令")
+	uw.passphrase.OnSubmitted = func(string) { uw.submit() }
+
+	title := "日报已加密，请输入口令解锁"
+	items := []fyne.CanvasObject{uw.passphrase}
+	if creating {
+		title = "首次启用加密，请设置口令（忘记口令将无法恢复日报）"
+		uw.confirm = widget.NewPasswordEntry()
+		uw.confirm.SetPlaceHolder("再次输入口令")
+		uw.confirm.OnSubmitted = func(string) { uw.submit() }
+		items = append(items, uw.confirm)
+	}
+	uw.message.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(widget.NewLabel(title))
+	for _, item := range items {
+		content.Add(item)
+	}
+	content.Add(uw.message)
+	content.Add(container.NewHBox(
+		widget.NewButton("退出", func() { app.Quit() }),
+		widget.NewButton("解锁", uw.submit),
+	))
+
+	uw.window.SetContent(container.NewPadded(content))
+	uw.window.Resize(fyne.NewSize(400, 200))
+	uw.window.CenterOnScreen()
+	return uw
+}
+
+// Show 显示窗口并聚焦口令输入框
+func (uw *UnlockWindow) Show() {
+	uw.window.Show()
+	uw.window.Canvas().Focus(uw.passphrase)
+}
+
+// submit 校验输入并尝试解锁
+func (uw *UnlockWindow) submit() {
+	passphrase := uw.passphrase.Text
+	if passphrase == "" {
+		uw.message.SetText("口令不能为空")
+		return
+	}
+	if uw.confirm != nil && uw.confirm.Text != passphrase {
+		uw.message.SetText("两次输入的口令不一致")
+		return
+	}
+
+	if err := uw.unlock(passphrase); err != nil {
+		if errors.Is(err, keystore.ErrWrongKey) {
+			uw.message.SetText("口令错误，请重新输入")
+		} else {
+			util.Error("解锁加密数据失败: %v", err)
+			uw.message.SetText("解锁失败: " + err.Error())
+		}
+		uw.passphrase.SetText("")
+		uw.window.Canvas().Focus(uw.passphrase)
+		return
+	}
+
+	util.Info("加密数据已解锁")
+	uw.onUnlocked()
+	uw.window.Close()
+}
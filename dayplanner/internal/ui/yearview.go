@@ -0,0 +1,500 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/service"
+	"daily-report-tool/internal/util"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// 热力图着色依据
+const (
+	heatmapMetricChars = "字数"
+	heatmapMetricHours = "工时"
+)
+
+// heatmapLevels 有日报时的颜色等级数，没有日报为 0 级
+const heatmapLevels = 4
+
+// heatmapMaxWeeks 一年最多跨越的周数（热力图的列数）
+const heatmapMaxWeeks = 54
+
+// heatmapAlphas 各颜色等级使用的主题色不透明度
+var heatmapAlphas = [heatmapLevels]float64{0.3, 0.5, 0.75, 1}
+
+// heatmapWeekdays 热力图左侧的星期标签，从周一开始
+var heatmapWeekdays = [7]string{"一", "", "三", "", "五", "", "日"}
+
+// YearView 年度视图，以贡献图形式的热力图显示全年的日报，显示在主窗口的"年度"标签页中
+// 颜色深浅按日报字数或记录的耗时计算，周末和节假日按节假日日历加深底色
+type YearView struct {
+	container    *fyne.Container
+	statsService service.StatsService
+	yearLabel    *widget.Label
+	metricSelect *widget.Select
+	jumpEntry    *widget.Entry
+	detailLabel  *widget.Label
+	grid         *heatmapGrid
+
+	year     int
+	selected time.Time
+	days     []service.DayActivity // 从 1 月 1 日开始按日期排列
+	maxValue float64               // 当前指标的全年最大值
+
+	// 回调函数
+	onDateOpened func(date time.Time)
+}
+
+// NewYearView 创建新的年度视图，初始显示今年并选中今天
+func NewYearView(statsService service.StatsService) *YearView {
+	today := util.StartOfDay(time.Now())
+	yv := &YearView{
+		statsService: statsService,
+		yearLabel:    widget.NewLabel(""),
+		detailLabel:  widget.NewLabel(""),
+		year:         today.Year(),
+		selected:     today,
+	}
+	yv.yearLabel.TextStyle = fyne.TextStyle{Bold: true}
+	yv.grid = newHeatmapGrid(yv)
+
+	prevButton := widget.NewButton("上一年", func() {
+		yv.selectDate(addMonths(yv.selected, -12), false)
+	})
+	nextButton := widget.NewButton("下一年", func() {
+		yv.selectDate(addMonths(yv.selected, 12), false)
+	})
+	yv.metricSelect = widget.NewSelect([]string{heatmapMetricChars, heatmapMetricHours}, func(string) {
+		yv.updateMaxValue()
+		yv.grid.Refresh()
+		yv.updateDetail()
+	})
+	yv.metricSelect.Selected = heatmapMetricChars
+
+	yv.jumpEntry = widget.NewEntry()
+	yv.jumpEntry.SetPlaceHolder("跳转到 YYYY-MM-DD")
+	yv.jumpEntry.OnSubmitted = yv.jumpTo
+
+	hint := widget.NewLabel("方向键切换日期，PageUp/PageDown 切换月份，按住 Shift 切换年份，回车打开日报")
+	hint.Wrapping = fyne.TextWrapWord
+
+	yv.container = container.NewBorder(
+		container.NewHBox(prevButton, yv.yearLabel, nextButton, layout.NewSpacer(),
+			widget.NewLabel("颜色"), yv.metricSelect, container.NewGridWrap(fyne.NewSize(180, yv.jumpEntry.MinSize().Height), yv.jumpEntry)), // top
+		container.NewVBox(yv.detailLabel, heatmapLegend(), hint), // bottom
+		nil,     // left
+		nil,     // right
+		yv.grid, // center
+	)
+	return yv
+}
+
+// GetContainer 获取视图容器
+func (yv *YearView) GetContainer() *fyne.Container {
+	return yv.container
+}
+
+// SetOnDateOpened 设置打开日期的回调函数，点击日期、回车或跳转时触发
+func (yv *YearView) SetOnDateOpened(callback func(date time.Time)) {
+	yv.onDateOpened = callback
+}
+
+// Refresh 重新统计当前年份每天的日报并更新热力图
+func (yv *YearView) Refresh() {
+	start := time.Date(yv.year, time.January, 1, 0, 0, 0, 0, time.Local)
+	days, err := yv.statsService.Activity(start, start.AddDate(1, 0, -1))
+	if err != nil {
+		util.Error("统计年度日报失败: %v", err)
+		yv.days = nil
+		yv.yearLabel.SetText(fmt.Sprintf("%d年", yv.year))
+		yv.detailLabel.SetText(fmt.Sprintf("统计失败: %v", err))
+		yv.grid.Refresh()
+		return
+	}
+	yv.days = days
+
+	reported := 0
+	var total time.Duration
+	for _, day := range days {
+		if day.Chars > 0 {
+			reported++
+		}
+		total += day.Duration
+	}
+	yv.yearLabel.SetText(fmt.Sprintf("%d年  %d 天有日报  记录耗时 %s", yv.year, reported, formatStatsHours(total)))
+	yv.updateMaxValue()
+	yv.grid.Refresh()
+	yv.updateDetail()
+}
+
+// selectDate 选中日期，跨年时重新统计；open 为 true 时同时打开该日期的日报
+func (yv *YearView) selectDate(date time.Time, open bool) {
+	yv.selected = util.StartOfDay(date)
+	if yv.selected.Year() != yv.year {
+		yv.year = yv.selected.Year()
+		yv.Refresh()
+	} else {
+		yv.grid.Refresh()
+		yv.updateDetail()
+	}
+	if open && yv.onDateOpened != nil {
+		yv.onDateOpened(yv.selected)
+	}
+}
+
+// jumpTo 跳转到输入的日期并打开其日报
+func (yv *YearView) jumpTo(text string) {
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(text), time.Local)
+	if err != nil {
+		yv.detailLabel.SetText("日期格式应为 YYYY-MM-DD")
+		return
+	}
+	yv.selectDate(date, true)
+}
+
+// activity 返回当前年份中某一天的活跃度
+func (yv *YearView) activity(date time.Time) (service.DayActivity, bool) {
+	index := date.YearDay() - 1
+	if date.Year() != yv.year || index >= len(yv.days) {
+		return service.DayActivity{}, false
+	}
+	return yv.days[index], true
+}
+
+// value 返回一天在当前着色依据下的数值
+func (yv *YearView) value(day service.DayActivity) float64 {
+	if yv.metricSelect.Selected == heatmapMetricHours {
+		return day.Duration.Hours()
+	}
+	return float64(day.Chars)
+}
+
+// updateMaxValue 重新计算当前着色依据下的全年最大值
+func (yv *YearView) updateMaxValue() {
+	yv.maxValue = 0
+	for _, day := range yv.days {
+		yv.maxValue = max(yv.maxValue, yv.value(day))
+	}
+}
+
+// updateDetail 显示选中日期的字数、耗时和工作日情况
+func (yv *YearView) updateDetail() {
+	parts := []string{yv.selected.Format("2006-01-02"), util.ChineseWeekday(yv.selected)}
+	if day, ok := yv.activity(yv.selected); ok {
+		if day.Chars == 0 {
+			parts = append(parts, "没有日报")
+		} else {
+			parts = append(parts, fmt.Sprintf("%d 字", day.Chars), "记录耗时 "+formatStatsHours(day.Duration))
+		}
+		weekend := yv.selected.Weekday() == time.Saturday || yv.selected.Weekday() == time.Sunday
+		switch {
+		case day.Holiday:
+			parts = append(parts, "节假日")
+		case !day.Workday:
+			parts = append(parts, "周末")
+		case weekend:
+			parts = append(parts, "调休补班")
+		}
+	}
+	yv.detailLabel.SetText(strings.Join(parts, "  "))
+}
+
+// heatmapLegend 创建颜色图例
+func heatmapLegend() *fyne.Container {
+	swatch := func(fill color.Color, stroke color.Color) fyne.CanvasObject {
+		rect := canvas.NewRectangle(fill)
+		if stroke != nil {
+			rect.StrokeColor = stroke
+			rect.StrokeWidth = 1
+		}
+		return container.NewGridWrap(fyne.NewSize(12, 12), rect)
+	}
+	objects := []fyne.CanvasObject{widget.NewLabel("少")}
+	for level := 0; level <= heatmapLevels; level++ {
+		objects = append(objects, container.NewCenter(swatch(heatColor(level, true), nil)))
+	}
+	objects = append(objects,
+		widget.NewLabel("多"),
+		container.NewCenter(swatch(heatColor(0, false), nil)), widget.NewLabel("周末和节假日"),
+		container.NewCenter(swatch(heatColor(0, false), theme.Color(theme.ColorNameError))), widget.NewLabel("节假日"),
+	)
+	return container.NewHBox(objects...)
+}
+
+// heatmapGrid 热力图网格，每列为一周、每行为周一至周日，支持点击和键盘导航
+type heatmapGrid struct {
+	widget.BaseWidget
+	view  *YearView
+	shift bool // Shift 键是否按下
+
+	// 由渲染器在布局时更新，用于将点击位置换算为日期
+	origin fyne.Position
+	pitch  float32
+}
+
+// newHeatmapGrid 创建热力图网格
+func newHeatmapGrid(view *YearView) *heatmapGrid {
+	g := &heatmapGrid{view: view}
+	g.ExtendBaseWidget(g)
+	return g
+}
+
+// CreateRenderer 实现 fyne.Widget 接口
+func (g *heatmapGrid) CreateRenderer() fyne.WidgetRenderer {
+	r := &heatmapRenderer{grid: g}
+	for i := 0; i < heatmapMaxWeeks*7; i++ {
+		rect := canvas.NewRectangle(color.Transparent)
+		r.cells = append(r.cells, rect)
+		r.objects = append(r.objects, rect)
+	}
+	for month := 1; month <= 12; month++ {
+		text := canvas.NewText(fmt.Sprintf("%d月", month), theme.Color(theme.ColorNameForeground))
+		text.TextSize = theme.CaptionTextSize()
+		r.months = append(r.months, text)
+		r.objects = append(r.objects, text)
+	}
+	for _, name := range heatmapWeekdays {
+		text := canvas.NewText(name, theme.Color(theme.ColorNameForeground))
+		text.TextSize = theme.CaptionTextSize()
+		r.weekdays = append(r.weekdays, text)
+		r.objects = append(r.objects, text)
+	}
+	r.Refresh()
+	return r
+}
+
+// Tapped 点击日期时选中并打开该日期，同时获取键盘焦点
+func (g *heatmapGrid) Tapped(event *fyne.PointEvent) {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(g); c != nil {
+		c.Focus(g)
+	}
+	if g.pitch <= 0 {
+		return
+	}
+	x := event.Position.X - g.origin.X
+	y := event.Position.Y - g.origin.Y
+	if x < 0 || y < 0 {
+		return
+	}
+	col, row := int(x/g.pitch), int(y/g.pitch)
+	if col >= heatmapMaxWeeks || row >= 7 {
+		return
+	}
+	if date, ok := heatmapDate(g.view.year, col, row); ok {
+		g.view.selectDate(date, true)
+	}
+}
+
+// FocusGained 实现 fyne.Focusable 接口
+func (g *heatmapGrid) FocusGained() {}
+
+// FocusLost 实现 fyne.Focusable 接口
+func (g *heatmapGrid) FocusLost() {
+	g.shift = false
+}
+
+// TypedRune 实现 fyne.Focusable 接口
+func (g *heatmapGrid) TypedRune(rune) {}
+
+// TypedKey 处理键盘导航，回车打开选中日期的日报
+func (g *heatmapGrid) TypedKey(event *fyne.KeyEvent) {
+	switch event.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		g.view.selectDate(g.view.selected, true)
+		return
+	}
+	if date, ok := navigateDate(g.view.selected, event.Name, g.shift); ok {
+		g.view.selectDate(date, false)
+	}
+}
+
+// KeyDown 记录 Shift 键状态，实现 desktop.Keyable 接口
+func (g *heatmapGrid) KeyDown(event *fyne.KeyEvent) {
+	if event.Name == desktop.KeyShiftLeft || event.Name == desktop.KeyShiftRight {
+		g.shift = true
+	}
+}
+
+// KeyUp 记录 Shift 键状态，实现 desktop.Keyable 接口
+func (g *heatmapGrid) KeyUp(event *fyne.KeyEvent) {
+	if event.Name == desktop.KeyShiftLeft || event.Name == desktop.KeyShiftRight {
+		g.shift = false
+	}
+}
+
+// heatmapRenderer 热力图网格的渲染器
+type heatmapRenderer struct {
+	grid     *heatmapGrid
+	cells    []*canvas.Rectangle // 按列（周）优先排列
+	months   []*canvas.Text
+	weekdays []*canvas.Text
+	objects  []fyne.CanvasObject
+}
+
+// Layout 按可用空间计算格子大小，月份标签放在每月第一天所在的列上方
+func (r *heatmapRenderer) Layout(size fyne.Size) {
+	labelWidth := r.weekdays[0].MinSize().Width + theme.Padding()
+	headerHeight := r.months[0].MinSize().Height + theme.Padding()
+	pitch := min((size.Width-labelWidth)/heatmapMaxWeeks, (size.Height-headerHeight)/7)
+	pitch = max(pitch, 4)
+	gap := max(1, pitch/8)
+	origin := fyne.NewPos(labelWidth, headerHeight)
+	r.grid.origin, r.grid.pitch = origin, pitch
+
+	for i, cell := range r.cells {
+		col, row := i/7, i%7
+		cell.Move(fyne.NewPos(origin.X+float32(col)*pitch, origin.Y+float32(row)*pitch))
+		cell.Resize(fyne.NewSize(pitch-gap, pitch-gap))
+	}
+	for i, text := range r.months {
+		col, _ := heatmapCell(time.Date(r.grid.view.year, time.Month(i+1), 1, 0, 0, 0, 0, time.Local))
+		text.Move(fyne.NewPos(origin.X+float32(col)*pitch, 0))
+	}
+	for row, text := range r.weekdays {
+		text.Move(fyne.NewPos(0, origin.Y+float32(row)*pitch+(pitch-gap-text.MinSize().Height)/2))
+	}
+}
+
+// MinSize 返回热力图的最小尺寸
+func (r *heatmapRenderer) MinSize() fyne.Size {
+	labelWidth := r.weekdays[0].MinSize().Width + theme.Padding()
+	headerHeight := r.months[0].MinSize().Height + theme.Padding()
+	return fyne.NewSize(labelWidth+heatmapMaxWeeks*10, headerHeight+7*10)
+}
+
+// Refresh 按当前年份的活跃度为每个格子着色
+func (r *heatmapRenderer) Refresh() {
+	view := r.grid.view
+	for i, cell := range r.cells {
+		date, ok := heatmapDate(view.year, i/7, i%7)
+		if !ok {
+			cell.Hide()
+			continue
+		}
+		cell.Show()
+		day, _ := view.activity(date)
+		workday := day.Workday
+		if len(view.days) == 0 {
+			workday = date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+		}
+		cell.FillColor = heatColor(heatLevel(view.value(day), view.maxValue), workday)
+		switch {
+		case date.Equal(view.selected):
+			cell.StrokeColor = theme.Color(theme.ColorNameForeground)
+			cell.StrokeWidth = 2
+		case day.Holiday:
+			cell.StrokeColor = theme.Color(theme.ColorNameError)
+			cell.StrokeWidth = 1
+		default:
+			cell.StrokeWidth = 0
+		}
+		cell.Refresh()
+	}
+	for _, texts := range [][]*canvas.Text{r.months, r.weekdays} {
+		for _, text := range texts {
+			text.Color = theme.Color(theme.ColorNameForeground)
+			text.Refresh()
+		}
+	}
+	r.Layout(r.grid.Size())
+}
+
+// Objects 返回所有画布对象
+func (r *heatmapRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// Destroy 实现 fyne.WidgetRenderer 接口
+func (r *heatmapRenderer) Destroy() {}
+
+// heatmapStart 返回热力图第一列的周一，即 1 月 1 日所在周的周一
+func heatmapStart(year int) time.Time {
+	monday, _ := util.WeekRange(time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local))
+	return monday
+}
+
+// heatmapCell 返回日期在其所在年份热力图中的列和行
+func heatmapCell(date time.Time) (col, row int) {
+	start := heatmapStart(date.Year())
+	// 按日历日计算间隔，避免夏令时切换造成的误差
+	days := int(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	return days / 7, days % 7
+}
+
+// heatmapDate 返回热力图中某一格对应的日期，不属于该年份时返回 false
+func heatmapDate(year, col, row int) (time.Time, bool) {
+	date := heatmapStart(year).AddDate(0, 0, col*7+row)
+	return date, date.Year() == year
+}
+
+// heatLevel 按数值占全年最大值的比例计算颜色等级，没有记录时为 0
+func heatLevel(value, maxValue float64) int {
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	level := int(math.Ceil(value / maxValue * heatmapLevels))
+	return min(max(level, 1), heatmapLevels)
+}
+
+// heatColor 返回颜色等级对应的填充色，没有日报的周末和节假日使用更深的底色
+func heatColor(level int, workday bool) color.Color {
+	if level == 0 {
+		if workday {
+			return theme.Color(theme.ColorNameInputBackground)
+		}
+		return theme.Color(theme.ColorNameDisabledButton)
+	}
+	r, g, b, _ := theme.Color(theme.ColorNamePrimary).RGBA()
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(255 * heatmapAlphas[level-1])}
+}
+
+// navigateDate 返回按键导航后的日期：上下键切换一天，左右键切换一周（热力图的相邻列），
+// PageUp/PageDown 切换一个月，按住 Shift 时切换一年，Home/End 跳到当年第一天和最后一天
+func navigateDate(date time.Time, key fyne.KeyName, shift bool) (time.Time, bool) {
+	switch key {
+	case fyne.KeyUp:
+		return date.AddDate(0, 0, -1), true
+	case fyne.KeyDown:
+		return date.AddDate(0, 0, 1), true
+	case fyne.KeyLeft:
+		return date.AddDate(0, 0, -7), true
+	case fyne.KeyRight:
+		return date.AddDate(0, 0, 7), true
+	case fyne.KeyPageUp:
+		if shift {
+			return addMonths(date, -12), true
+		}
+		return addMonths(date, -1), true
+	case fyne.KeyPageDown:
+		if shift {
+			return addMonths(date, 12), true
+		}
+		return addMonths(date, 1), true
+	case fyne.KeyHome:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location()), true
+	case fyne.KeyEnd:
+		return time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, date.Location()), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// addMonths 按月份加减日期，目标月份没有该日时取月末，如 3 月 31 日减一个月为 2 月最后一天
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	_, last := util.MonthRange(first.Year(), first.Month(), date.Location())
+	return time.Date(first.Year(), first.Month(), min(date.Day(), last.Day()), 0, 0, 0, 0, date.Location())
+}
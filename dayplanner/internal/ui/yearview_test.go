@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+
+	"daily-report-tool/internal/service"
+)
+
+// stubStatsService 返回固定活跃度的统计服务
+type stubStatsService struct {
+	chars map[string]int
+}
+
+func (s *stubStatsService) Compute(startDate, endDate time.Time) (*service.Stats, error) {
+	return &service.Stats{Start: startDate, End: endDate}, nil
+}
+
+func (s *stubStatsService) Activity(startDate, endDate time.Time) ([]service.DayActivity, error) {
+	var days []service.DayActivity
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		weekday := day.Weekday()
+		days = append(days, service.DayActivity{
+			Date:    day,
+			Chars:   s.chars[day.Format("2006-01-02")],
+			Workday: weekday != time.Saturday && weekday != time.Sunday,
+		})
+	}
+	return days, nil
+}
+
+func TestHeatmapCell(t *testing.T) {
+	// 2025-01-01 为周三，所在周的周一为 2024-12-30
+	tests := []struct {
+		date     string
+		col, row int
+	}{
+		{"2025-01-01", 0, 2},
+		{"2025-01-05", 0, 6},
+		{"2025-01-06", 1, 0},
+		{"2025-12-31", 52, 2},
+	}
+	for _, tt := range tests {
+		date, _ := time.ParseInLocation("2006-01-02", tt.date, time.Local)
+		col, row := heatmapCell(date)
+		if col != tt.col || row != tt.row {
+			t.Errorf("%s 应位于第 %d 列第 %d 行，实际: %d, %d", tt.date, tt.col, tt.row, col, row)
+		}
+		if back, ok := heatmapDate(2025, col, row); !ok || !back.Equal(date) {
+			t.Errorf("第 %d 列第 %d 行应为 %s，实际: %s", col, row, tt.date, back.Format("2006-01-02"))
+		}
+	}
+	if _, ok := heatmapDate(2025, 0, 0); ok {
+		t.Error("2024-12-30 不属于 2025 年的热力图")
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	tests := []struct {
+		value, max float64
+		want       int
+	}{
+		{0, 100, 0},
+		{10, 0, 0},
+		{1, 100, 1},
+		{25, 100, 1},
+		{26, 100, 2},
+		{75, 100, 3},
+		{100, 100, 4},
+	}
+	for _, tt := range tests {
+		if got := heatLevel(tt.value, tt.max); got != tt.want {
+			t.Errorf("heatLevel(%v, %v) = %d, 期望 %d", tt.value, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestNavigateDate(t *testing.T) {
+	date := time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		key   fyne.KeyName
+		shift bool
+		want  string
+	}{
+		{fyne.KeyUp, false, "2025-03-30"},
+		{fyne.KeyDown, false, "2025-04-01"},
+		{fyne.KeyLeft, false, "2025-03-24"},
+		{fyne.KeyRight, false, "2025-04-07"},
+		{fyne.KeyPageUp, false, "2025-02-28"},
+		{fyne.KeyPageDown, false, "2025-04-30"},
+		{fyne.KeyPageUp, true, "2024-03-31"},
+		{fyne.KeyHome, false, "2025-01-01"},
+		{fyne.KeyEnd, false, "2025-12-31"},
+	}
+	for _, tt := range tests {
+		got, ok := navigateDate(date, tt.key, tt.shift)
+		if !ok || got.Format("2006-01-02") != tt.want {
+			t.Errorf("按键 %s (shift=%v) 应跳到 %s，实际: %s", tt.key, tt.shift, tt.want, got.Format("2006-01-02"))
+		}
+	}
+	if _, ok := navigateDate(date, fyne.KeyA, false); ok {
+		t.Error("其他按键不应改变日期")
+	}
+}
+
+func TestYearView_KeyboardAndJump(t *testing.T) {
+	test.NewApp()
+
+	yv := NewYearView(&stubStatsService{chars: map[string]int{"2025-11-10": 300, "2025-11-11": 100}})
+	var opened []string
+	yv.SetOnDateOpened(func(date time.Time) {
+		opened = append(opened, date.Format("2006-01-02"))
+	})
+	window := test.NewWindow(yv.GetContainer())
+	defer window.Close()
+
+	yv.jumpTo("2025-11-10")
+	if yv.year != 2025 || len(opened) != 1 || opened[0] != "2025-11-10" {
+		t.Fatalf("跳转后应切换到 2025 年并打开日报，实际: %d %v", yv.year, opened)
+	}
+	if yv.maxValue != 300 {
+		t.Errorf("全年最大字数应为 300，实际: %v", yv.maxValue)
+	}
+	if day, _ := yv.activity(yv.selected); heatLevel(yv.value(day), yv.maxValue) != heatmapLevels {
+		t.Errorf("字数最多的一天应为最深的颜色")
+	}
+
+	yv.grid.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if yv.selected.Format("2006-01-02") != "2025-11-11" || len(opened) != 1 {
+		t.Errorf("方向键只切换选中日期，实际: %s %v", yv.selected.Format("2006-01-02"), opened)
+	}
+	yv.grid.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if len(opened) != 2 || opened[1] != "2025-11-11" {
+		t.Errorf("回车应打开选中日期，实际: %v", opened)
+	}
+
+	yv.grid.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	yv.grid.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown})
+	yv.grid.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	if yv.year != 2026 || yv.selected.Format("2006-01-02") != "2026-11-11" {
+		t.Errorf("Shift+PageDown 应切换到下一年，实际: %s", yv.selected.Format("2006-01-02"))
+	}
+
+	yv.jumpTo("11/10")
+	if yv.detailLabel.Text != "日期格式应为 YYYY-MM-DD" {
+		t.Errorf("无效日期应提示格式，实际: %s", yv.detailLabel.Text)
+	}
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// AttachmentDir 数据目录中保存附件的子目录，日报中的附件链接都以它开头
+const AttachmentDir = "attachments"
+
+// 日报中引用附件的 Markdown 链接，如 ![截图](attachments/2025-11-10/dashboard-1a2b3c4d.png)
+// 附件文件名由仓库生成，不包含空白和括号
+var (
+	attachmentLinkPattern      = regexp.MustCompile(`\]\((attachments/\d{4}-\d{2}-\d{2}/[^()\s/]+)\)`)
+	attachmentReferencePattern = regexp.MustCompile(`!?\[[^\]\n]*\]\((attachments/\d{4}-\d{2}-\d{2}/[^()\s/]+)\)`)
+)
+
+// imageExtensions 可以在预览和导出中内嵌显示的图片扩展名
+var imageExtensions = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// AttachmentLinks 返回日报中引用的附件链接，按出现顺序去重
+func AttachmentLinks(markdown string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, match := range attachmentLinkPattern.FindAllStringSubmatch(markdown, -1) {
+		if link := match[1]; !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// ReplaceAttachmentLinks 将日报中每个附件链接的地址替换为 replace 的返回值，链接文字保持不变
+func ReplaceAttachmentLinks(markdown string, replace func(link string) string) string {
+	return attachmentLinkPattern.ReplaceAllStringFunc(markdown, func(match string) string {
+		link := attachmentLinkPattern.FindStringSubmatch(match)[1]
+		return "](" + replace(link) + ")"
+	})
+}
+
+// RemoveAttachmentReferences 删除日报中引用指定附件的图片和链接，只包含该引用的列表项或行一并删除
+func RemoveAttachmentReferences(markdown, link string) string {
+	lines := strings.Split(markdown, "\n")
+	result := lines[:0]
+	for _, line := range lines {
+		removed := attachmentReferencePattern.ReplaceAllStringFunc(line, func(match string) string {
+			if attachmentReferencePattern.FindStringSubmatch(match)[1] == link {
+				return ""
+			}
+			return match
+		})
+		if removed != line && isEmptyListLine(removed) {
+			continue
+		}
+		result = append(result, removed)
+	}
+	return strings.Join(result, "\n")
+}
+
+// isEmptyListLine 判断删除引用后的行是否只剩空白或列表标记
+func isEmptyListLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, marker := range []string{"- [ ]", "- [x]", "-", "*", "+"} {
+		trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, marker))
+	}
+	return trimmed == ""
+}
+
+// AttachmentMarkdown 返回插入日报的附件链接：图片使用图片语法内嵌显示，其他文件使用普通链接
+func AttachmentMarkdown(link string) string {
+	name := path.Base(link)
+	if IsImageAttachment(link) {
+		return "![" + name + "](" + link + ")"
+	}
+	return "[" + name + "](" + link + ")"
+}
+
+// IsImageAttachment 判断附件是否为可以内嵌显示的图片
+func IsImageAttachment(name string) bool {
+	_, ok := imageExtensions[strings.ToLower(path.Ext(name))]
+	return ok
+}
+
+// ImageMIMEType 返回图片附件的 MIME 类型，不是图片时返回空字符串
+func ImageMIMEType(name string) string {
+	return imageExtensions[strings.ToLower(path.Ext(name))]
+}
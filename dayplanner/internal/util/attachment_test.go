@@ -0,0 +1,52 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentLinks(t *testing.T) {
+	markdown := "- 页面改版 ![截图](attachments/2025-11-10/dashboard-1a2b3c4d.png)\n" +
+		"- 需求文档 [需求.pdf](attachments/2025-11-10/需求-5e6f7a8b.pdf)\n" +
+		"- 外部链接 [官网](https://example.com/attachments/2025-11-10/a.png)\n" +
+		"- 再次引用 ![](attachments/2025-11-10/dashboard-1a2b3c4d.png)\n"
+
+	want := []string{"attachments/2025-11-10/dashboard-1a2b3c4d.png", "attachments/2025-11-10/需求-5e6f7a8b.pdf"}
+	if got := AttachmentLinks(markdown); !reflect.DeepEqual(got, want) {
+		t.Errorf("附件链接不正确: %v", got)
+	}
+
+	replaced := ReplaceAttachmentLinks(markdown, func(link string) string { return "/data/" + link })
+	if !strings.Contains(replaced, "![截图](/data/attachments/2025-11-10/dashboard-1a2b3c4d.png)") ||
+		!strings.Contains(replaced, "(https://example.com/attachments/2025-11-10/a.png)") {
+		t.Errorf("替换结果不正确:\n%s", replaced)
+	}
+}
+
+func TestRemoveAttachmentReferences(t *testing.T) {
+	markdown := "- 页面改版 ![截图](attachments/2025-11-10/a-1a2b3c4d.png)\n" +
+		"- ![截图](attachments/2025-11-10/a-1a2b3c4d.png)\n" +
+		"- [需求.pdf](attachments/2025-11-10/b-5e6f7a8b.pdf)\n" +
+		"\n" +
+		"总结"
+	want := "- 页面改版 \n" +
+		"- [需求.pdf](attachments/2025-11-10/b-5e6f7a8b.pdf)\n" +
+		"\n" +
+		"总结"
+	if got := RemoveAttachmentReferences(markdown, "attachments/2025-11-10/a-1a2b3c4d.png"); got != want {
+		t.Errorf("删除引用结果不正确:\n%q", got)
+	}
+}
+
+func TestAttachmentMarkdown(t *testing.T) {
+	if got := AttachmentMarkdown("attachments/2025-11-10/a-1a2b3c4d.PNG"); got != "![a-1a2b3c4d.PNG](attachments/2025-11-10/a-1a2b3c4d.PNG)" {
+		t.Errorf("图片应使用图片语法: %s", got)
+	}
+	if got := AttachmentMarkdown("attachments/2025-11-10/b-5e6f7a8b.pdf"); got != "[b-5e6f7a8b.pdf](attachments/2025-11-10/b-5e6f7a8b.pdf)" {
+		t.Errorf("其他文件应使用普通链接: %s", got)
+	}
+	if ImageMIMEType("a.jpeg") != "image/jpeg" || ImageMIMEType("a.pdf") != "" {
+		t.Error("MIME 类型不正确")
+	}
+}
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// MarkdownBlock Markdown 文档中的一个顶层块（段落、标题、列表、代码块等）
+type MarkdownBlock struct {
+	Source string // 块的原文，包括其后的空行；所有块的原文依次拼接即为整篇文档
+	Line   int    // 起始行号（从 0 开始）
+
+	// 块为围栏代码块时的语言和内容
+	Fenced   bool
+	Language string
+	Code     string
+}
+
+// SplitMarkdownBlocks 使用 goldmark 将 Markdown 按顶层块切分，用于按块增量渲染预览
+// 没有原文行的块（如分隔线、空标题）合并到前一个块中
+func SplitMarkdownBlocks(markdown string) []MarkdownBlock {
+	if markdown == "" {
+		return nil
+	}
+	source := []byte(markdown)
+	doc := ParseMarkdown(source)
+
+	type blockStart struct {
+		offset int
+		node   ast.Node
+		nodes  int // 合并到该块中的顶层节点数
+	}
+	var starts []*blockStart
+	for child := doc.FirstChild(); child != nil; child = child.NextSibling() {
+		offset, ok := blockStartOffset(child, source)
+		if !ok || (len(starts) > 0 && offset <= starts[len(starts)-1].offset) {
+			if len(starts) > 0 {
+				starts[len(starts)-1].nodes++
+			}
+			continue
+		}
+		starts = append(starts, &blockStart{offset: offset, node: child, nodes: 1})
+	}
+	if len(starts) == 0 {
+		return []MarkdownBlock{{Source: markdown}}
+	}
+	// 文档开头的空行归入第一个块
+	starts[0].offset = 0
+
+	blocks := make([]MarkdownBlock, 0, len(starts))
+	for i, start := range starts {
+		end := len(source)
+		if i+1 < len(starts) {
+			end = starts[i+1].offset
+		}
+		block := MarkdownBlock{
+			Source: markdown[start.offset:end],
+			Line:   bytes.Count(source[:start.offset], []byte("\n")),
+		}
+		if fenced, ok := start.node.(*ast.FencedCodeBlock); ok && start.nodes == 1 {
+			block.Fenced = true
+			block.Language = strings.TrimSpace(string(fenced.Language(source)))
+			block.Code = CodeBlockText(fenced, source)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// blockStartOffset 返回顶层块第一行的开头位置，块没有原文行时返回 false
+func blockStartOffset(node ast.Node, source []byte) (int, bool) {
+	if fenced, ok := node.(*ast.FencedCodeBlock); ok {
+		// 围栏代码块的原文行不包括开头的围栏行，空代码块无法确定位置
+		if fenced.Lines().Len() == 0 {
+			return 0, false
+		}
+		first := lineStart(source, fenced.Lines().At(0).Start)
+		if first == 0 {
+			return 0, false
+		}
+		return lineStart(source, first-1), true
+	}
+
+	offset := -1
+	ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && n.Type() == ast.TypeBlock && n.Lines().Len() > 0 {
+			offset = n.Lines().At(0).Start
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	if offset < 0 {
+		return 0, false
+	}
+	return lineStart(source, offset), true
+}
+
+// lineStart 返回 offset 所在行的开头位置
+func lineStart(source []byte, offset int) int {
+	return bytes.LastIndexByte(source[:offset], '\n') + 1
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// checkboxMarkerPattern 匹配任务列表项开头的复选框标记
+var checkboxMarkerPattern = regexp.MustCompile(`^\[[ xX]\]\s*`)
+
+// ChecklistItem Markdown 任务列表（GFM task list）中的一项
+type ChecklistItem struct {
+	Text    string // 去除 Markdown 标记后的纯文本，用于展示和去重
+	Raw     string // 复选框之后的原始 Markdown 文本，用于原样复制
+	Checked bool   // 是否已勾选
+	Line    int    // 所在行号（从 0 开始）
+}
+
+// ParseChecklist 使用 goldmark 解析 Markdown 中的所有任务列表项（含嵌套列表），按出现顺序返回
+func ParseChecklist(markdown string) []ChecklistItem {
+	source := []byte(markdown)
+	doc := ParseMarkdown(source)
+
+	var items []ChecklistItem
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		checkbox, ok := node.(*extast.TaskCheckBox)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+
+		// 复选框位于列表项第一个块（TextBlock 或 Paragraph）的开头
+		block := checkbox.Parent()
+		if block == nil || block.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+
+		items = append(items, ChecklistItem{
+			Text:    strings.TrimSpace(inlineText(block, source)),
+			Raw:     blockRawText(block, source),
+			Checked: checkbox.IsChecked,
+			Line:    bytes.Count(source[:block.Lines().At(0).Start], []byte("\n")),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+
+	return items
+}
+
+// inlineText 提取内联节点的纯文本内容
+func inlineText(node ast.Node, source []byte) string {
+	var sb strings.Builder
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.Text:
+			sb.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(n.Value)
+		case *ast.AutoLink:
+			sb.Write(n.URL(source))
+		default:
+			sb.WriteString(inlineText(child, source))
+		}
+	}
+	return sb.String()
+}
+
+// blockRawText 提取块的原始 Markdown 文本，去掉开头的复选框标记，多行合并为一行
+func blockRawText(block ast.Node, source []byte) string {
+	lines := block.Lines()
+	parts := make([]string, 0, lines.Len())
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		parts = append(parts, strings.TrimSpace(string(segment.Value(source))))
+	}
+	raw := strings.Join(parts, " ")
+	return strings.TrimSpace(checkboxMarkerPattern.ReplaceAllString(raw, ""))
+}
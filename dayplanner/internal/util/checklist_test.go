@@ -0,0 +1,52 @@
+package util
+
+import "testing"
+
+func TestParseChecklist(t *testing.T) {
+	markdown := "# 今日工作\n\n" +
+		"- [x] 完成 **需求文档**\n" +
+		"- [ ] 联调 [支付接口](https://example.com/pay)\n" +
+		"  - [X] 子任务已完成\n" +
+		"- 普通列表项\n\n" +
+		"```\n- [ ] 代码块中的内容不是任务\n```\n"
+
+	items := ParseChecklist(markdown)
+	if len(items) != 3 {
+		t.Fatalf("期望 3 个任务项，实际 %d: %+v", len(items), items)
+	}
+
+	tests := []struct {
+		text    string
+		raw     string
+		checked bool
+		line    int
+	}{
+		{text: "完成 需求文档", raw: "完成 **需求文档**", checked: true, line: 2},
+		{text: "联调 支付接口", raw: "联调 [支付接口](https://example.com/pay)", checked: false, line: 3},
+		{text: "子任务已完成", raw: "子任务已完成", checked: true, line: 4},
+	}
+	for i, tt := range tests {
+		item := items[i]
+		if item.Text != tt.text {
+			t.Errorf("第 %d 项文本不匹配: 期望 %q, 实际 %q", i, tt.text, item.Text)
+		}
+		if item.Raw != tt.raw {
+			t.Errorf("第 %d 项原文不匹配: 期望 %q, 实际 %q", i, tt.raw, item.Raw)
+		}
+		if item.Checked != tt.checked {
+			t.Errorf("第 %d 项勾选状态不匹配: 期望 %v, 实际 %v", i, tt.checked, item.Checked)
+		}
+		if item.Line != tt.line {
+			t.Errorf("第 %d 项行号不匹配: 期望 %d, 实际 %d", i, tt.line, item.Line)
+		}
+	}
+}
+
+func TestParseChecklist_Empty(t *testing.T) {
+	if items := ParseChecklist(""); len(items) != 0 {
+		t.Errorf("空内容不应包含任务项: %+v", items)
+	}
+	if items := ParseChecklist("- 没有复选框的列表"); len(items) != 0 {
+		t.Errorf("普通列表不应解析为任务项: %+v", items)
+	}
+}
@@ -0,0 +1,31 @@
+package util
+
+import "time"
+
+// chineseWeekdays 中文星期名称，按 time.Weekday 顺序排列
+var chineseWeekdays = [...]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+// ChineseWeekday 返回日期对应的中文星期名称，如 "星期一"
+func ChineseWeekday(date time.Time) string {
+	return chineseWeekdays[date.Weekday()]
+}
+
+// StartOfDay 返回日期当天零点（保留时区）
+func StartOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// WeekRange 返回日期所在周的周一和周日（均为当天零点）
+func WeekRange(date time.Time) (time.Time, time.Time) {
+	day := StartOfDay(date)
+	// time.Weekday 中周日为 0，换算为距离周一的天数
+	offset := (int(day.Weekday()) + 6) % 7
+	monday := day.AddDate(0, 0, -offset)
+	return monday, monday.AddDate(0, 0, 6)
+}
+
+// MonthRange 返回月份的第一天和最后一天（均为当天零点）
+func MonthRange(year int, month time.Month, loc *time.Location) (time.Time, time.Time) {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return first, first.AddDate(0, 1, -1)
+}
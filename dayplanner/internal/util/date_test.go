@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChineseWeekday(t *testing.T) {
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.Local) // 星期一
+	if got := ChineseWeekday(date); got != "星期一" {
+		t.Errorf("期望 星期一, 实际 %s", got)
+	}
+	if got := ChineseWeekday(date.AddDate(0, 0, 6)); got != "星期日" {
+		t.Errorf("期望 星期日, 实际 %s", got)
+	}
+}
+
+func TestWeekRange(t *testing.T) {
+	tests := []struct {
+		date   time.Time
+		monday string
+		sunday string
+	}{
+		{date: time.Date(2025, 11, 10, 15, 0, 0, 0, time.Local), monday: "2025-11-10", sunday: "2025-11-16"},
+		{date: time.Date(2025, 11, 13, 0, 0, 0, 0, time.Local), monday: "2025-11-10", sunday: "2025-11-16"},
+		{date: time.Date(2025, 11, 16, 23, 0, 0, 0, time.Local), monday: "2025-11-10", sunday: "2025-11-16"},
+		{date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), monday: "2025-12-29", sunday: "2026-01-04"},
+	}
+	for _, tt := range tests {
+		monday, sunday := WeekRange(tt.date)
+		if monday.Format("2006-01-02") != tt.monday || sunday.Format("2006-01-02") != tt.sunday {
+			t.Errorf("%s 所在周不正确: %s ~ %s", tt.date.Format("2006-01-02"),
+				monday.Format("2006-01-02"), sunday.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestMonthRange(t *testing.T) {
+	first, last := MonthRange(2024, time.February, time.Local)
+	if first.Format("2006-01-02") != "2024-02-01" || last.Format("2006-01-02") != "2024-02-29" {
+		t.Errorf("月份范围不正确: %s ~ %s", first.Format("2006-01-02"), last.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,112 @@
+package util
+
+import "strings"
+
+// DiffOp 行差异的类型
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // 未变化
+	DiffInsert               // 新增行
+	DiffDelete               // 删除行
+)
+
+// DiffLine 行级差异中的一行
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines 基于最长公共子序列计算两段文本的行级差异
+// 结果按新文本顺序排列，同一位置的删除行排在新增行之前
+func DiffLines(oldText, newText string) []DiffLine {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// 去掉公共前缀和后缀，缩小 LCS 表的规模
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	// lcs[i][j] 为 midA[i:] 与 midB[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			result = append(result, DiffLine{Op: DiffEqual, Text: midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, DiffLine{Op: DiffDelete, Text: midA[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		result = append(result, DiffLine{Op: DiffDelete, Text: midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		result = append(result, DiffLine{Op: DiffInsert, Text: midB[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+	return result
+}
+
+// FormatDiff 将行级差异格式化为统一格式风格的文本，每行以 " "、"+" 或 "-" 开头
+func FormatDiff(lines []DiffLine) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		switch line.Op {
+		case DiffInsert:
+			sb.WriteString("+")
+		case DiffDelete:
+			sb.WriteString("-")
+		default:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(line.Text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// splitLines 按行拆分文本，统一换行符，空文本返回空切片
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,37 @@
+package util
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	oldText := "# 今日工作\n- 任务一\n- 任务二\n- 任务三\n"
+	newText := "# 今日工作\n- 任务一\n- 任务二（已完成）\n- 任务三\n- 任务四"
+
+	want := " # 今日工作\n" +
+		" - 任务一\n" +
+		"-- 任务二\n" +
+		"+- 任务二（已完成）\n" +
+		" - 任务三\n" +
+		"+- 任务四\n"
+	if got := FormatDiff(DiffLines(oldText, newText)); got != want {
+		t.Errorf("差异结果不正确:\n期望:\n%s\n实际:\n%s", want, got)
+	}
+}
+
+func TestDiffLines_Empty(t *testing.T) {
+	if lines := DiffLines("", ""); len(lines) != 0 {
+		t.Errorf("两段空文本不应有差异: %v", lines)
+	}
+
+	lines := DiffLines("", "a\nb")
+	if len(lines) != 2 || lines[0].Op != DiffInsert || lines[1].Op != DiffInsert {
+		t.Errorf("从空文本开始应全部为新增: %v", lines)
+	}
+
+	lines = DiffLines("a\r\nb\r\n", "a\nb")
+	for _, line := range lines {
+		if line.Op != DiffEqual {
+			t.Errorf("换行符不同不应视为差异: %v", lines)
+			break
+		}
+	}
+}
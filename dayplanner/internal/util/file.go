@@ -0,0 +1,48 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic 安全地写入文件：先写入同一目录中的 .tmp 临时文件并同步到磁盘，再重命名为目标文件
+// 写入过程中崩溃或断电时，目标文件保持原来的完整内容，最多留下一个临时文件
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tmpPath := filePath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("同步到磁盘失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	syncDir(filepath.Dir(filePath))
+	return nil
+}
+
+// syncDir 将目录同步到磁盘，使重命名在断电后仍然有效
+// Windows 不支持同步目录，失败时忽略
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
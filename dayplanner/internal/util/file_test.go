@@ -0,0 +1,36 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "2025-11-10.json")
+
+	if err := WriteFileAtomic(filePath, []byte(`{"content":"第一版"}`), 0600); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	if err := WriteFileAtomic(filePath, []byte(`{"content":"第二版"}`), 0600); err != nil {
+		t.Fatalf("覆盖写入失败: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil || string(data) != `{"content":"第二版"}` {
+		t.Errorf("文件内容错误: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filePath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("写入完成后不应留下临时文件")
+	}
+	if info, _ := os.Stat(filePath); runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("文件权限错误: %v", info.Mode().Perm())
+	}
+
+	// 目录不存在时返回错误，不留下任何文件
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "a.json"), []byte("{}"), 0600); err == nil {
+		t.Error("目录不存在时应返回错误")
+	}
+}
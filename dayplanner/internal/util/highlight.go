@@ -0,0 +1,296 @@
+package util
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	gutil "github.com/yuin/goldmark/util"
+)
+
+// CodeTokenKind 代码高亮的词法类别
+type CodeTokenKind int
+
+const (
+	CodeTokenPlain   CodeTokenKind = iota // 普通文字（标识符、运算符、空白）
+	CodeTokenKeyword                      // 关键字和字面量常量
+	CodeTokenString                       // 字符串
+	CodeTokenComment                      // 注释
+	CodeTokenNumber                       // 数字
+)
+
+// codeTokenClasses 高亮 HTML 中各类别使用的 CSS 类名
+var codeTokenClasses = map[CodeTokenKind]string{
+	CodeTokenKeyword: "hl-keyword",
+	CodeTokenString:  "hl-string",
+	CodeTokenComment: "hl-comment",
+	CodeTokenNumber:  "hl-number",
+}
+
+// CodeToken 代码中的一段文字及其类别
+type CodeToken struct {
+	Text string
+	Kind CodeTokenKind
+}
+
+// codeLanguage 一种语言的词法规则
+type codeLanguage struct {
+	keywords        map[string]bool
+	lineComments    []string
+	blockComment    [2]string // 块注释的开始和结束，为空时不支持
+	quotes          string    // 字符串的引号
+	multilineQuotes string    // 可以跨行的引号，如 Go 和 JavaScript 的反引号
+	tripleQuotes    bool      // 支持 Python 的三引号字符串
+	caseInsensitive bool      // 关键字不区分大小写，如 SQL
+}
+
+// newCodeLanguage 创建语言的词法规则，keywords 以空格分隔
+func newCodeLanguage(keywords string, rules codeLanguage) *codeLanguage {
+	rules.keywords = make(map[string]bool)
+	for _, keyword := range strings.Fields(keywords) {
+		rules.keywords[keyword] = true
+	}
+	return &rules
+}
+
+var (
+	cStyleComments = codeLanguage{lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: `"'`}
+
+	goLanguage = newCodeLanguage("break case chan const continue default defer else fallthrough for func go goto if import "+
+		"interface map package range return select struct switch type var true false nil iota",
+		codeLanguage{lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: `"'`, multilineQuotes: "`"})
+	javaScriptLanguage = newCodeLanguage("async await break case catch class const continue debugger default delete do else "+
+		"export extends finally for from function if import in instanceof interface let new of return static super switch "+
+		"this throw try type typeof var void while yield true false null undefined",
+		codeLanguage{lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: `"'`, multilineQuotes: "`"})
+	javaLanguage = newCodeLanguage("abstract boolean break byte case catch char class continue default do double else enum "+
+		"extends final finally float for if implements import instanceof int interface long new package private protected "+
+		"public return short static super switch synchronized this throw throws try void volatile while var true false null",
+		cStyleComments)
+	cLanguage = newCodeLanguage("auto bool break case char class const continue default delete do double else enum extern "+
+		"float for goto if include define inline int long namespace new nullptr private protected public return short signed "+
+		"sizeof static struct switch template this typedef typename union unsigned using virtual void volatile while true false NULL",
+		codeLanguage{lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: `"'`})
+	rustLanguage = newCodeLanguage("as async await break const continue crate dyn else enum extern fn for if impl in let loop "+
+		"match mod move mut pub ref return self Self static struct super trait type unsafe use where while true false None Some Ok Err",
+		cStyleComments)
+	pythonLanguage = newCodeLanguage("and as assert async await break class continue def del elif else except finally for "+
+		"from global if import in is lambda nonlocal not or pass raise return try while with yield True False None self",
+		codeLanguage{lineComments: []string{"#"}, quotes: `"'`, tripleQuotes: true})
+	shellLanguage = newCodeLanguage("if then else elif fi for while until do done case esac in function return export local "+
+		"readonly set unset source echo exit",
+		codeLanguage{lineComments: []string{"#"}, quotes: `"'`, multilineQuotes: `"'`})
+	sqlLanguage = newCodeLanguage("select from where insert into values update set delete create alter drop table index view "+
+		"join left right inner outer full cross on using group by order having limit offset union all distinct as and or not "+
+		"null is in between like exists case when then else end asc desc primary key foreign references default begin commit rollback",
+		codeLanguage{lineComments: []string{"--"}, blockComment: [2]string{"/*", "*/"}, quotes: `'"`, caseInsensitive: true})
+	jsonLanguage = newCodeLanguage("true false null", codeLanguage{quotes: `"`})
+	yamlLanguage = newCodeLanguage("true false null yes no on off",
+		codeLanguage{lineComments: []string{"#"}, quotes: `"'`})
+)
+
+// codeLanguages 支持高亮的语言，按围栏代码块中的语言名称（小写）索引
+var codeLanguages = map[string]*codeLanguage{
+	"go": goLanguage, "golang": goLanguage,
+	"javascript": javaScriptLanguage, "js": javaScriptLanguage, "jsx": javaScriptLanguage,
+	"typescript": javaScriptLanguage, "ts": javaScriptLanguage, "tsx": javaScriptLanguage,
+	"java": javaLanguage, "kotlin": javaLanguage,
+	"c": cLanguage, "cpp": cLanguage, "c++": cLanguage, "h": cLanguage, "csharp": cLanguage, "cs": cLanguage,
+	"rust": rustLanguage, "rs": rustLanguage,
+	"python": pythonLanguage, "py": pythonLanguage,
+	"shell": shellLanguage, "sh": shellLanguage, "bash": shellLanguage, "zsh": shellLanguage, "console": shellLanguage,
+	"sql":  sqlLanguage,
+	"json": jsonLanguage,
+	"yaml": yamlLanguage, "yml": yamlLanguage,
+}
+
+// HighlightCode 将代码按语言切分为带类别的片段，拼接后与原文相同；不支持该语言时返回 false
+func HighlightCode(code, language string) ([]CodeToken, bool) {
+	lang, ok := codeLanguages[strings.ToLower(strings.TrimSpace(language))]
+	if !ok {
+		return nil, false
+	}
+
+	var tokens []CodeToken
+	emit := func(text string, kind CodeTokenKind) {
+		// 相邻的同类片段合并，减少渲染的片段数
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += text
+			return
+		}
+		tokens = append(tokens, CodeToken{Text: text, Kind: kind})
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		if end := lang.commentEnd(rest); end > 0 {
+			emit(rest[:end], CodeTokenComment)
+			i += end
+			continue
+		}
+		if end := lang.stringEnd(rest); end > 0 {
+			emit(rest[:end], CodeTokenString)
+			i += end
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case unicode.IsDigit(r):
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return !(unicode.IsDigit(r) || unicode.IsLetter(r) || r == '.' || r == '_')
+			})
+			if end < 0 {
+				end = len(rest)
+			}
+			emit(rest[:end], CodeTokenNumber)
+			i += end
+		case isIdentifierRune(r):
+			end := strings.IndexFunc(rest, func(r rune) bool { return !isIdentifierRune(r) && !unicode.IsDigit(r) })
+			if end < 0 {
+				end = len(rest)
+			}
+			word := rest[:end]
+			if lang.isKeyword(word) {
+				emit(word, CodeTokenKeyword)
+			} else {
+				emit(word, CodeTokenPlain)
+			}
+			i += end
+		default:
+			emit(rest[:size], CodeTokenPlain)
+			i += size
+		}
+	}
+	return tokens, true
+}
+
+// commentEnd 返回以注释开头的文字中注释的长度，不是注释时返回 0
+func (l *codeLanguage) commentEnd(text string) int {
+	for _, prefix := range l.lineComments {
+		if strings.HasPrefix(text, prefix) {
+			if end := strings.IndexByte(text, '\n'); end >= 0 {
+				return end
+			}
+			return len(text)
+		}
+	}
+	if l.blockComment[0] != "" && strings.HasPrefix(text, l.blockComment[0]) {
+		if end := strings.Index(text[len(l.blockComment[0]):], l.blockComment[1]); end >= 0 {
+			return len(l.blockComment[0]) + end + len(l.blockComment[1])
+		}
+		return len(text)
+	}
+	return 0
+}
+
+// stringEnd 返回以字符串开头的文字中字符串的长度，不是字符串时返回 0；未闭合的字符串到行尾为止
+func (l *codeLanguage) stringEnd(text string) int {
+	if text == "" || !strings.ContainsRune(l.quotes+l.multilineQuotes, rune(text[0])) {
+		return 0
+	}
+	quote := text[0]
+	if l.tripleQuotes && strings.HasPrefix(text, strings.Repeat(string(quote), 3)) {
+		delimiter := text[:3]
+		if end := strings.Index(text[3:], delimiter); end >= 0 {
+			return 3 + end + 3
+		}
+		return len(text)
+	}
+
+	multiline := strings.IndexByte(l.multilineQuotes, quote) >= 0
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		case '\n':
+			if !multiline {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
+
+// isKeyword 判断单词是否为关键字
+func (l *codeLanguage) isKeyword(word string) bool {
+	if l.caseInsensitive {
+		word = strings.ToLower(word)
+	}
+	return l.keywords[word]
+}
+
+// isIdentifierRune 判断字符是否可以作为标识符的开头
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$'
+}
+
+// codeHighlighting goldmark 扩展：围栏代码块输出带 hl-* 类名的高亮 HTML，
+// mermaid 代码块输出 <pre class="mermaid">，页面引入 Mermaid 脚本后可以渲染为图表
+type codeHighlighting struct{}
+
+// Extend 注册代码块渲染器，优先级高于 goldmark 默认的 HTML 渲染器
+func (e *codeHighlighting) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(gutil.Prioritized(&codeBlockRenderer{}, 100)))
+}
+
+// codeBlockRenderer 围栏代码块的 HTML 渲染器
+type codeBlockRenderer struct{}
+
+// RegisterFuncs 注册围栏代码块的渲染函数
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+// renderFencedCodeBlock 渲染围栏代码块
+func (r *codeBlockRenderer) renderFencedCodeBlock(w gutil.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	block := node.(*ast.FencedCodeBlock)
+	language := string(block.Language(source))
+	code := CodeBlockText(block, source)
+
+	if strings.EqualFold(language, "mermaid") {
+		_, _ = w.WriteString(`<pre class="mermaid">` + html.EscapeString(code) + "</pre>\n")
+		return ast.WalkSkipChildren, nil
+	}
+
+	_, _ = w.WriteString("<pre><code")
+	if language != "" {
+		_, _ = w.WriteString(` class="language-` + html.EscapeString(language) + `"`)
+	}
+	_ = w.WriteByte('>')
+	tokens, ok := HighlightCode(code, language)
+	if !ok {
+		tokens = []CodeToken{{Text: code}}
+	}
+	for _, token := range tokens {
+		if class, ok := codeTokenClasses[token.Kind]; ok {
+			_, _ = w.WriteString(`<span class="` + class + `">` + html.EscapeString(token.Text) + "</span>")
+		} else {
+			_, _ = w.WriteString(html.EscapeString(token.Text))
+		}
+	}
+	_, _ = w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// CodeBlockText 返回代码块的内容
+func CodeBlockText(block ast.Node, source []byte) string {
+	var sb strings.Builder
+	lines := block.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		sb.Write(line.Value(source))
+	}
+	return sb.String()
+}
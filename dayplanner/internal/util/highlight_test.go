@@ -0,0 +1,108 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	code := "func main() { // 入口\n\tmsg := \"a\\\"b\" + `多\n行` + 42\n}"
+	tokens, ok := HighlightCode(code, "Go")
+	if !ok {
+		t.Fatal("应支持 Go 语言高亮")
+	}
+
+	var sb strings.Builder
+	kinds := make(map[string]CodeTokenKind)
+	for _, token := range tokens {
+		sb.WriteString(token.Text)
+		kinds[token.Text] = token.Kind
+	}
+	if sb.String() != code {
+		t.Fatalf("片段拼接后应与原文相同，实际: %q", sb.String())
+	}
+	for text, want := range map[string]CodeTokenKind{
+		"func":   CodeTokenKeyword,
+		"// 入口":  CodeTokenComment,
+		`"a\"b"`: CodeTokenString,
+		"`多\n行`": CodeTokenString,
+		"42":     CodeTokenNumber,
+		"\n}":    CodeTokenPlain,
+	} {
+		if got, ok := kinds[text]; !ok || got != want {
+			t.Errorf("%q 的类别应为 %d，实际: %d (存在: %v)", text, want, got, ok)
+		}
+	}
+
+	// SQL 关键字不区分大小写，Python 支持三引号字符串
+	if tokens, _ := HighlightCode("SELECT id FROM t -- 注释", "sql"); tokens[0].Kind != CodeTokenKeyword || tokens[len(tokens)-1].Kind != CodeTokenComment {
+		t.Errorf("SQL 高亮不正确: %+v", tokens)
+	}
+	if tokens, _ := HighlightCode(`"""多行\n文档"""`, "python"); len(tokens) != 1 || tokens[0].Kind != CodeTokenString {
+		t.Errorf("Python 三引号字符串高亮不正确: %+v", tokens)
+	}
+	if _, ok := HighlightCode("x", "brainfuck"); ok {
+		t.Error("不支持的语言应返回 false")
+	}
+}
+
+func TestMarkdownToHTML_CodeHighlighting(t *testing.T) {
+	html, err := MarkdownToHTML("```go\nif x < 1 {}\n```\n\n```mermaid\ngraph TD\nA-->B\n```\n\n```\n<b>\n```\n")
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	for _, want := range []string{
+		`<pre><code class="language-go"><span class="hl-keyword">if</span> x &lt; <span class="hl-number">1</span> {}`,
+		"<pre class=\"mermaid\">graph TD\nA--&gt;B\n</pre>",
+		"<pre><code>&lt;b&gt;\n</code></pre>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML 中应包含 %q，实际:\n%s", want, html)
+		}
+	}
+}
+
+func TestSplitMarkdownBlocks(t *testing.T) {
+	markdown := "\n# 今日工作\n\n" +
+		"- 写文档\n- 评审\n\n  续行\n\n" +
+		"段落\n\n---\n\n" +
+		"```go\nfunc main() {}\n```\n" +
+		"> 引用\n"
+
+	blocks := SplitMarkdownBlocks(markdown)
+	want := []struct {
+		line   int
+		source string
+	}{
+		{line: 0, source: "\n# 今日工作\n\n"},
+		{line: 3, source: "- 写文档\n- 评审\n\n  续行\n\n"},
+		{line: 8, source: "段落\n\n---\n\n"},
+		{line: 12, source: "```go\nfunc main() {}\n```\n"},
+		{line: 15, source: "> 引用\n"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("期望 %d 个块，实际 %d: %+v", len(want), len(blocks), blocks)
+	}
+
+	var sb strings.Builder
+	for i, block := range blocks {
+		sb.WriteString(block.Source)
+		if block.Line != want[i].line || block.Source != want[i].source {
+			t.Errorf("第 %d 个块不匹配: 期望 %d %q, 实际 %d %q", i, want[i].line, want[i].source, block.Line, block.Source)
+		}
+	}
+	if sb.String() != markdown {
+		t.Errorf("所有块拼接后应与原文相同")
+	}
+
+	code := blocks[3]
+	if !code.Fenced || code.Language != "go" || code.Code != "func main() {}\n" {
+		t.Errorf("围栏代码块信息不正确: %+v", code)
+	}
+	if blocks[2].Fenced {
+		t.Error("合并了分隔线的段落不是代码块")
+	}
+	if blocks := SplitMarkdownBlocks(""); blocks != nil {
+		t.Errorf("空文档不应有块: %+v", blocks)
+	}
+}
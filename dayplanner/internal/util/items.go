@@ -0,0 +1,340 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"daily-report-tool/internal/model"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// 任务项中的结构化标记：!doing / !blocked 表示状态，~2h 表示预估耗时
+var (
+	statusMarkerPattern = regexp.MustCompile(`(^|\s)!(doing|blocked)\b`)
+	estimatePattern     = regexp.MustCompile(`(?i)(^|\s)~(\d+(?:\.\d+)?\s*(?:h|hr|hrs|小时)(?:\s*\d+\s*(?:m|min|mins|分钟|分))?|\d+\s*(?:m|min|mins|分钟|分))`)
+	codeSpanPattern     = regexp.MustCompile("`[^`]*`")
+	spacesPattern       = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// itemSpan 任务项及其在 Markdown 中的位置
+type itemSpan struct {
+	item      model.Item
+	prefix    string // 复选框之前的缩进和列表标记，如 "  - "
+	startLine int
+	endLine   int // 任务项第一个段落的最后一行
+}
+
+// ParseItems 使用 goldmark 解析 Markdown 中的任务项（带复选框的列表项），按出现顺序返回结构化表示
+// 代码块中的内容不会被当作任务项，行内代码中的内容不会被当作标记
+func ParseItems(markdown string) []model.Item {
+	spans := parseItemSpans(markdown)
+	items := make([]model.Item, 0, len(spans))
+	for _, span := range spans {
+		items = append(items, span.item)
+	}
+	return items
+}
+
+// parseItemSpans 解析任务项及其位置
+func parseItemSpans(markdown string) []itemSpan {
+	source := []byte(markdown)
+	doc := ParseMarkdown(source)
+
+	var spans []itemSpan
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		checkbox, ok := node.(*extast.TaskCheckBox)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		block := checkbox.Parent()
+		if block == nil || block.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+
+		lines := block.Lines()
+		first := lines.At(0)
+		last := lines.At(lines.Len() - 1)
+		lineStart := bytes.LastIndexByte(source[:first.Start], '\n') + 1
+
+		item := parseItemText(blockRawText(block, source), checkbox.IsChecked)
+		item.Links = itemLinks(block, source)
+		item.Line = bytes.Count(source[:first.Start], []byte("\n"))
+		spans = append(spans, itemSpan{
+			item:      item,
+			prefix:    string(source[lineStart:first.Start]),
+			startLine: item.Line,
+			endLine:   bytes.Count(source[:last.Start], []byte("\n")),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	return spans
+}
+
+// parseItemText 从复选框之后的原始 Markdown 中提取标记，剩余部分作为标题
+func parseItemText(raw string, checked bool) model.Item {
+	item := model.Item{Status: model.ItemStatusTodo}
+	if checked {
+		item.Status = model.ItemStatusDone
+	}
+
+	// 行内代码原样保留，只在代码之外查找标记
+	var title strings.Builder
+	rest := raw
+	for rest != "" {
+		loc := codeSpanPattern.FindStringIndex(rest)
+		text := rest
+		if loc != nil {
+			text = rest[:loc[0]]
+		}
+		title.WriteString(extractItemMarkers(text, &item))
+		if loc == nil {
+			break
+		}
+		title.WriteString(rest[loc[0]:loc[1]])
+		rest = rest[loc[1]:]
+	}
+	item.Title = strings.TrimSpace(spacesPattern.ReplaceAllString(title.String(), " "))
+	return item
+}
+
+// extractItemMarkers 提取文本中的状态、耗时、标签和项目标记，返回去掉标记后的文本
+func extractItemMarkers(text string, item *model.Item) string {
+	text = statusMarkerPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := statusMarkerPattern.FindStringSubmatch(match)
+		if item.Status != model.ItemStatusDone {
+			item.Status = model.ItemStatus(groups[2])
+		}
+		return groups[1]
+	})
+	text = estimatePattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := estimatePattern.FindStringSubmatch(match)
+		item.Estimate += ParseDurations("(" + groups[2] + ")")
+		return groups[1]
+	})
+	text = durationPattern.ReplaceAllStringFunc(text, func(match string) string {
+		duration := ParseDurations(match)
+		if duration == 0 {
+			return match
+		}
+		item.Actual += duration
+		return ""
+	})
+	text = removeMarkers(tagPattern, text, &item.Tags)
+	text = removeMarkers(projectPattern, text, &item.Projects)
+	return text
+}
+
+// removeMarkers 提取标签或项目名称并从文本中去掉，纯数字的名称保留在文本中
+func removeMarkers(pattern *regexp.Regexp, text string, names *[]string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+		name := strings.TrimRight(groups[2], "-/")
+		if name == "" || isDigits(name) {
+			return match
+		}
+		if !containsString(*names, name) {
+			*names = append(*names, name)
+		}
+		return groups[1]
+	})
+}
+
+// itemLinks 提取任务项中的链接
+func itemLinks(block ast.Node, source []byte) []model.Link {
+	var links []model.Link
+	ast.Walk(block, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			links = append(links, model.Link{Text: strings.TrimSpace(inlineText(n, source)), URL: string(n.Destination)})
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			links = append(links, model.Link{URL: string(n.URL(source))})
+		case *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return links
+}
+
+// ApplyItems 将修改后的任务项写回 Markdown，返回新的内容
+// items 中 Line 为 -1 的任务项追加到最后一个任务项所在列表之后；Markdown 中有、items 中没有的任务项会被删除。
+// 与原文解析结果相同的任务项保持原文不变，因此 ApplyItems(md, ParseItems(md)) 总是返回 md；
+// 修改过的任务项按 "- [ ] 标题 #标签 +项目 ~预估 (实际) !状态" 的顺序重新生成
+func ApplyItems(markdown string, items []model.Item) (string, error) {
+	spans := parseItemSpans(markdown)
+	byLine := make(map[int]int, len(spans))
+	for i, span := range spans {
+		byLine[span.startLine] = i
+	}
+
+	listed := make(map[int]bool, len(items))
+	rendered := make(map[int]string)
+	var added []string
+	for _, item := range items {
+		if !model.ValidItemStatus(item.Status) {
+			return "", fmt.Errorf("无效的任务项状态: %q", item.Status)
+		}
+		if item.Line >= 0 && listed[item.Line] {
+			return "", fmt.Errorf("第 %d 行的任务项重复出现", item.Line+1)
+		}
+		i, ok := byLine[item.Line]
+		if item.Line >= 0 && !ok {
+			return "", fmt.Errorf("第 %d 行不是任务项", item.Line+1)
+		}
+		if ok && sameItem(spans[i].item, item) {
+			listed[item.Line] = true
+			continue
+		}
+		if strings.TrimSpace(item.Title) == "" {
+			return "", fmt.Errorf("任务项标题不能为空")
+		}
+		if !ok {
+			added = append(added, newItemPrefix(spans)+RenderItem(item))
+			continue
+		}
+		listed[item.Line] = true
+		rendered[item.Line] = spans[i].prefix + RenderItem(item)
+	}
+
+	lines := strings.Split(markdown, "\n")
+	if len(spans) == 0 {
+		if len(added) == 0 {
+			return markdown, nil
+		}
+		body := strings.TrimRight(markdown, "\n")
+		if body != "" {
+			body += "\n\n"
+		}
+		return body + strings.Join(added, "\n") + "\n", nil
+	}
+
+	// 新增的任务项放在最后一个任务项及其缩进的续行、子项之后
+	insertAfter := spans[len(spans)-1].endLine
+	for insertAfter+1 < len(lines) && strings.TrimSpace(lines[insertAfter+1]) != "" &&
+		(strings.HasPrefix(lines[insertAfter+1], " ") || strings.HasPrefix(lines[insertAfter+1], "\t")) {
+		insertAfter++
+	}
+
+	out := make([]string, 0, len(lines)+len(added))
+	for i := 0; i < len(lines); i++ {
+		if j, ok := byLine[i]; ok && (!listed[i] || rendered[i] != "") {
+			span := spans[j]
+			if listed[i] {
+				// 保留原文的 CRLF 换行
+				suffix := ""
+				if strings.HasSuffix(lines[span.endLine], "\r") {
+					suffix = "\r"
+				}
+				out = append(out, rendered[i]+suffix)
+			}
+			i = span.endLine
+		} else {
+			out = append(out, lines[i])
+		}
+		if i == insertAfter {
+			out = append(out, added...)
+		}
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// newItemPrefix 返回新增任务项的列表标记，沿用最后一个任务项的无序列表符号
+func newItemPrefix(spans []itemSpan) string {
+	if len(spans) > 0 {
+		prefix := strings.TrimLeft(spans[len(spans)-1].prefix, " \t")
+		if prefix != "" && strings.ContainsAny(prefix[:1], "-*+") {
+			return prefix[:1] + " "
+		}
+	}
+	return "- "
+}
+
+// RenderItem 生成任务项复选框及之后的 Markdown 文本，不包括列表标记
+func RenderItem(item model.Item) string {
+	parts := []string{"[ ]"}
+	if item.Status == model.ItemStatusDone {
+		parts[0] = "[x]"
+	}
+	parts = append(parts, strings.TrimSpace(item.Title))
+	for _, link := range item.Links {
+		if strings.Contains(item.Title, link.URL) {
+			continue
+		}
+		if link.Text == "" {
+			parts = append(parts, "<"+link.URL+">")
+		} else {
+			parts = append(parts, "["+link.Text+"]("+link.URL+")")
+		}
+	}
+	for _, tag := range item.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	for _, project := range item.Projects {
+		parts = append(parts, "+"+project)
+	}
+	if item.Estimate > 0 {
+		parts = append(parts, "~"+FormatItemDuration(item.Estimate))
+	}
+	if item.Actual > 0 {
+		parts = append(parts, "("+FormatItemDuration(item.Actual)+")")
+	}
+	if item.Status == model.ItemStatusDoing || item.Status == model.ItemStatusBlocked {
+		parts = append(parts, "!"+string(item.Status))
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatItemDuration 将耗时格式化为任务项中的写法，如 "2h"、"30m"、"1h30m"
+func FormatItemDuration(duration time.Duration) string {
+	duration = duration.Round(time.Minute)
+	hours := int(duration / time.Hour)
+	minutes := int(duration % time.Hour / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// sameItem 判断两个任务项的内容是否相同，空列表和 nil 视为相同
+func sameItem(a, b model.Item) bool {
+	normalize := func(item model.Item) model.Item {
+		if len(item.Tags) == 0 {
+			item.Tags = nil
+		}
+		if len(item.Projects) == 0 {
+			item.Projects = nil
+		}
+		if len(item.Links) == 0 {
+			item.Links = nil
+		}
+		return item
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// containsString 判断字符串切片中是否包含指定字符串
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
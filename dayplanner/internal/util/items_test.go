@@ -0,0 +1,84 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"daily-report-tool/internal/model"
+)
+
+const itemsMarkdown = "# 今日工作\r\n\n" +
+	"- [x] 完成 **登录接口** #后端 +支付 ~3h (2.5h)\n" +
+	"- [ ] 联调 [支付回调](https://example.com/pay) #联调 !doing\n" +
+	"  - [ ] 等待运维开通权限 #123 !blocked\n" +
+	"* [ ] 修复 `#include ~2h` 报错\n" +
+	"  补充说明写在续行\n" +
+	"- 普通列表项 (1h)\n\n" +
+	"```\n- [ ] 代码块中的内容不是任务\n```\n"
+
+func TestParseItems(t *testing.T) {
+	items := ParseItems(itemsMarkdown)
+	expected := []model.Item{
+		{Title: "完成 **登录接口**", Status: model.ItemStatusDone, Estimate: 3 * time.Hour, Actual: 150 * time.Minute,
+			Tags: []string{"后端"}, Projects: []string{"支付"}, Line: 2},
+		{Title: "联调 [支付回调](https://example.com/pay)", Status: model.ItemStatusDoing, Tags: []string{"联调"},
+			Links: []model.Link{{Text: "支付回调", URL: "https://example.com/pay"}}, Line: 3},
+		{Title: "等待运维开通权限 #123", Status: model.ItemStatusBlocked, Line: 4},
+		{Title: "修复 `#include ~2h` 报错 补充说明写在续行", Status: model.ItemStatusTodo, Line: 5},
+	}
+	if !reflect.DeepEqual(items, expected) {
+		t.Errorf("解析结果不匹配:\n期望 %+v\n实际 %+v", expected, items)
+	}
+}
+
+func TestApplyItems_RoundTrip(t *testing.T) {
+	for _, markdown := range []string{itemsMarkdown, "", "没有任务项的日报\n", "- [ ] 无换行结尾"} {
+		got, err := ApplyItems(markdown, ParseItems(markdown))
+		if err != nil {
+			t.Fatalf("写回失败: %v", err)
+		}
+		if got != markdown {
+			t.Errorf("未修改的任务项写回后内容应保持不变:\n期望 %q\n实际 %q", markdown, got)
+		}
+	}
+}
+
+func TestApplyItems_Modify(t *testing.T) {
+	items := ParseItems(itemsMarkdown)
+
+	// 完成联调并记录耗时，删除阻塞项，修改多行任务项，新增一项
+	items[1].Status = model.ItemStatusDone
+	items[1].Actual = 90 * time.Minute
+	items[3].Title = "修复编译报错"
+	items = append(items[:2], items[3],
+		model.Item{Title: "编写文档", Status: model.ItemStatusTodo, Estimate: 30 * time.Minute, Tags: []string{"文档"}, Line: -1})
+
+	got, err := ApplyItems(itemsMarkdown, items)
+	if err != nil {
+		t.Fatalf("写回失败: %v", err)
+	}
+	expected := "# 今日工作\r\n\n" +
+		"- [x] 完成 **登录接口** #后端 +支付 ~3h (2.5h)\n" +
+		"- [x] 联调 [支付回调](https://example.com/pay) #联调 (1h30m)\n" +
+		"* [ ] 修复编译报错\n" +
+		"* [ ] 编写文档 #文档 ~30m\n" +
+		"- 普通列表项 (1h)\n\n" +
+		"```\n- [ ] 代码块中的内容不是任务\n```\n"
+	if got != expected {
+		t.Errorf("写回结果不匹配:\n期望 %q\n实际 %q", expected, got)
+	}
+
+	// 重新生成的任务项再次解析后内容一致
+	reparsed := ParseItems(got)
+	if reparsed[1].Status != model.ItemStatusDone || reparsed[1].Actual != 90*time.Minute || reparsed[3].Estimate != 30*time.Minute {
+		t.Errorf("重新解析结果不匹配: %+v", reparsed)
+	}
+
+	if _, err := ApplyItems(itemsMarkdown, []model.Item{{Title: "x", Status: model.ItemStatusTodo, Line: 0}}); err == nil {
+		t.Error("行号不是任务项时应返回错误")
+	}
+	if _, err := ApplyItems(itemsMarkdown, []model.Item{{Title: "x", Status: "unknown", Line: -1}}); err == nil {
+		t.Error("无效状态应返回错误")
+	}
+}
@@ -50,8 +50,19 @@ var (
 	once          sync.Once
 )
 
-// InitLogger 初始化日志系统
+// InitLogger 初始化日志系统，日志同时输出到文件和控制台
 func InitLogger(logPath string, minLevel LogLevel) error {
+	return initLogger(logPath, minLevel, true)
+}
+
+// InitFileLogger 初始化只输出到文件的日志系统
+// 用于命令行模式，避免日志混入命令的标准输出
+func InitFileLogger(logPath string, minLevel LogLevel) error {
+	return initLogger(logPath, minLevel, false)
+}
+
+// initLogger 初始化默认日志记录器
+func initLogger(logPath string, minLevel LogLevel, console bool) error {
 	var err error
 	once.Do(func() {
 		// 创建日志目录
@@ -67,11 +78,14 @@ func InitLogger(logPath string, minLevel LogLevel) error {
 			return
 		}
 
-		// 创建多写入器，同时输出到文件和控制台
-		multiWriter := io.MultiWriter(file, os.Stdout)
+		// 控制台模式下创建多写入器，同时输出到文件和控制台
+		var writer io.Writer = file
+		if console {
+			writer = io.MultiWriter(file, os.Stdout)
+		}
 
 		defaultLogger = &Logger{
-			logger:   log.New(multiWriter, "", 0),
+			logger:   log.New(writer, "", 0),
 			file:     file,
 			minLevel: minLevel,
 		}
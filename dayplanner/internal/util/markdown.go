@@ -4,19 +4,21 @@ import (
 	"bytes"
 
 	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 )
 
-// MarkdownToHTML converts Markdown content to HTML
-// Supports common extensions like tables, strikethrough, etc.
-func MarkdownToHTML(markdown string) (string, error) {
+// newMarkdown creates the goldmark instance shared by rendering and parsing
+func newMarkdown() goldmark.Markdown {
 	// Configure goldmark with extensions
-	md := goldmark.New(
+	return goldmark.New(
 		goldmark.WithExtensions(
-			extension.GFM,        // GitHub Flavored Markdown (tables, strikethrough, etc.)
+			extension.GFM,         // GitHub Flavored Markdown (tables, strikethrough, etc.)
 			extension.Typographer, // Smart quotes, dashes, etc.
+			&codeHighlighting{},   // Syntax highlighting for fenced code blocks
 		),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(), // Auto-generate heading IDs
@@ -26,6 +28,12 @@ func MarkdownToHTML(markdown string) (string, error) {
 			html.WithXHTML(),     // Use XHTML-style tags
 		),
 	)
+}
+
+// MarkdownToHTML converts Markdown content to HTML
+// Supports common extensions like tables, strikethrough, etc.
+func MarkdownToHTML(markdown string) (string, error) {
+	md := newMarkdown()
 
 	var buf bytes.Buffer
 	if err := md.Convert([]byte(markdown), &buf); err != nil {
@@ -34,3 +42,9 @@ func MarkdownToHTML(markdown string) (string, error) {
 
 	return buf.String(), nil
 }
+
+// ParseMarkdown parses Markdown into a goldmark AST using the same
+// extensions as MarkdownToHTML
+func ParseMarkdown(source []byte) ast.Node {
+	return newMarkdown().Parser().Parse(text.NewReader(source))
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// 编辑器格式化命令使用的行首标记
+var (
+	headingPrefixPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+|$)`)
+	listItemPattern      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])(?:[ \t]+|$)`)
+	checkboxItemPattern  = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])[ \t]+)\[([ xX])\]`)
+)
+
+// TextRange 文本中的一段范围，Start 和 End 为字符（rune）偏移，不包含 End
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// ToggleHeading 将一行设置为指定级别的标题；已经是该级别的标题时恢复为普通文字
+func ToggleHeading(line string, level int) string {
+	text := line
+	current := 0
+	if match := headingPrefixPattern.FindStringSubmatch(line); match != nil {
+		current = len(match[1])
+		text = line[len(match[0]):]
+	} else {
+		text = strings.TrimLeft(line, " \t")
+	}
+	if current == level {
+		return text
+	}
+	return strings.Repeat("#", level) + " " + text
+}
+
+// ToggleList 将多行设置为无序列表：所有非空行都已是列表项时去掉列表标记（连同复选框），
+// 否则给还不是列表项的非空行加上 "- "，缩进保持不变
+func ToggleList(lines []string) []string {
+	allListed := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" && !listItemPattern.MatchString(line) {
+			allListed = false
+			break
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "" && len(lines) > 1:
+			result[i] = line
+		case allListed && strings.TrimSpace(line) != "":
+			match := listItemPattern.FindStringSubmatch(line)
+			rest := line[len(match[0]):]
+			rest = checkboxMarkerPattern.ReplaceAllString(rest, "")
+			result[i] = match[1] + rest
+		case listItemPattern.MatchString(line):
+			result[i] = line
+		default:
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			result[i] = indent + "- " + line[len(indent):]
+		}
+	}
+	return result
+}
+
+// ToggleCheckbox 切换一行的复选框：已勾选和未勾选互换，列表项加上未勾选的复选框，
+// 普通文字（包括空行）变为未勾选的任务项
+func ToggleCheckbox(line string) string {
+	if match := checkboxItemPattern.FindStringSubmatchIndex(line); match != nil {
+		mark := "x"
+		if line[match[4]:match[5]] != " " {
+			mark = " "
+		}
+		return line[:match[4]] + mark + line[match[5]:]
+	}
+	if match := listItemPattern.FindStringSubmatch(line); match != nil {
+		return match[1] + match[2] + " [ ] " + line[len(match[0]):]
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	return indent + "- [ ] " + line[len(indent):]
+}
+
+// FindMatches 查找文本中所有不重叠的 query，返回字符偏移范围；caseSensitive 为 false 时忽略大小写
+func FindMatches(text, query string, caseSensitive bool) []TextRange {
+	if query == "" {
+		return nil
+	}
+	haystack := []rune(text)
+	needle := []rune(query)
+	if !caseSensitive {
+		haystack = lowerRunes(haystack)
+		needle = lowerRunes(needle)
+	}
+
+	var matches []TextRange
+	for i := 0; i+len(needle) <= len(haystack); {
+		if runesEqual(haystack[i:i+len(needle)], needle) {
+			matches = append(matches, TextRange{Start: i, End: i + len(needle)})
+			i += len(needle)
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+// ReplaceMatches 将文本中 matches 指定的范围替换为 replacement，matches 需按顺序排列且不重叠
+func ReplaceMatches(text string, matches []TextRange, replacement string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+	last := 0
+	for _, match := range matches {
+		sb.WriteString(string(runes[last:match.Start]))
+		sb.WriteString(replacement)
+		last = match.End
+	}
+	sb.WriteString(string(runes[last:]))
+	return sb.String()
+}
+
+// lowerRunes 逐个字符转换为小写，保持字符数不变，使偏移与原文对应
+func lowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	return lower
+}
+
+// runesEqual 判断两个字符切片是否相同
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToggleHeading(t *testing.T) {
+	tests := []struct {
+		line  string
+		level int
+		want  string
+	}{
+		{line: "今日工作", level: 1, want: "# 今日工作"},
+		{line: "## 今日工作", level: 2, want: "今日工作"},
+		{line: "## 今日工作", level: 3, want: "### 今日工作"},
+		{line: "  缩进的文字", level: 2, want: "## 缩进的文字"},
+		{line: "", level: 1, want: "# "},
+		{line: "#标签 不是标题", level: 1, want: "# #标签 不是标题"},
+	}
+	for _, tt := range tests {
+		if got := ToggleHeading(tt.line, tt.level); got != tt.want {
+			t.Errorf("ToggleHeading(%q, %d) = %q, 期望 %q", tt.line, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestToggleList(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{name: "普通文字", lines: []string{"写文档", "", "  评审"}, want: []string{"- 写文档", "", "  - 评审"}},
+		{name: "部分是列表项", lines: []string{"- 写文档", "评审"}, want: []string{"- 写文档", "- 评审"}},
+		{name: "全部是列表项", lines: []string{"- 写文档", "  * [x] 评审", "1. 发布"}, want: []string{"写文档", "  评审", "发布"}},
+		{name: "空行", lines: []string{""}, want: []string{"- "}},
+	}
+	for _, tt := range tests {
+		if got := ToggleList(tt.lines); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ToggleList(%q) = %q, 期望 %q", tt.name, tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestToggleCheckbox(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "- [ ] 写文档", want: "- [x] 写文档"},
+		{line: "  - [X] 评审", want: "  - [ ] 评审"},
+		{line: "1. [x] 发布", want: "1. [ ] 发布"},
+		{line: "- 写文档", want: "- [ ] 写文档"},
+		{line: "  写文档", want: "  - [ ] 写文档"},
+		{line: "", want: "- [ ] "},
+	}
+	for _, tt := range tests {
+		if got := ToggleCheckbox(tt.line); got != tt.want {
+			t.Errorf("ToggleCheckbox(%q) = %q, 期望 %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFindMatches(t *testing.T) {
+	text := "修复 Bug，bug 复现；再看 BUG"
+
+	matches := FindMatches(text, "bug", false)
+	want := []TextRange{{Start: 3, End: 6}, {Start: 7, End: 10}, {Start: 17, End: 20}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Fatalf("忽略大小写查找结果不匹配: 期望 %v, 实际 %v", want, matches)
+	}
+
+	if matches := FindMatches(text, "bug", true); !reflect.DeepEqual(matches, []TextRange{{Start: 7, End: 10}}) {
+		t.Errorf("区分大小写查找结果不匹配: %v", matches)
+	}
+	if matches := FindMatches("aaaa", "aa", true); len(matches) != 2 {
+		t.Errorf("匹配不应重叠: %v", matches)
+	}
+	if matches := FindMatches(text, "", false); matches != nil {
+		t.Errorf("空查询不应有匹配: %v", matches)
+	}
+
+	if got := ReplaceMatches(text, want, "问题"); got != "修复 问题，问题 复现；再看 问题" {
+		t.Errorf("替换结果不匹配: %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"bytes"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// Heading 日报大纲中的一个标题
+type Heading struct {
+	Level int    // 标题级别，1-6
+	Text  string // 标题的纯文本
+	ID    string // goldmark 自动生成的标题 ID，与导出 HTML 中的锚点相同
+	Line  int    // 标题所在行号（从 0 开始）
+}
+
+// MarkdownOutline 使用 goldmark 解析 Markdown 中的标题，按出现顺序返回，跳过没有文字的标题
+func MarkdownOutline(markdown string) []Heading {
+	source := []byte(markdown)
+	doc := ParseMarkdown(source)
+
+	var headings []Heading
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		heading, ok := node.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		text := strings.TrimSpace(html.UnescapeString(inlineText(heading, source)))
+		if text == "" || heading.Lines().Len() == 0 {
+			return ast.WalkSkipChildren, nil
+		}
+
+		var id string
+		if value, ok := heading.AttributeString("id"); ok {
+			if b, ok := value.([]byte); ok {
+				id = string(b)
+			}
+		}
+		headings = append(headings, Heading{
+			Level: heading.Level,
+			Text:  text,
+			ID:    id,
+			Line:  bytes.Count(source[:heading.Lines().At(0).Start], []byte("\n")),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	return headings
+}
@@ -0,0 +1,39 @@
+package util
+
+import "testing"
+
+func TestMarkdownOutline(t *testing.T) {
+	markdown := "# 今日工作\n\n" +
+		"## 今日完成\n- [x] 写文档\n\n" +
+		"## 今日完成\n\n" +
+		"Release Notes\n---\n\n" +
+		"```\n# 代码块中的注释\n```\n" +
+		"#\n" +
+		"### **API** 设计\n"
+
+	headings := MarkdownOutline(markdown)
+	want := []Heading{
+		{Level: 1, Text: "今日工作", Line: 0},
+		{Level: 2, Text: "今日完成", Line: 2},
+		{Level: 2, Text: "今日完成", Line: 5},
+		{Level: 2, Text: "Release Notes", ID: "release-notes", Line: 7},
+		{Level: 3, Text: "API 设计", Line: 14},
+	}
+	if len(headings) != len(want) {
+		t.Fatalf("期望 %d 个标题，实际 %d: %+v", len(want), len(headings), headings)
+	}
+
+	ids := make(map[string]bool)
+	for i, heading := range headings {
+		if heading.Level != want[i].Level || heading.Text != want[i].Text || heading.Line != want[i].Line {
+			t.Errorf("第 %d 个标题不匹配: 期望 %+v, 实际 %+v", i, want[i], heading)
+		}
+		if want[i].ID != "" && heading.ID != want[i].ID {
+			t.Errorf("第 %d 个标题 ID 不匹配: 期望 %q, 实际 %q", i, want[i].ID, heading.ID)
+		}
+		if heading.ID == "" || ids[heading.ID] {
+			t.Errorf("标题 ID 应非空且唯一: %+v", heading)
+		}
+		ids[heading.ID] = true
+	}
+}
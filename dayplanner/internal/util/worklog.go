@@ -0,0 +1,131 @@
+package util
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// 工作记录中的标记：#标签、+项目 以及括号中的耗时，如 (2h)、(30m)、（1小时30分钟）
+// 标签和项目前面不能紧跟英文字母或数字，避免把 C#、1+1 这样的文字当作标记
+var (
+	tagPattern      = regexp.MustCompile(`(^|[^0-9A-Za-z_&/#＃+])[#＃]([\p{L}\p{N}_/-]+)`)
+	projectPattern  = regexp.MustCompile(`(^|[^0-9A-Za-z_&/#＃+])\+([\p{L}\p{N}_/-]+)`)
+	durationPattern = regexp.MustCompile(`(?i)[(（]\s*(?:(\d+(?:\.\d+)?)\s*(?:h|hr|hrs|小时))?\s*(?:(\d+)\s*(?:m|min|mins|分钟|分))?\s*[)）]`)
+)
+
+// WorkEntry 日报中的一条工作记录：一个列表项或一个段落
+type WorkEntry struct {
+	Text     string        // 去除 Markdown 标记后的纯文本
+	Tags     []string      // #标签，按出现顺序去重
+	Projects []string      // +项目，按出现顺序去重
+	Duration time.Duration // 括号中记录的耗时之和，未记录时为 0
+	Line     int           // 所在行号（从 0 开始）
+}
+
+// ParseWorkEntries 使用 goldmark 解析 Markdown 中的工作记录，按出现顺序返回
+// 标题、代码块和行内代码中的内容不会被当作标签或耗时；嵌套列表项作为独立的记录，各自计算耗时
+func ParseWorkEntries(markdown string) []WorkEntry {
+	source := []byte(markdown)
+	doc := ParseMarkdown(source)
+
+	var entries []WorkEntry
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node.(type) {
+		case *ast.Paragraph, *ast.TextBlock:
+		default:
+			return ast.WalkContinue, nil
+		}
+		if node.Lines().Len() == 0 {
+			return ast.WalkSkipChildren, nil
+		}
+
+		text := strings.TrimSpace(markerText(node, source))
+		if text == "" {
+			return ast.WalkSkipChildren, nil
+		}
+		entries = append(entries, WorkEntry{
+			Text:     text,
+			Tags:     matchMarkers(tagPattern, text),
+			Projects: matchMarkers(projectPattern, text),
+			Duration: ParseDurations(text),
+			Line:     bytes.Count(source[:node.Lines().At(0).Start], []byte("\n")),
+		})
+		// 段落内的行内节点已处理，嵌套列表是列表项的子节点而不是段落的子节点，不会被跳过
+		return ast.WalkSkipChildren, nil
+	})
+
+	return entries
+}
+
+// ParseDurations 返回文本中所有括号耗时之和，如 "(1.5h)"、"(30m)"、"(1h30m)"、"（2小时）"
+func ParseDurations(text string) time.Duration {
+	var total time.Duration
+	for _, groups := range durationPattern.FindAllStringSubmatch(text, -1) {
+		if groups[1] == "" && groups[2] == "" {
+			continue
+		}
+		if groups[1] != "" {
+			hours, err := strconv.ParseFloat(groups[1], 64)
+			if err == nil {
+				total += time.Duration(hours * float64(time.Hour))
+			}
+		}
+		if groups[2] != "" {
+			minutes, err := strconv.Atoi(groups[2])
+			if err == nil {
+				total += time.Duration(minutes) * time.Minute
+			}
+		}
+	}
+	return total
+}
+
+// matchMarkers 提取标签或项目名称，去掉末尾的连字符和斜杠，纯数字（如 #123 这样的编号）不算
+func matchMarkers(pattern *regexp.Regexp, text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, groups := range pattern.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(groups[2], "-/")
+		if name == "" || seen[name] || isDigits(name) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// isDigits 判断字符串是否只包含数字
+func isDigits(s string) bool {
+	return strings.TrimLeft(s, "0123456789") == ""
+}
+
+// markerText 提取段落的纯文本，跳过行内代码和任务复选框，链接只保留文字部分
+func markerText(node ast.Node, source []byte) string {
+	var sb strings.Builder
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.CodeSpan, *extast.TaskCheckBox, *ast.AutoLink, *ast.RawHTML:
+			sb.WriteByte(' ')
+		case *ast.Text:
+			sb.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(n.Value)
+		default:
+			sb.WriteString(markerText(child, source))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWorkEntries(t *testing.T) {
+	markdown := "# 今日工作 #不是标签\n\n" +
+		"- [x] 完成 **登录接口** #后端 +支付 (2h)\n" +
+		"- [ ] 联调 [支付回调](https://example.com/#anchor) #后端 #联调（1小时30分钟）\n" +
+		"  - 排查 C# 客户端问题 #123 (30m)\n" +
+		"- 修复 `#include` 报错 (0.5h)\n\n" +
+		"评审会议 #会议 +支付 (1h) (15min)\n\n" +
+		"```\n- 代码块 #忽略 (8h)\n```\n"
+
+	entries := ParseWorkEntries(markdown)
+	tests := []struct {
+		tags     []string
+		projects []string
+		duration time.Duration
+		line     int
+	}{
+		{tags: []string{"后端"}, projects: []string{"支付"}, duration: 2 * time.Hour, line: 2},
+		{tags: []string{"后端", "联调"}, duration: 90 * time.Minute, line: 3},
+		{duration: 30 * time.Minute, line: 4},
+		{duration: 30 * time.Minute, line: 5},
+		{tags: []string{"会议"}, projects: []string{"支付"}, duration: 75 * time.Minute, line: 7},
+	}
+	if len(entries) != len(tests) {
+		t.Fatalf("期望 %d 条记录，实际 %d: %+v", len(tests), len(entries), entries)
+	}
+	for i, tt := range tests {
+		entry := entries[i]
+		if !reflect.DeepEqual(entry.Tags, tt.tags) {
+			t.Errorf("第 %d 条标签不匹配: 期望 %v, 实际 %v", i, tt.tags, entry.Tags)
+		}
+		if !reflect.DeepEqual(entry.Projects, tt.projects) {
+			t.Errorf("第 %d 条项目不匹配: 期望 %v, 实际 %v", i, tt.projects, entry.Projects)
+		}
+		if entry.Duration != tt.duration {
+			t.Errorf("第 %d 条耗时不匹配: 期望 %v, 实际 %v", i, tt.duration, entry.Duration)
+		}
+		if entry.Line != tt.line {
+			t.Errorf("第 %d 条行号不匹配: 期望 %d, 实际 %d", i, tt.line, entry.Line)
+		}
+	}
+}
@@ -42,6 +42,8 @@ mkdir "%RELEASE_DIR%\data\tasks"
 REM Copy configuration example
 echo [3/5] Copying configuration files...
 copy config\config.json.example "%RELEASE_DIR%\config\config.json.example" >nul
+copy config\holidays.json.example "%RELEASE_DIR%\config\holidays.json.example" >nul
+xcopy /E /I /Q config\templates.example "%RELEASE_DIR%\config\templates.example" >nul
 
 REM Copy documentation
 echo [4/5] Copying documentation...
@@ -41,6 +41,8 @@ mkdir -p "${RELEASE_DIR}/data/tasks"
 # Copy configuration example
 echo "[3/5] Copying configuration files..."
 cp config/config.json.example "${RELEASE_DIR}/config/config.json.example"
+cp config/holidays.json.example "${RELEASE_DIR}/config/holidays.json.example"
+cp -r config/templates.example "${RELEASE_DIR}/config/templates.example"
 
 # Copy documentation
 echo "[4/5] Copying documentation..."